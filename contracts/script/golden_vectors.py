@@ -0,0 +1,211 @@
+#!/usr/bin/env python3
+"""ratemodel 골든 벡터 생성기 / Golden vector generator for the Go ratemodel package.
+
+src/InterestRateModel.sol과 src/JumpRateModel.sol의 식을 Solidity 0.8 의미 그대로
+(uint256 범위 검사, 0으로 나누기, 내림 나눗셈) 임의 정밀도 정수로 옮겨 계산하고,
+monitoring/internal/ratemodel/testdata/golden.json을 씁니다. Go 코드와 독립적으로 만든 값이며,
+test/GoldenVectors.t.sol이 같은 파일을 실제 컨트랙트에 대고 다시 확인합니다.
+
+Transcribes the formulas of src/InterestRateModel.sol and src/JumpRateModel.sol with Solidity 0.8
+semantics (uint256 range checks, division by zero, truncating division) on arbitrary-precision
+integers and writes monitoring/internal/ratemodel/testdata/golden.json. The values are produced
+independently of the Go code, and test/GoldenVectors.t.sol re-checks the same file against the
+real contracts.
+
+실행 / Run (from contracts/):
+
+    python3 script/golden_vectors.py
+    forge test --match-contract GoldenVectors
+"""
+
+import json
+import os
+
+UINT256_MAX = 2**256 - 1
+PRECISION = 10**18
+SECONDS_PER_YEAR = 365 * 24 * 60 * 60
+
+OUT = os.path.join(os.path.dirname(__file__), "..", "..", "monitoring", "internal", "ratemodel", "testdata", "golden.json")
+
+
+class Revert(Exception):
+    """컨트랙트가 revert하는 이유 / Why the contract reverts."""
+
+
+# Solidity 0.8 검사 연산 / Solidity 0.8 checked arithmetic
+def add(a, b):
+    if a + b > UINT256_MAX:
+        raise Revert("overflow")
+    return a + b
+
+
+def sub(a, b):
+    if b > a:
+        raise Revert("underflow")
+    return a - b
+
+
+def mul(a, b):
+    if a * b > UINT256_MAX:
+        raise Revert("overflow")
+    return a * b
+
+
+def div(a, b):
+    if b == 0:
+        raise Revert("division by zero")
+    return a // b
+
+
+def kinked_rate(p, utilization):
+    """getBorrowRate의 kink 분기 (두 컨트랙트 공통) / The kink branch of getBorrowRate (shared by both contracts)."""
+    if utilization <= p["kink"]:
+        return add(p["baseRate"], div(mul(utilization, p["multiplier"]), PRECISION))
+    normal = add(p["baseRate"], div(mul(p["kink"], p["multiplier"]), PRECISION))
+    excess = sub(utilization, p["kink"])
+    return add(normal, div(mul(excess, p["jumpMultiplier"]), PRECISION))
+
+
+def supply_rate(borrow_rate, utilization, reserve_factor):
+    rate_to_pool = div(mul(borrow_rate, utilization), PRECISION)
+    return div(mul(rate_to_pool, sub(PRECISION, reserve_factor)), PRECISION)
+
+
+# InterestRateModel.sol (totalDeposits, totalBorrows)
+def irm_utilization(p, deposits, borrows):
+    if deposits == 0 or borrows == 0:
+        return 0
+    return div(mul(borrows, PRECISION), deposits)
+
+
+def irm_borrow_rate(p, deposits, borrows):
+    return kinked_rate(p, irm_utilization(p, deposits, borrows))
+
+
+def irm_borrow_rate_per_second(p, deposits, borrows):
+    return div(irm_borrow_rate(p, deposits, borrows), SECONDS_PER_YEAR)
+
+
+def irm_supply_rate(p, deposits, borrows, reserve_factor):
+    borrow_rate = irm_borrow_rate(p, deposits, borrows)
+    return supply_rate(borrow_rate, irm_utilization(p, deposits, borrows), reserve_factor)
+
+
+# JumpRateModel.sol (cash, borrows, reserves)
+def jrm_utilization(p, cash, borrows, reserves):
+    if borrows == 0:
+        return 0
+    total_liquidity = sub(add(cash, borrows), reserves)
+    if total_liquidity == 0:
+        raise Revert("zero liquidity")  # require(totalLiquidity > 0)
+    return div(mul(borrows, PRECISION), total_liquidity)
+
+
+def jrm_borrow_rate(p, cash, borrows, reserves):
+    return kinked_rate(p, jrm_utilization(p, cash, borrows, reserves))
+
+
+def jrm_borrow_rate_per_second(p, cash, borrows, reserves):
+    # JumpRateModel.sol에는 없음: Go 포트가 InterestRateModel과 같은 식으로 제공
+    # Not in JumpRateModel.sol: the Go port provides it with InterestRateModel's formula
+    return div(jrm_borrow_rate(p, cash, borrows, reserves), SECONDS_PER_YEAR)
+
+
+def jrm_supply_rate(p, cash, borrows, reserves, reserve_factor):
+    borrow_rate = jrm_borrow_rate(p, cash, borrows, reserves)
+    return supply_rate(borrow_rate, jrm_utilization(p, cash, borrows, reserves), reserve_factor)
+
+
+E18 = PRECISION
+
+PARAMS = {
+    "typical": (2 * E18 // 100, E18 // 10, E18, 8 * E18 // 10),
+    "kink90": (2 * E18 // 100, E18 // 10, E18, 9 * E18 // 10),
+    "odd": (1, 333333333333333333, 7000000000000000003, 123456789012345678),
+    "zeroKink": (0, 5 * E18 // 100, 3 * E18, 0),
+    "huge": (2**255, 1, 1, 2**255),
+    "steep": (0, 2**200, 2**200, E18 // 2),
+}
+
+RESERVE_FACTORS = [0, E18 // 10, 2 * E18 // 10, E18, E18 + 1]
+
+MODELS = [
+    (
+        "InterestRateModel",
+        (irm_utilization, irm_borrow_rate, irm_borrow_rate_per_second, irm_supply_rate),
+        # (totalDeposits, totalBorrows)
+        [
+            (1000 * E18, 0),
+            (0, 500 * E18),
+            (1000 * E18, 500 * E18),
+            (1000 * E18, 800 * E18),
+            (1000 * E18, 900 * E18),
+            (1000 * E18, 1000 * E18),
+            (3, 1),
+            (3, 2),
+            (7 * E18 + 1, 5 * E18 + 3),
+            (10**30, 10**30 - 1),
+            (1000 * E18, 1200 * E18),
+            (1, 2**200),
+            (2**250, 2**240),
+            (1234567 * E18 + 89, 987654 * E18 + 321),
+        ],
+    ),
+    (
+        "JumpRateModel",
+        (jrm_utilization, jrm_borrow_rate, jrm_borrow_rate_per_second, jrm_supply_rate),
+        # (cash, borrows, reserves)
+        [
+            (1000 * E18, 0, 0),
+            (500 * E18, 500 * E18, 0),
+            (200 * E18, 800 * E18, 0),
+            (0, 1000 * E18, 0),
+            (400 * E18, 500 * E18, 100 * E18),
+            (100 * E18, 900 * E18, 0),
+            (0, 5, 5),
+            (0, 5, 6),
+            (1, 1, 0),
+            (17, 13, 11),
+            (2**255, 2**255, 0),
+            (1, 2**200, 2**199),
+            (123456789 * E18, 987654321 * E18, 5555 * E18 + 7),
+        ],
+    ),
+]
+
+
+def case(model, params, fn, f, p, args):
+    c = {"model": model, "params": params, "fn": fn, "args": [str(a) for a in args]}
+    try:
+        c["want"] = str(f(p, *args))
+    except Revert as e:
+        c["error"] = str(e)
+    return c
+
+
+def main():
+    params = {
+        name: dict(zip(("baseRate", "multiplier", "jumpMultiplier", "kink"), (str(v) for v in values)))
+        for name, values in PARAMS.items()
+    }
+    cases = []
+    for name, values in PARAMS.items():
+        p = dict(zip(("baseRate", "multiplier", "jumpMultiplier", "kink"), values))
+        for model, (utilization, borrow_rate, per_second, supply), inputs in MODELS:
+            for args in inputs:
+                cases.append(case(model, name, "utilization", utilization, p, args))
+                cases.append(case(model, name, "borrowRate", borrow_rate, p, args))
+                cases.append(case(model, name, "borrowRatePerSecond", per_second, p, args))
+                for rf in RESERVE_FACTORS:
+                    cases.append(case(model, name, "supplyRate", supply, p, args + (rf,)))
+
+    with open(OUT, "w") as f:
+        f.write("{\n")
+        f.write('"params": ' + json.dumps(params) + ",\n")
+        f.write('"cases": [\n')
+        f.write(",\n".join(json.dumps(c, separators=(",", ":")) for c in cases))
+        f.write("\n]\n}\n")
+
+
+if __name__ == "__main__":
+    main()
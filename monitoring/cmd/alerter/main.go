@@ -10,6 +10,7 @@
 // 3. 오라클 지연 > 1시간 → 경고 / Oracle staleness > 1 hour → warning
 // 4. 사용률 > 90% → 경고 / Utilization > 90% → warning
 // 5. 대규모 청산 이벤트 → 긴급 / Large liquidation event → critical
+// 6. 예상 청산 시간 < 지평 → 조기 경고 / Projected time to liquidation < horizon → early warning
 //
 // DevOps 관점:
 // - 인시던트 대응의 첫 번째 단계: 빠른 감지 + 알림
@@ -22,21 +23,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"math/big"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/jeongseup/lending-monitor/internal/alert"
+	"github.com/jeongseup/lending-monitor/internal/cmdutil"
+	"github.com/jeongseup/lending-monitor/internal/config"
 	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/monitor"
+	"github.com/jeongseup/lending-monitor/internal/risk"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+	"github.com/jeongseup/lending-monitor/internal/trend"
 )
 
 // 알림 임계값 / Alert thresholds
@@ -48,22 +57,30 @@ const (
 	// HealthFactorCritical은 긴급 헬스팩터 임계값입니다.
 	// HealthFactorCritical is the critical health factor threshold.
 	HealthFactorCritical = 1.0
-
-	// OracleMaxStaleness는 최대 허용 오라클 지연 시간입니다.
-	// OracleMaxStaleness is the maximum allowed oracle staleness.
-	OracleMaxStaleness = 1 * time.Hour
-
-	// UtilizationWarning은 경고 사용률 임계값입니다.
-	// UtilizationWarning is the warning utilization threshold.
-	UtilizationWarning = 0.90
 )
 
 func main() {
 	// CLI 플래그 / CLI flags
-	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL / Ethereum RPC URL (required)")
-	addresses := flag.String("addresses", "", "모니터링할 주소 / Addresses to monitor (comma-separated)")
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (쉼표로 여러 개) / Ethereum RPC URL(s), comma-separated (required)")
+	chainIDFlag := flag.Uint64("chain-id", 0, "기대하는 체인 ID (0 = 확인 안 함) / Expected chain ID (0 = not checked)")
+	flag.String("addresses", "", "모니터링할 주소 / Addresses to monitor (comma-separated)")
 	webhookURL := flag.String("webhook-url", "", "알림 웹훅 URL / Alert webhook URL (required)")
 	interval := flag.Duration("interval", 1*time.Minute, "확인 주기 / Check interval")
+	realert := flag.Duration("realert-interval", 0, "같은 수준의 헬스팩터 알림 반복 주기 (0 = 수준이 바뀔 때만) / Repeat interval for an unchanged health factor alert level (0 = only on level changes)")
+	quorum := flag.Int("quorum", 0, "긴급 알림 전 합의할 엔드포인트 수 (0 = 비활성, 그 외 2 이상) / Endpoints that must agree before a critical alert (0 = disabled, otherwise >= 2)")
+	maxBlockLag := flag.Uint64("max-block-lag", 3, "정상 엔드포인트의 최대 블록 지연 / Max block lag for a healthy endpoint")
+	rateLimit := flag.Float64("rate-limit", 0, "초당 RPC 컴퓨트 유닛 한도 (0 = 무제한) / RPC compute units per second (0 = unlimited)")
+	rateBurst := flag.Float64("rate-burst", 0, "RPC 버스트 한도 (컴퓨트 유닛) / RPC burst size in compute units")
+	dailyBudget := flag.Float64("daily-budget", 0, "일일 RPC 예산 (컴퓨트 유닛, 0 = 무제한) / Daily RPC budget in compute units (0 = unlimited)")
+	poolAddress := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
+	flag.Float64("hf-warning", HealthFactorWarning, "경고 헬스팩터 임계값 / Warning health factor threshold")
+	flag.Float64("hf-critical", HealthFactorCritical, "긴급 헬스팩터 임계값 / Critical health factor threshold")
+	trendWindow := flag.Duration("trend-window", time.Hour, "헬스팩터 추세 계산 창 / Health factor trend window")
+	ttlHorizon := flag.Duration("ttl-horizon", 6*time.Hour, "예상 청산 시간이 이보다 짧으면 조기 경고 (0 = 비활성) / Early warning when the projected time to liquidation is shorter (0 = disabled)")
+	rateRefresh := flag.Duration("rate-refresh", time.Hour, "이자 기준 추정용 포지션/이자율 갱신 주기 (0 = 이자 추정 비활성) / Position and rate refresh for the interest estimate (0 = disabled)")
+	uiProvider := flag.String("ui-pool-data-provider", "", "UiPoolDataProvider 주소 (V3.0, 계정당 한 번의 호출로 포지션 조회; 비우면 리저브별 조회) / UiPoolDataProvider address (V3.0, reads a position in one call; empty = per-reserve reads)")
+	breakdown := flag.Bool("position-breakdown", true, "헬스팩터 알림에 상위 담보/부채 자산 표시 / Show the top collateral/debt assets in health factor alerts")
 	flag.Parse()
 
 	// 로거 설정 / Logger setup
@@ -72,62 +89,142 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	explicit := config.ExplicitFlags(flag.CommandLine)
+	cfg, err := config.LoadIntoFlags(flag.CommandLine, *configPath)
+	if err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+	if !common.IsHexAddress(*poolAddress) {
+		logger.Error("잘못된 Pool 주소 / Invalid pool address", "pool", *poolAddress)
+		os.Exit(1)
+	}
+
 	if *rpcURL == "" || *webhookURL == "" {
 		logger.Error("RPC URL과 웹훅 URL이 필요합니다 / RPC URL and webhook URL are required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	// 이더리움 클라이언트 연결 / Connect to Ethereum client
-	client, err := ethclient.Dial(*rpcURL)
+	// 컨텍스트 / Context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 이더리움 클라이언트 연결 (다중 엔드포인트 풀) / Connect to Ethereum client (multi-endpoint pool)
+	// 공유 속도 제한기: 모든 RPC 요청이 같은 버킷과 예산을 사용
+	// Shared rate limiter: every RPC request draws from the same bucket and budget
+	limiter := cmdutil.NewLimiter(*rateLimit, *rateBurst, *dailyBudget)
+
+	poolOpts := rpcpool.DefaultOptions()
+	poolOpts.MaxBlockLag = *maxBlockLag
+	poolOpts.Limiter = limiter
+	client, err := rpcpool.Dial(ctx, rpcpool.SplitURLs(*rpcURL), poolOpts, logger)
 	if err != nil {
 		logger.Error("RPC 연결 실패 / Failed to connect to RPC", "error", err)
 		os.Exit(1)
 	}
 	defer client.Close()
+	client.Start(ctx)
 
-	// 주소 파싱 / Parse addresses
-	var monitorAddresses []common.Address
-	if *addresses != "" {
-		for _, addr := range strings.Split(*addresses, ",") {
-			addr = strings.TrimSpace(addr)
-			if common.IsHexAddress(addr) {
-				monitorAddresses = append(monitorAddresses, common.HexToAddress(addr))
-			}
-		}
+	// 체인 ID 확인 / Verify chain ID
+	if _, err := cmdutil.CheckChainID(ctx, client, *chainIDFlag); err != nil {
+		logger.Error("체인 ID 확인 실패 / Chain ID check failed", "error", err)
+		os.Exit(1)
+	}
+
+	// 쿼럼 1은 단일 제공자와 같으므로 조용히 끄지 않고 거부 / A quorum of 1 is a single provider, so reject it instead of silently disabling
+	if *quorum == 1 || *quorum < 0 {
+		logger.Error("쿼럼은 0(비활성) 또는 2 이상이어야 합니다 / Quorum must be 0 (disabled) or at least 2", "quorum", *quorum)
+		os.Exit(1)
+	}
+	if *quorum > client.Len() {
+		logger.Error("쿼럼이 엔드포인트 수보다 큽니다 / Quorum exceeds number of endpoints",
+			"quorum", *quorum,
+			"endpoints", client.Len(),
+		)
+		os.Exit(1)
 	}
 
 	// Aave Pool 클라이언트 / Aave Pool client
-	poolCaller := contracts.NewAavePoolCaller(client, contracts.AaveV3Pool)
+	poolCaller := contracts.NewAavePoolCaller(client, common.HexToAddress(*poolAddress))
+
+	// 쿼럼 클라이언트: 긴급 알림 전 여러 제공자 합의 확인
+	// Quorum client: confirm agreement across providers before a critical alert
+	var quorumCaller *contracts.AavePoolCaller
+	if *quorum > 1 {
+		quorumCaller = contracts.NewAavePoolCaller(client.Quorum(*quorum), common.HexToAddress(*poolAddress))
+	}
 
 	// 알림 전송기 / Alert sender
 	alerter := alert.NewWebhookAlerter(*webhookURL, logger)
+	alerter.SetRealertInterval(*realert)
 
-	// 컨텍스트 / Context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// 감시 주소와 임계값: 설정 파일 변경 또는 SIGHUP으로 재시작 없이 교체
+	// Watch list and thresholds: swapped without a restart on config file change or SIGHUP
+	reloader := config.NewReloader(flag.CommandLine, *configPath, explicit, cfg, logger)
+	rt := reloader.Current()
+	if err := rt.Validate(); err != nil {
+		logger.Error("잘못된 임계값 / Invalid thresholds", "error", err)
+		os.Exit(1)
+	}
+	alerter.SetHealthFactorThresholds(rt.HealthFactorWarning, rt.HealthFactorCritical)
+	reloader.OnReload(func(_, cur *config.Runtime) {
+		alerter.SetHealthFactorThresholds(cur.HealthFactorWarning, cur.HealthFactorCritical)
+	})
+	go reloader.Run(ctx)
+
+	// 리저브별 포지션 리더 (알림의 자산 분석, 이자 기준 추정용)
+	// Per-reserve position reader (asset breakdown in alerts, interest-based estimate)
+	var positions *contracts.PositionReader
+	if *breakdown || (*ttlHorizon > 0 && *rateRefresh > 0) {
+		positions = cmdutil.NewPositionReader(ctx, logger, client, poolCaller, *uiProvider, "asset breakdown, interest estimate")
+	}
+	var alertPositions *contracts.PositionReader
+	if *breakdown {
+		alertPositions = positions
+	}
+
+	// 조기 경고: HF 추세와 이자만으로 인한 하락으로 청산 시점 추정
+	// Early warning: project liquidation from the HF trend and interest-only decay
+	var early *earlyWarning
+	if *ttlHorizon > 0 {
+		trendOpts := trend.DefaultOptions()
+		trendOpts.Window = *trendWindow
+		early = &earlyWarning{tracker: trend.NewTracker(trendOpts), horizon: *ttlHorizon}
+		if positions != nil && *rateRefresh > 0 {
+			early.drifts = &interestDrifts{
+				reader:  positions,
+				refresh: *rateRefresh,
+				drift:   make(map[common.Address]float64),
+			}
+		}
+	}
 
 	// 시그널 핸들링 / Signal handling
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
 	logger.Info("알림 서비스 시작 / Alert service started",
-		"addresses", len(monitorAddresses),
+		"addresses", len(rt.Addresses),
 		"interval", interval.String(),
-		"webhook", *webhookURL,
+		"webhooks", webhookHosts(*webhookURL),
 	)
 
 	// 모니터링 루프 / Monitoring loop
 	ticker := time.NewTicker(*interval)
 	defer ticker.Stop()
+	currentInterval := *interval
 
 	// 첫 번째 실행 / First run
-	checkAndAlert(ctx, logger, poolCaller, alerter, monitorAddresses)
+	checkAndAlert(ctx, logger, poolCaller, quorumCaller, alerter, alertPositions, reloader.Current(), early)
+	currentInterval = cmdutil.ApplyBackpressure(logger, ticker, limiter, "alerter", *interval, currentInterval)
 
 	for {
 		select {
 		case <-ticker.C:
-			checkAndAlert(ctx, logger, poolCaller, alerter, monitorAddresses)
+			checkAndAlert(ctx, logger, poolCaller, quorumCaller, alerter, alertPositions, reloader.Current(), early)
+			currentInterval = cmdutil.ApplyBackpressure(logger, ticker, limiter, "alerter", *interval, currentInterval)
 		case sig := <-sigCh:
 			logger.Info("종료 시그널 수신 / Received shutdown signal", "signal", sig)
 			return
@@ -137,18 +234,118 @@ func main() {
 	}
 }
 
+// earlyWarning은 예상 청산 시간 기반 조기 경고 상태입니다.
+// earlyWarning holds the state for early warnings based on the projected time to liquidation.
+type earlyWarning struct {
+	tracker *trend.Tracker
+	horizon time.Duration
+
+	// drifts가 nil이면 이자 기준 추정을 생략합니다.
+	// The interest-based estimate is skipped when drifts is nil.
+	drifts *interestDrifts
+}
+
+// interestDrifts는 계정별 이자 기준 HF 변화율을 refresh 주기로 갱신해 보관합니다.
+// 계정마다 리저브 수만큼 호출이 필요하므로 매 확인 주기마다 읽지 않습니다.
+// interestDrifts keeps each account's interest-only HF drift, re-read every refresh period.
+// Reading one account costs one call per reserve, so it is not re-read every check.
+type interestDrifts struct {
+	reader  *contracts.PositionReader
+	refresh time.Duration
+	at      time.Time
+	drift   map[common.Address]float64
+}
+
+// current는 필요하면 모든 감시 계정의 변화율을 다시 읽고 현재 값을 반환합니다.
+// 갱신에 실패하면 이전 값을 유지하고 다음 확인 때 재시도합니다.
+// current re-reads the drift of every watched account when due and returns the current values.
+// On failure the previous values are kept and the refresh is retried on the next check.
+func (d *interestDrifts) current(ctx context.Context, logger *slog.Logger, addrs []common.Address) map[common.Address]float64 {
+	if !d.at.IsZero() && time.Since(d.at) < d.refresh {
+		return d.drift
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	reserves, err := d.reader.CachedReserves(opts, d.refresh)
+	if err != nil {
+		logger.Warn("리저브 조회 실패, 이자 추정 유지 / Failed to read reserves, keeping interest estimates", "error", err)
+		return d.drift
+	}
+	prices, err := d.reader.Prices(opts, reserves)
+	if err != nil {
+		logger.Warn("오라클 가격 조회 실패, 이자 추정 유지 / Failed to read oracle prices, keeping interest estimates", "error", err)
+		return d.drift
+	}
+	rates, err := d.reader.Rates(opts, reserves)
+	if err != nil {
+		logger.Warn("이자율 조회 실패, 이자 추정 유지 / Failed to read rates, keeping interest estimates", "error", err)
+		return d.drift
+	}
+
+	drift := make(map[common.Address]float64, len(addrs))
+	for _, addr := range addrs {
+		pos, err := d.reader.UserPosition(opts, addr, reserves, prices)
+		if err != nil {
+			logger.Warn("포지션 조회 실패 / Failed to read position", "address", addr.Hex(), "error", err)
+			if v, ok := d.drift[addr]; ok {
+				drift[addr] = v
+			}
+			continue
+		}
+		v, missing := risk.InterestDrift(pos, rates)
+		if len(missing) > 0 {
+			logger.Warn("이자율 없는 리저브 제외, 이자 추정이 낙관적일 수 있음 / Reserves without a rate left out, interest estimate may be optimistic",
+				"address", addr.Hex(),
+				"reserves", missing,
+			)
+		}
+		drift[addr] = v
+	}
+	d.drift, d.at = drift, time.Now()
+	return d.drift
+}
+
 // checkAndAlert는 포지션을 확인하고 필요시 알림을 전송합니다.
+// positions가 nil이 아니면 헬스팩터 알림에 상위 담보/부채 자산을 붙입니다.
+// early가 nil이 아니면 HF 추세를 기록하고 예상 청산 시간이 지평보다 짧을 때 조기 경고를 보냅니다.
 // checkAndAlert checks positions and sends alerts when needed.
+// When positions is non-nil, health factor alerts carry the top collateral/debt assets.
+// When early is non-nil it records the HF trend and sends an early warning when the projected
+// time to liquidation is shorter than the horizon.
 func checkAndAlert(
 	ctx context.Context,
 	logger *slog.Logger,
 	poolCaller *contracts.AavePoolCaller,
+	quorumCaller *contracts.AavePoolCaller,
 	alerter *alert.WebhookAlerter,
-	addresses []common.Address,
+	positions *contracts.PositionReader,
+	rt *config.Runtime,
+	early *earlyWarning,
 ) {
 	scale := new(big.Float).SetFloat64(1e18)
+	hfWarning, hfCritical := rt.HealthFactorWarning, rt.HealthFactorCritical
+
+	var drifts map[common.Address]float64
+	if early != nil {
+		keep := make(map[common.Address]bool, len(rt.Addresses))
+		for _, addr := range rt.Addresses {
+			keep[addr] = true
+		}
+		early.tracker.Retain(keep)
+		if early.drifts != nil {
+			drifts = early.drifts.current(ctx, logger, rt.Addresses)
+		}
+	}
+
+	for _, addr := range rt.Addresses {
+		// 라벨과 그룹을 로그와 알림에 표시 / Surface label and group in logs and alerts
+		l := rt.LabelOf(addr, monitor.GroupPinned)
+		meta := map[string]string{"group": l.Group}
+		logger := logger.With("group", l.Group)
+		if l.Label != "" {
+			meta["label"] = l.Label
+			logger = logger.With("label", l.Label)
+		}
 
-	for _, addr := range addresses {
 		data, err := poolCaller.GetUserAccountData(nil, addr)
 		if err != nil {
 			logger.Error("계정 데이터 조회 실패 / Failed to get account data",
@@ -160,6 +357,7 @@ func checkAndAlert(
 
 		// 부채가 없으면 건너뛰기 / Skip if no debt
 		if data.TotalDebtBase.Sign() == 0 {
+			alerter.ResolveHealthFactor(addr.Hex())
 			continue
 		}
 
@@ -168,23 +366,146 @@ func checkAndAlert(
 		hfFloat.Quo(hfFloat, scale)
 		hfValue, _ := hfFloat.Float64()
 
+		// 긴급 판정은 쿼럼으로 재확인 / Re-check a critical verdict under quorum
+		if hfValue < hfCritical && quorumCaller != nil {
+			confirmed, err := quorumCaller.GetUserAccountData(&bind.CallOpts{Context: ctx}, addr)
+			switch {
+			case errors.Is(err, rpcpool.ErrQuorumMismatch):
+				// 제공자들이 서로 다른 값을 주면 알림 보류 / Withhold the alert when providers positively disagree
+				logger.Warn("쿼럼 불일치, 알림 보류 / Quorum mismatch, withholding alert",
+					"address", addr.Hex(),
+					"health_factor", fmt.Sprintf("%.4f", hfValue),
+					"error", err,
+				)
+				continue
+			case err != nil:
+				// 제공자 장애로 확인할 수 없으면 단일 제공자 판정으로 알리고 표시
+				// When an outage prevents confirmation, alert on the single-provider verdict and mark it
+				logger.Warn("쿼럼 확인 실패, 미확인 알림 전송 / Quorum check failed, sending unconfirmed alert",
+					"address", addr.Hex(),
+					"health_factor", fmt.Sprintf("%.4f", hfValue),
+					"error", err,
+				)
+				meta["quorum_unconfirmed"] = "true"
+			default:
+				hfFloat = new(big.Float).Quo(new(big.Float).SetInt(confirmed.HealthFactor), scale)
+				hfValue, _ = hfFloat.Float64()
+			}
+		}
+
+		// 어떤 자산이 위험을 만드는지 알림에 표시 / Show in the alert which assets drive the risk
+		if hfValue < hfWarning && positions != nil {
+			addBreakdown(ctx, logger, positions, addr, meta)
+		}
+
 		// 알림 전송 / Send alerts
-		if hfValue < HealthFactorCritical {
+		if hfValue < hfCritical {
 			logger.Error("긴급: 청산 가능 포지션! / CRITICAL: Liquidatable position!",
 				"address", addr.Hex(),
 				"health_factor", fmt.Sprintf("%.4f", hfValue),
 			)
-			if err := alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat); err != nil {
+			if err := alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat, meta); err != nil {
 				logger.Error("알림 전송 실패 / Failed to send alert", "error", err)
 			}
-		} else if hfValue < HealthFactorWarning {
+		} else if hfValue < hfWarning {
 			logger.Warn("경고: 낮은 헬스팩터 / WARNING: Low health factor",
 				"address", addr.Hex(),
 				"health_factor", fmt.Sprintf("%.4f", hfValue),
 			)
-			if err := alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat); err != nil {
+			if err := alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat, meta); err != nil {
 				logger.Error("알림 전송 실패 / Failed to send alert", "error", err)
 			}
+		} else {
+			alerter.ResolveHealthFactor(addr.Hex())
+		}
+
+		// 추세 기록 및 조기 경고 (경고 임계값 위에서만) / Record the trend and warn early (only above the warning threshold)
+		if early == nil {
+			continue
+		}
+		early.tracker.Observe(addr, time.Now(), hfValue)
+		proj := early.tracker.Project(addr)
+		if drift, ok := drifts[addr]; ok {
+			proj.InterestETA, proj.HasInterestETA = risk.InterestTimeToLiquidation(hfValue, drift)
+		}
+		eta, ok := proj.ETA()
+		if !ok || hfValue < hfWarning || eta >= early.horizon {
+			continue
+		}
+		trendMeta := projectionMetadata(meta, proj)
+		logger.Warn("조기 경고: 청산 임박 예상 / EARLY WARNING: Liquidation projected",
+			"address", addr.Hex(),
+			"health_factor", fmt.Sprintf("%.4f", hfValue),
+			"time_to_liquidation", eta.Round(time.Minute).String(),
+			"basis", trendMeta["projection_basis"],
+		)
+		if err := alerter.AlertOnProjectedLiquidation(ctx, addr.Hex(), hfValue, eta, early.horizon, trendMeta); err != nil {
+			logger.Error("알림 전송 실패 / Failed to send alert", "error", err)
+		}
+	}
+}
+
+// addBreakdown은 계정의 리저브별 포지션을 읽어 상위 담보/부채 자산을 메타데이터에 추가합니다.
+// 실패하면 경고만 남기고 알림은 분석 없이 전송됩니다.
+// addBreakdown reads the account's per-reserve position and adds the top collateral/debt assets to the metadata.
+// On failure it only logs a warning and the alert goes out without the breakdown.
+func addBreakdown(ctx context.Context, logger *slog.Logger, positions *contracts.PositionReader, addr common.Address, meta map[string]string) {
+	pos, err := readPosition(&bind.CallOpts{Context: ctx}, positions, addr)
+	if err != nil {
+		logger.Warn("포지션 분석 실패, 분석 없이 알림 / Failed to read position breakdown, alerting without it",
+			"address", addr.Hex(),
+			"error", err,
+		)
+		return
+	}
+	risk.AddBreakdown(meta, pos, 3)
+}
+
+// readPosition은 캐시된 리저브 목록과 현재 오라클 가격으로 계정의 리저브별 포지션을 읽습니다.
+// readPosition reads an account's per-reserve position with the cached reserve list and current oracle prices.
+func readPosition(opts *bind.CallOpts, positions *contracts.PositionReader, addr common.Address) (*contracts.UserPosition, error) {
+	reserves, err := positions.CachedReserves(opts, contracts.ReservesTTL)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := positions.Prices(opts, reserves)
+	if err != nil {
+		return nil, err
+	}
+	return positions.UserPosition(opts, addr, reserves, prices)
+}
+
+// projectionMetadata는 기본 메타데이터에 추세와 추정 근거를 더한 사본을 만듭니다.
+// projectionMetadata returns a copy of the base metadata with the trend and estimate basis added.
+func projectionMetadata(base map[string]string, p trend.Projection) map[string]string {
+	md := make(map[string]string, len(base)+4)
+	for k, v := range base {
+		md[k] = v
+	}
+	if p.HasSlope {
+		md["hf_slope_per_hour"] = fmt.Sprintf("%.6f", p.SlopePerHour)
+	}
+	if p.HasTrendETA {
+		md["trend_eta"] = p.TrendETA.Round(time.Minute).String()
+	}
+	if p.HasInterestETA {
+		md["interest_eta"] = p.InterestETA.Round(time.Minute).String()
+	}
+	md["projection_basis"] = "trend"
+	if p.HasInterestETA && (!p.HasTrendETA || p.InterestETA < p.TrendETA) {
+		md["projection_basis"] = "interest"
+	}
+	return md
+}
+
+// webhookHosts는 로그에 남길 웹훅 호스트만 추립니다. URL 경로와 쿼리에는 비밀 토큰이 들어 있습니다.
+// webhookHosts extracts only the webhook hosts for logging. URL paths and queries carry the secret token.
+func webhookHosts(raw string) []string {
+	var hosts []string
+	for _, s := range strings.Split(raw, ",") {
+		if u, err := url.Parse(strings.TrimSpace(s)); err == nil && u.Host != "" {
+			hosts = append(hosts, u.Host)
 		}
 	}
+	return hosts
 }
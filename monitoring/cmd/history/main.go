@@ -0,0 +1,285 @@
+// 과거 헬스팩터 시계열
+// Historical health factor time series
+//
+// 이 프로그램은 계정 하나에 대해 블록 범위를 step 간격으로 나눠 각 블록 시점의 getUserAccountData를 조회하고
+// (CallOpts.BlockNumber), 담보/부채/헬스팩터 시계열을 CSV 또는 JSON으로 씁니다. 청산 조사용입니다.
+// For one account this program reads getUserAccountData at every step-th block of a range
+// (CallOpts.BlockNumber) and writes the collateral/debt/health factor series as CSV or JSON.
+// Meant for investigating liquidations.
+//
+// 과거 상태가 필요하므로 시작 전에 가장 오래된 블록을 조회해 아카이브 노드인지 확인합니다.
+// 확정된 블록(최신 - confirmations)의 결과는 캐시 디렉터리에 보관해 다시 실행할 때 재사용합니다.
+// Historical state is required, so the oldest block is probed first to verify the node is an archive node.
+// Results for finalized blocks (latest - confirmations) are kept in the cache directory and reused on later runs.
+//
+// 사용 예 / Usage:
+//
+//	go run ./cmd/history --rpc-url $ARCHIVE_RPC --address 0xabc... --lookback 21600 --step 300 --out hf.csv
+//	go run ./cmd/history --rpc-url $ARCHIVE_RPC --address 0xabc... --from-block 19000000 --to-block 19010000 --format json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/cmdutil"
+	"github.com/jeongseup/lending-monitor/internal/config"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/history"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+)
+
+func main() {
+	defaultCache := ""
+	if dir, err := os.UserCacheDir(); err == nil {
+		defaultCache = filepath.Join(dir, "lending-monitor", "history")
+	}
+
+	// CLI 플래그 / CLI flags
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	rpcURL := flag.String("rpc-url", "", "아카이브 노드 RPC URL (쉼표로 여러 개) / Archive node RPC URL(s), comma-separated (required)")
+	chainIDFlag := flag.Uint64("chain-id", 0, "기대하는 체인 ID (0 = 확인 안 함) / Expected chain ID (0 = not checked)")
+	poolAddress := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
+	address := flag.String("address", "", "조회할 계정 주소 / Account address to read (required)")
+	fromBlock := flag.Uint64("from-block", 0, "시작 블록 (0 = to-block - lookback) / Start block (0 = to-block - lookback)")
+	toBlock := flag.Uint64("to-block", 0, "끝 블록 (0 = 최신) / End block (0 = latest)")
+	lookback := flag.Uint64("lookback", 21_600, "from-block이 0일 때 거슬러 올라갈 블록 수 (메인넷 약 3일) / Blocks to look back when from-block is 0 (about 3 days on mainnet)")
+	step := flag.Uint64("step", 300, "조회 간격 (블록, 메인넷 약 1시간) / Sampling interval in blocks (about 1 hour on mainnet)")
+	maxPoints := flag.Int("max-points", 10_000, "조회 블록 수 상한 (실수로 큰 범위를 돌리지 않도록) / Block count limit (guards against accidentally huge ranges)")
+	workers := flag.Int("workers", 8, "동시 조회 워커 수 / Number of concurrent workers")
+	callTimeout := flag.Duration("call-timeout", 30*time.Second, "블록별 RPC 타임아웃 / Per-block RPC timeout")
+	cacheDir := flag.String("cache-dir", defaultCache, "결과 캐시 디렉터리 (비우면 캐시 없음) / Result cache directory (empty = no cache)")
+	confirmations := flag.Uint64("confirmations", 64, "캐시할 블록의 최소 확인 수 (재구성 대비) / Minimum confirmations before a block is cached (reorg safety)")
+	out := flag.String("out", "", "결과 파일 (비우면 표준 출력) / Output file (empty = stdout)")
+	format := flag.String("format", "", "출력 형식: csv, json (비우면 --out 확장자, 기본 csv) / Output format: csv, json (empty = --out extension, default csv)")
+	rateLimit := flag.Float64("rate-limit", 0, "초당 RPC 컴퓨트 유닛 한도 (0 = 무제한) / RPC compute units per second (0 = unlimited)")
+	rateBurst := flag.Float64("rate-burst", 0, "RPC 버스트 한도 (컴퓨트 유닛) / RPC burst size in compute units")
+	dailyBudget := flag.Float64("daily-budget", 0, "일일 RPC 예산 (컴퓨트 유닛, 0 = 무제한) / Daily RPC budget in compute units (0 = unlimited)")
+	flag.Parse()
+
+	// 로거 설정 (결과가 표준 출력으로 갈 수 있으므로 표준 에러) / Logger setup (stderr, results may go to stdout)
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	slog.SetDefault(logger)
+
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	if _, err := config.LoadIntoFlags(flag.CommandLine, *configPath); err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+	if *rpcURL == "" || !common.IsHexAddress(*address) {
+		logger.Error("RPC URL과 계정 주소가 필요합니다 / RPC URL and account address are required", "address", *address)
+		flag.Usage()
+		os.Exit(1)
+	}
+	if !common.IsHexAddress(*poolAddress) {
+		logger.Error("잘못된 Pool 주소 / Invalid pool address", "pool", *poolAddress)
+		os.Exit(1)
+	}
+	if *step == 0 {
+		logger.Error("step은 1 이상이어야 합니다 / step must be at least 1")
+		os.Exit(1)
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if strings.EqualFold(filepath.Ext(*out), ".json") {
+			*format = "json"
+		}
+	}
+	write := history.WriteCSV
+	switch *format {
+	case "csv":
+	case "json":
+		write = history.WriteJSON
+	default:
+		logger.Error("알 수 없는 출력 형식 / Unknown output format", "format", *format)
+		os.Exit(1)
+	}
+
+	// Ctrl-C로 중단 / Interrupt with Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 아카이브 조회는 비싸므로 공유 속도 제한기를 거침
+	// Archive reads are expensive, so they go through the shared rate limiter
+	poolOpts := rpcpool.DefaultOptions()
+	poolOpts.Limiter = cmdutil.NewLimiter(*rateLimit, *rateBurst, *dailyBudget)
+	client, err := rpcpool.Dial(ctx, rpcpool.SplitURLs(*rpcURL), poolOpts, logger)
+	if err != nil {
+		logger.Error("RPC 연결 실패 / Failed to connect to RPC", "error", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+	client.Start(ctx)
+
+	// 블록 범위 / Block range
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		logger.Error("최신 블록 조회 실패 / Failed to get latest block", "error", err)
+		os.Exit(1)
+	}
+	to := *toBlock
+	if to == 0 || to > latest {
+		to = latest
+	}
+	from := *fromBlock
+	if from == 0 {
+		from = to - min(*lookback, to)
+	}
+	if from > to {
+		logger.Error("시작 블록이 끝 블록보다 큽니다 / Start block is after the end block", "from", from, "to", to)
+		os.Exit(1)
+	}
+	blocks := history.Blocks(from, to, *step)
+	if len(blocks) > *maxPoints {
+		logger.Error("조회 블록이 너무 많습니다 (--step을 늘리세요) / Too many blocks (increase --step)", "blocks", len(blocks), "max", *maxPoints)
+		os.Exit(1)
+	}
+
+	chainID, err := cmdutil.CheckChainID(ctx, client, *chainIDFlag)
+	if err != nil {
+		logger.Error("체인 ID 확인 실패 / Chain ID check failed", "error", err)
+		os.Exit(1)
+	}
+	pool, user := common.HexToAddress(*poolAddress), common.HexToAddress(*address)
+	var cache *history.Cache
+	if *cacheDir != "" {
+		if cache, err = history.OpenCache(history.CachePath(*cacheDir, chainID, pool, user)); err != nil {
+			logger.Error("캐시 열기 실패 / Failed to open cache", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	opts := history.DefaultOptions()
+	opts.Workers = *workers
+	opts.CallTimeout = *callTimeout
+	opts.Finalized = latest - min(*confirmations, latest)
+	fetcher := history.New(client, pool, cache, opts, logger)
+
+	// 아카이브 노드 확인: 가장 오래된 블록의 상태를 조회 (캐시로 모두 채워지면 생략)
+	// Archive node check: read state at the oldest block (skipped when the cache covers it)
+	if _, cached := cacheGet(cache, from); !cached {
+		if err := fetcher.CheckArchive(ctx, from); err != nil {
+			switch {
+			case errors.Is(err, history.ErrNoArchive):
+				logger.Error("아카이브 노드가 아닙니다, 아카이브 RPC를 쓰거나 범위를 최근으로 줄이세요 / Not an archive node; use an archive RPC or move the range closer to head", "error", err)
+			case errors.Is(err, history.ErrNotDeployed):
+				logger.Error("시작 블록에 Pool이 없습니다, --from-block을 늘리세요 / Pool not deployed at the start block; raise --from-block", "error", err)
+			default:
+				logger.Error("아카이브 확인 실패 / Archive check failed", "error", err)
+			}
+			os.Exit(1)
+		}
+	}
+
+	logger.Info("과거 헬스팩터 조회 시작 / Reading historical health factors",
+		"user", user.Hex(),
+		"from", from,
+		"to", to,
+		"step", *step,
+		"blocks", len(blocks),
+	)
+	start := time.Now()
+	points, fetchErr := fetcher.Fetch(ctx, user, blocks)
+	if fetchErr != nil && points == nil {
+		logger.Error("조회 중단 / Read aborted", "error", fetchErr)
+		os.Exit(1)
+	}
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			logger.Warn("캐시 저장 실패 / Failed to save cache", "error", err)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			logger.Error("결과 파일 생성 실패 / Failed to create output file", "error", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := write(w, points); err != nil {
+		logger.Error("결과 쓰기 실패 / Failed to write results", "error", err)
+		os.Exit(1)
+	}
+
+	s := summarize(points)
+	logger.Info("과거 헬스팩터 조회 완료 / Historical health factors read",
+		"points", len(points),
+		"failed", s.failed,
+		"min_health_factor", s.minHF,
+		"min_health_factor_block", s.minBlock,
+		"first_liquidatable_block", s.firstLiquidatable,
+		"elapsed", time.Since(start).Round(time.Millisecond).String(),
+	)
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "%d행 → %s / %d rows → %s\n", len(points), *out, len(points), *out)
+	}
+	if fetchErr != nil {
+		// 일부 블록은 과거 상태가 없어 실패 (결과에는 error 열로 표시)
+		// Some blocks failed for lack of historical state (shown in the error column)
+		logger.Error("일부 블록 조회 실패 / Some blocks could not be read", "error", fetchErr)
+		os.Exit(1)
+	}
+}
+
+// cacheGet은 nil 캐시를 허용하는 Get입니다.
+// cacheGet is Get that tolerates a nil cache.
+func cacheGet(c *history.Cache, block uint64) (history.Point, bool) {
+	if c == nil {
+		return history.Point{}, false
+	}
+	return c.Get(block)
+}
+
+// summary는 시계열 요약입니다.
+// summary summarizes a series.
+type summary struct {
+	failed            int
+	minHF             float64
+	minBlock          uint64
+	firstLiquidatable uint64
+}
+
+// summarize는 최저 헬스팩터와 처음으로 1 미만이 된 블록을 찾습니다 (없으면 0).
+// summarize finds the minimum health factor and the first block where it fell below 1 (0 when none).
+func summarize(points []history.Point) summary {
+	s := summary{minHF: math.Inf(1)}
+	for _, p := range points {
+		if p.Error != "" {
+			s.failed++
+			continue
+		}
+		if p.HealthFactor == nil {
+			continue
+		}
+		if hf := *p.HealthFactor; hf < s.minHF {
+			s.minHF, s.minBlock = hf, p.Block
+		}
+		if *p.HealthFactor < 1 && s.firstLiquidatable == 0 {
+			s.firstLiquidatable = p.Block
+		}
+	}
+	if math.IsInf(s.minHF, 1) {
+		s.minHF = 0
+	}
+	return s
+}
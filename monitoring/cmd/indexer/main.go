@@ -7,6 +7,7 @@
 // 수집하는 이벤트 / Events collected:
 // - Deposit: 예치 이벤트 / Deposit events
 // - Borrow: 대출 이벤트 / Borrow events
+// - Withdraw: 인출 이벤트 / Withdraw events
 // - Repay: 상환 이벤트 / Repay events
 // - LiquidationCall: 청산 이벤트 / Liquidation events
 //
@@ -23,39 +24,30 @@ import (
 	"context"
 	"flag"
 	"log/slog"
-	"math/big"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-)
-
-// Aave V3 이벤트 시그니처 / Aave V3 event signatures
-// 이벤트 토픽 = keccak256(이벤트 시그니처)
-// Event topic = keccak256(event signature)
-var (
-	// Supply(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint16 indexed referralCode)
-	supplyEventSig = crypto.Keccak256Hash([]byte("Supply(address,address,address,uint256,uint16)"))
-
-	// Borrow(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint8 interestRateMode, uint256 borrowRate, uint16 indexed referralCode)
-	borrowEventSig = crypto.Keccak256Hash([]byte("Borrow(address,address,address,uint256,uint8,uint256,uint16)"))
 
-	// Repay(address indexed reserve, address indexed user, address indexed repayer, uint256 amount, bool useATokens)
-	repayEventSig = crypto.Keccak256Hash([]byte("Repay(address,address,address,uint256,bool)"))
-
-	// LiquidationCall(address indexed collateralAsset, address indexed debtAsset, address indexed user, uint256 debtToCover, uint256 liquidatedCollateralAmount, address liquidator, bool receiveAToken)
-	liquidationEventSig = crypto.Keccak256Hash([]byte("LiquidationCall(address,address,address,uint256,uint256,address,bool)"))
+	"github.com/jeongseup/lending-monitor/internal/cmdutil"
+	"github.com/jeongseup/lending-monitor/internal/config"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/indexer"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
 )
 
 func main() {
 	// CLI 플래그 / CLI flags
-	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (WebSocket 권장) / Ethereum RPC URL (WebSocket recommended)")
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (WebSocket 권장, 쉼표로 여러 개) / Ethereum RPC URL(s) (WebSocket recommended, comma-separated)")
 	fromBlock := flag.Uint64("from-block", 0, "시작 블록 번호 / Starting block number (0 = latest)")
+	backfillChunk := flag.Uint64("backfill-chunk", 2000, "백필 요청당 블록 수 / Blocks per backfill request")
+	rateLimit := flag.Float64("rate-limit", 0, "초당 RPC 컴퓨트 유닛 한도 (0 = 무제한) / RPC compute units per second (0 = unlimited)")
+	rateBurst := flag.Float64("rate-burst", 0, "RPC 버스트 한도 (컴퓨트 유닛) / RPC burst size in compute units")
+	dailyBudget := flag.Float64("daily-budget", 0, "일일 RPC 예산 (컴퓨트 유닛, 0 = 무제한) / Daily RPC budget in compute units (0 = unlimited)")
+	poolFlag := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
 	flag.Parse()
 
 	// 로거 설정 / Logger setup
@@ -64,53 +56,52 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	if _, err := config.LoadIntoFlags(flag.CommandLine, *configPath); err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+	if !common.IsHexAddress(*poolFlag) {
+		logger.Error("잘못된 Pool 주소 / Invalid pool address", "pool", *poolFlag)
+		os.Exit(1)
+	}
+
 	if *rpcURL == "" {
 		logger.Error("RPC URL이 필요합니다 / RPC URL is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	// 이더리움 클라이언트 연결 / Connect to Ethereum client
-	logger.Info("RPC 연결 중... / Connecting to RPC...", "url", *rpcURL)
-	client, err := ethclient.Dial(*rpcURL)
+	// 컨텍스트 및 시그널 핸들링 / Context and signal handling
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 이더리움 클라이언트 연결 (다중 엔드포인트 풀) / Connect to Ethereum client (multi-endpoint pool)
+	urls := rpcpool.SplitURLs(*rpcURL)
+	logger.Info("RPC 연결 중... / Connecting to RPC...", "endpoints", len(urls))
+	// 공유 속도 제한기: 모든 RPC 요청이 같은 버킷과 예산을 사용
+	// Shared rate limiter: every RPC request draws from the same bucket and budget
+	limiter := cmdutil.NewLimiter(*rateLimit, *rateBurst, *dailyBudget)
+
+	poolOpts := rpcpool.DefaultOptions()
+	poolOpts.Limiter = limiter
+	client, err := rpcpool.Dial(ctx, urls, poolOpts, logger)
 	if err != nil {
 		logger.Error("RPC 연결 실패 / Failed to connect to RPC", "error", err)
 		os.Exit(1)
 	}
 	defer client.Close()
-
-	// 컨텍스트 및 시그널 핸들링 / Context and signal handling
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	client.Start(ctx)
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
 	// Aave V3 Pool 주소 / Aave V3 Pool address
-	poolAddress := common.HexToAddress("0x87870Bca3F3fD6335C3F4ce8392D69350B4fA4E2")
-
-	// 이벤트 필터 설정 / Event filter setup
-	// 관심 있는 이벤트 토픽만 필터링합니다
-	// Only filter for events we're interested in
-	topics := [][]common.Hash{{
-		supplyEventSig,
-		borrowEventSig,
-		repayEventSig,
-		liquidationEventSig,
-	}}
-
-	// 시작 블록 설정 / Set starting block
-	var startBlock *big.Int
-	if *fromBlock > 0 {
-		startBlock = new(big.Int).SetUint64(*fromBlock)
-	}
+	poolAddress := common.HexToAddress(*poolFlag)
 
-	// 이벤트 구독 쿼리 / Event subscription query
-	query := ethereum.FilterQuery{
-		FromBlock: startBlock,
-		Addresses: []common.Address{poolAddress},
-		Topics:    topics,
-	}
+	// 관심 있는 이벤트 토픽만 필터링합니다 (contracts.PositionEventSigs)
+	// Only filter for events we're interested in (contracts.PositionEventSigs)
+	ix := indexer.New(client, poolAddress, indexer.NewDedupStore(), "aave-v3", logger)
 
 	logger.Info("이벤트 인덱싱 시작 / Starting event indexing...",
 		"pool", poolAddress.Hex(),
@@ -118,22 +109,18 @@ func main() {
 	)
 
 	// 방법 1: 과거 로그 조회 (백필) / Method 1: Historical log query (backfill)
-	if startBlock != nil {
-		logs, err := client.FilterLogs(ctx, query)
-		if err != nil {
+	// 블록 범위를 나눠 조회하여 각 요청이 속도 제한기를 거치도록 합니다
+	// Query in block-range chunks so each request goes through the rate limiter
+	if *fromBlock > 0 {
+		if _, err := ix.Backfill(ctx, *fromBlock, *backfillChunk); err != nil {
 			logger.Error("과거 로그 조회 실패 / Failed to query historical logs", "error", err)
-		} else {
-			logger.Info("과거 로그 조회 완료 / Historical logs retrieved", "count", len(logs))
-			for _, vLog := range logs {
-				processLog(logger, vLog)
-			}
 		}
 	}
 
 	// 방법 2: 실시간 이벤트 구독 / Method 2: Real-time event subscription
 	// WebSocket 연결이 필요합니다 / Requires WebSocket connection
 	logCh := make(chan types.Log)
-	sub, err := client.SubscribeFilterLogs(ctx, query, logCh)
+	sub, err := client.SubscribeFilterLogs(ctx, ix.Query(), logCh)
 	if err != nil {
 		logger.Warn("실시간 구독 실패 (HTTP RPC?) / Real-time subscription failed (HTTP RPC?)",
 			"error", err,
@@ -151,7 +138,9 @@ func main() {
 	for {
 		select {
 		case vLog := <-logCh:
-			processLog(logger, vLog)
+			if err := ix.Process(vLog); err != nil {
+				logger.Error("이벤트 저장 실패 / Failed to store event", "error", err)
+			}
 		case err := <-sub.Err():
 			logger.Error("구독 오류 / Subscription error", "error", err)
 			return
@@ -163,59 +152,3 @@ func main() {
 		}
 	}
 }
-
-// processLog는 수신된 이벤트 로그를 처리합니다.
-// processLog processes a received event log.
-func processLog(logger *slog.Logger, vLog types.Log) {
-	if len(vLog.Topics) == 0 {
-		return
-	}
-
-	switch vLog.Topics[0] {
-	case supplyEventSig:
-		// Supply 이벤트 처리 / Process Supply event
-		logger.Info("Supply 이벤트 감지 / Supply event detected",
-			"block", vLog.BlockNumber,
-			"tx", vLog.TxHash.Hex(),
-			"reserve", common.BytesToAddress(vLog.Topics[1].Bytes()).Hex(),
-		)
-
-	case borrowEventSig:
-		// Borrow 이벤트 처리 / Process Borrow event
-		logger.Info("Borrow 이벤트 감지 / Borrow event detected",
-			"block", vLog.BlockNumber,
-			"tx", vLog.TxHash.Hex(),
-			"reserve", common.BytesToAddress(vLog.Topics[1].Bytes()).Hex(),
-		)
-
-	case repayEventSig:
-		// Repay 이벤트 처리 / Process Repay event
-		logger.Info("Repay 이벤트 감지 / Repay event detected",
-			"block", vLog.BlockNumber,
-			"tx", vLog.TxHash.Hex(),
-			"reserve", common.BytesToAddress(vLog.Topics[1].Bytes()).Hex(),
-		)
-
-	case liquidationEventSig:
-		// LiquidationCall 이벤트 처리 — 가장 중요한 이벤트!
-		// Process LiquidationCall event — the most important event!
-		logger.Warn("청산 이벤트 감지! / Liquidation event detected!",
-			"block", vLog.BlockNumber,
-			"tx", vLog.TxHash.Hex(),
-			"collateral_asset", common.BytesToAddress(vLog.Topics[1].Bytes()).Hex(),
-			"debt_asset", common.BytesToAddress(vLog.Topics[2].Bytes()).Hex(),
-			"user", common.BytesToAddress(vLog.Topics[3].Bytes()).Hex(),
-		)
-
-		// TODO: 청산 이벤트를 데이터베이스에 저장
-		// TODO: Store liquidation event in database
-		// TODO: Prometheus 카운터 증가
-		// TODO: Increment Prometheus counter
-		// metrics.LiquidationEventsTotal.WithLabelValues("aave-v3").Inc()
-
-	default:
-		logger.Debug("알 수 없는 이벤트 / Unknown event",
-			"topic", vLog.Topics[0].Hex(),
-		)
-	}
-}
@@ -0,0 +1,364 @@
+// 청산 기회 스캐너
+// Liquidation opportunity scanner
+//
+// 이 프로그램은 감시 주소와 (선택) 이벤트로 발견한 대출자 중 HF < 1인 계정을 찾아, 계정마다 순이익이 가장 큰
+// (부채 자산, 담보 자산) 쌍과 최대 debtToCover (Aave V3 Close Factor: HF > 0.95이면 50%, 이하이면 100%),
+// 보너스를 포함한 압류 담보, 현재 기본 수수료로 계산한 가스 비용, USD 순이익을 구해 순위를 매깁니다.
+// 리스크 팀용 읽기 전용 분석이며 트랜잭션을 보내지 않습니다.
+// Among watched addresses and (optionally) borrowers discovered from events, this program finds accounts with
+// HF < 1 and, per account, picks the (debt asset, collateral asset) pair with the largest net profit, the max
+// debtToCover (Aave V3 close factor: 50% while HF > 0.95, 100% at or below), collateral seized including the bonus,
+// the gas cost at the current base fee and the net profit in USD, then ranks them.
+// Read-only analysis for the risk team; it never sends transactions.
+//
+// 모든 조회는 같은 블록에 고정됩니다.
+// Every read is pinned to the same block.
+//
+// 사용 예 / Usage:
+//
+//	go run ./cmd/liquidations --rpc-url $RPC --addresses 0xabc...,0xdef...
+//	go run ./cmd/liquidations --config config.yaml --discover --discover-lookback 200000 --top 20 --json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/config"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/discovery"
+	"github.com/jeongseup/lending-monitor/internal/liquidation"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+)
+
+func main() {
+	// CLI 플래그 / CLI flags
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (쉼표로 여러 개) / Ethereum RPC URL(s), comma-separated (required)")
+	addresses := flag.String("addresses", "", "검사할 주소 (쉼표 구분) / Addresses to check (comma-separated)")
+	poolAddress := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
+	uiProvider := flag.String("ui-pool-data-provider", "", "UiPoolDataProvider 주소 (계정당 한 번의 호출) / UiPoolDataProvider address (one call per account)")
+	discover := flag.Bool("discover", false, "이벤트로 대출자를 발견해 함께 검사 / Discover borrowers from events and check them too")
+	discoverFromBlock := flag.Uint64("discover-from-block", 0, "발견 스캔 시작 블록 (0 = 최신 - lookback) / Discovery scan start block (0 = latest - lookback)")
+	discoverLookback := flag.Uint64("discover-lookback", 50_000, "발견 시 거슬러 올라갈 블록 수 / Blocks to look back for discovery")
+	discoverMax := flag.Int("discover-max", 1000, "발견 계정 최대 수 (부채 큰 순) / Max discovered accounts (largest debt first)")
+	workers := flag.Int("workers", 8, "동시 조회 워커 수 / Number of concurrent workers")
+	callTimeout := flag.Duration("call-timeout", 10*time.Second, "RPC 호출별 타임아웃 / Per-call RPC timeout")
+	closeFactor := flag.Float64("close-factor", 0.5, "HF가 full-close-hf보다 클 때의 Close Factor / Close factor while HF is above full-close-hf")
+	fullCloseHF := flag.Float64("full-close-hf", 0.95, "이 HF 이하에서는 부채 전액 청산 가능 / At or below this HF the whole debt can be liquidated")
+	gasUsed := flag.Uint64("gas-used", liquidation.DefaultOptions().GasUsed, "liquidationCall 가스 사용량 추정 / Estimated liquidationCall gas used")
+	priorityFee := flag.Float64("priority-fee", 1, "기본 수수료에 더할 우선순위 팁 (gwei) / Priority tip added to the base fee (gwei)")
+	gasPrice := flag.Float64("gas-price", 0, "가스 가격 고정 (gwei, 0 = 기본 수수료 + 팁) / Fixed gas price (gwei, 0 = base fee + tip)")
+	nativeSymbol := flag.String("native-symbol", "WETH", "가스 비용 환산에 쓸 네이티브 토큰 리저브 심볼 / Reserve symbol used to price gas in USD")
+	slippage := flag.Float64("slippage", 0, "압류 담보 매도 슬리피지 (0.005 = 0.5%) / Slippage selling seized collateral (0.005 = 0.5%)")
+	profitableOnly := flag.Bool("profitable-only", false, "순이익이 양수인 기회만 출력 / Only print opportunities with positive net profit")
+	top := flag.Int("top", 0, "상위 N개만 출력 (0 = 전부) / Print only the top N (0 = all)")
+	jsonOut := flag.Bool("json", false, "JSON으로 출력 / Print as JSON")
+	flag.Parse()
+
+	// 로거 설정 / Logger setup
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	slog.SetDefault(logger)
+
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	if _, err := config.LoadIntoFlags(flag.CommandLine, *configPath); err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+	if *rpcURL == "" {
+		logger.Error("RPC URL이 필요합니다 / RPC URL is required")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if !common.IsHexAddress(*poolAddress) {
+		logger.Error("잘못된 Pool 주소 / Invalid pool address", "pool", *poolAddress)
+		os.Exit(1)
+	}
+	if *closeFactor <= 0 || *closeFactor > 1 || *fullCloseHF <= 0 || *slippage < 0 || *slippage >= 1 {
+		logger.Error("잘못된 청산 파라미터 / Invalid liquidation parameters",
+			"close_factor", *closeFactor,
+			"full_close_hf", *fullCloseHF,
+			"slippage", *slippage,
+		)
+		os.Exit(1)
+	}
+	opts := liquidation.DefaultOptions()
+	opts.CloseFactor = uint16(*closeFactor*10000 + 0.5)
+	opts.FullCloseThreshold, _ = new(big.Float).Mul(big.NewFloat(*fullCloseHF), big.NewFloat(1e18)).Int(nil)
+	opts.GasUsed = *gasUsed
+	opts.Slippage = *slippage
+
+	// Ctrl-C로 중단 / Interrupt with Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := rpcpool.Dial(ctx, rpcpool.SplitURLs(*rpcURL), rpcpool.DefaultOptions(), logger)
+	if err != nil {
+		logger.Error("RPC 연결 실패 / Failed to connect to RPC", "error", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	// 모든 조회를 최신 블록에 고정 / Pin every read to the latest block
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logger.Error("최신 블록 조회 실패 / Failed to get latest block", "error", err)
+		os.Exit(1)
+	}
+	callOpts := func(ctx context.Context) *bind.CallOpts {
+		return &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	}
+
+	poolCaller := contracts.NewAavePoolCaller(client, common.HexToAddress(*poolAddress))
+	addrs, err := contracts.ResolveAaveAddresses(callOpts(ctx), client, poolCaller)
+	if err != nil {
+		logger.Error("데이터 제공자 조회 실패 / Failed to resolve data provider", "error", err)
+		os.Exit(1)
+	}
+	if common.IsHexAddress(*uiProvider) {
+		addrs.UiPoolDataProvider = common.HexToAddress(*uiProvider)
+	}
+	positions := contracts.NewPositionReader(client, addrs)
+	reserves, err := positions.Reserves(callOpts(ctx))
+	if err != nil {
+		logger.Error("리저브 목록 조회 실패 / Failed to read reserves", "error", err)
+		os.Exit(1)
+	}
+	prices, err := positions.Prices(callOpts(ctx), reserves)
+	if err != nil {
+		logger.Error("오라클 가격 조회 실패 / Failed to read oracle prices", "error", err)
+		os.Exit(1)
+	}
+	gas := gasParams(logger, head.BaseFee, *gasPrice, *priorityFee, *nativeSymbol, reserves, prices)
+
+	candidates := parseAddresses(logger, *addresses)
+	if *discover {
+		discOpts := discovery.DefaultOptions()
+		discOpts.FromBlock = *discoverFromBlock
+		discOpts.Lookback = *discoverLookback
+		discOpts.MaxAccounts = *discoverMax
+		discOpts.CallTimeout = *callTimeout
+		disc := discovery.New(client, poolCaller, discOpts, logger)
+		if err := disc.Scan(ctx); err != nil {
+			logger.Error("대출자 발견 실패 / Borrower discovery failed", "error", err)
+			os.Exit(1)
+		}
+		candidates = appendUnique(candidates, disc.Addresses())
+	}
+	if len(candidates) == 0 {
+		logger.Error("검사할 주소가 없습니다 (--addresses 또는 --discover) / No addresses to check (--addresses or --discover)")
+		os.Exit(1)
+	}
+
+	logger.Info("청산 기회 스캔 시작 / Scanning for liquidation opportunities",
+		"block", head.Number.Uint64(),
+		"accounts", len(candidates),
+		"gas_usd", gas.USD(opts.GasUsed),
+	)
+	ops, failed := scan(ctx, logger, positions, callOpts, *callTimeout, *workers, candidates, reserves, prices, gas, opts)
+	if ctx.Err() != nil {
+		logger.Error("스캔 중단 / Scan aborted", "error", ctx.Err())
+		os.Exit(1)
+	}
+	liquidation.Rank(ops)
+	if *profitableOnly {
+		kept := ops[:0]
+		for _, o := range ops {
+			if o.NetProfitUSD > 0 {
+				kept = append(kept, o)
+			}
+		}
+		ops = kept
+	}
+	logger.Info("스캔 완료 / Scan finished",
+		"accounts", len(candidates),
+		"failed", failed,
+		"liquidatable", len(ops),
+	)
+	if *top > 0 && len(ops) > *top {
+		ops = ops[:*top]
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(ops); err != nil {
+			logger.Error("JSON 출력 실패 / Failed to write JSON", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	printTable(os.Stdout, ops, reserves)
+}
+
+// scan은 후보 계정의 포지션을 병렬로 읽고 청산 가능한 계정의 기회를 모읍니다. 읽기 실패 수도 반환합니다.
+// scan reads candidate positions in parallel and collects opportunities for liquidatable accounts. It also returns the number of failed reads.
+func scan(
+	ctx context.Context,
+	logger *slog.Logger,
+	positions *contracts.PositionReader,
+	callOpts func(context.Context) *bind.CallOpts,
+	callTimeout time.Duration,
+	workers int,
+	candidates []common.Address,
+	reserves []contracts.Reserve,
+	prices map[common.Address]*big.Int,
+	gas liquidation.Gas,
+	opts liquidation.Options,
+) ([]*liquidation.Opportunity, int) {
+	jobs := make(chan common.Address)
+	var (
+		mu     sync.Mutex
+		ops    []*liquidation.Opportunity
+		failed int
+		wg     sync.WaitGroup
+	)
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range jobs {
+				callCtx, cancel := context.WithTimeout(ctx, callTimeout)
+				pos, err := positions.UserPosition(callOpts(callCtx), addr, reserves, prices)
+				cancel()
+				if err != nil {
+					logger.Warn("포지션 조회 실패 / Failed to read position", "address", addr.Hex(), "error", err)
+					mu.Lock()
+					failed++
+					mu.Unlock()
+					continue
+				}
+				if o := liquidation.Evaluate(pos, gas, opts); o != nil {
+					mu.Lock()
+					ops = append(ops, o)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, addr := range candidates {
+		select {
+		case jobs <- addr:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return ops, failed
+}
+
+// gasParams는 가스 가격 (고정값 또는 기본 수수료 + 팁)과 네이티브 토큰 가격을 정합니다.
+// 네이티브 토큰 리저브를 찾지 못하면 경고하고 가스 비용을 0으로 둡니다.
+// gasParams settles the gas price (fixed, or base fee + tip) and the native token price.
+// When the native token reserve is not found it warns and leaves the gas cost at zero.
+func gasParams(logger *slog.Logger, baseFee *big.Int, fixedGwei, tipGwei float64, nativeSymbol string, reserves []contracts.Reserve, prices map[common.Address]*big.Int) liquidation.Gas {
+	var gas liquidation.Gas
+	switch {
+	case fixedGwei > 0:
+		gas.Price = gwei(fixedGwei)
+	case baseFee != nil:
+		gas.Price = new(big.Int).Add(baseFee, gwei(tipGwei))
+	default:
+		logger.Warn("기본 수수료 없음 (EIP-1559 이전 체인), --gas-price 필요 / No base fee (pre-EIP-1559 chain), --gas-price needed")
+	}
+	for _, r := range reserves {
+		if strings.EqualFold(r.Symbol, nativeSymbol) {
+			gas.NativePrice = prices[r.Asset]
+		}
+	}
+	if gas.NativePrice == nil {
+		logger.Warn("네이티브 토큰 리저브 없음, 가스 비용 0 / Native token reserve not found, gas cost is zero", "symbol", nativeSymbol)
+	}
+	return gas
+}
+
+// gwei는 gwei 값을 wei로 변환합니다.
+// gwei converts a gwei value to wei.
+func gwei(v float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(v), big.NewFloat(1e9)).Int(nil)
+	return wei
+}
+
+// parseAddresses는 쉼표로 구분된 주소를 파싱합니다. 잘못된 주소는 경고 후 건너뜁니다.
+// parseAddresses parses comma-separated addresses. Invalid ones are skipped with a warning.
+func parseAddresses(logger *slog.Logger, s string) []common.Address {
+	var out []common.Address
+	for _, a := range strings.Split(s, ",") {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if !common.IsHexAddress(a) {
+			logger.Warn("잘못된 주소 건너뜀 / Skipping invalid address", "address", a)
+			continue
+		}
+		out = appendUnique(out, []common.Address{common.HexToAddress(a)})
+	}
+	return out
+}
+
+// appendUnique는 중복 없이 주소를 이어 붙입니다.
+// appendUnique appends addresses without duplicates.
+func appendUnique(list, more []common.Address) []common.Address {
+	seen := make(map[common.Address]bool, len(list))
+	for _, a := range list {
+		seen[a] = true
+	}
+	for _, a := range more {
+		if !seen[a] {
+			seen[a] = true
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+// printTable은 순위 목록을 표로 씁니다.
+// printTable writes the ranked list as a table.
+func printTable(w io.Writer, ops []*liquidation.Opportunity, reserves []contracts.Reserve) {
+	decimals := make(map[common.Address]uint8, len(reserves))
+	for _, r := range reserves {
+		decimals[r.Asset] = r.Decimals
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "#\tuser\tHF\tclose\tdebt\tdebtToCover\trepay $\tcollateral\treceived\treceived $\tgross $\tgas $\tnet $\t")
+	for i, o := range ops {
+		fmt.Fprintf(tw, "%d\t%s\t%.4f\t%d%%\t%s\t%s\t%.2f\t%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+			i+1, o.User.Hex(), o.HealthFactor, o.CloseFactor/100,
+			o.DebtSymbol, units(o.DebtToCover, decimals[o.DebtAsset]), o.DebtToCoverUSD,
+			o.CollateralSymbol, units(o.CollateralReceived, decimals[o.CollateralAsset]), o.CollateralReceivedUSD,
+			o.GrossProfitUSD, o.GasUSD, o.NetProfitUSD,
+		)
+	}
+	tw.Flush()
+	if len(ops) == 0 {
+		fmt.Fprintln(w, "청산 가능한 계정 없음 / No liquidatable accounts")
+	}
+}
+
+// units는 자산 단위 정수를 소수 6자리 문자열로 씁니다.
+// units writes an integer amount in asset units as a string with 6 decimals.
+func units(v *big.Int, decimals uint8) string {
+	f := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return f.Text('f', 6)
+}
@@ -8,6 +8,18 @@
 //
 //	go run ./cmd/monitor --rpc-url $ETH_RPC_URL --addresses 0x123...,0x456...
 //
+// 여러 RPC 제공자 사용 (페일오버 + 청산 판단 쿼럼) / Multiple RPC providers (failover + liquidation quorum):
+//
+//	go run ./cmd/monitor --rpc-url $RPC_A,$RPC_B --quorum 2 --addresses 0x123...
+//
+// 블록 기반 모니터링 (5블록마다, 모든 호출을 같은 블록에 고정) / Block-driven (every 5 blocks, all calls pinned to one block):
+//
+//	go run ./cmd/monitor --rpc-url wss://... --mode block --block-step 5 --addresses 0x123...
+//
+// 대출자 자동 발견 (이벤트 기반, 부채 큰 순 최대 500개) / Automatic borrower discovery (event-based, top 500 by debt):
+//
+//	go run ./cmd/monitor --rpc-url $ETH_RPC_URL --discover --discover-max 500
+//
 // DevOps 관점:
 // - 노드 운영 경험의 RPC 연결 패턴을 활용합니다
 // - Prometheus 메트릭으로 Grafana 대시보드와 연동합니다
@@ -22,31 +34,73 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"log/slog"
-	"math/big"
+	"math"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/jeongseup/lending-monitor/internal/alert"
+	"github.com/jeongseup/lending-monitor/internal/cmdutil"
+	"github.com/jeongseup/lending-monitor/internal/config"
 	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/discovery"
 	"github.com/jeongseup/lending-monitor/internal/metrics"
+	"github.com/jeongseup/lending-monitor/internal/monitor"
+	"github.com/jeongseup/lending-monitor/internal/ratelimit"
+	"github.com/jeongseup/lending-monitor/internal/risk"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
 )
 
+// secondsPerBlock은 이더리움 메인넷의 블록 시간입니다 (block 모드의 주기 메트릭 환산용).
+// secondsPerBlock is the Ethereum mainnet block time (for the interval metric in block mode).
+const secondsPerBlock = 12
+
 func main() {
 	// CLI 플래그 설정 / CLI flag setup
-	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL / Ethereum RPC URL (required)")
-	addresses := flag.String("addresses", "", "모니터링할 주소 (쉼표 구분) / Addresses to monitor (comma-separated)")
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (쉼표로 여러 개) / Ethereum RPC URL(s), comma-separated (required)")
+	chainIDFlag := flag.Uint64("chain-id", 0, "기대하는 체인 ID (0 = 확인 안 함) / Expected chain ID (0 = not checked)")
+	flag.String("addresses", "", "모니터링할 주소 (쉼표 구분) / Addresses to monitor (comma-separated)")
 	interval := flag.Duration("interval", 30*time.Second, "모니터링 주기 / Monitoring interval")
 	metricsPort := flag.String("metrics-port", ":9090", "Prometheus 메트릭 포트 / Prometheus metrics port")
 	webhookURL := flag.String("webhook-url", "", "알림 웹훅 URL / Alert webhook URL (optional)")
+	realert := flag.Duration("realert-interval", 0, "같은 수준의 헬스팩터 알림 반복 주기 (0 = 수준이 바뀔 때만) / Repeat interval for an unchanged health factor alert level (0 = only on level changes)")
+	mode := flag.String("mode", "interval", "사이클 트리거: interval(시간) 또는 block(새 블록) / Cycle trigger: interval (wall clock) or block (new heads)")
+	blockStep := flag.Uint64("block-step", 1, "block 모드에서 사이클당 블록 수 / Blocks per cycle in block mode")
+	blockPoll := flag.Duration("block-poll", 4*time.Second, "구독 불가 시 블록 번호 폴링 주기 / Block number polling interval when subscriptions are unavailable")
+	workers := flag.Int("workers", 8, "동시 조회 워커 수 / Number of concurrent workers")
+	cycleTimeout := flag.Duration("cycle-timeout", 0, "사이클 데드라인 (0 = 주기와 동일) / Cycle deadline (0 = same as interval)")
+	callTimeout := flag.Duration("call-timeout", 10*time.Second, "RPC 호출별 타임아웃 / Per-call RPC timeout")
+	quorum := flag.Int("quorum", 0, "HF < 1 판정 전 합의할 엔드포인트 수 (0 = 비활성, 그 외 2 이상) / Endpoints that must agree before reporting HF < 1 (0 = disabled, otherwise >= 2)")
+	maxBlockLag := flag.Uint64("max-block-lag", 3, "정상 엔드포인트의 최대 블록 지연 / Max block lag for a healthy endpoint")
+	rateLimit := flag.Float64("rate-limit", 0, "초당 RPC 컴퓨트 유닛 한도 (0 = 무제한) / RPC compute units per second (0 = unlimited)")
+	rateBurst := flag.Float64("rate-burst", 0, "RPC 버스트 한도 (컴퓨트 유닛) / RPC burst size in compute units")
+	dailyBudget := flag.Float64("daily-budget", 0, "일일 RPC 예산 (컴퓨트 유닛, 0 = 무제한) / Daily RPC budget in compute units (0 = unlimited)")
+	poolAddress := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
+	discover := flag.Bool("discover", false, "이벤트로 대출자를 자동 발견해 감시 목록에 추가 / Discover borrowers from events and add them to the watch list")
+	discoverFromBlock := flag.Uint64("discover-from-block", 0, "발견 스캔 시작 블록 (0 = 최신 - lookback) / Discovery scan start block (0 = latest - lookback)")
+	discoverLookback := flag.Uint64("discover-lookback", 50_000, "발견 시 거슬러 올라갈 블록 수 / Blocks to look back for discovery")
+	discoverMax := flag.Int("discover-max", 1000, "발견 계정 최대 수 (부채 큰 순) / Max discovered accounts (largest debt first)")
+	discoverPoll := flag.Duration("discover-poll", 12*time.Second, "새 이벤트 확인 주기 / Interval for scanning new events")
+	exposition := flag.String("hf-exposition", string(monitor.ExposePinned), "사용자별 HF 메트릭 대상: all, pinned(고정 주소만), none / Per-user HF series for: all, pinned (pinned addresses only), none")
+	topN := flag.Int("hf-top-n", 20, "위험도 상위 N개 계정 게이지 (0 = 비활성) / Gauges for the N riskiest accounts (0 = disabled)")
+	hfBuckets := flag.String("hf-buckets", "1,1.05,1.1,1.25,1.5,2", "포지션/부채 집계용 HF 구간 경계 (오름차순, 쉼표 구분, 비우면 비활성) / HF range boundaries for position/debt counts (ascending, comma-separated, empty = disabled)")
+	riskInterval := flag.Duration("risk-interval", 5*time.Minute, "가격 충격 분석 주기 (0 = 비활성) / Price shock analysis interval (0 = disabled)")
+	riskShocks := flag.String("risk-shocks", "-5,-10,-20,-30", "가격 충격 시나리오 (%, 쉼표 구분) / Price shock scenarios in percent (comma-separated)")
+	uiProvider := flag.String("ui-pool-data-provider", "", "UiPoolDataProvider 주소 (V3.0, 계정당 한 번의 호출로 포지션 조회; 비우면 리저브별 조회) / UiPoolDataProvider address (V3.0, reads a position in one call; empty = per-reserve reads)")
+	liqPrices := flag.Bool("liquidation-prices", true, "고정 계정과 경고 계정의 청산 가격 계산 / Compute liquidation prices for pinned and warning accounts")
+	riskMax := flag.Int("risk-max-accounts", 200, "충격 분석할 최대 계정 수 (HF 낮은 순) / Max accounts in the shock analysis (lowest HF first)")
+	flag.Float64("hf-warning", 1.2, "경고 헬스팩터 임계값 / Warning health factor threshold")
+	flag.Float64("hf-critical", 1.0, "긴급 헬스팩터 임계값 / Critical health factor threshold")
 	flag.Parse()
 
 	// 로거 설정 / Logger setup
@@ -55,51 +109,183 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	explicit := config.ExplicitFlags(flag.CommandLine)
+	cfg, err := config.LoadIntoFlags(flag.CommandLine, *configPath)
+	if err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+	if !common.IsHexAddress(*poolAddress) {
+		logger.Error("잘못된 Pool 주소 / Invalid pool address", "pool", *poolAddress)
+		os.Exit(1)
+	}
+
+	if *mode != "interval" && *mode != "block" {
+		logger.Error("잘못된 모드 / Invalid mode", "mode", *mode)
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	if *rpcURL == "" {
 		logger.Error("RPC URL이 필요합니다 / RPC URL is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	// 컨텍스트 설정 (graceful shutdown) / Context setup (graceful shutdown)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// 이더리움 클라이언트 연결 / Connect to Ethereum client
-	// 노드 운영 경험 활용: 안정적인 RPC 연결 패턴
-	// Leveraging node ops experience: reliable RPC connection pattern
-	logger.Info("RPC 연결 중... / Connecting to RPC...", "url", *rpcURL)
-	client, err := ethclient.Dial(*rpcURL)
+	// 노드 운영 경험 활용: 여러 제공자에 연결하고 가장 건강한 곳으로 라우팅
+	// Leveraging node ops experience: connect to several providers and route to the healthiest
+	urls := rpcpool.SplitURLs(*rpcURL)
+	logger.Info("RPC 연결 중... / Connecting to RPC...", "endpoints", len(urls))
+	// 공유 속도 제한기: 모든 RPC 요청이 같은 버킷과 예산을 사용
+	// Shared rate limiter: every RPC request draws from the same bucket and budget
+	limiter := cmdutil.NewLimiter(*rateLimit, *rateBurst, *dailyBudget)
+
+	poolOpts := rpcpool.DefaultOptions()
+	poolOpts.MaxBlockLag = *maxBlockLag
+	poolOpts.Limiter = limiter
+	client, err := rpcpool.Dial(ctx, urls, poolOpts, logger)
 	if err != nil {
 		logger.Error("RPC 연결 실패 / Failed to connect to RPC", "error", err)
 		os.Exit(1)
 	}
 	defer client.Close()
+	client.Start(ctx)
+
+	// 쿼럼 1은 단일 제공자와 같으므로 조용히 끄지 않고 거부 / A quorum of 1 is a single provider, so reject it instead of silently disabling
+	if *quorum == 1 || *quorum < 0 {
+		logger.Error("쿼럼은 0(비활성) 또는 2 이상이어야 합니다 / Quorum must be 0 (disabled) or at least 2", "quorum", *quorum)
+		os.Exit(1)
+	}
+	if *quorum > client.Len() {
+		logger.Error("쿼럼이 엔드포인트 수보다 큽니다 / Quorum exceeds number of endpoints",
+			"quorum", *quorum,
+			"endpoints", client.Len(),
+		)
+		os.Exit(1)
+	}
 
 	// 체인 ID 확인 / Verify chain ID
-	chainID, err := client.ChainID(context.Background())
+	chainID, err := cmdutil.CheckChainID(ctx, client, *chainIDFlag)
 	if err != nil {
-		logger.Error("체인 ID 조회 실패 / Failed to get chain ID", "error", err)
+		logger.Error("체인 ID 확인 실패 / Chain ID check failed", "error", err)
 		os.Exit(1)
 	}
 	logger.Info("연결 완료 / Connected", "chainID", chainID)
 
-	// 모니터링 대상 주소 파싱 / Parse monitoring target addresses
-	var monitorAddresses []common.Address
-	if *addresses != "" {
-		for _, addr := range strings.Split(*addresses, ",") {
-			addr = strings.TrimSpace(addr)
-			if common.IsHexAddress(addr) {
-				monitorAddresses = append(monitorAddresses, common.HexToAddress(addr))
-			} else {
-				logger.Warn("잘못된 주소 무시 / Ignoring invalid address", "address", addr)
-			}
-		}
+	// 감시 주소와 임계값: 설정 파일 변경 또는 SIGHUP으로 재시작 없이 교체
+	// Watch list and thresholds: swapped without a restart on config file change or SIGHUP
+	reloader := config.NewReloader(flag.CommandLine, *configPath, explicit, cfg, logger)
+	rt := reloader.Current()
+	if err := rt.Validate(); err != nil {
+		logger.Error("잘못된 임계값 / Invalid thresholds", "error", err)
+		os.Exit(1)
 	}
 
 	logger.Info("모니터링 설정 완료 / Monitor configured",
-		"addresses", len(monitorAddresses),
+		"addresses", len(rt.Addresses),
 		"interval", interval.String(),
 	)
 
 	// Aave Pool 클라이언트 생성 / Create Aave Pool client
-	poolCaller := contracts.NewAavePoolCaller(client, contracts.AaveV3Pool)
+	poolCaller := contracts.NewAavePoolCaller(client, common.HexToAddress(*poolAddress))
+
+	// 쿼럼 클라이언트: 청산 가능 판정은 여러 제공자가 동의해야 함
+	// Quorum client: a liquidatable verdict requires agreement across providers
+	var quorumCaller *contracts.AavePoolCaller
+	if *quorum > 1 {
+		quorumCaller = contracts.NewAavePoolCaller(client.Quorum(*quorum), common.HexToAddress(*poolAddress))
+	}
+
+	// 모니터 생성 / Create monitor
+	monitorOpts := monitor.DefaultOptions()
+	monitorOpts.Workers = *workers
+	monitorOpts.CycleTimeout = *interval
+	if *cycleTimeout > 0 {
+		monitorOpts.CycleTimeout = *cycleTimeout
+	}
+	monitorOpts.CallTimeout = *callTimeout
+	monitorOpts.WarningThreshold = rt.HealthFactorWarning
+	monitorOpts.CriticalThreshold = rt.HealthFactorCritical
+	monitorOpts.TopN = *topN
+	if monitorOpts.Exposition, err = monitor.ParseExposition(*exposition); err != nil {
+		logger.Error("잘못된 HF 노출 방식 / Invalid HF exposition", "error", err)
+		os.Exit(1)
+	}
+	if monitorOpts.HFBuckets, err = monitor.ParseHFBuckets(*hfBuckets); err != nil {
+		logger.Error("잘못된 HF 구간 / Invalid HF buckets", "error", err)
+		os.Exit(1)
+	}
+
+	// 알림 전송기 (웹훅 URL이 있을 때만) / Alert sender (only when a webhook URL is set)
+	var alerter *alert.WebhookAlerter
+	if *webhookURL != "" {
+		alerter = alert.NewWebhookAlerter(*webhookURL, logger)
+		alerter.SetHealthFactorThresholds(rt.HealthFactorWarning, rt.HealthFactorCritical)
+		alerter.SetRealertInterval(*realert)
+	}
+	mon := monitor.New(poolCaller, quorumCaller, alerter, monitorOpts, logger)
+
+	// 리저브별 포지션 리더 (청산 가격, 충격 분석용). 준비에 실패하면 두 기능만 비활성화
+	// Per-reserve position reader (liquidation prices, shock analysis). On setup failure only those features are disabled
+	var positions *contracts.PositionReader
+	if *liqPrices || *riskInterval > 0 {
+		positions = cmdutil.NewPositionReader(ctx, logger, client, poolCaller, *uiProvider, "liquidation prices, shock analysis")
+	}
+	if positions != nil && *liqPrices {
+		mon.SetPositionReader(positions)
+	}
+
+	// 대출자 자동 발견: 이벤트로 찾은 부채 보유 계정을 고정 목록 뒤에 부채 큰 순으로 추가
+	// Borrower discovery: debt-holding accounts found from events follow the pinned list, largest debt first
+	var disc *discovery.Discovery
+	if *discover {
+		discOpts := discovery.DefaultOptions()
+		discOpts.FromBlock = *discoverFromBlock
+		discOpts.Lookback = *discoverLookback
+		discOpts.MaxAccounts = *discoverMax
+		discOpts.PollInterval = *discoverPoll
+		discOpts.CallTimeout = *callTimeout
+		disc = discovery.New(client, poolCaller, discOpts, logger)
+		go disc.Run(ctx)
+	}
+	watchList := func() []monitor.Target {
+		return mergeWatchList(reloader.Current(), disc)
+	}
+
+	// 마지막 사이클의 헬스팩터 (충격 분석 후보) / Health factors of the last cycle (shock analysis candidates)
+	var lastPositions atomic.Pointer[map[common.Address]float64]
+	runCycle := func(block *monitor.BlockRef) monitor.CycleResult {
+		result := mon.RunCycle(ctx, watchList(), block)
+		lastPositions.Store(&result.Positions)
+		return result
+	}
+
+	// 가격 충격 분석: 담보 자산별로 충격 시 청산 가능해지는 부채 계산
+	// Price shock analysis: debt that becomes liquidatable per collateral asset under shocks
+	if positions != nil && *riskInterval > 0 {
+		startRiskAnalyzer(ctx, logger, positions, *riskInterval, *riskShocks, *riskMax, func() map[common.Address]float64 {
+			if p := lastPositions.Load(); p != nil {
+				return *p
+			}
+			return nil
+		})
+	}
+
+	// 리로드 시 임계값 교체 (빠진 주소의 메트릭은 다음 사이클에 정리됨)
+	// On reload swap thresholds (metrics of removed addresses are cleaned up next cycle)
+	reloader.OnReload(func(_, cur *config.Runtime) {
+		mon.SetThresholds(cur.HealthFactorWarning, cur.HealthFactorCritical)
+		if alerter != nil {
+			alerter.SetHealthFactorThresholds(cur.HealthFactorWarning, cur.HealthFactorCritical)
+		}
+	})
+	go reloader.Run(ctx)
 
 	// Prometheus 메트릭 서버 시작 / Start Prometheus metrics server
 	go func() {
@@ -110,100 +296,154 @@ func main() {
 		}
 	}()
 
-	// 컨텍스트 설정 (graceful shutdown) / Context setup (graceful shutdown)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	// 시그널 핸들링 / Signal handling
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
-	// 모니터링 루프 / Monitoring loop
-	ticker := time.NewTicker(*interval)
-	defer ticker.Stop()
+	logger.Info("모니터링 시작 / Starting monitoring loop...", "mode", *mode)
 
-	logger.Info("모니터링 시작 / Starting monitoring loop...")
+	switch *mode {
+	case "block":
+		// 블록 기반: N 블록마다 사이클 실행, 모든 호출을 해당 블록에 고정
+		// Block-driven: one cycle every N blocks, every call pinned to that block
+		heads := monitor.WatchHeads(ctx, client, *blockPoll, logger)
+		var lastBlock uint64
+		for {
+			select {
+			case h, ok := <-heads:
+				if !ok {
+					return
+				}
+				n := h.Number.Uint64()
+				step := stretchBlockStep(limiter, *blockStep)
+				if lastBlock != 0 && n < lastBlock+step {
+					continue
+				}
+				if lastBlock != 0 && n >= lastBlock+2*step {
+					skipped := (n-lastBlock)/step - 1
+					metrics.MonitorCyclesSkippedTotal.Add(float64(skipped))
+					logger.Warn("블록 사이클 누락 / Block cycles skipped",
+						"last_block", lastBlock,
+						"block", n,
+						"step", step,
+						"skipped", skipped,
+					)
+				}
+				lastBlock = n
+				runCycle(monitor.BlockRefFromHeader(h))
+			case sig := <-sigCh:
+				logger.Info("종료 시그널 수신 / Received shutdown signal", "signal", sig)
+				cancel()
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
 
-	// 첫 번째 실행 / First run
-	monitorCycle(ctx, logger, poolCaller, monitorAddresses, *webhookURL)
+	default:
+		// 시간 기반: 주기마다 최신 블록을 조회해 사이클 내 호출을 고정
+		// Interval-driven: each cycle pins its calls to the latest block at cycle start
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+		currentInterval := *interval
 
-	for {
-		select {
-		case <-ticker.C:
-			monitorCycle(ctx, logger, poolCaller, monitorAddresses, *webhookURL)
-		case sig := <-sigCh:
-			logger.Info("종료 시그널 수신 / Received shutdown signal", "signal", sig)
-			cancel()
-			return
-		case <-ctx.Done():
-			return
+		// 첫 번째 실행 / First run
+		result := runCycle(latestBlock(ctx, logger, client))
+		monitor.RecordOverrun(logger, result.Duration, currentInterval)
+		currentInterval = cmdutil.ApplyBackpressure(logger, ticker, limiter, "monitor", *interval, currentInterval)
+
+		for {
+			select {
+			case <-ticker.C:
+				result := runCycle(latestBlock(ctx, logger, client))
+				monitor.RecordOverrun(logger, result.Duration, currentInterval)
+				currentInterval = cmdutil.ApplyBackpressure(logger, ticker, limiter, "monitor", *interval, currentInterval)
+			case sig := <-sigCh:
+				logger.Info("종료 시그널 수신 / Received shutdown signal", "signal", sig)
+				cancel()
+				return
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
 
-// monitorCycle은 한 번의 모니터링 사이클을 실행합니다.
-// monitorCycle executes one monitoring cycle.
-func monitorCycle(
+// startRiskAnalyzer는 충격 분석기를 시작합니다. 잘못된 충격 시나리오는 경고 후 무시합니다.
+// startRiskAnalyzer starts the shock analyzer. Invalid shock scenarios are ignored with a warning.
+func startRiskAnalyzer(
 	ctx context.Context,
 	logger *slog.Logger,
-	poolCaller *contracts.AavePoolCaller,
-	addresses []common.Address,
-	webhookURL string,
+	positions *contracts.PositionReader,
+	interval time.Duration,
+	shocks string,
+	maxAccounts int,
+	candidates func() map[common.Address]float64,
 ) {
-	start := time.Now()
-	defer func() {
-		duration := time.Since(start).Seconds()
-		metrics.MonitorCycleDuration.Observe(duration)
-		logger.Info("모니터링 사이클 완료 / Monitor cycle complete",
-			"duration_ms", time.Since(start).Milliseconds(),
-			"addresses_checked", len(addresses),
-		)
-	}()
-
-	// 1e18을 big.Float로 변환 (헬스팩터 스케일링용)
-	// Convert 1e18 to big.Float (for health factor scaling)
-	scale := new(big.Float).SetFloat64(1e18)
-
-	for _, addr := range addresses {
-		// 사용자 계정 데이터 조회 / Get user account data
-		data, err := poolCaller.GetUserAccountData(nil, addr)
-		if err != nil {
-			logger.Error("계정 데이터 조회 실패 / Failed to get account data",
-				"address", addr.Hex(),
-				"error", err,
-			)
+	opts := risk.DefaultOptions()
+	opts.Interval = interval
+	opts.MaxAccounts = maxAccounts
+	opts.Shocks = nil
+	for _, s := range strings.Split(shocks, ",") {
+		pct, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || pct >= 0 || pct <= -100 {
+			logger.Warn("잘못된 충격 시나리오 무시 / Ignoring invalid shock scenario", "shock", s)
 			continue
 		}
+		opts.Shocks = append(opts.Shocks, pct/100)
+	}
 
-		// 헬스팩터를 사람이 읽을 수 있는 형식으로 변환
-		// Convert health factor to human-readable format
-		// 헬스팩터는 18 소수점 (1e18 = 1.0)
-		// Health factor has 18 decimals (1e18 = 1.0)
-		hfFloat := new(big.Float).SetInt(data.HealthFactor)
-		hfFloat.Quo(hfFloat, scale)
-		hfValue, _ := hfFloat.Float64()
-
-		// Prometheus 메트릭 업데이트 / Update Prometheus metrics
-		metrics.HealthFactor.WithLabelValues("aave-v3", addr.Hex()).Set(hfValue)
-
-		// 로깅 / Logging
-		logger.Info("포지션 상태 / Position status",
-			"address", addr.Hex(),
-			"health_factor", fmt.Sprintf("%.4f", hfValue),
-			"total_collateral", data.TotalCollateralBase.String(),
-			"total_debt", data.TotalDebtBase.String(),
-		)
+	logger.Info("충격 분석 시작 / Starting shock analysis", "interval", interval.String())
+	analyzer := risk.NewAnalyzer(positions, opts, logger)
+	go analyzer.Run(ctx, candidates)
+}
 
-		// 헬스팩터 알림 확인 / Check health factor alerts
-		// < 1.0: 즉시 청산 가능 / immediately liquidatable
-		// < 1.2: 경고 (곧 청산될 수 있음) / warning (may become liquidatable)
-		if hfValue < 1.2 && hfValue > 0 && webhookURL != "" {
-			logger.Warn("낮은 헬스팩터 감지! / Low health factor detected!",
-				"address", addr.Hex(),
-				"health_factor", hfValue,
-			)
-			// TODO: 웹훅 알림 전송 / Send webhook alert
-			// alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat)
+// mergeWatchList는 고정 주소 뒤에 발견된 주소를 중복 없이 이어 붙이고 라벨과 그룹을 붙입니다.
+// mergeWatchList appends discovered addresses after the pinned ones without duplicates,
+// attaching labels and groups.
+//
+// 사이클은 목록 순서대로 주소를 분배하므로 고정 주소와 큰 포지션이 먼저 확인됩니다.
+// A cycle dispatches addresses in list order, so pinned addresses and large positions are checked first.
+func mergeWatchList(rt *config.Runtime, disc *discovery.Discovery) []monitor.Target {
+	var discovered []common.Address
+	if disc != nil {
+		discovered = disc.Addresses()
+	}
+	list := make([]monitor.Target, 0, len(rt.Addresses)+len(discovered))
+	seen := make(map[common.Address]bool, len(rt.Addresses)+len(discovered))
+	add := func(addr common.Address, defaultGroup string, pinned bool) {
+		if seen[addr] {
+			return
 		}
+		seen[addr] = true
+		l := rt.LabelOf(addr, defaultGroup)
+		list = append(list, monitor.Target{Address: addr, Label: l.Label, Group: l.Group, Pinned: pinned})
+	}
+	for _, addr := range rt.Addresses {
+		add(addr, monitor.GroupPinned, true)
+	}
+	for _, addr := range discovered {
+		add(addr, monitor.GroupDiscovered, false)
+	}
+	return list
+}
+
+// latestBlock은 사이클을 고정할 최신 블록을 조회합니다. 실패하면 nil (고정 없음)을 반환합니다.
+// latestBlock fetches the latest block to pin a cycle to. Returns nil (unpinned) on failure.
+func latestBlock(ctx context.Context, logger *slog.Logger, client *rpcpool.Pool) *monitor.BlockRef {
+	h, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logger.Warn("최신 블록 조회 실패, 고정 없이 실행 / Failed to get latest block, running unpinned", "error", err)
+		return nil
 	}
+	return monitor.BlockRefFromHeader(h)
+}
+
+// stretchBlockStep은 RPC 예산 역압을 블록 간격에 적용합니다.
+// stretchBlockStep applies RPC budget backpressure to the block step.
+func stretchBlockStep(limiter *ratelimit.Limiter, step uint64) uint64 {
+	factor := float64(limiter.Stretch(time.Second)) / float64(time.Second)
+	stretched := uint64(math.Ceil(float64(step) * factor))
+	metrics.MonitorIntervalSeconds.WithLabelValues("monitor").Set(float64(stretched) * secondsPerBlock)
+	return stretched
 }
@@ -0,0 +1,73 @@
+// 렌딩 풀 시나리오 시뮬레이터
+// Lending pool scenario simulator
+//
+// 이 프로그램은 시나리오 파일 (YAML/JSON)을 체인 없이 Go LendingPool 시뮬레이터로 실행합니다.
+// This program runs scenario files (YAML/JSON) on the Go LendingPool simulator without a chain.
+//
+// 각 파일에 대해 타임라인 보고서를 출력하고, 단언이 하나라도 실패하면 0이 아닌 코드로 종료합니다.
+// It prints a timeline report per file and exits non-zero when any assertion fails.
+//
+// 사용 예 / Usage:
+//
+//	go run ./cmd/simulate scenarios/*.yaml scenarios/*.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/jeongseup/lending-monitor/internal/sim"
+)
+
+func main() {
+	// CLI 플래그 / CLI flags
+	quiet := flag.Bool("quiet", false, "실패한 시나리오만 타임라인 출력 / Print timelines of failed scenarios only")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] scenario.yaml...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// 로거 설정 / Logger setup
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	slog.SetDefault(logger)
+
+	if flag.NArg() == 0 {
+		logger.Error("시나리오 파일이 필요합니다 / Scenario files are required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, path := range flag.Args() {
+		scenario, err := sim.LoadScenario(path)
+		if err != nil {
+			logger.Error("시나리오 로드 실패 / Failed to load scenario", "file", path, "error", err)
+			failed++
+			continue
+		}
+		report, err := scenario.Run()
+		if err != nil {
+			logger.Error("시나리오 실행 실패 / Failed to run scenario", "file", path, "error", err)
+			failed++
+			continue
+		}
+		if report.Failed() {
+			failed++
+		}
+		if !*quiet || report.Failed() {
+			report.WriteTimeline(os.Stdout)
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("%d개 중 %d개 통과 / %d of %d scenarios passed\n",
+		flag.NArg(), flag.NArg()-failed, flag.NArg()-failed, flag.NArg())
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,261 @@
+// 연쇄 청산 스트레스 테스트
+// Cascading liquidation stress test
+//
+// 이 프로그램은 포지션 스냅샷 (파일 또는 체인)에 가격 경로를 적용해 연쇄 청산을 시뮬레이션하고,
+// 경로별 부실 채권, 청산 규모, 소진된 프로토콜 준비금과 몬테카를로 백분위 요약을 출력합니다.
+// This program applies price paths to a position snapshot (from a file or the chain), simulates cascading
+// liquidations, and reports bad debt, liquidation volume and protocol reserves consumed per path,
+// plus percentile summaries across Monte Carlo runs.
+//
+// 가격 경로 / Price paths:
+// - --history: 일별 가격 CSV의 과거 변동을 --horizon일 창으로 재생 / replays historical daily moves in --horizon-day windows
+// - --model gbm: 상관된 기하 브라운 운동 / correlated geometric Brownian motion
+// - --model jump: GBM + 시장 전체 Merton 점프 / GBM plus market-wide Merton jumps
+//
+// 사용 예 / Usage:
+//
+//	go run ./cmd/stress --snapshot stress/snapshot.yaml --model jump --runs 1000 --days 30
+//	go run ./cmd/stress --snapshot stress/snapshot.yaml --history stress/eth-btc-2022-06.csv --horizon 7
+//	go run ./cmd/stress --rpc-url $RPC --addresses 0xabc...,0xdef... --save-snapshot snapshot.yaml --model gbm
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/config"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+	"github.com/jeongseup/lending-monitor/internal/stress"
+)
+
+func main() {
+	// CLI 플래그 / CLI flags
+	configPath := flag.String("config", "", "설정 파일 경로 (YAML, 명령줄 플래그가 우선) / Config file path (YAML, command-line flags take precedence)")
+	snapshotPath := flag.String("snapshot", "", "포지션 스냅샷 파일 (YAML/JSON; 비우면 체인에서 읽음) / Position snapshot file (YAML/JSON; empty = read from chain)")
+	saveSnapshot := flag.String("save-snapshot", "", "체인에서 읽은 스냅샷을 저장할 경로 / Path to save the snapshot read from chain")
+	rpcURL := flag.String("rpc-url", "", "이더리움 RPC URL (쉼표로 여러 개) / Ethereum RPC URL(s), comma-separated")
+	addresses := flag.String("addresses", "", "스냅샷에 넣을 주소 (쉼표 구분) / Addresses to snapshot (comma-separated)")
+	poolAddress := flag.String("pool-address", contracts.AaveV3Pool.Hex(), "Aave V3 Pool 컨트랙트 주소 / Aave V3 Pool contract address")
+	uiProvider := flag.String("ui-pool-data-provider", "", "UiPoolDataProvider 주소 (V3.0, 계정당 한 번의 호출로 포지션 조회) / UiPoolDataProvider address (V3.0, reads a position in one call)")
+	reservesUSD := flag.Float64("reserves-usd", 0, "부실 채권을 흡수할 프로토콜 준비금 (USD, 지정하면 스냅샷 값을 덮어씀) / Protocol reserves absorbing bad debt (USD, overrides the snapshot when set)")
+
+	history := flag.String("history", "", "일별 가격 CSV (date,SYMBOL...; 지정하면 과거 경로 사용) / Daily price CSV (date,SYMBOL...; uses historical paths when set)")
+	horizon := flag.Int("horizon", 7, "과거 경로 창 길이 (일, 0 = 전체 이력) / Historical window length in days (0 = whole history)")
+	model := flag.String("model", "gbm", "합성 경로 모델: gbm, jump / Synthetic path model: gbm, jump")
+	runs := flag.Int("runs", 1000, "몬테카를로 실행 횟수 / Monte Carlo runs")
+	days := flag.Int("days", 30, "합성 경로 길이 (일) / Synthetic path length in days")
+	seed := flag.Uint64("seed", 1, "난수 시드 (같은 시드 = 같은 경로) / Random seed (same seed = same paths)")
+	vol := flag.Float64("vol", 0.8, "기본 연율 변동성 / Default annual volatility")
+	vols := flag.String("vols", "USDC=0,USDT=0,DAI=0", "자산별 연율 변동성 (SYMBOL=값, 스테이블코인은 0) / Per-asset annual volatility (SYMBOL=value, 0 for stablecoins)")
+	drift := flag.Float64("drift", 0, "연율 기대 수익률 / Annual drift")
+	correlation := flag.Float64("correlation", 0.8, "변동 자산 간 상관계수 / Correlation between volatile assets")
+	jumpIntensity := flag.Float64("jump-intensity", 4, "연간 평균 점프 횟수 (jump 모델) / Mean jumps per year (jump model)")
+	jumpMean := flag.Float64("jump-mean", -0.15, "로그 점프 크기 평균 (jump 모델) / Mean log jump size (jump model)")
+	jumpVol := flag.Float64("jump-vol", 0.1, "로그 점프 크기 표준편차 (jump 모델) / Log jump size standard deviation (jump model)")
+
+	closeFactor := flag.Float64("close-factor", stress.DefaultParams().CloseFactor, "청산 한 번에 갚을 수 있는 부채 비율 / Share of debt one liquidation may repay")
+	fullClose := flag.Float64("full-close-hf", 0, "이 HF 미만이면 부채 전액 청산 (Aave V3 = 0.95, 0 = 끔) / Liquidate the whole debt below this HF (Aave V3 = 0.95, 0 = off)")
+	depth := flag.Float64("depth", 0, "가격을 1% 움직이는 매도 금액 (USD, 0 = 가격 충격 없음) / Sell amount moving the price by 1% (USD, 0 = no price impact)")
+	depths := flag.String("depths", "", "자산별 시장 깊이 (SYMBOL=USD) / Per-asset market depth (SYMBOL=USD)")
+	top := flag.Int("top", 10, "출력할 최악 경로 수 (부실 채권 순) / Worst paths to print (by bad debt)")
+	jsonOut := flag.Bool("json", false, "JSON으로 출력 / Print JSON")
+	flag.Parse()
+
+	// 로거 설정 / Logger setup
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	slog.SetDefault(logger)
+
+	// 설정 파일 적용 (명시적 플래그가 우선) / Apply config file (explicit flags win)
+	explicit := config.ExplicitFlags(flag.CommandLine)
+	if _, err := config.LoadIntoFlags(flag.CommandLine, *configPath); err != nil {
+		logger.Error("설정 파일 오류 / Config file error", "error", err)
+		os.Exit(1)
+	}
+
+	if *closeFactor <= 0 || *closeFactor > 1 {
+		logger.Error("Close Factor는 0 초과 1 이하여야 합니다 / Close factor must be within (0, 1]", "close_factor", *closeFactor)
+		os.Exit(1)
+	}
+	volMap, err := stress.ParseSymbolFloats(*vols)
+	if err != nil {
+		logger.Error("잘못된 --vols / Invalid --vols", "error", err)
+		os.Exit(1)
+	}
+	depthMap, err := stress.ParseSymbolFloats(*depths)
+	if err != nil {
+		logger.Error("잘못된 --depths / Invalid --depths", "error", err)
+		os.Exit(1)
+	}
+
+	// 스냅샷 / Snapshot
+	var snap *stress.Snapshot
+	if *snapshotPath != "" {
+		snap, err = stress.LoadSnapshot(*snapshotPath)
+	} else {
+		snap, err = chainSnapshot(logger, *rpcURL, *poolAddress, *uiProvider, *addresses)
+	}
+	if err != nil {
+		logger.Error("스냅샷 준비 실패 / Failed to prepare snapshot", "error", err)
+		os.Exit(1)
+	}
+	if explicit["reserves-usd"] {
+		snap.ReservesUSD = *reservesUSD
+	}
+	if err := snap.Validate(); err != nil {
+		logger.Error("잘못된 스냅샷 / Invalid snapshot", "error", err)
+		os.Exit(1)
+	}
+	if *saveSnapshot != "" {
+		if err := snap.Save(*saveSnapshot); err != nil {
+			logger.Error("스냅샷 저장 실패 / Failed to save snapshot", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("스냅샷 저장 / Snapshot saved", "path", *saveSnapshot, "positions", len(snap.Positions))
+	}
+
+	// 가격 경로 / Price paths
+	var paths []stress.Path
+	if *history != "" {
+		h, err := stress.LoadHistory(*history)
+		if err != nil {
+			logger.Error("가격 이력 로드 실패 / Failed to load price history", "error", err)
+			os.Exit(1)
+		}
+		paths = h.Paths(*horizon)
+	} else {
+		m := stress.Model{DefaultVol: *vol, Vol: volMap, Drift: *drift, Correlation: *correlation}
+		switch *model {
+		case "gbm":
+		case "jump":
+			m.JumpIntensity, m.JumpMean, m.JumpVol = *jumpIntensity, *jumpMean, *jumpVol
+		default:
+			logger.Error("알 수 없는 모델 / Unknown model", "model", *model)
+			os.Exit(1)
+		}
+		if err := m.Validate(); err != nil {
+			logger.Error("잘못된 모델 파라미터 / Invalid model parameters", "error", err)
+			os.Exit(1)
+		}
+		symbols := make([]string, len(snap.Assets))
+		for i, a := range snap.Assets {
+			symbols[i] = a.Symbol
+		}
+		for run := range *runs {
+			paths = append(paths, m.Path(symbols, *days, *seed, run))
+		}
+	}
+
+	// 시뮬레이션 / Simulation
+	params := stress.DefaultParams()
+	params.CloseFactor = *closeFactor
+	params.FullCloseThreshold = *fullClose
+	params.DefaultDepth = *depth
+	params.Depth = depthMap
+	results := make([]stress.Result, len(paths))
+	for i, p := range paths {
+		results[i] = stress.Run(snap, p, params)
+	}
+	summary := stress.Summarize(results)
+
+	// 최악 경로 순 정렬 (부실 채권, 청산 규모) / Sort worst paths first (bad debt, liquidation volume)
+	worst := slices.Clone(results)
+	slices.SortStableFunc(worst, func(a, b stress.Result) int {
+		return cmp.Or(cmp.Compare(b.BadDebt, a.BadDebt), cmp.Compare(b.LiquidationVolume, a.LiquidationVolume))
+	})
+	if *top >= 0 && *top < len(worst) {
+		worst = worst[:*top]
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(struct {
+			Positions   int             `json:"positions"`
+			ReservesUSD float64         `json:"reserves_usd"`
+			Worst       []stress.Result `json:"worst_paths"`
+			Summary     stress.Summary  `json:"summary"`
+		}{len(snap.Positions), snap.ReservesUSD, worst, summary}); err != nil {
+			logger.Error("JSON 출력 실패 / Failed to write JSON", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Printf("포지션 %d개, 준비금 $%.0f / %d positions, reserves $%.0f\n\n",
+		len(snap.Positions), snap.ReservesUSD, len(snap.Positions), snap.ReservesUSD)
+	if len(worst) > 0 {
+		stress.WriteResults(os.Stdout, worst)
+		fmt.Println()
+	}
+	summary.WriteSummary(os.Stdout)
+}
+
+// chainSnapshot은 주어진 주소의 현재 포지션을 체인에서 읽어 스냅샷을 만듭니다.
+// 읽지 못한 주소는 경고만 남기고 건너뜁니다.
+// chainSnapshot reads the current positions of the given addresses from chain and builds a snapshot.
+// Addresses that fail to read are skipped with a warning.
+func chainSnapshot(logger *slog.Logger, rpcURL, poolAddress, uiProvider, addresses string) (*stress.Snapshot, error) {
+	if rpcURL == "" || addresses == "" {
+		return nil, fmt.Errorf("--snapshot 또는 --rpc-url과 --addresses가 필요합니다 / --snapshot or --rpc-url and --addresses are required")
+	}
+	if !common.IsHexAddress(poolAddress) {
+		return nil, fmt.Errorf("잘못된 Pool 주소 / invalid pool address: %s", poolAddress)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, err := rpcpool.Dial(ctx, rpcpool.SplitURLs(rpcURL), rpcpool.DefaultOptions(), logger)
+	if err != nil {
+		return nil, fmt.Errorf("RPC 연결 실패 / failed to connect to RPC: %w", err)
+	}
+	defer client.Close()
+
+	opts := &bind.CallOpts{Context: ctx}
+	poolCaller := contracts.NewAavePoolCaller(client, common.HexToAddress(poolAddress))
+	addrs, err := contracts.ResolveAaveAddresses(opts, client, poolCaller)
+	if err != nil {
+		return nil, fmt.Errorf("데이터 제공자 조회 실패 / failed to resolve data provider: %w", err)
+	}
+	if common.IsHexAddress(uiProvider) {
+		addrs.UiPoolDataProvider = common.HexToAddress(uiProvider)
+	}
+	positions := contracts.NewPositionReader(client, addrs)
+	reserves, err := positions.Reserves(opts)
+	if err != nil {
+		return nil, fmt.Errorf("리저브 목록 조회 실패 / failed to read reserves: %w", err)
+	}
+	prices, err := positions.Prices(opts, reserves)
+	if err != nil {
+		return nil, fmt.Errorf("오라클 가격 조회 실패 / failed to read oracle prices: %w", err)
+	}
+
+	var read []*contracts.UserPosition
+	for _, a := range strings.Split(addresses, ",") {
+		a = strings.TrimSpace(a)
+		if !common.IsHexAddress(a) {
+			logger.Warn("잘못된 주소 건너뜀 / Skipping invalid address", "address", a)
+			continue
+		}
+		pos, err := positions.UserPosition(opts, common.HexToAddress(a), reserves, prices)
+		if err != nil {
+			logger.Warn("포지션 조회 실패 / Failed to read position", "address", a, "error", err)
+			continue
+		}
+		read = append(read, pos)
+	}
+	logger.Info("체인 스냅샷 완료 / Chain snapshot done", "positions", len(read))
+	return stress.FromPositions(read, 0), nil
+}
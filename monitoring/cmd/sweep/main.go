@@ -0,0 +1,166 @@
+// 리스크 파라미터 스윕
+// Risk parameter sweep
+//
+// 이 프로그램은 담보 인정 비율, 청산 기준, 청산 보너스, Close Factor, 금리 모델 kink/기울기 범위의
+// 모든 조합에 대해 LendingPool 시뮬레이터로 기준 시나리오의 포지션을 열고, 가격 충격마다 연쇄 청산을 실행해
+// 결과 격자를 CSV 또는 JSON으로 씁니다 (조합 × 충격당 한 행, 히트맵용).
+// For every combination in the ranges of collateral factor, liquidation threshold, liquidation bonus,
+// close factor and rate model kink/slopes, this program opens the base scenario's positions on the LendingPool
+// simulator, runs cascading liquidations for each price shock and writes the result grid as CSV or JSON
+// (one row per combination × shock, for heatmaps).
+//
+// 범위는 스펙 파일의 grid에 두고, 같은 이름의 플래그로 덮어쓸 수 있습니다.
+// Ranges live in the spec file's grid and can be overridden by flags of the same name.
+//
+// 사용 예 / Usage:
+//
+//	go run ./cmd/sweep --spec sweep/parameter-impact.yaml --out grid.csv
+//	go run ./cmd/sweep --spec sweep/parameter-impact.yaml --liquidation-bonus 0.05:0.15:0.025 --format json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jeongseup/lending-monitor/internal/sweep"
+)
+
+func main() {
+	// CLI 플래그 / CLI flags
+	specPath := flag.String("spec", "", "스윕 스펙 파일 (YAML/JSON) / Sweep spec file (YAML/JSON, required)")
+	out := flag.String("out", "", "결과 파일 (비우면 표준 출력) / Output file (empty = stdout)")
+	format := flag.String("format", "", "출력 형식: csv, json (비우면 --out 확장자, 기본 csv) / Output format: csv, json (empty = --out extension, default csv)")
+	workers := flag.Int("workers", runtime.NumCPU(), "병렬 작업자 수 / Parallel workers")
+	maxCombos := flag.Int("max-combinations", 100_000, "조합 수 상한 (실수로 큰 격자를 돌리지 않도록) / Combination limit (guards against accidentally huge grids)")
+	target := flag.String("target", "", "담보 파라미터를 스윕할 리저브 (스펙 덮어쓰기) / Reserve whose collateral parameters are swept (overrides the spec)")
+	horizon := flag.String("horizon", "", "충격 전 이자 누적 기간 (스펙 덮어쓰기, 예: 30d) / Interest accrual before the shock (overrides the spec, e.g. 30d)")
+
+	// 격자 덮어쓰기 / Grid overrides
+	rangeHelp := " (시작:끝:간격 또는 쉼표 목록) / (from:to:step or a comma list)"
+	cf := flag.String("collateral-factor", "", "담보 인정 비율 범위 / Collateral factor range"+rangeHelp)
+	lt := flag.String("liquidation-threshold", "", "청산 기준 범위 / Liquidation threshold range"+rangeHelp)
+	bonus := flag.String("liquidation-bonus", "", "청산 보너스 범위 / Liquidation bonus range"+rangeHelp)
+	closeFactor := flag.String("close-factor", "", "Close Factor 범위 / Close factor range"+rangeHelp)
+	baseRate := flag.String("base-rate", "", "기본 이자율 범위 / Base rate range"+rangeHelp)
+	slope1 := flag.String("multiplier", "", "kink 이하 기울기 범위 / Slope below the kink range"+rangeHelp)
+	slope2 := flag.String("jump-multiplier", "", "kink 이상 기울기 범위 / Slope above the kink range"+rangeHelp)
+	kink := flag.String("kink", "", "kink 범위 / Kink range"+rangeHelp)
+	flag.Parse()
+
+	// 로거 설정 / Logger setup
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	slog.SetDefault(logger)
+
+	if *specPath == "" {
+		logger.Error("스윕 스펙 파일이 필요합니다 / Sweep spec file is required")
+		flag.Usage()
+		os.Exit(1)
+	}
+	spec, err := sweep.LoadSpec(*specPath)
+	if err != nil {
+		logger.Error("스윕 스펙 로드 실패 / Failed to load sweep spec", "error", err)
+		os.Exit(1)
+	}
+
+	// 명시한 플래그만 스펙을 덮어씀 / Only explicit flags override the spec
+	overrides := map[string]*string{
+		"collateral-factor": &spec.Grid.CollateralFactor, "liquidation-threshold": &spec.Grid.LiquidationThreshold,
+		"liquidation-bonus": &spec.Grid.LiquidationBonus, "close-factor": &spec.Grid.CloseFactor,
+		"base-rate": &spec.Grid.BaseRate, "multiplier": &spec.Grid.Multiplier,
+		"jump-multiplier": &spec.Grid.JumpMultiplier, "kink": &spec.Grid.Kink,
+		"target": &spec.Target, "horizon": &spec.Horizon,
+	}
+	values := map[string]*string{
+		"collateral-factor": cf, "liquidation-threshold": lt, "liquidation-bonus": bonus, "close-factor": closeFactor,
+		"base-rate": baseRate, "multiplier": slope1, "jump-multiplier": slope2, "kink": kink,
+		"target": target, "horizon": horizon,
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if dst, ok := overrides[f.Name]; ok {
+			*dst = *values[f.Name]
+		}
+	})
+	if err := spec.Bind(spec.Base()); err != nil {
+		logger.Error("잘못된 스윕 설정 / Invalid sweep settings", "error", err)
+		os.Exit(1)
+	}
+	combos, _ := spec.Combinations()
+	if len(combos) > *maxCombos {
+		logger.Error("조합이 너무 많습니다 / Too many combinations", "combinations", len(combos), "max", *maxCombos)
+		os.Exit(1)
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if strings.EqualFold(filepath.Ext(*out), ".json") {
+			*format = "json"
+		}
+	}
+	write := sweep.WriteCSV
+	switch *format {
+	case "csv":
+	case "json":
+		write = sweep.WriteJSON
+	default:
+		logger.Error("알 수 없는 출력 형식 / Unknown output format", "format", *format)
+		os.Exit(1)
+	}
+
+	// Ctrl-C로 중단 / Interrupt with Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("스윕 시작 / Sweep started",
+		"combinations", len(combos),
+		"shocks", len(spec.Shocks),
+		"workers", *workers,
+	)
+	start := time.Now()
+	rows, err := spec.Run(ctx, *workers)
+	if err != nil {
+		logger.Error("스윕 중단 / Sweep aborted", "error", err)
+		os.Exit(1)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			logger.Error("결과 파일 생성 실패 / Failed to create output file", "error", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := write(w, rows); err != nil {
+		logger.Error("결과 쓰기 실패 / Failed to write results", "error", err)
+		os.Exit(1)
+	}
+
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.Status]++
+	}
+	logger.Info("스윕 완료 / Sweep finished",
+		"rows", len(rows),
+		"ok", counts[sweep.StatusOK],
+		"invalid", counts[sweep.StatusInvalid],
+		"error", counts[sweep.StatusError],
+		"elapsed", time.Since(start).Round(time.Millisecond).String(),
+	)
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "%d행 → %s / %d rows → %s\n", len(rows), *out, len(rows), *out)
+	}
+}
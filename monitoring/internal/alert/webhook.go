@@ -6,10 +6,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -58,83 +62,269 @@ type Alert struct {
 // WebhookAlerter는 웹훅을 통해 알림을 전송합니다.
 // WebhookAlerter sends alerts via webhook.
 type WebhookAlerter struct {
-	webhookURL string
-	client     *http.Client
-	logger     *slog.Logger
+	webhookURLs []string
+	client      *http.Client
+	logger      *slog.Logger
+
+	// 헬스팩터 임계값 (리로드 시 교체됨) / Health factor thresholds (swapped on reload)
+	mu         sync.RWMutex
+	hfWarning  float64
+	hfCritical float64
+
+	// 계정별 마지막 헬스팩터 알림 (중복 억제) / Last health factor alert per account (deduplication)
+	stateMu sync.Mutex
+	hfState map[string]sentAlert
+	realert time.Duration
+	now     func() time.Time
+}
+
+// sentAlert는 계정에 마지막으로 보낸 알림의 수준과 시각입니다.
+// sentAlert is the level and time of the last alert sent for an account.
+type sentAlert struct {
+	level AlertLevel
+	at    time.Time
 }
 
 // NewWebhookAlerter는 새로운 WebhookAlerter를 생성합니다.
 // NewWebhookAlerter creates a new WebhookAlerter.
+//
+// webhookURL은 쉼표로 구분해 여러 대상을 지정할 수 있습니다.
+// webhookURL may list several destinations separated by commas.
 func NewWebhookAlerter(webhookURL string, logger *slog.Logger) *WebhookAlerter {
+	var urls []string
+	for _, u := range strings.Split(webhookURL, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
 	return &WebhookAlerter{
-		webhookURL: webhookURL,
+		webhookURLs: urls,
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		logger: logger,
+		logger:     logger,
+		hfWarning:  1.2,
+		hfCritical: 1.0,
+		hfState:    make(map[string]sentAlert),
+		now:        time.Now,
+	}
+}
+
+// SetRealertInterval은 수준이 바뀌지 않은 계정에 같은 헬스팩터 알림을 다시 보낼 주기를 설정합니다.
+// 0이면 수준이 바뀔 때만 보냅니다 (기본값).
+// SetRealertInterval sets how often the same health factor alert is repeated for an account whose
+// level has not changed. 0 sends only on a level change (the default).
+func (w *WebhookAlerter) SetRealertInterval(d time.Duration) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	w.realert = d
+}
+
+// ResolveHealthFactor는 계정이 임계값 위로 회복했음을 기록합니다.
+// 다음에 다시 임계값 아래로 내려가면 곧바로 알림을 보냅니다.
+// ResolveHealthFactor records that an account recovered above the thresholds.
+// The next time it drops below them an alert is sent right away.
+func (w *WebhookAlerter) ResolveHealthFactor(user string) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	delete(w.hfState, user)
+}
+
+// shouldAlert는 계정의 알림 수준이 바뀌었거나 재알림 주기가 지났는지 확인합니다.
+// shouldAlert reports whether the account's alert level changed or the re-alert interval has passed.
+func (w *WebhookAlerter) shouldAlert(user string, level AlertLevel) bool {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	last, ok := w.hfState[user]
+	if !ok || last.level != level {
+		return true
 	}
+	return w.realert > 0 && w.now().Sub(last.at) >= w.realert
+}
+
+// recordAlert는 계정에 보낸 알림을 기록합니다 (전송 성공 후에만, 실패하면 다음 사이클에 재시도).
+// recordAlert records the alert sent for an account (only after a successful send, so failures retry next cycle).
+func (w *WebhookAlerter) recordAlert(user string, level AlertLevel) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	w.hfState[user] = sentAlert{level: level, at: w.now()}
+}
+
+// SetHealthFactorThresholds는 헬스팩터 경고/긴급 임계값을 변경합니다.
+// SetHealthFactorThresholds changes the health factor warning/critical thresholds.
+//
+// 실행 중에도 안전하게 호출할 수 있습니다 (설정 리로드).
+// Safe to call while alerts are being sent (config reload).
+func (w *WebhookAlerter) SetHealthFactorThresholds(warning, critical float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hfWarning = warning
+	w.hfCritical = critical
 }
 
-// SendAlert는 알림을 웹훅으로 전송합니다.
-// SendAlert sends an alert via webhook.
+// SendAlert는 알림을 모든 웹훅으로 전송합니다.
+// SendAlert sends an alert to every webhook.
 func (w *WebhookAlerter) SendAlert(ctx context.Context, alert Alert) error {
 	payload, err := json.Marshal(alert)
 	if err != nil {
 		return fmt.Errorf("알림 직렬화 실패 / failed to marshal alert: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhookURL, bytes.NewReader(payload))
+	var errs []error
+	for _, u := range w.webhookURLs {
+		if err := w.post(ctx, u, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	w.logger.Info("알림 전송 완료 / Alert sent",
+		"level", alert.Level,
+		"title", alert.Title,
+	)
+	return nil
+}
+
+// post는 페이로드를 웹훅 하나로 전송합니다.
+// post sends the payload to one webhook.
+func (w *WebhookAlerter) post(ctx context.Context, webhookURL string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
 	if err != nil {
-		return fmt.Errorf("요청 생성 실패 / failed to create request: %w", err)
+		return fmt.Errorf("요청 생성 실패 / failed to create request: %w", redactURL(err))
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := w.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("웹훅 전송 실패 / failed to send webhook: %w", err)
+		return fmt.Errorf("웹훅 %s 전송 실패 / failed to send webhook %s: %w", req.URL.Host, req.URL.Host, redactURL(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
 		return fmt.Errorf("웹훅 응답 오류: %d / webhook response error: %d", resp.StatusCode, resp.StatusCode)
 	}
-
-	w.logger.Info("알림 전송 완료 / Alert sent",
-		"level", alert.Level,
-		"title", alert.Title,
-	)
 	return nil
 }
 
+// redactURL은 url.Error에서 전체 URL을 떼어냅니다. 웹훅 URL에는 비밀 토큰이 들어 있어 로그에 남기면 안 됩니다.
+// redactURL strips the full URL from a url.Error. Webhook URLs carry their secret token and must not reach the logs.
+func redactURL(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return ue.Err
+	}
+	return err
+}
+
 // AlertOnLowHealthFactor는 헬스팩터가 기준 이하일 때 알림을 전송합니다.
 // AlertOnLowHealthFactor sends an alert when health factor is below threshold.
 //
-// 알림 기준 / Alert thresholds:
+// 계정마다 수준(WARNING/CRITICAL)이 바뀔 때만 보내고, 같은 수준은 SetRealertInterval 주기로만 반복합니다.
+// An alert is sent only when an account's level (WARNING/CRITICAL) changes; the same level is repeated
+// only at the SetRealertInterval period.
+//
+// 알림 기준 (기본값, SetHealthFactorThresholds로 변경) / Alert thresholds (defaults, see SetHealthFactorThresholds):
 // - HF < 1.2 → WARNING (곧 청산 가능 / may become liquidatable soon)
 // - HF < 1.0 → CRITICAL (즉시 청산 가능 / immediately liquidatable)
-func (w *WebhookAlerter) AlertOnLowHealthFactor(ctx context.Context, user string, healthFactor *big.Float) error {
-	one := new(big.Float).SetFloat64(1.0)
-	warningThreshold := new(big.Float).SetFloat64(1.2)
+//
+// extra는 라벨, 그룹, 블록 번호, 상위 담보/부채 자산 (top_collateral, top_debt) 등 추가 메타데이터입니다 (nil 가능).
+// extra holds additional metadata such as label, group, block number and the top collateral/debt
+// assets (top_collateral, top_debt) (may be nil).
+func (w *WebhookAlerter) AlertOnLowHealthFactor(ctx context.Context, user string, healthFactor *big.Float, extra map[string]string) error {
+	w.mu.RLock()
+	criticalThreshold := new(big.Float).SetFloat64(w.hfCritical)
+	warningThreshold := new(big.Float).SetFloat64(w.hfWarning)
+	w.mu.RUnlock()
 
 	var level AlertLevel
-	if healthFactor.Cmp(one) < 0 {
+	if healthFactor.Cmp(criticalThreshold) < 0 {
 		level = AlertCritical
 	} else if healthFactor.Cmp(warningThreshold) < 0 {
 		level = AlertWarning
 	} else {
+		w.ResolveHealthFactor(user)
 		return nil // 건전한 포지션 / healthy position
 	}
 
+	// 같은 수준의 반복 알림은 억제 (모든 웹훅으로 퍼지는 것 방지)
+	// Suppress repeats at the same level (so they don't fan out to every webhook)
+	if !w.shouldAlert(user, level) {
+		return nil
+	}
+
+	// 라벨이 있으면 당직자가 알아볼 수 있도록 메시지에 함께 표시
+	// Show the label in the message when present so on-call can recognise the account
+	who := user
+	if label := extra["label"]; label != "" {
+		who = fmt.Sprintf("%s (%s)", label, user)
+	}
+
+	// 리저브별 분석이 있으면 어떤 자산이 위험을 만드는지 함께 표시
+	// When a per-reserve breakdown is present, show which assets drive the risk
+	message := fmt.Sprintf("사용자 %s의 헬스팩터: %s / User %s health factor: %s", who, healthFactor.Text('f', 4), who, healthFactor.Text('f', 4))
+	if c := extra["top_collateral"]; c != "" {
+		message += fmt.Sprintf("\n담보 / Collateral: %s", c)
+	}
+	if d := extra["top_debt"]; d != "" {
+		message += fmt.Sprintf("\n부채 / Debt: %s", d)
+	}
+
 	alert := Alert{
 		Level:     level,
 		Title:     "낮은 헬스팩터 감지 / Low Health Factor Detected",
-		Message:   fmt.Sprintf("사용자 %s의 헬스팩터: %s / User %s health factor: %s", user, healthFactor.Text('f', 4), user, healthFactor.Text('f', 4)),
+		Message:   message,
 		Timestamp: time.Now(),
 		Metadata: map[string]string{
 			"user":          user,
 			"health_factor": healthFactor.Text('f', 6),
 		},
 	}
+	for k, v := range extra {
+		alert.Metadata[k] = v
+	}
+
+	if err := w.SendAlert(ctx, alert); err != nil {
+		return err
+	}
+	w.recordAlert(user, level)
+	return nil
+}
+
+// AlertOnProjectedLiquidation은 예상 청산 시간이 horizon보다 짧을 때 조기 경고를 전송합니다.
+// 헬스팩터가 아직 경고 임계값 위에 있는 포지션을 위한 알림입니다.
+// AlertOnProjectedLiquidation sends an early warning when the projected time to liquidation is shorter than horizon.
+// Meant for positions whose health factor is still above the warning threshold.
+//
+// extra는 기울기, 추정 근거 등 추가 메타데이터입니다 (nil 가능).
+// extra holds additional metadata such as the slope and the basis of the estimate (may be nil).
+func (w *WebhookAlerter) AlertOnProjectedLiquidation(ctx context.Context, user string, healthFactor float64, eta, horizon time.Duration, extra map[string]string) error {
+	if eta >= horizon {
+		return nil
+	}
+
+	who := user
+	if label := extra["label"]; label != "" {
+		who = fmt.Sprintf("%s (%s)", label, user)
+	}
+	eta = eta.Round(time.Minute)
+
+	alert := Alert{
+		Level:     AlertWarning,
+		Title:     "청산 임박 예상 / Liquidation Projected",
+		Message:   fmt.Sprintf("사용자 %s의 헬스팩터 %.4f, 약 %v 후 1.0 도달 예상 / User %s health factor %.4f projected to reach 1.0 in about %v", who, healthFactor, eta, who, healthFactor, eta),
+		Timestamp: time.Now(),
+		Metadata: map[string]string{
+			"user":                  user,
+			"health_factor":         fmt.Sprintf("%.6f", healthFactor),
+			"time_to_liquidation":   eta.String(),
+			"early_warning_horizon": horizon.String(),
+		},
+	}
+	for k, v := range extra {
+		alert.Metadata[k] = v
+	}
 
 	return w.SendAlert(ctx, alert)
 }
@@ -0,0 +1,137 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder는 받은 알림 수준을 기록하는 웹훅입니다.
+// recorder is a webhook that records the levels of the alerts it receives.
+type recorder struct {
+	mu     sync.Mutex
+	levels []AlertLevel
+	fail   bool
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	var a Alert
+	if err := json.NewDecoder(req.Body).Decode(&a); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.levels = append(r.levels, a.Level)
+}
+
+func (r *recorder) take() []AlertLevel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.levels
+	r.levels = nil
+	return out
+}
+
+func TestAlertOnLowHealthFactorDedup(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	// 두 웹훅 모두 같은 서버: 억제된 알림은 어느 쪽으로도 나가지 않아야 함
+	// Both webhooks hit the same server: a suppressed alert must go to neither
+	w := NewWebhookAlerter(srv.URL+","+srv.URL, slog.New(slog.DiscardHandler))
+	now := time.Unix(1_700_000_000, 0)
+	w.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	const user = "0x1"
+	send := func(hf float64) {
+		t.Helper()
+		if err := w.AlertOnLowHealthFactor(ctx, user, big.NewFloat(hf), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(step string, want ...AlertLevel) {
+		t.Helper()
+		got := rec.take()
+		if len(got) != len(want) {
+			t.Fatalf("%s: alerts = %v, want %v", step, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s: alerts = %v, want %v", step, got, want)
+			}
+		}
+	}
+
+	send(1.1)
+	expect("first warning", AlertWarning, AlertWarning)
+	send(1.15)
+	expect("unchanged warning")
+	send(0.9)
+	expect("escalation to critical", AlertCritical, AlertCritical)
+	send(0.95)
+	expect("unchanged critical")
+	send(1.1)
+	expect("back to warning", AlertWarning, AlertWarning)
+
+	// 회복 후 다시 내려가면 곧바로 알림 / After a recovery, a new drop alerts right away
+	send(1.5)
+	expect("recovered")
+	send(1.1)
+	expect("warning after recovery", AlertWarning, AlertWarning)
+	w.ResolveHealthFactor(user)
+	send(1.1)
+	expect("warning after explicit resolve", AlertWarning, AlertWarning)
+
+	// 재알림 주기 / Re-alert interval
+	w.SetRealertInterval(time.Hour)
+	now = now.Add(30 * time.Minute)
+	send(1.1)
+	expect("before re-alert interval")
+	now = now.Add(30 * time.Minute)
+	send(1.1)
+	expect("at re-alert interval", AlertWarning, AlertWarning)
+
+	// 전송 실패는 기록하지 않아 다음 사이클에 재시도 / A failed send is not recorded, so the next cycle retries
+	rec.mu.Lock()
+	rec.fail = true
+	rec.mu.Unlock()
+	if err := w.AlertOnLowHealthFactor(ctx, user, big.NewFloat(0.9), nil); err == nil {
+		t.Fatal("expected an error from the failing webhook")
+	}
+	rec.mu.Lock()
+	rec.fail = false
+	rec.mu.Unlock()
+	send(0.9)
+	expect("retry after failure", AlertCritical, AlertCritical)
+}
+
+func TestSendAlertRedactsURL(t *testing.T) {
+	// 닫힌 포트로 전송: 오류에 호스트는 있어도 토큰이 든 경로는 없어야 함
+	// Send to a closed port: the error may name the host but not the path carrying the token
+	srv := httptest.NewServer(http.NotFoundHandler())
+	target := srv.URL + "/services/T000/B000/secret-token"
+	srv.Close()
+
+	w := NewWebhookAlerter(target, slog.New(slog.DiscardHandler))
+	err := w.SendAlert(context.Background(), Alert{Level: AlertInfo, Title: "test"})
+	if err == nil {
+		t.Fatal("expected an error from the closed server")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("error leaks the webhook token: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Package cmdutil은 RPC를 쓰는 명령들이 공유하는 시작 및 루프 보조 함수입니다.
+// Package cmdutil holds the startup and loop helpers shared by the RPC-backed commands.
+//
+// 속도 제한기 구성, RPC 예산 역압, 체인 ID 확인, 포지션 리더 준비는 monitor, alerter, indexer 등
+// 여러 명령에서 똑같이 필요하므로 한 곳에 둡니다.
+// Rate limiter construction, RPC budget backpressure, the chain ID check and position reader setup are needed
+// the same way by monitor, alerter, indexer and others, so they live in one place.
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+	"github.com/jeongseup/lending-monitor/internal/ratelimit"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+)
+
+// NewLimiter는 --rate-limit, --rate-burst, --daily-budget 플래그 값으로 공유 속도 제한기를 만듭니다.
+// 모든 RPC 요청이 같은 버킷과 예산을 사용합니다.
+// NewLimiter builds the shared rate limiter from the --rate-limit, --rate-burst and --daily-budget flag values.
+// Every RPC request draws from the same bucket and budget.
+func NewLimiter(ratePerSecond, burst, dailyBudget float64) *ratelimit.Limiter {
+	cfg := ratelimit.DefaultConfig()
+	cfg.RatePerSecond = ratePerSecond
+	cfg.Burst = burst
+	cfg.DailyBudget = dailyBudget
+	return ratelimit.New(cfg)
+}
+
+// ApplyBackpressure는 남은 RPC 예산에 맞춰 티커 주기를 늘리거나 되돌리고, 새 주기를 반환합니다.
+// component는 monitor_interval_seconds 라벨입니다 (예: "monitor", "alerter").
+// ApplyBackpressure stretches or restores the ticker interval to fit the remaining RPC budget and returns the new interval.
+// component is the monitor_interval_seconds label (e.g. "monitor", "alerter").
+func ApplyBackpressure(
+	logger *slog.Logger,
+	ticker *time.Ticker,
+	limiter *ratelimit.Limiter,
+	component string,
+	base time.Duration,
+	current time.Duration,
+) time.Duration {
+	next := limiter.Stretch(base).Round(time.Second)
+	metrics.MonitorIntervalSeconds.WithLabelValues(component).Set(next.Seconds())
+	if next == current {
+		return current
+	}
+	ticker.Reset(next)
+	logger.Info("RPC 예산에 따라 주기 조정 / Interval adjusted for RPC budget",
+		"base", base.String(),
+		"interval", next.String(),
+		"budget_remaining", limiter.Remaining(),
+	)
+	return next
+}
+
+// CheckChainID는 연결된 체인의 ID를 조회하고, want가 0이 아니면 일치하는지 확인합니다.
+// 잘못된 네트워크의 RPC로 모니터링하는 실수를 시작 시점에 막습니다.
+// CheckChainID reads the connected chain's ID and, when want is non-zero, checks that it matches.
+// This catches an RPC pointed at the wrong network at startup.
+func CheckChainID(ctx context.Context, client *rpcpool.Pool, want uint64) (*big.Int, error) {
+	id, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("체인 ID 조회 실패 / failed to get chain ID: %w", err)
+	}
+	if want != 0 && (!id.IsUint64() || id.Uint64() != want) {
+		return nil, fmt.Errorf("체인 ID 불일치: %s (기대값 %d) / chain ID mismatch: %s (expected %d)", id, want, id, want)
+	}
+	return id, nil
+}
+
+// NewPositionReader는 Pool에서 데이터 제공자와 오라클을 찾아 포지션 리더를 만듭니다.
+// uiProvider가 비어 있지 않으면 UiPoolDataProvider로 계정당 한 번의 호출로 읽습니다.
+// 실패하면 경고만 남기고 nil을 반환합니다. features는 그때 꺼지는 기능으로, 경고에 함께 기록됩니다.
+// NewPositionReader resolves the data provider and oracle from the Pool and builds a position reader.
+// When uiProvider is set, positions are read with one UiPoolDataProvider call per account.
+// On failure it only logs a warning and returns nil; features names what is disabled and goes into the warning.
+func NewPositionReader(ctx context.Context, logger *slog.Logger, client *rpcpool.Pool, poolCaller *contracts.AavePoolCaller, uiProvider, features string) *contracts.PositionReader {
+	addrs, err := contracts.ResolveAaveAddresses(&bind.CallOpts{Context: ctx}, client, poolCaller)
+	if err != nil {
+		logger.Warn("데이터 제공자 조회 실패, 포지션 기능 비활성 / Failed to resolve data provider, position features disabled",
+			"disabled", features,
+			"error", err,
+		)
+		return nil
+	}
+	if common.IsHexAddress(uiProvider) {
+		addrs.UiPoolDataProvider = common.HexToAddress(uiProvider)
+	} else if uiProvider != "" {
+		logger.Warn("잘못된 UiPoolDataProvider 주소, 리저브별 조회 사용 / Invalid UiPoolDataProvider address, using per-reserve reads", "address", uiProvider)
+	}
+	logger.Info("포지션 리더 준비 완료 / Position reader ready",
+		"data_provider", addrs.DataProvider.Hex(),
+		"oracle", addrs.Oracle.Hex(),
+		"ui_pool_data_provider", addrs.UiPoolDataProvider.Hex(),
+	)
+	reader := contracts.NewPositionReader(client, addrs)
+	// 리저브 캐시를 미리 채우고 설정 비트맵에서 풀어낸 파라미터를 기록합니다.
+	// Warm the reserve cache and log the parameters unpacked from the configuration bitmaps.
+	if reserves, err := reader.CachedReserves(&bind.CallOpts{Context: ctx}, contracts.ReservesTTL); err == nil {
+		for _, r := range reserves {
+			logger.Debug("리저브 파라미터 / Reserve parameters", "symbol", r.Symbol, "id", r.ID, "config", r.Config.String())
+		}
+	}
+	return reader
+}
@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+func TestNewLimiter(t *testing.T) {
+	l := NewLimiter(0, 0, 1000)
+	if r := l.Remaining(); r != 1000 {
+		t.Errorf("Remaining = %v, want the daily budget 1000", r)
+	}
+	if w := l.Weight("eth_getLogs"); w != 75 {
+		t.Errorf("Weight(eth_getLogs) = %v, want the default weight 75", w)
+	}
+	if r := NewLimiter(0, 0, 0).Remaining(); r != -1 {
+		t.Errorf("Remaining without a budget = %v, want -1", r)
+	}
+}
+
+func TestApplyBackpressure(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	gauge := metrics.MonitorIntervalSeconds.WithLabelValues("cmdutil-test")
+
+	// 제한기가 없으면 기본 주기 유지 / Without a limiter the base interval stays
+	if got := ApplyBackpressure(logger, ticker, nil, "cmdutil-test", time.Minute, time.Minute); got != time.Minute {
+		t.Errorf("nil limiter: interval = %v, want 1m", got)
+	}
+	if v := testutil.ToFloat64(gauge); v != 60 {
+		t.Errorf("nil limiter: gauge = %v, want 60", v)
+	}
+
+	// 예산을 다 쓰면 MaxStretch(10배)까지 늘림 / An exhausted budget stretches to MaxStretch (10x)
+	l := NewLimiter(0, 0, 100)
+	for range 2 {
+		if err := l.Wait(context.Background(), "eth_getLogs"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := ApplyBackpressure(logger, ticker, l, "cmdutil-test", time.Minute, time.Minute); got != 10*time.Minute {
+		t.Errorf("exhausted budget: interval = %v, want 10m", got)
+	}
+	if v := testutil.ToFloat64(gauge); v != 600 {
+		t.Errorf("exhausted budget: gauge = %v, want 600", v)
+	}
+}
@@ -0,0 +1,217 @@
+// Package config는 모든 명령(monitor, alerter, indexer)이 공유하는 YAML 설정 파일을 정의합니다.
+// Package config defines the YAML configuration file shared by every command (monitor, alerter, indexer).
+//
+// 설정 파일 하나로 체인, RPC 엔드포인트, 프로토콜, 감시 주소, 임계값, 알림 대상,
+// 메트릭 설정을 기술합니다. 비밀값은 ${ENV_VAR} 형태로 환경 변수에서 읽습니다.
+// A single file describes chains, RPC endpoints, protocols, watched addresses, thresholds,
+// notifier destinations and metrics settings. Secrets are read from ${ENV_VAR} references.
+//
+// 예시 / Example:
+//
+//	chains:
+//	  ethereum:
+//	    chain_id: 1
+//	    rpc: [ "${ETH_RPC_PRIMARY}", "${ETH_RPC_BACKUP}" ]
+//	    quorum: 2
+//	protocols:
+//	  - name: aave-v3
+//	    chain: ethereum
+//	    pool: "0x87870Bca3F3fD6335C3F4ce8392D69350B4fA4E2"
+//	watch:
+//	  - address: "0x1234..."
+//	    label: treasury
+//	    group: protocol-owned
+//	notifiers:
+//	  - type: webhook
+//	    url: "${SLACK_WEBHOOK_URL}"
+package config
+
+import (
+	"time"
+)
+
+// Config는 설정 파일의 최상위 구조입니다.
+// Config is the top-level structure of the configuration file.
+type Config struct {
+	// Chains는 체인 이름 → 체인 설정입니다.
+	// Chains maps chain name → chain settings.
+	Chains map[string]Chain `yaml:"chains"`
+
+	// Protocols는 모니터링할 프로토콜 배포 목록입니다. 첫 번째가 기본값입니다.
+	// Protocols lists the protocol deployments to monitor. The first one is the default.
+	Protocols []Protocol `yaml:"protocols"`
+
+	// Watch는 감시할 주소 목록입니다.
+	// Watch lists the addresses to watch.
+	Watch []WatchEntry `yaml:"watch"`
+
+	// Thresholds는 알림 임계값입니다.
+	// Thresholds holds alert thresholds.
+	Thresholds Thresholds `yaml:"thresholds"`
+
+	// Notifiers는 알림 대상 목록입니다.
+	// Notifiers lists alert destinations.
+	Notifiers []Notifier `yaml:"notifiers"`
+
+	// Metrics는 Prometheus 메트릭 설정입니다.
+	// Metrics holds Prometheus metrics settings.
+	Metrics Metrics `yaml:"metrics"`
+
+	// Monitor는 모니터링 루프 설정입니다 (monitor, alerter 공용).
+	// Monitor holds monitoring loop settings (shared by monitor and alerter).
+	Monitor Monitor `yaml:"monitor"`
+
+	// Indexer는 이벤트 인덱서 설정입니다.
+	// Indexer holds event indexer settings.
+	Indexer Indexer `yaml:"indexer"`
+
+	// Discovery는 이벤트 기반 대출자 자동 발견 설정입니다 (monitor).
+	// Discovery holds event-based borrower discovery settings (monitor).
+	Discovery Discovery `yaml:"discovery"`
+}
+
+// Chain은 체인 하나의 RPC 설정입니다.
+// Chain is the RPC configuration of one chain.
+type Chain struct {
+	// ChainID는 기대하는 체인 ID입니다 (0 = 확인 안 함).
+	// ChainID is the expected chain ID (0 = not checked).
+	ChainID uint64 `yaml:"chain_id"`
+
+	// RPC는 RPC 엔드포인트 URL 목록입니다.
+	// RPC lists RPC endpoint URLs.
+	RPC []string `yaml:"rpc"`
+
+	// Quorum은 청산 판정 전 합의할 엔드포인트 수입니다 (0 = 비활성, 그 외 2 이상).
+	// Quorum is the number of endpoints that must agree before a liquidation verdict (0 = disabled, otherwise at least 2).
+	Quorum int `yaml:"quorum"`
+
+	// MaxBlockLag는 정상 엔드포인트의 최대 블록 지연입니다.
+	// MaxBlockLag is the maximum block lag of a healthy endpoint.
+	MaxBlockLag uint64 `yaml:"max_block_lag"`
+
+	// RateLimit은 RPC 속도 제한 및 예산입니다.
+	// RateLimit is the RPC rate limit and budget.
+	RateLimit RateLimit `yaml:"rate_limit"`
+}
+
+// RateLimit은 RPC 속도 제한 설정입니다 (단위: 컴퓨트 유닛).
+// RateLimit configures RPC rate limiting (unit: compute units).
+type RateLimit struct {
+	PerSecond   float64 `yaml:"per_second"`
+	Burst       float64 `yaml:"burst"`
+	DailyBudget float64 `yaml:"daily_budget"`
+}
+
+// Protocol은 프로토콜 배포 하나입니다.
+// Protocol is one protocol deployment.
+type Protocol struct {
+	// Name은 메트릭 라벨에 쓰이는 프로토콜 이름입니다 (예: aave-v3).
+	// Name is the protocol name used in metric labels (e.g. aave-v3).
+	Name string `yaml:"name"`
+
+	// Chain은 Chains의 키입니다.
+	// Chain is a key of Chains.
+	Chain string `yaml:"chain"`
+
+	// Pool은 Pool 컨트랙트 주소입니다.
+	// Pool is the Pool contract address.
+	Pool string `yaml:"pool"`
+
+	// UiPoolDataProvider는 UiPoolDataProvider 주소입니다 (선택, V3.0 구조체).
+	// UiPoolDataProvider is the UiPoolDataProvider address (optional, V3.0 struct layout).
+	UiPoolDataProvider string `yaml:"ui_pool_data_provider"`
+}
+
+// WatchEntry는 감시 주소 하나입니다.
+// WatchEntry is one watched address.
+type WatchEntry struct {
+	// Address는 감시할 계정 주소입니다.
+	// Address is the account address to watch.
+	Address string `yaml:"address"`
+
+	// Label은 사람이 읽을 수 있는 이름입니다 (예: treasury).
+	// Label is a human-readable name (e.g. treasury).
+	Label string `yaml:"label"`
+
+	// Group은 메트릭 집계용 그룹입니다 (예: top-100-whales). 비어 있으면 pinned.
+	// Group is the group used to aggregate metrics (e.g. top-100-whales). Empty means pinned.
+	Group string `yaml:"group"`
+}
+
+// Thresholds는 알림 임계값입니다.
+// Thresholds holds alert thresholds.
+type Thresholds struct {
+	HealthFactorWarning  float64 `yaml:"health_factor_warning"`
+	HealthFactorCritical float64 `yaml:"health_factor_critical"`
+
+	// EarlyWarningHorizon은 예상 청산 시간이 이보다 짧으면 조기 경고를 보내는 기준입니다 (alerter).
+	// EarlyWarningHorizon triggers an early warning when the projected time to liquidation is shorter (alerter).
+	EarlyWarningHorizon time.Duration `yaml:"early_warning_horizon"`
+}
+
+// Notifier는 알림 대상 하나입니다. 현재는 webhook 타입만 지원합니다.
+// Notifier is one alert destination. Only the webhook type is currently supported.
+type Notifier struct {
+	Type string `yaml:"type"`
+	URL  string `yaml:"url"`
+}
+
+// Metrics는 Prometheus 메트릭 설정입니다.
+// Metrics holds Prometheus metrics settings.
+type Metrics struct {
+	// Listen은 메트릭 서버 주소입니다 (예: ":9090").
+	// Listen is the metrics server address (e.g. ":9090").
+	Listen string `yaml:"listen"`
+}
+
+// Monitor는 모니터링 루프 설정입니다.
+// Monitor holds monitoring loop settings.
+type Monitor struct {
+	Mode         string        `yaml:"mode"`
+	Interval     time.Duration `yaml:"interval"`
+	BlockStep    uint64        `yaml:"block_step"`
+	BlockPoll    time.Duration `yaml:"block_poll"`
+	Workers      int           `yaml:"workers"`
+	CycleTimeout time.Duration `yaml:"cycle_timeout"`
+	CallTimeout  time.Duration `yaml:"call_timeout"`
+
+	// Exposition은 사용자별 HF 메트릭 대상입니다 (all|pinned|none).
+	// Exposition selects the per-user HF series (all|pinned|none).
+	Exposition string `yaml:"hf_exposition"`
+
+	// TopN은 위험도 상위 계정 게이지 수입니다.
+	// TopN is the number of riskiest-account gauges.
+	TopN int `yaml:"hf_top_n"`
+
+	// HFBuckets는 포지션/부채 집계용 헬스팩터 구간 경계입니다 (오름차순).
+	// HFBuckets are the health factor range boundaries for position/debt counts (ascending).
+	HFBuckets []float64 `yaml:"hf_buckets"`
+
+	// RealertInterval은 같은 수준의 헬스팩터 알림을 반복하는 주기입니다 (0 = 수준이 바뀔 때만).
+	// RealertInterval repeats an unchanged health factor alert level (0 = only on level changes).
+	RealertInterval time.Duration `yaml:"realert_interval"`
+}
+
+// Indexer는 이벤트 인덱서 설정입니다.
+// Indexer holds event indexer settings.
+type Indexer struct {
+	FromBlock     uint64 `yaml:"from_block"`
+	BackfillChunk uint64 `yaml:"backfill_chunk"`
+}
+
+// Discovery는 대출자 자동 발견 설정입니다.
+// Discovery holds borrower discovery settings.
+type Discovery struct {
+	Enabled     bool          `yaml:"enabled"`
+	FromBlock   uint64        `yaml:"from_block"`
+	Lookback    uint64        `yaml:"lookback"`
+	MaxAccounts int           `yaml:"max_accounts"`
+	Poll        time.Duration `yaml:"poll"`
+}
+
+// PrimaryProtocol은 기본 프로토콜(첫 번째)과 그 체인을 반환합니다.
+// PrimaryProtocol returns the default (first) protocol and its chain.
+func (c *Config) PrimaryProtocol() (Protocol, Chain) {
+	p := c.Protocols[0]
+	return p, c.Chains[p.Chain]
+}
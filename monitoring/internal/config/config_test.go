@@ -0,0 +1,163 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// base는 검증을 통과하는 최소 설정입니다. 테스트는 뒤에 줄을 덧붙여 씁니다.
+// base is a minimal config that validates. Tests append lines to it.
+const base = `chains:
+  ethereum:
+    rpc: [ "https://rpc.example" ]
+protocols:
+  - name: aave-v3
+    chain: ethereum
+    pool: "0x87870Bca3F3fD6335C3F4ce8392D69350B4fA4E2"
+`
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("CFG_TEST_SET", "https://hooks.example/set")
+	t.Setenv("CFG_TEST_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantURL string
+		wantErr string
+	}{
+		{"set", `url: "${CFG_TEST_SET}"`, "https://hooks.example/set", ""},
+		{"default when unset", `url: "${CFG_TEST_UNSET:-https://hooks.example/default}"`, "https://hooks.example/default", ""},
+		{"default when empty", `url: "${CFG_TEST_EMPTY:-https://hooks.example/default}"`, "https://hooks.example/default", ""},
+		{"value wins over default", `url: "${CFG_TEST_SET:-https://hooks.example/default}"`, "https://hooks.example/set", ""},
+		{"trailing comment ignored", `url: "${CFG_TEST_SET}"  # see ${CFG_TEST_UNSET}`, "https://hooks.example/set", ""},
+		{"hash inside quotes is not a comment", `url: "${CFG_TEST_UNSET:-https://hooks.example/#x}"`, "https://hooks.example/#x", ""},
+		{"unset without default", `url: "${CFG_TEST_UNSET}"`, "", "config.yaml:11: 환경 변수 CFG_TEST_UNSET 미설정"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := base + "# comment ${CFG_TEST_UNSET}\nnotifiers:\n  - type: webhook\n    " + tt.yaml + "\n"
+			cfg, err := Parse("config.yaml", []byte(raw))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cfg.Notifiers[0].URL; got != tt.wantURL {
+				t.Errorf("url = %q, want %q", got, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestValidationLineNumbers(t *testing.T) {
+	raw := base + `watch:
+  - address: "0x1234"
+  - address: "0x0000000000000000000000000000000000000001"
+    group: "Bad Group"
+thresholds:
+  health_factor_warning: 1.1
+  health_factor_critical: 1.2
+`
+	_, err := Parse("config.yaml", []byte(raw))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"config.yaml:9: watch[0].address: ",
+		"config.yaml:11: watch[1].group: ",
+		"config.yaml:14: thresholds.health_factor_critical: ",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+
+	// 알 수 없는 필드도 줄 번호와 함께 거부 / Unknown fields are rejected with their line number too
+	_, err = Parse("config.yaml", []byte(base+"monitr:\n  interval: 30s\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 8") {
+		t.Errorf("unknown field: err = %v, want a line 8 error", err)
+	}
+}
+
+func TestFlagPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	raw := base + `thresholds:
+  health_factor_warning: 1.3
+monitor:
+  interval: 45s
+  workers: 4
+`
+	if err := os.WriteFile(path, []byte(raw), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	interval := fs.Duration("interval", 30*time.Second, "")
+	workers := fs.Int("workers", 8, "")
+	warning := fs.Float64("hf-warning", 1.2, "")
+	critical := fs.Float64("hf-critical", 1.0, "")
+	rpcURL := fs.String("rpc-url", "", "")
+	if err := fs.Parse([]string{"-workers", "16"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadIntoFlags(fs, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// 명령줄 > 설정 파일 > 기본값 / command line > config file > default
+	if *workers != 16 {
+		t.Errorf("workers = %d, want the command-line 16", *workers)
+	}
+	if *interval != 45*time.Second {
+		t.Errorf("interval = %v, want the config 45s", *interval)
+	}
+	if *warning != 1.3 {
+		t.Errorf("hf-warning = %v, want the config 1.3", *warning)
+	}
+	if *critical != 1.0 {
+		t.Errorf("hf-critical = %v, want the default 1.0", *critical)
+	}
+	if *rpcURL != "https://rpc.example" {
+		t.Errorf("rpc-url = %q, want the config endpoint", *rpcURL)
+	}
+}
+
+func TestHFBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets string
+		want    string
+		wantErr string
+	}{
+		{"ascending", "[1, 1.05, 1.5]", "1,1.05,1.5", ""},
+		{"descending", "[1, 1.5, 1.05]", "", "config.yaml:9: monitor.hf_buckets[2]: "},
+		{"duplicate", "[1, 1]", "", "config.yaml:9: monitor.hf_buckets[1]: "},
+		{"zero", "[0, 1]", "", "config.yaml:9: monitor.hf_buckets[0]: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Parse("config.yaml", []byte(base+"monitor:\n  hf_buckets: "+tt.buckets+"\n"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cfg.FlagValues()["hf-buckets"]; got != tt.want {
+				t.Errorf("hf-buckets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
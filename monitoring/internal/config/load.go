@@ -0,0 +1,419 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// envRef는 ${VAR} 또는 ${VAR:-default} 형태의 환경 변수 참조입니다.
+// envRef matches environment variable references of the form ${VAR} or ${VAR:-default}.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// groupName은 메트릭 라벨로 쓰이는 그룹 이름 형식입니다.
+// groupName is the format of group names, which are used as metric labels.
+var groupName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Load는 설정 파일을 읽고 환경 변수를 치환한 뒤 엄격하게 검증합니다.
+// Load reads a configuration file, interpolates environment variables and validates it strictly.
+//
+// 모든 오류는 "파일:줄: 필드: 메시지" 형식입니다.
+// Every error has the form "file:line: field: message".
+func Load(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 읽기 실패 / failed to read config file: %w", err)
+	}
+	return Parse(path, raw)
+}
+
+// Parse는 메모리의 설정 내용을 파싱합니다. name은 오류 메시지에 쓰입니다.
+// Parse parses configuration content from memory. name is used in error messages.
+func Parse(name string, raw []byte) (*Config, error) {
+	data, err := interpolate(name, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// 알 수 없는 필드는 오류 (오타 방지) / Unknown fields are errors (catches typos)
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	// 검증 오류에 줄 번호를 붙이기 위한 노드 트리 / Node tree for attaching line numbers to validation errors
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var errs []error
+	for _, fe := range cfg.validate() {
+		errs = append(errs, fmt.Errorf("%s:%d: %s: %s", name, lineOf(&root, fe.path), formatPath(fe.path), fe.msg))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &cfg, nil
+}
+
+// interpolate는 ${VAR} 참조를 줄 단위로 치환합니다 (주석은 건너뜀).
+// interpolate substitutes ${VAR} references line by line (comments are skipped).
+//
+// 줄 단위로 치환하므로 이후 YAML 오류의 줄 번호가 원본 파일과 일치합니다.
+// Substituting per line keeps later YAML error line numbers aligned with the original file.
+func interpolate(name string, raw []byte) ([]byte, error) {
+	lines := strings.Split(string(raw), "\n")
+	var errs []error
+	for i, line := range lines {
+		code, comment := splitComment(line)
+		lines[i] = envRef.ReplaceAllStringFunc(code, func(ref string) string {
+			m := envRef.FindStringSubmatch(ref)
+			value, ok := os.LookupEnv(m[1])
+			switch {
+			case ok && value != "":
+			case m[2] != "":
+				value = m[3]
+			default:
+				errs = append(errs, fmt.Errorf("%s:%d: 환경 변수 %s 미설정 / environment variable %s is not set", name, i+1, m[1], m[1]))
+				return ref
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				errs = append(errs, fmt.Errorf("%s:%d: 환경 변수 %s에 줄바꿈 포함 / environment variable %s contains a newline", name, i+1, m[1], m[1]))
+				return ref
+			}
+			return value
+		}) + comment
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// splitComment는 YAML 한 줄을 값 부분과 주석 부분으로 나눕니다.
+// 따옴표 밖에서 줄 처음이나 공백 뒤에 오는 #부터가 주석입니다 (URL의 #fragment는 주석이 아님).
+// splitComment splits one YAML line into its content and its comment.
+// A comment starts at a # outside quotes that begins the line or follows whitespace (a URL #fragment is not a comment).
+func splitComment(line string) (code, comment string) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++ // 이스케이프된 문자 건너뜀 / skip the escaped character
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && (i == 0 || strings.IndexByte(" \t[{,", line[i-1]) >= 0):
+			quote = ch // 스칼라를 여는 따옴표만 (bob's의 '는 아님) / only a quote that opens a scalar (not the ' in bob's)
+		case ch == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
+
+// fieldError는 필드 경로가 붙은 검증 오류입니다.
+// fieldError is a validation error tagged with its field path.
+type fieldError struct {
+	path []any
+	msg  string
+}
+
+// validate는 의미 검증을 수행합니다.
+// validate performs semantic validation.
+func (c *Config) validate() []fieldError {
+	var errs []fieldError
+	fail := func(msg string, path ...any) {
+		errs = append(errs, fieldError{path: path, msg: msg})
+	}
+
+	for name, ch := range c.Chains {
+		if len(ch.RPC) == 0 {
+			fail("RPC 엔드포인트가 하나 이상 필요 / at least one RPC endpoint is required", "chains", name)
+		}
+		for i, u := range ch.RPC {
+			if !validURL(u, "http", "https", "ws", "wss") {
+				fail("잘못된 RPC URL / invalid RPC URL", "chains", name, "rpc", i)
+			}
+		}
+		if ch.Quorum < 0 || ch.Quorum > len(ch.RPC) {
+			fail(fmt.Sprintf("쿼럼은 0..%d 범위여야 함 / quorum must be within 0..%d", len(ch.RPC), len(ch.RPC)), "chains", name, "quorum")
+		} else if ch.Quorum == 1 {
+			fail("쿼럼은 0(비활성) 또는 2 이상이어야 함 / quorum must be 0 (disabled) or at least 2", "chains", name, "quorum")
+		}
+		if ch.RateLimit.PerSecond < 0 || ch.RateLimit.Burst < 0 || ch.RateLimit.DailyBudget < 0 {
+			fail("음수 불가 / must not be negative", "chains", name, "rate_limit")
+		}
+	}
+
+	if len(c.Protocols) == 0 {
+		fail("프로토콜이 하나 이상 필요 / at least one protocol is required", "protocols")
+	}
+	for i, p := range c.Protocols {
+		if p.Name == "" {
+			fail("이름 필요 / name is required", "protocols", i, "name")
+		}
+		if _, ok := c.Chains[p.Chain]; !ok {
+			fail(fmt.Sprintf("정의되지 않은 체인 %q / undefined chain %q", p.Chain, p.Chain), "protocols", i, "chain")
+		}
+		if !common.IsHexAddress(p.Pool) {
+			fail("잘못된 주소 / invalid address", "protocols", i, "pool")
+		}
+		if p.UiPoolDataProvider != "" && !common.IsHexAddress(p.UiPoolDataProvider) {
+			fail("잘못된 주소 / invalid address", "protocols", i, "ui_pool_data_provider")
+		}
+	}
+
+	seen := make(map[common.Address]int)
+	for i, w := range c.Watch {
+		if !common.IsHexAddress(w.Address) {
+			fail("잘못된 주소 / invalid address", "watch", i, "address")
+			continue
+		}
+		addr := common.HexToAddress(w.Address)
+		if prev, ok := seen[addr]; ok {
+			fail(fmt.Sprintf("watch[%d]과 중복 / duplicate of watch[%d]", prev, prev), "watch", i, "address")
+		}
+		seen[addr] = i
+		if w.Group != "" && !groupName.MatchString(w.Group) {
+			fail("그룹은 소문자, 숫자, -, _ 만 허용 / group must use lowercase letters, digits, - and _", "watch", i, "group")
+		}
+	}
+
+	t := c.Thresholds
+	if t.HealthFactorWarning < 0 || t.HealthFactorCritical < 0 {
+		fail("음수 불가 / must not be negative", "thresholds")
+	}
+	if t.HealthFactorWarning > 0 && t.HealthFactorCritical >= t.HealthFactorWarning {
+		fail("critical은 warning보다 작아야 함 / critical must be below warning", "thresholds", "health_factor_critical")
+	}
+	if t.EarlyWarningHorizon < 0 {
+		fail("음수 불가 / must not be negative", "thresholds", "early_warning_horizon")
+	}
+
+	for i, n := range c.Notifiers {
+		if n.Type != "webhook" {
+			fail(fmt.Sprintf("지원하지 않는 타입 %q (webhook만 지원) / unsupported type %q (only webhook)", n.Type, n.Type), "notifiers", i, "type")
+		}
+		if !validURL(n.URL, "http", "https") {
+			fail("잘못된 URL / invalid URL", "notifiers", i, "url")
+		}
+	}
+
+	m := c.Monitor
+	if m.Mode != "" && m.Mode != "interval" && m.Mode != "block" {
+		fail("interval 또는 block이어야 함 / must be interval or block", "monitor", "mode")
+	}
+	switch m.Exposition {
+	case "", "all", "pinned", "none":
+	default:
+		fail("all, pinned 또는 none이어야 함 / must be all, pinned or none", "monitor", "hf_exposition")
+	}
+	if m.Interval < 0 || m.BlockPoll < 0 || m.CycleTimeout < 0 || m.CallTimeout < 0 || m.Workers < 0 || m.TopN < 0 || m.RealertInterval < 0 {
+		fail("음수 불가 / must not be negative", "monitor")
+	}
+	for i, b := range m.HFBuckets {
+		if b <= 0 {
+			fail("양수여야 함 / must be positive", "monitor", "hf_buckets", i)
+		} else if i > 0 && b <= m.HFBuckets[i-1] {
+			fail("경계는 오름차순이어야 함 / boundaries must be ascending", "monitor", "hf_buckets", i)
+		}
+	}
+	if c.Discovery.MaxAccounts < 0 || c.Discovery.Poll < 0 {
+		fail("음수 불가 / must not be negative", "discovery")
+	}
+	return errs
+}
+
+// validURL은 URL이 파싱되고 허용된 스킴인지 확인합니다.
+// validURL reports whether the URL parses and uses an allowed scheme.
+func validURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, s := range schemes {
+		if u.Scheme == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lineOf는 노드 트리에서 경로에 해당하는 줄 번호를 찾습니다 (가장 가까운 상위 노드).
+// lineOf finds the line number for a path in the node tree (nearest existing ancestor).
+func lineOf(root *yaml.Node, path []any) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+	for _, key := range path {
+		var next *yaml.Node
+		switch k := key.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == k {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && k < len(node.Content) {
+				next = node.Content[k]
+			}
+		}
+		if next == nil {
+			break
+		}
+		node, line = next, next.Line
+	}
+	return line
+}
+
+// formatPath는 경로를 "protocols[0].pool" 형식으로 표시합니다.
+// formatPath renders a path as "protocols[0].pool".
+func formatPath(path []any) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch v := p.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(v) + "]")
+		case string:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(v)
+		}
+	}
+	return b.String()
+}
+
+// FlagValues는 설정을 명령줄 플래그 이름 → 값으로 변환합니다 (설정된 값만).
+// FlagValues converts the config into command-line flag name → value (set values only).
+//
+// 모든 명령이 같은 플래그 이름을 쓰므로 각 명령은 자신이 정의한 플래그만 적용받습니다.
+// Every command uses the same flag names, so each command only picks up the flags it defines.
+func (c *Config) FlagValues() map[string]string {
+	v := make(map[string]string)
+	set := func(name, value string, ok bool) {
+		if ok {
+			v[name] = value
+		}
+	}
+
+	if len(c.Protocols) > 0 {
+		p, ch := c.PrimaryProtocol()
+		set("pool-address", p.Pool, p.Pool != "")
+		set("ui-pool-data-provider", p.UiPoolDataProvider, p.UiPoolDataProvider != "")
+		set("rpc-url", strings.Join(ch.RPC, ","), len(ch.RPC) > 0)
+		set("chain-id", strconv.FormatUint(ch.ChainID, 10), ch.ChainID > 0)
+		set("quorum", strconv.Itoa(ch.Quorum), ch.Quorum > 0)
+		set("max-block-lag", strconv.FormatUint(ch.MaxBlockLag, 10), ch.MaxBlockLag > 0)
+		set("rate-limit", formatFloat(ch.RateLimit.PerSecond), ch.RateLimit.PerSecond > 0)
+		set("rate-burst", formatFloat(ch.RateLimit.Burst), ch.RateLimit.Burst > 0)
+		set("daily-budget", formatFloat(ch.RateLimit.DailyBudget), ch.RateLimit.DailyBudget > 0)
+	}
+
+	addrs := make([]string, 0, len(c.Watch))
+	for _, w := range c.Watch {
+		addrs = append(addrs, w.Address)
+	}
+	set("addresses", strings.Join(addrs, ","), len(addrs) > 0)
+
+	var hooks []string
+	for _, n := range c.Notifiers {
+		hooks = append(hooks, n.URL)
+	}
+	set("webhook-url", strings.Join(hooks, ","), len(hooks) > 0)
+
+	t := c.Thresholds
+	set("hf-warning", formatFloat(t.HealthFactorWarning), t.HealthFactorWarning > 0)
+	set("hf-critical", formatFloat(t.HealthFactorCritical), t.HealthFactorCritical > 0)
+	set("ttl-horizon", t.EarlyWarningHorizon.String(), t.EarlyWarningHorizon > 0)
+
+	set("metrics-port", c.Metrics.Listen, c.Metrics.Listen != "")
+
+	m := c.Monitor
+	set("mode", m.Mode, m.Mode != "")
+	set("interval", m.Interval.String(), m.Interval > 0)
+	set("block-step", strconv.FormatUint(m.BlockStep, 10), m.BlockStep > 0)
+	set("block-poll", m.BlockPoll.String(), m.BlockPoll > 0)
+	set("workers", strconv.Itoa(m.Workers), m.Workers > 0)
+	set("cycle-timeout", m.CycleTimeout.String(), m.CycleTimeout > 0)
+	set("call-timeout", m.CallTimeout.String(), m.CallTimeout > 0)
+	set("hf-exposition", m.Exposition, m.Exposition != "")
+	set("hf-top-n", strconv.Itoa(m.TopN), m.TopN > 0)
+	set("realert-interval", m.RealertInterval.String(), m.RealertInterval > 0)
+	buckets := make([]string, 0, len(m.HFBuckets))
+	for _, b := range m.HFBuckets {
+		buckets = append(buckets, formatFloat(b))
+	}
+	set("hf-buckets", strings.Join(buckets, ","), len(buckets) > 0)
+
+	set("from-block", strconv.FormatUint(c.Indexer.FromBlock, 10), c.Indexer.FromBlock > 0)
+	set("backfill-chunk", strconv.FormatUint(c.Indexer.BackfillChunk, 10), c.Indexer.BackfillChunk > 0)
+
+	d := c.Discovery
+	set("discover", strconv.FormatBool(d.Enabled), d.Enabled)
+	set("discover-from-block", strconv.FormatUint(d.FromBlock, 10), d.FromBlock > 0)
+	set("discover-lookback", strconv.FormatUint(d.Lookback, 10), d.Lookback > 0)
+	set("discover-max", strconv.Itoa(d.MaxAccounts), d.MaxAccounts > 0)
+	set("discover-poll", d.Poll.String(), d.Poll > 0)
+	return v
+}
+
+// ApplyFlags는 명령줄에서 명시적으로 지정되지 않은 플래그에 설정 값을 적용합니다.
+// ApplyFlags applies config values to flags not explicitly set on the command line.
+//
+// 우선순위: 명령줄 플래그 > 설정 파일 > 플래그 기본값
+// Precedence: command-line flag > config file > flag default
+func ApplyFlags(fs *flag.FlagSet, values map[string]string) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for name, value := range values {
+		if explicit[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("플래그 %s 적용 실패 / failed to apply flag %s: %w", name, name, err)
+		}
+	}
+	return nil
+}
+
+// LoadIntoFlags는 path가 비어 있지 않으면 설정을 읽어 플래그에 적용합니다.
+// LoadIntoFlags loads the config at path (if non-empty) and applies it to the flags.
+func LoadIntoFlags(fs *flag.FlagSet, path string) (*Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, ApplyFlags(fs, cfg.FlagValues())
+}
+
+// formatFloat은 float을 플래그 문자열로 변환합니다.
+// formatFloat renders a float as a flag string.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,330 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// reloadDebounce는 편집기가 파일을 여러 번 나눠 쓸 때 리로드를 한 번으로 합치는 대기 시간입니다.
+// reloadDebounce coalesces the several writes an editor makes into a single reload.
+const reloadDebounce = 250 * time.Millisecond
+
+// configMapDataDir은 Kubernetes ConfigMap 볼륨이 새 버전으로 바꿔 끼우는 심볼릭 링크 이름입니다.
+// configMapDataDir is the symlink a Kubernetes ConfigMap volume swaps to publish a new version.
+const configMapDataDir = "..data"
+
+// Runtime은 재시작 없이 교체할 수 있는 설정입니다 (감시 주소와 임계값).
+// Runtime is the part of the configuration that can be swapped without a restart (watch list and thresholds).
+type Runtime struct {
+	// Generation은 이 설정의 세대 번호입니다 (시작 시 1).
+	// Generation is the generation of this configuration (1 at startup).
+	Generation uint64
+
+	// Addresses는 감시할 주소 목록입니다.
+	// Addresses is the list of addresses to watch.
+	Addresses []common.Address
+
+	// Labels는 주소별 이름과 그룹입니다 (설정 파일의 watch 항목).
+	// Labels holds the name and group per address (from the config file's watch entries).
+	Labels map[common.Address]AddressLabel
+
+	// HealthFactorWarning은 경고 헬스팩터 임계값입니다.
+	// HealthFactorWarning is the warning health factor threshold.
+	HealthFactorWarning float64
+
+	// HealthFactorCritical은 긴급 헬스팩터 임계값입니다.
+	// HealthFactorCritical is the critical health factor threshold.
+	HealthFactorCritical float64
+}
+
+// Validate는 설정 파일과 명시적 플래그를 합친 결과를 검증합니다.
+// 파일은 각각 유효해도 명령줄 플래그와 합치면 임계값 순서가 뒤집힐 수 있습니다.
+// Validate checks the result of merging the config file with the explicit flags.
+// Each may be valid on its own while the merge inverts the threshold order.
+func (rt *Runtime) Validate() error {
+	if rt.HealthFactorWarning <= 0 || rt.HealthFactorCritical <= 0 {
+		return fmt.Errorf("헬스팩터 임계값은 양수여야 함 (warning %v, critical %v) / health factor thresholds must be positive (warning %v, critical %v)",
+			rt.HealthFactorWarning, rt.HealthFactorCritical, rt.HealthFactorWarning, rt.HealthFactorCritical)
+	}
+	if rt.HealthFactorCritical >= rt.HealthFactorWarning {
+		return fmt.Errorf("critical(%v)은 warning(%v)보다 작아야 함 / critical (%v) must be below warning (%v)",
+			rt.HealthFactorCritical, rt.HealthFactorWarning, rt.HealthFactorCritical, rt.HealthFactorWarning)
+	}
+	return nil
+}
+
+// AddressLabel은 감시 주소의 표시 이름과 그룹입니다.
+// AddressLabel is the display name and group of a watched address.
+type AddressLabel struct {
+	Label string
+	Group string
+}
+
+// LabelOf는 주소의 이름과 그룹을 반환합니다. 그룹이 없으면 defaultGroup을 씁니다.
+// LabelOf returns the name and group of an address, using defaultGroup when it has none.
+func (rt *Runtime) LabelOf(addr common.Address, defaultGroup string) AddressLabel {
+	l := rt.Labels[addr]
+	if l.Group == "" {
+		l.Group = defaultGroup
+	}
+	return l
+}
+
+// ExplicitFlags는 명령줄에서 명시적으로 지정된 플래그 이름을 반환합니다.
+// ExplicitFlags returns the names of flags set explicitly on the command line.
+//
+// 설정을 플래그에 적용하기 전에 호출해야 합니다 (적용 후에는 설정 값도 "지정됨"으로 보임).
+// Must be called before the config is applied to the flags (afterwards config values look "set" too).
+func ExplicitFlags(fs *flag.FlagSet) map[string]bool {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	return explicit
+}
+
+// Reloader는 설정 파일 변경이나 SIGHUP에 맞춰 Runtime을 원자적으로 교체합니다.
+// Reloader atomically swaps the Runtime when the config file changes or on SIGHUP.
+//
+// 검증에 실패한 설정은 적용하지 않고 이전 설정을 유지합니다.
+// 명령줄에서 명시한 플래그는 리로드 후에도 설정 파일보다 우선합니다.
+// A config that fails validation is not applied and the previous one is kept.
+// Flags given explicitly on the command line keep precedence over the file after a reload.
+type Reloader struct {
+	fs       *flag.FlagSet
+	path     string
+	explicit map[string]bool
+	logger   *slog.Logger
+
+	current atomic.Pointer[Runtime]
+
+	mu       sync.Mutex
+	onReload []func(old, cur *Runtime)
+}
+
+// NewReloader는 현재 플래그와 (있다면) 시작 시 읽은 설정으로 Reloader를 생성합니다.
+// NewReloader creates a Reloader from the current flags and the config loaded at startup (if any).
+//
+// explicit은 ExplicitFlags의 결과이고, cfg는 path가 비어 있으면 nil입니다.
+// explicit is the result of ExplicitFlags, and cfg is nil when path is empty.
+func NewReloader(fs *flag.FlagSet, path string, explicit map[string]bool, cfg *Config, logger *slog.Logger) *Reloader {
+	r := &Reloader{
+		fs:       fs,
+		path:     path,
+		explicit: explicit,
+		logger:   logger,
+	}
+	rt := r.resolve(cfg)
+	rt.Generation = 1
+	r.current.Store(rt)
+	metrics.ConfigGeneration.Set(1)
+	return r
+}
+
+// Current는 현재 Runtime을 반환합니다. 반환값은 읽기 전용으로 취급해야 합니다.
+// Current returns the active Runtime. Callers must treat it as read-only.
+func (r *Reloader) Current() *Runtime {
+	return r.current.Load()
+}
+
+// OnReload는 리로드가 성공할 때마다 호출될 함수를 등록합니다.
+// OnReload registers a function called after every successful reload.
+func (r *Reloader) OnReload(fn func(old, cur *Runtime)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onReload = append(r.onReload, fn)
+}
+
+// Reload는 설정 파일을 다시 읽고 검증에 성공하면 Runtime을 교체합니다.
+// Reload re-reads the config file and swaps the Runtime if it validates.
+func (r *Reloader) Reload() error {
+	if r.path == "" {
+		return fmt.Errorf("설정 파일 없음 (--config 미지정) / no config file (--config not given)")
+	}
+
+	metrics.ConfigLastReloadTimestamp.Set(float64(time.Now().Unix()))
+	fail := func(err error) error {
+		metrics.ConfigReloadsTotal.WithLabelValues("failure").Inc()
+		metrics.ConfigLastReloadSuccess.Set(0)
+		return err
+	}
+	cfg, err := Load(r.path)
+	if err != nil {
+		return fail(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old := r.current.Load()
+	cur := r.resolve(cfg)
+	if err := cur.Validate(); err != nil {
+		return fail(err)
+	}
+	cur.Generation = old.Generation + 1
+	r.current.Store(cur)
+
+	metrics.ConfigReloadsTotal.WithLabelValues("success").Inc()
+	metrics.ConfigLastReloadSuccess.Set(1)
+	metrics.ConfigGeneration.Set(float64(cur.Generation))
+	for _, fn := range r.onReload {
+		fn(old, cur)
+	}
+	return nil
+}
+
+// Run은 ctx가 끝날 때까지 설정 파일 변경과 SIGHUP을 감시하며 리로드합니다.
+// Run watches for config file changes and SIGHUP, reloading until ctx is done.
+//
+// 편집기는 보통 새 파일을 쓰고 이름을 바꾸므로 파일이 아닌 디렉터리를 감시합니다.
+// Kubernetes ConfigMap은 파일이 아닌 ..data 심볼릭 링크를 원자적으로 바꾸므로 그 이벤트에도 반응합니다.
+// Editors usually write a new file and rename it, so the directory is watched rather than the file.
+// Kubernetes ConfigMaps atomically swap the ..data symlink rather than the file, so its events count too.
+func (r *Reloader) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events <-chan fsnotify.Event
+	var watchErrs <-chan error
+	if r.path != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			r.logger.Warn("설정 파일 감시 불가, SIGHUP만 사용 / Cannot watch config file, SIGHUP only", "error", err)
+		} else {
+			defer watcher.Close()
+			if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+				r.logger.Warn("설정 파일 감시 불가, SIGHUP만 사용 / Cannot watch config file, SIGHUP only", "error", err)
+			} else {
+				events, watchErrs = watcher.Events, watcher.Errors
+			}
+		}
+	}
+
+	target := filepath.Clean(r.path)
+	configMapData := filepath.Join(filepath.Dir(target), configMapDataDir)
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case ev := <-events:
+			name := filepath.Clean(ev.Name)
+			if (name != target && name != configMapData) || ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			debounce.Reset(reloadDebounce)
+		case err := <-watchErrs:
+			r.logger.Warn("설정 파일 감시 오류 / Config file watch error", "error", err)
+		case <-debounce.C:
+			r.reload("file")
+		case <-hup:
+			r.reload("sighup")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload는 리로드를 실행하고 결과를 로그로 남깁니다.
+// reload runs a reload and logs the outcome.
+func (r *Reloader) reload(trigger string) {
+	if err := r.Reload(); err != nil {
+		r.logger.Error("설정 리로드 실패, 이전 설정 유지 / Config reload failed, keeping previous config",
+			"trigger", trigger,
+			"generation", r.Current().Generation,
+			"error", err,
+		)
+		return
+	}
+	cur := r.Current()
+	r.logger.Info("설정 리로드 완료 / Config reloaded",
+		"trigger", trigger,
+		"generation", cur.Generation,
+		"addresses", len(cur.Addresses),
+		"hf_warning", cur.HealthFactorWarning,
+		"hf_critical", cur.HealthFactorCritical,
+	)
+}
+
+// resolve는 설정과 명시적 플래그로 Runtime을 만듭니다.
+// resolve builds a Runtime from the config and the explicit flags.
+//
+// 우선순위는 시작 시와 같습니다: 명령줄 플래그 > 설정 파일 > 플래그 기본값
+// Precedence matches startup: command-line flag > config file > flag default
+func (r *Reloader) resolve(cfg *Config) *Runtime {
+	rt := &Runtime{
+		Labels:               make(map[common.Address]AddressLabel),
+		HealthFactorWarning:  r.floatFlag("hf-warning", cfg, func(c *Config) float64 { return c.Thresholds.HealthFactorWarning }),
+		HealthFactorCritical: r.floatFlag("hf-critical", cfg, func(c *Config) float64 { return c.Thresholds.HealthFactorCritical }),
+	}
+
+	// 라벨은 명령줄로 지정한 주소에도 적용 / Labels apply to addresses given on the command line too
+	if cfg != nil {
+		for _, w := range cfg.Watch {
+			if w.Label != "" || w.Group != "" {
+				rt.Labels[common.HexToAddress(w.Address)] = AddressLabel{Label: w.Label, Group: w.Group}
+			}
+		}
+	}
+
+	if cfg == nil || r.explicit["addresses"] {
+		for _, a := range strings.Split(r.flagValue("addresses"), ",") {
+			a = strings.TrimSpace(a)
+			if a == "" {
+				continue
+			}
+			if !common.IsHexAddress(a) {
+				r.logger.Warn("잘못된 주소 무시 / Ignoring invalid address", "address", a)
+				continue
+			}
+			rt.Addresses = append(rt.Addresses, common.HexToAddress(a))
+		}
+		return rt
+	}
+	for _, w := range cfg.Watch {
+		rt.Addresses = append(rt.Addresses, common.HexToAddress(w.Address))
+	}
+	return rt
+}
+
+// floatFlag는 명시적 플래그, 설정 값(> 0), 플래그 기본값 순으로 float 값을 고릅니다.
+// floatFlag picks a float from the explicit flag, the config value (> 0), then the flag default.
+func (r *Reloader) floatFlag(name string, cfg *Config, fromConfig func(*Config) float64) float64 {
+	f := r.fs.Lookup(name)
+	if f == nil {
+		return 0
+	}
+	raw := f.DefValue
+	switch {
+	case cfg == nil || r.explicit[name]:
+		raw = f.Value.String()
+	case fromConfig(cfg) > 0:
+		return fromConfig(cfg)
+	}
+	v, _ := strconv.ParseFloat(raw, 64)
+	return v
+}
+
+// flagValue는 플래그의 현재 값을 반환합니다 (정의되지 않았으면 빈 문자열).
+// flagValue returns a flag's current value (empty if undefined).
+func (r *Reloader) flagValue(name string) string {
+	if f := r.fs.Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
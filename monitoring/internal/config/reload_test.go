@@ -0,0 +1,231 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// watchConfig는 감시 주소 하나와 임계값을 담은 설정 파일 내용입니다.
+// watchConfig is config file content with one watched address and the thresholds.
+func watchConfig(addr string, warning, critical string) string {
+	return base + `watch:
+  - address: "` + addr + `"
+thresholds:
+  health_factor_warning: ` + warning + `
+  health_factor_critical: ` + critical + `
+`
+}
+
+const (
+	addrA = "0x0000000000000000000000000000000000000001"
+	addrB = "0x0000000000000000000000000000000000000002"
+)
+
+// newTestReloader는 명령줄 args로 플래그를 파싱하고 path의 설정으로 Reloader를 만듭니다.
+// newTestReloader parses the flags from the command-line args and builds a Reloader from the config at path.
+func newTestReloader(t *testing.T, path string, args ...string) *Reloader {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("addresses", "", "")
+	fs.Float64("hf-warning", 1.2, "")
+	fs.Float64("hf-critical", 1.0, "")
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	explicit := ExplicitFlags(fs)
+	cfg, err := LoadIntoFlags(fs, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewReloader(fs, path, explicit, cfg, slog.New(slog.DiscardHandler))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitGeneration은 세대가 want에 도달할 때까지 기다립니다.
+// waitGeneration waits until the generation reaches want.
+func waitGeneration(t *testing.T, r *Reloader, want uint64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Current().Generation < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("generation = %d, want %d", r.Current().Generation, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReloadKeepsPreviousOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, watchConfig(addrA, "1.3", "1.1"))
+	r := newTestReloader(t, path)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid yaml", "chains: [\n"},
+		{"failed validation", watchConfig("0x1234", "1.3", "1.1")},
+		{"critical above warning", watchConfig(addrB, "1.1", "1.3")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeFile(t, path, tt.content)
+			if err := r.Reload(); err == nil {
+				t.Fatal("expected a reload error")
+			}
+			cur := r.Current()
+			if cur.Generation != 1 || len(cur.Addresses) != 1 || cur.Addresses[0] != common.HexToAddress(addrA) || cur.HealthFactorWarning != 1.3 {
+				t.Errorf("runtime after failed reload = %+v, want the previous one", cur)
+			}
+			if v := testutil.ToFloat64(metrics.ConfigLastReloadSuccess); v != 0 {
+				t.Errorf("config_last_reload_success = %v, want 0", v)
+			}
+		})
+	}
+
+	writeFile(t, path, watchConfig(addrB, "1.3", "1.1"))
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if cur := r.Current(); cur.Generation != 2 || cur.Addresses[0] != common.HexToAddress(addrB) {
+		t.Errorf("runtime after valid reload = %+v", cur)
+	}
+}
+
+func TestReloadValidatesMergedFlags(t *testing.T) {
+	// 파일은 유효하지만 명령줄 --hf-critical 1.25가 리로드된 warning 1.2보다 큼
+	// The file is valid, but the command-line --hf-critical 1.25 exceeds the reloaded warning 1.2
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, watchConfig(addrA, "1.3", "1.1"))
+	r := newTestReloader(t, path, "-hf-critical", "1.25")
+	if err := r.Current().Validate(); err != nil {
+		t.Fatalf("startup runtime: %v", err)
+	}
+
+	writeFile(t, path, watchConfig(addrA, "1.2", "1.1"))
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected the merged thresholds to be rejected")
+	}
+	if cur := r.Current(); cur.Generation != 1 || cur.HealthFactorWarning != 1.3 || cur.HealthFactorCritical != 1.25 {
+		t.Errorf("runtime = %+v, want the previous thresholds", cur)
+	}
+}
+
+// runReloader는 Run을 시작하고 성공한 리로드 수를 세는 카운터를 반환합니다.
+// runReloader starts Run and returns a counter of successful reloads.
+func runReloader(t *testing.T, r *Reloader) *atomic.Int32 {
+	t.Helper()
+	var reloads atomic.Int32
+	r.OnReload(func(_, _ *Runtime) { reloads.Add(1) })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// 감시자가 디렉터리를 등록할 시간 / Time for the watcher to register the directory
+	time.Sleep(200 * time.Millisecond)
+	return &reloads
+}
+
+func TestRunDebouncesWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, watchConfig(addrA, "1.3", "1.1"))
+	r := newTestReloader(t, path)
+	reloads := runReloader(t, r)
+
+	// 편집기처럼 짧은 간격으로 여러 번 쓰면 리로드는 한 번 / Several quick writes, like an editor's, reload once
+	for _, warning := range []string{"1.4", "1.5", "1.6"} {
+		writeFile(t, path, watchConfig(addrB, warning, "1.1"))
+		time.Sleep(20 * time.Millisecond)
+	}
+	waitGeneration(t, r, 2)
+	time.Sleep(2 * reloadDebounce)
+	if n := reloads.Load(); n != 1 {
+		t.Errorf("reloads = %d, want 1", n)
+	}
+	if cur := r.Current(); cur.HealthFactorWarning != 1.6 || cur.Addresses[0] != common.HexToAddress(addrB) {
+		t.Errorf("runtime = %+v, want the last write", cur)
+	}
+}
+
+func TestRunConfigMapSwap(t *testing.T) {
+	// Kubernetes ConfigMap 볼륨 구조: config.yaml → ..data/config.yaml, ..data → ..<timestamp>
+	// Kubernetes ConfigMap volume layout: config.yaml → ..data/config.yaml, ..data → ..<timestamp>
+	dir := t.TempDir()
+	publish := func(version, content string) {
+		t.Helper()
+		if err := os.Mkdir(filepath.Join(dir, version), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, version, "config.yaml"), content)
+		tmp := filepath.Join(dir, "..data_tmp")
+		if err := os.Symlink(version, tmp); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, filepath.Join(dir, configMapDataDir)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	publish("..2024_01_01", watchConfig(addrA, "1.3", "1.1"))
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(filepath.Join(configMapDataDir, "config.yaml"), path); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestReloader(t, path)
+	runReloader(t, r)
+	publish("..2024_01_02", watchConfig(addrB, "1.3", "1.1"))
+
+	waitGeneration(t, r, 2)
+	if cur := r.Current(); cur.Addresses[0] != common.HexToAddress(addrB) {
+		t.Errorf("addresses = %v, want %s", cur.Addresses, addrB)
+	}
+}
+
+func TestRunSIGHUP(t *testing.T) {
+	// 테스트도 SIGHUP을 구독해 두어 Run이 등록되기 전에 보낸 신호가 프로세스를 끝내지 않게 함
+	// The test subscribes to SIGHUP too, so a signal sent before Run registers cannot kill the process
+	own := make(chan os.Signal, 1)
+	signal.Notify(own, syscall.SIGHUP)
+	t.Cleanup(func() { signal.Stop(own) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, watchConfig(addrA, "1.3", "1.1"))
+	r := newTestReloader(t, path)
+	runReloader(t, r)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Current().Generation < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("generation = %d after SIGHUP, want 2", r.Current().Generation)
+		}
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,286 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// aaveAddressesProviderABI는 PoolAddressesProvider의 조회 함수 최소 ABI입니다.
+// aaveAddressesProviderABI is a minimal ABI of the PoolAddressesProvider lookup functions.
+const aaveAddressesProviderABI = `[
+	{"type":"function","name":"getPoolDataProvider","stateMutability":"view",
+	 "inputs":[],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"getPriceOracle","stateMutability":"view",
+	 "inputs":[],"outputs":[{"name":"","type":"address"}]}
+]`
+
+// aaveDataProviderABI는 AaveProtocolDataProvider의 최소 ABI입니다.
+// aaveDataProviderABI is a minimal ABI of the AaveProtocolDataProvider.
+const aaveDataProviderABI = `[
+	{"type":"function","name":"getAllReservesTokens","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"tuple[]","components":[
+		{"name":"symbol","type":"string"},
+		{"name":"tokenAddress","type":"address"}]}]},
+	{"type":"function","name":"getReserveConfigurationData","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"}],
+	 "outputs":[
+		{"name":"decimals","type":"uint256"},
+		{"name":"ltv","type":"uint256"},
+		{"name":"liquidationThreshold","type":"uint256"},
+		{"name":"liquidationBonus","type":"uint256"},
+		{"name":"reserveFactor","type":"uint256"},
+		{"name":"usageAsCollateralEnabled","type":"bool"},
+		{"name":"borrowingEnabled","type":"bool"},
+		{"name":"stableBorrowRateEnabled","type":"bool"},
+		{"name":"isActive","type":"bool"},
+		{"name":"isFrozen","type":"bool"}]},
+	{"type":"function","name":"getReserveData","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"}],
+	 "outputs":[
+		{"name":"unbacked","type":"uint256"},
+		{"name":"accruedToTreasuryScaled","type":"uint256"},
+		{"name":"totalAToken","type":"uint256"},
+		{"name":"totalStableDebt","type":"uint256"},
+		{"name":"totalVariableDebt","type":"uint256"},
+		{"name":"liquidityRate","type":"uint256"},
+		{"name":"variableBorrowRate","type":"uint256"},
+		{"name":"stableBorrowRate","type":"uint256"},
+		{"name":"averageStableBorrowRate","type":"uint256"},
+		{"name":"liquidityIndex","type":"uint256"},
+		{"name":"variableBorrowIndex","type":"uint256"},
+		{"name":"lastUpdateTimestamp","type":"uint40"}]},
+	{"type":"function","name":"getUserReserveData","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"},{"name":"user","type":"address"}],
+	 "outputs":[
+		{"name":"currentATokenBalance","type":"uint256"},
+		{"name":"currentStableDebt","type":"uint256"},
+		{"name":"currentVariableDebt","type":"uint256"},
+		{"name":"principalStableDebt","type":"uint256"},
+		{"name":"scaledVariableDebt","type":"uint256"},
+		{"name":"stableBorrowRate","type":"uint256"},
+		{"name":"liquidityRate","type":"uint256"},
+		{"name":"stableRateLastUpdated","type":"uint40"},
+		{"name":"usageAsCollateralEnabled","type":"bool"}]}
+]`
+
+// aaveOracleABI는 AaveOracle의 최소 ABI입니다.
+// aaveOracleABI is a minimal ABI of the AaveOracle.
+const aaveOracleABI = `[
+	{"type":"function","name":"getAssetsPrices","stateMutability":"view",
+	 "inputs":[{"name":"assets","type":"address[]"}],
+	 "outputs":[{"name":"","type":"uint256[]"}]}
+]`
+
+var (
+	parsedAaveAddressesProviderABI = mustParseABI(aaveAddressesProviderABI)
+	parsedAaveDataProviderABI      = mustParseABI(aaveDataProviderABI)
+	parsedAaveOracleABI            = mustParseABI(aaveOracleABI)
+)
+
+// AaveAddresses는 Pool에서 찾은 보조 컨트랙트 주소입니다.
+// AaveAddresses holds the auxiliary contract addresses resolved from a Pool.
+type AaveAddresses struct {
+	Pool              common.Address
+	AddressesProvider common.Address
+	DataProvider      common.Address
+	Oracle            common.Address
+
+	// UiPoolDataProvider는 선택 사항입니다 (0 주소 = 리저브별 조회). ResolveAaveAddresses는 채우지 않습니다.
+	// UiPoolDataProvider is optional (zero address = per-reserve reads). ResolveAaveAddresses does not fill it in.
+	UiPoolDataProvider common.Address
+}
+
+// ResolveAaveAddresses는 Pool → PoolAddressesProvider를 따라 데이터 제공자와 오라클 주소를 찾습니다.
+// ResolveAaveAddresses follows Pool → PoolAddressesProvider to find the data provider and oracle addresses.
+func ResolveAaveAddresses(opts *bind.CallOpts, backend bind.ContractCaller, pool *AavePoolCaller) (*AaveAddresses, error) {
+	provider, err := pool.AddressesProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(provider, parsedAaveAddressesProviderABI, backend, nil, nil)
+
+	lookup := func(method string) (common.Address, error) {
+		var out []interface{}
+		if err := contract.Call(opts, &out, method); err != nil {
+			return common.Address{}, fmt.Errorf("%s 호출 실패 / %s call failed: %w", method, method, err)
+		}
+		return out[0].(common.Address), nil
+	}
+	dataProvider, err := lookup("getPoolDataProvider")
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := lookup("getPriceOracle")
+	if err != nil {
+		return nil, err
+	}
+	return &AaveAddresses{Pool: pool.Address(), AddressesProvider: provider, DataProvider: dataProvider, Oracle: oracle}, nil
+}
+
+// ReserveToken은 리저브 자산의 심볼과 주소입니다.
+// ReserveToken is the symbol and address of a reserve asset.
+type ReserveToken struct {
+	Symbol       string         `json:"symbol"`
+	TokenAddress common.Address `json:"tokenAddress"`
+}
+
+// ReserveConfiguration은 리저브 설정입니다 (bps 값은 10000 = 100%).
+// ReserveConfiguration is a reserve's configuration (bps values: 10000 = 100%).
+type ReserveConfiguration struct {
+	Decimals                 uint8
+	Ltv                      *big.Int
+	LiquidationThreshold     *big.Int
+	LiquidationBonus         *big.Int
+	ReserveFactor            *big.Int
+	UsageAsCollateralEnabled bool
+	BorrowingEnabled         bool
+	IsActive                 bool
+	IsFrozen                 bool
+}
+
+// ReserveData는 리저브의 총량과 현재 이자율입니다 (이자율은 ray, 1e27 = 100% APR).
+// ReserveData is a reserve's totals and current rates (rates in ray, 1e27 = 100% APR).
+type ReserveData struct {
+	Unbacked                *big.Int
+	TotalAToken             *big.Int
+	TotalStableDebt         *big.Int
+	TotalVariableDebt       *big.Int
+	LiquidityRate           *big.Int
+	VariableBorrowRate      *big.Int
+	StableBorrowRate        *big.Int
+	AverageStableBorrowRate *big.Int
+	LiquidityIndex          *big.Int
+	VariableBorrowIndex     *big.Int
+	LastUpdateTimestamp     uint64
+}
+
+// UserReserveData는 사용자의 리저브 하나에 대한 잔고입니다 (자산 단위).
+// UserReserveData is a user's balance in one reserve (in asset units).
+type UserReserveData struct {
+	// CurrentATokenBalance는 이자 포함 예치 잔고입니다.
+	// CurrentATokenBalance is the supplied balance including interest.
+	CurrentATokenBalance *big.Int
+
+	// CurrentStableDebt는 고정금리 부채입니다.
+	// CurrentStableDebt is the stable-rate debt.
+	CurrentStableDebt *big.Int
+
+	// CurrentVariableDebt는 변동금리 부채입니다.
+	// CurrentVariableDebt is the variable-rate debt.
+	CurrentVariableDebt *big.Int
+
+	// UsageAsCollateralEnabled는 사용자가 이 자산을 담보로 쓰는지 여부입니다.
+	// UsageAsCollateralEnabled reports whether the user uses this asset as collateral.
+	UsageAsCollateralEnabled bool
+}
+
+// AaveDataProviderCaller는 AaveProtocolDataProvider를 호출하는 클라이언트입니다.
+// AaveDataProviderCaller is a client for calling the AaveProtocolDataProvider.
+type AaveDataProviderCaller struct {
+	contract *bind.BoundContract
+}
+
+// NewAaveDataProviderCaller는 새로운 AaveDataProviderCaller를 생성합니다.
+// NewAaveDataProviderCaller creates a new AaveDataProviderCaller.
+func NewAaveDataProviderCaller(backend bind.ContractCaller, address common.Address) *AaveDataProviderCaller {
+	return &AaveDataProviderCaller{
+		contract: bind.NewBoundContract(address, parsedAaveDataProviderABI, backend, nil, nil),
+	}
+}
+
+// GetAllReservesTokens는 모든 리저브 자산을 조회합니다.
+// GetAllReservesTokens retrieves every reserve asset.
+func (c *AaveDataProviderCaller) GetAllReservesTokens(opts *bind.CallOpts) ([]ReserveToken, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getAllReservesTokens"); err != nil {
+		return nil, fmt.Errorf("getAllReservesTokens 호출 실패 / getAllReservesTokens call failed: %w", err)
+	}
+	tokens := *abi.ConvertType(out[0], new([]ReserveToken)).(*[]ReserveToken)
+	return tokens, nil
+}
+
+// GetReserveConfigurationData는 리저브 설정을 조회합니다.
+// GetReserveConfigurationData retrieves a reserve's configuration.
+func (c *AaveDataProviderCaller) GetReserveConfigurationData(opts *bind.CallOpts, asset common.Address) (*ReserveConfiguration, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getReserveConfigurationData", asset); err != nil {
+		return nil, fmt.Errorf("getReserveConfigurationData 호출 실패 / getReserveConfigurationData call failed: %w", err)
+	}
+	return &ReserveConfiguration{
+		Decimals:                 uint8(out[0].(*big.Int).Uint64()),
+		Ltv:                      out[1].(*big.Int),
+		LiquidationThreshold:     out[2].(*big.Int),
+		LiquidationBonus:         out[3].(*big.Int),
+		ReserveFactor:            out[4].(*big.Int),
+		UsageAsCollateralEnabled: out[5].(bool),
+		BorrowingEnabled:         out[6].(bool),
+		IsActive:                 out[8].(bool),
+		IsFrozen:                 out[9].(bool),
+	}, nil
+}
+
+// GetReserveData는 리저브의 총량과 이자율을 조회합니다.
+// GetReserveData retrieves a reserve's totals and rates.
+func (c *AaveDataProviderCaller) GetReserveData(opts *bind.CallOpts, asset common.Address) (*ReserveData, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getReserveData", asset); err != nil {
+		return nil, fmt.Errorf("getReserveData 호출 실패 / getReserveData call failed: %w", err)
+	}
+	return &ReserveData{
+		Unbacked:                out[0].(*big.Int),
+		TotalAToken:             out[2].(*big.Int),
+		TotalStableDebt:         out[3].(*big.Int),
+		TotalVariableDebt:       out[4].(*big.Int),
+		LiquidityRate:           out[5].(*big.Int),
+		VariableBorrowRate:      out[6].(*big.Int),
+		StableBorrowRate:        out[7].(*big.Int),
+		AverageStableBorrowRate: out[8].(*big.Int),
+		LiquidityIndex:          out[9].(*big.Int),
+		VariableBorrowIndex:     out[10].(*big.Int),
+		LastUpdateTimestamp:     out[11].(*big.Int).Uint64(),
+	}, nil
+}
+
+// GetUserReserveData는 사용자의 리저브 잔고를 조회합니다.
+// GetUserReserveData retrieves a user's balances in a reserve.
+func (c *AaveDataProviderCaller) GetUserReserveData(opts *bind.CallOpts, asset, user common.Address) (*UserReserveData, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getUserReserveData", asset, user); err != nil {
+		return nil, fmt.Errorf("getUserReserveData 호출 실패 / getUserReserveData call failed: %w", err)
+	}
+	return &UserReserveData{
+		CurrentATokenBalance:     out[0].(*big.Int),
+		CurrentStableDebt:        out[1].(*big.Int),
+		CurrentVariableDebt:      out[2].(*big.Int),
+		UsageAsCollateralEnabled: out[8].(bool),
+	}, nil
+}
+
+// AaveOracleCaller는 AaveOracle을 호출하는 클라이언트입니다.
+// AaveOracleCaller is a client for calling the AaveOracle.
+type AaveOracleCaller struct {
+	contract *bind.BoundContract
+}
+
+// NewAaveOracleCaller는 새로운 AaveOracleCaller를 생성합니다.
+// NewAaveOracleCaller creates a new AaveOracleCaller.
+func NewAaveOracleCaller(backend bind.ContractCaller, address common.Address) *AaveOracleCaller {
+	return &AaveOracleCaller{
+		contract: bind.NewBoundContract(address, parsedAaveOracleABI, backend, nil, nil),
+	}
+}
+
+// GetAssetsPrices는 자산 가격을 기본 통화 단위로 조회합니다 (USD, 8 소수점).
+// GetAssetsPrices retrieves asset prices in base currency units (USD, 8 decimals).
+func (c *AaveOracleCaller) GetAssetsPrices(opts *bind.CallOpts, assets []common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getAssetsPrices", assets); err != nil {
+		return nil, fmt.Errorf("getAssetsPrices 호출 실패 / getAssetsPrices call failed: %w", err)
+	}
+	return out[0].([]*big.Int), nil
+}
@@ -0,0 +1,63 @@
+package contracts
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Aave V3 이벤트 시그니처 / Aave V3 event signatures
+// 이벤트 토픽 = keccak256(이벤트 시그니처)
+// Event topic = keccak256(event signature)
+var (
+	// Supply(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint16 indexed referralCode)
+	SupplyEventSig = crypto.Keccak256Hash([]byte("Supply(address,address,address,uint256,uint16)"))
+
+	// Withdraw(address indexed reserve, address indexed user, address indexed to, uint256 amount)
+	WithdrawEventSig = crypto.Keccak256Hash([]byte("Withdraw(address,address,address,uint256)"))
+
+	// Borrow(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint8 interestRateMode, uint256 borrowRate, uint16 indexed referralCode)
+	BorrowEventSig = crypto.Keccak256Hash([]byte("Borrow(address,address,address,uint256,uint8,uint256,uint16)"))
+
+	// Repay(address indexed reserve, address indexed user, address indexed repayer, uint256 amount, bool useATokens)
+	RepayEventSig = crypto.Keccak256Hash([]byte("Repay(address,address,address,uint256,bool)"))
+
+	// LiquidationCall(address indexed collateralAsset, address indexed debtAsset, address indexed user, uint256 debtToCover, uint256 liquidatedCollateralAmount, address liquidator, bool receiveAToken)
+	LiquidationCallEventSig = crypto.Keccak256Hash([]byte("LiquidationCall(address,address,address,uint256,uint256,address,bool)"))
+)
+
+// PositionEventSigs는 계정 포지션을 바꾸는 이벤트 토픽 목록입니다 (FilterQuery.Topics[0]용).
+// PositionEventSigs lists the event topics that change an account's position (for FilterQuery.Topics[0]).
+var PositionEventSigs = []common.Hash{
+	SupplyEventSig,
+	WithdrawEventSig,
+	BorrowEventSig,
+	RepayEventSig,
+	LiquidationCallEventSig,
+}
+
+// PositionOwner는 이벤트로 포지션이 바뀐 계정을 반환합니다.
+// PositionOwner returns the account whose position the event changed.
+//
+// 호출자(user/repayer)가 아니라 포지션 소유자(onBehalfOf 등)를 반환합니다.
+// Returns the position owner (onBehalfOf etc.), not the caller (user/repayer).
+func PositionOwner(vLog types.Log) (common.Address, bool) {
+	if len(vLog.Topics) == 0 {
+		return common.Address{}, false
+	}
+	var idx int
+	switch vLog.Topics[0] {
+	case SupplyEventSig, BorrowEventSig:
+		idx = 2 // onBehalfOf
+	case WithdrawEventSig, RepayEventSig:
+		idx = 2 // user
+	case LiquidationCallEventSig:
+		idx = 3 // user
+	default:
+		return common.Address{}, false
+	}
+	if len(vLog.Topics) <= idx {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(vLog.Topics[idx].Bytes()), true
+}
@@ -3,11 +3,13 @@
 package contracts
 
 import (
+	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // Aave V3 메인넷 컨트랙트 주소 / Aave V3 mainnet contract addresses
@@ -49,22 +51,99 @@ type UserAccountData struct {
 	HealthFactor *big.Int
 }
 
+// aavePoolABI는 모니터링에 필요한 Aave V3 Pool 함수만 포함한 최소 ABI입니다.
+// aavePoolABI is a minimal Aave V3 Pool ABI containing only the functions monitoring needs.
+const aavePoolABI = `[
+	{"type":"function","name":"getUserAccountData","stateMutability":"view",
+	 "inputs":[{"name":"user","type":"address"}],
+	 "outputs":[
+		{"name":"totalCollateralBase","type":"uint256"},
+		{"name":"totalDebtBase","type":"uint256"},
+		{"name":"availableBorrowsBase","type":"uint256"},
+		{"name":"currentLiquidationThreshold","type":"uint256"},
+		{"name":"ltv","type":"uint256"},
+		{"name":"healthFactor","type":"uint256"}]},
+	{"type":"function","name":"ADDRESSES_PROVIDER","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"getUserConfiguration","stateMutability":"view",
+	 "inputs":[{"name":"user","type":"address"}],
+	 "outputs":[{"name":"","type":"tuple","components":[{"name":"data","type":"uint256"}]}]},
+	{"type":"function","name":"getConfiguration","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"}],
+	 "outputs":[{"name":"","type":"tuple","components":[{"name":"data","type":"uint256"}]}]},
+	{"type":"function","name":"getReserveData","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"}],
+	 "outputs":[{"name":"","type":"tuple","components":[
+		{"name":"configuration","type":"tuple","components":[{"name":"data","type":"uint256"}]},
+		{"name":"liquidityIndex","type":"uint128"},
+		{"name":"currentLiquidityRate","type":"uint128"},
+		{"name":"variableBorrowIndex","type":"uint128"},
+		{"name":"currentVariableBorrowRate","type":"uint128"},
+		{"name":"currentStableBorrowRate","type":"uint128"},
+		{"name":"lastUpdateTimestamp","type":"uint40"},
+		{"name":"id","type":"uint16"},
+		{"name":"aTokenAddress","type":"address"},
+		{"name":"stableDebtTokenAddress","type":"address"},
+		{"name":"variableDebtTokenAddress","type":"address"},
+		{"name":"interestRateStrategyAddress","type":"address"},
+		{"name":"accruedToTreasury","type":"uint128"},
+		{"name":"unbacked","type":"uint128"},
+		{"name":"isolationModeTotalDebt","type":"uint128"}]}]},
+	{"type":"function","name":"getUserEMode","stateMutability":"view",
+	 "inputs":[{"name":"user","type":"address"}],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getEModeCategoryData","stateMutability":"view",
+	 "inputs":[{"name":"id","type":"uint8"}],
+	 "outputs":[{"name":"","type":"tuple","components":[
+		{"name":"ltv","type":"uint16"},
+		{"name":"liquidationThreshold","type":"uint16"},
+		{"name":"liquidationBonus","type":"uint16"},
+		{"name":"priceSource","type":"address"},
+		{"name":"label","type":"string"}]}]}
+]`
+
+// parsedAavePoolABI는 한 번만 파싱된 Pool ABI입니다.
+// parsedAavePoolABI is the Pool ABI parsed once.
+var parsedAavePoolABI = mustParseABI(aavePoolABI)
+
+// mustParseABI는 패키지에 내장된 ABI를 파싱합니다 (실패는 프로그래밍 오류).
+// mustParseABI parses an ABI embedded in this package (failure is a programming error).
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(fmt.Sprintf("내장 ABI 파싱 실패 / failed to parse embedded ABI: %v", err))
+	}
+	return parsed
+}
+
 // AavePoolCaller는 Aave V3 Pool 컨트랙트를 호출하는 클라이언트입니다.
 // AavePoolCaller is a client for calling the Aave V3 Pool contract.
+//
+// backend는 *ethclient.Client, *rpcpool.Pool, *rpcpool.QuorumCaller 등
+// bind.ContractCaller를 구현하는 모든 타입이 될 수 있습니다.
+// backend can be anything implementing bind.ContractCaller, such as
+// *ethclient.Client, *rpcpool.Pool or *rpcpool.QuorumCaller.
 type AavePoolCaller struct {
-	client  *ethclient.Client
-	address common.Address
+	contract *bind.BoundContract
+	address  common.Address
 }
 
 // NewAavePoolCaller는 새로운 AavePoolCaller를 생성합니다.
 // NewAavePoolCaller creates a new AavePoolCaller.
-func NewAavePoolCaller(client *ethclient.Client, poolAddress common.Address) *AavePoolCaller {
+func NewAavePoolCaller(backend bind.ContractCaller, poolAddress common.Address) *AavePoolCaller {
 	return &AavePoolCaller{
-		client:  client,
-		address: poolAddress,
+		contract: bind.NewBoundContract(poolAddress, parsedAavePoolABI, backend, nil, nil),
+		address:  poolAddress,
 	}
 }
 
+// Address는 Pool 컨트랙트 주소를 반환합니다.
+// Address returns the Pool contract address.
+func (c *AavePoolCaller) Address() common.Address {
+	return c.address
+}
+
 // GetUserAccountData는 사용자의 계정 데이터를 조회합니다.
 // GetUserAccountData retrieves user's account data from Aave V3 Pool.
 //
@@ -84,36 +163,168 @@ func NewAavePoolCaller(client *ethclient.Client, poolAddress common.Address) *Aa
 // - LTV
 // - Health factor (1e18 scale)
 func (c *AavePoolCaller) GetUserAccountData(opts *bind.CallOpts, user common.Address) (*UserAccountData, error) {
-	// getUserAccountData(address) ABI 인코딩
-	// Encode getUserAccountData(address) ABI
-	//
-	// 참고: 실제 구현에서는 abigen으로 생성된 바인딩을 사용합니다.
-	// Note: In production, use bindings generated by abigen.
-	//
-	// 함수 시그니처: getUserAccountData(address)
-	// Function signature: getUserAccountData(address)
-	// 셀렉터: 0xbf92857c
-	// Selector: 0xbf92857c
-
-	// TODO: go-ethereum의 abi 패키지를 사용하여 실제 호출 구현
-	// TODO: Implement actual call using go-ethereum's abi package
-	//
-	// 예시 코드 (abigen 바인딩 사용 시):
-	// Example code (with abigen bindings):
-	//
-	// pool, err := aavev3.NewPool(c.address, c.client)
-	// if err != nil {
-	//     return nil, err
-	// }
-	// data, err := pool.GetUserAccountData(opts, user)
-	// if err != nil {
-	//     return nil, err
-	// }
-
-	_ = opts // suppress unused warning
-	_ = user
-
-	return &UserAccountData{}, nil
+	// 함수 시그니처: getUserAccountData(address), 셀렉터: 0xbf92857c
+	// Function signature: getUserAccountData(address), selector: 0xbf92857c
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getUserAccountData", user); err != nil {
+		return nil, fmt.Errorf("getUserAccountData 호출 실패 / getUserAccountData call failed: %w", err)
+	}
+
+	return &UserAccountData{
+		TotalCollateralBase:         out[0].(*big.Int),
+		TotalDebtBase:               out[1].(*big.Int),
+		AvailableBorrowsBase:        out[2].(*big.Int),
+		CurrentLiquidationThreshold: out[3].(*big.Int),
+		Ltv:                         out[4].(*big.Int),
+		HealthFactor:                out[5].(*big.Int),
+	}, nil
+}
+
+// AddressesProvider는 Pool이 등록된 PoolAddressesProvider 주소를 조회합니다.
+// AddressesProvider retrieves the PoolAddressesProvider the Pool is registered with.
+//
+// 데이터 제공자와 오라클 주소는 여기서 찾으므로 배포마다 하드코딩할 필요가 없습니다.
+// The data provider and oracle addresses are looked up from it, so they need no per-deployment hardcoding.
+func (c *AavePoolCaller) AddressesProvider(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "ADDRESSES_PROVIDER"); err != nil {
+		return common.Address{}, fmt.Errorf("ADDRESSES_PROVIDER 호출 실패 / ADDRESSES_PROVIDER call failed: %w", err)
+	}
+	return out[0].(common.Address), nil
+}
+
+// PoolReserveData는 Pool.getReserveData의 반환값입니다 (V3.0 구조체, V3.1+의 ReserveDataLegacy와 같음).
+// 이자율과 인덱스는 ray (1e27) 단위입니다.
+// PoolReserveData is the return value of Pool.getReserveData (V3.0 struct, same as ReserveDataLegacy on V3.1+).
+// Rates and indices are in ray (1e27).
+type PoolReserveData struct {
+	Configuration               ReserveConfigurationMap
+	LiquidityIndex              *big.Int
+	CurrentLiquidityRate        *big.Int
+	VariableBorrowIndex         *big.Int
+	CurrentVariableBorrowRate   *big.Int
+	CurrentStableBorrowRate     *big.Int
+	LastUpdateTimestamp         uint64
+	ID                          uint16
+	ATokenAddress               common.Address
+	StableDebtTokenAddress      common.Address
+	VariableDebtTokenAddress    common.Address
+	InterestRateStrategyAddress common.Address
+
+	// IsolationModeTotalDebt는 이 자산을 격리 담보로 쓴 계정들의 총 부채입니다 (USD 2 소수점).
+	// IsolationModeTotalDebt is the total debt of accounts using this asset as isolated collateral (USD with 2 decimals).
+	IsolationModeTotalDebt *big.Int
+}
+
+// bitmapTuple은 { uint256 data } 형태의 설정 비트맵 튜플입니다.
+// bitmapTuple is a configuration bitmap tuple of the form { uint256 data }.
+type bitmapTuple struct {
+	Data *big.Int `json:"data"`
+}
+
+// rawPoolReserveData는 getReserveData 튜플의 ABI 디코딩 대상입니다.
+// rawPoolReserveData is the ABI decoding target of the getReserveData tuple.
+type rawPoolReserveData struct {
+	Configuration               bitmapTuple    `json:"configuration"`
+	LiquidityIndex              *big.Int       `json:"liquidityIndex"`
+	CurrentLiquidityRate        *big.Int       `json:"currentLiquidityRate"`
+	VariableBorrowIndex         *big.Int       `json:"variableBorrowIndex"`
+	CurrentVariableBorrowRate   *big.Int       `json:"currentVariableBorrowRate"`
+	CurrentStableBorrowRate     *big.Int       `json:"currentStableBorrowRate"`
+	LastUpdateTimestamp         *big.Int       `json:"lastUpdateTimestamp"`
+	Id                          uint16         `json:"id"`
+	ATokenAddress               common.Address `json:"aTokenAddress"`
+	StableDebtTokenAddress      common.Address `json:"stableDebtTokenAddress"`
+	VariableDebtTokenAddress    common.Address `json:"variableDebtTokenAddress"`
+	InterestRateStrategyAddress common.Address `json:"interestRateStrategyAddress"`
+	AccruedToTreasury           *big.Int       `json:"accruedToTreasury"`
+	Unbacked                    *big.Int       `json:"unbacked"`
+	IsolationModeTotalDebt      *big.Int       `json:"isolationModeTotalDebt"`
+}
+
+// rawEModeCategory는 getEModeCategoryData 튜플의 ABI 디코딩 대상입니다.
+// rawEModeCategory is the ABI decoding target of the getEModeCategoryData tuple.
+type rawEModeCategory struct {
+	Ltv                  uint16         `json:"ltv"`
+	LiquidationThreshold uint16         `json:"liquidationThreshold"`
+	LiquidationBonus     uint16         `json:"liquidationBonus"`
+	PriceSource          common.Address `json:"priceSource"`
+	Label                string         `json:"label"`
+}
+
+// GetUserConfiguration은 사용자 설정 비트맵을 조회합니다 (Aave V3 배치).
+// GetUserConfiguration retrieves a user's configuration bitmap (Aave V3 layout).
+func (c *AavePoolCaller) GetUserConfiguration(opts *bind.CallOpts, user common.Address) (UserConfigurationMap, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getUserConfiguration", user); err != nil {
+		return UserConfigurationMap{}, fmt.Errorf("getUserConfiguration 호출 실패 / getUserConfiguration call failed: %w", err)
+	}
+	raw := *abi.ConvertType(out[0], new(bitmapTuple)).(*bitmapTuple)
+	return NewUserConfiguration(raw.Data), nil
+}
+
+// GetConfiguration은 리저브 설정 비트맵을 조회해 풉니다.
+// GetConfiguration retrieves and unpacks a reserve's configuration bitmap.
+func (c *AavePoolCaller) GetConfiguration(opts *bind.CallOpts, asset common.Address) (ReserveConfigurationMap, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getConfiguration", asset); err != nil {
+		return ReserveConfigurationMap{}, fmt.Errorf("getConfiguration 호출 실패 / getConfiguration call failed: %w", err)
+	}
+	raw := *abi.ConvertType(out[0], new(bitmapTuple)).(*bitmapTuple)
+	return DecodeReserveConfiguration(raw.Data), nil
+}
+
+// GetReserveData는 리저브 상태 (설정, 인덱스, 이자율, ID, 토큰 주소)를 한 번에 조회합니다.
+// GetReserveData retrieves a reserve's state (configuration, indices, rates, ID, token addresses) in one call.
+func (c *AavePoolCaller) GetReserveData(opts *bind.CallOpts, asset common.Address) (*PoolReserveData, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getReserveData", asset); err != nil {
+		return nil, fmt.Errorf("getReserveData 호출 실패 / getReserveData call failed: %w", err)
+	}
+	raw := *abi.ConvertType(out[0], new(rawPoolReserveData)).(*rawPoolReserveData)
+	return &PoolReserveData{
+		Configuration:               DecodeReserveConfiguration(raw.Configuration.Data),
+		LiquidityIndex:              raw.LiquidityIndex,
+		CurrentLiquidityRate:        raw.CurrentLiquidityRate,
+		VariableBorrowIndex:         raw.VariableBorrowIndex,
+		CurrentVariableBorrowRate:   raw.CurrentVariableBorrowRate,
+		CurrentStableBorrowRate:     raw.CurrentStableBorrowRate,
+		LastUpdateTimestamp:         raw.LastUpdateTimestamp.Uint64(),
+		ID:                          raw.Id,
+		ATokenAddress:               raw.ATokenAddress,
+		StableDebtTokenAddress:      raw.StableDebtTokenAddress,
+		VariableDebtTokenAddress:    raw.VariableDebtTokenAddress,
+		InterestRateStrategyAddress: raw.InterestRateStrategyAddress,
+		IsolationModeTotalDebt:      raw.IsolationModeTotalDebt,
+	}, nil
+}
+
+// GetUserEMode는 사용자의 e-mode 카테고리를 조회합니다 (0 = e-mode 아님).
+// GetUserEMode retrieves a user's e-mode category (0 = not in e-mode).
+func (c *AavePoolCaller) GetUserEMode(opts *bind.CallOpts, user common.Address) (uint8, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getUserEMode", user); err != nil {
+		return 0, fmt.Errorf("getUserEMode 호출 실패 / getUserEMode call failed: %w", err)
+	}
+	return uint8(out[0].(*big.Int).Uint64()), nil
+}
+
+// GetEModeCategoryData는 e-mode 카테고리의 파라미터를 조회합니다 (V3.0/V3.1 구조체).
+// GetEModeCategoryData retrieves an e-mode category's parameters (V3.0/V3.1 struct).
+func (c *AavePoolCaller) GetEModeCategoryData(opts *bind.CallOpts, id uint8) (*EModeCategory, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getEModeCategoryData", id); err != nil {
+		return nil, fmt.Errorf("getEModeCategoryData 호출 실패 / getEModeCategoryData call failed: %w", err)
+	}
+	raw := *abi.ConvertType(out[0], new(rawEModeCategory)).(*rawEModeCategory)
+	return &EModeCategory{
+		ID:                   id,
+		LTV:                  raw.Ltv,
+		LiquidationThreshold: raw.LiquidationThreshold,
+		LiquidationBonus:     raw.LiquidationBonus,
+		PriceSource:          raw.PriceSource,
+		Label:                raw.Label,
+	}, nil
 }
 
 // ChainlinkRoundData는 Chainlink 가격 피드의 라운드 데이터입니다.
@@ -0,0 +1,89 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// aaveRateStrategyABI는 DefaultReserveInterestRateStrategy (V3.0/V3.1, 리저브별 배포)의 조회 함수 최소 ABI입니다.
+// V3.2 이후의 DefaultReserveInterestRateStrategyV2는 리저브 주소를 인자로 받는 싱글턴이라 이 ABI로 읽을 수 없습니다.
+// aaveRateStrategyABI is a minimal ABI of the DefaultReserveInterestRateStrategy getters (V3.0/V3.1, one deployment per reserve).
+// DefaultReserveInterestRateStrategyV2 from V3.2 on is a singleton keyed by reserve address and cannot be read with it.
+const aaveRateStrategyABI = `[
+	{"type":"function","name":"OPTIMAL_USAGE_RATIO","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"OPTIMAL_STABLE_TO_TOTAL_DEBT_RATIO","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"MAX_EXCESS_USAGE_RATIO","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"MAX_EXCESS_STABLE_TO_TOTAL_DEBT_RATIO","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getBaseVariableBorrowRate","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getVariableRateSlope1","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getVariableRateSlope2","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getStableRateSlope1","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getStableRateSlope2","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getStableRateExcessOffset","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getBaseStableBorrowRate","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var parsedAaveRateStrategyABI = mustParseABI(aaveRateStrategyABI)
+
+// AaveRateStrategyParams는 DefaultReserveInterestRateStrategy의 파라미터입니다 (모두 ray, 1e27 = 100%).
+// AaveRateStrategyParams are the parameters of a DefaultReserveInterestRateStrategy (all in ray, 1e27 = 100%).
+type AaveRateStrategyParams struct {
+	OptimalUsageRatio               *big.Int
+	OptimalStableToTotalDebtRatio   *big.Int
+	MaxExcessUsageRatio             *big.Int
+	MaxExcessStableToTotalDebtRatio *big.Int
+	BaseVariableBorrowRate          *big.Int
+	VariableRateSlope1              *big.Int
+	VariableRateSlope2              *big.Int
+	StableRateSlope1                *big.Int
+	StableRateSlope2                *big.Int
+	StableRateExcessOffset          *big.Int
+	BaseStableBorrowRate            *big.Int
+}
+
+// AaveRateStrategyCaller는 DefaultReserveInterestRateStrategy를 호출하는 클라이언트입니다.
+// AaveRateStrategyCaller is a client for calling a DefaultReserveInterestRateStrategy.
+type AaveRateStrategyCaller struct {
+	contract *bind.BoundContract
+}
+
+// NewAaveRateStrategyCaller는 새로운 AaveRateStrategyCaller를 생성합니다.
+// NewAaveRateStrategyCaller creates a new AaveRateStrategyCaller.
+func NewAaveRateStrategyCaller(backend bind.ContractCaller, address common.Address) *AaveRateStrategyCaller {
+	return &AaveRateStrategyCaller{
+		contract: bind.NewBoundContract(address, parsedAaveRateStrategyABI, backend, nil, nil),
+	}
+}
+
+// GetParams는 전략 파라미터를 모두 조회합니다 (getter당 한 번씩 호출).
+// GetParams retrieves every strategy parameter (one call per getter).
+func (c *AaveRateStrategyCaller) GetParams(opts *bind.CallOpts) (*AaveRateStrategyParams, error) {
+	p := &AaveRateStrategyParams{}
+	fields := []struct {
+		method string
+		dst    **big.Int
+	}{
+		{"OPTIMAL_USAGE_RATIO", &p.OptimalUsageRatio},
+		{"OPTIMAL_STABLE_TO_TOTAL_DEBT_RATIO", &p.OptimalStableToTotalDebtRatio},
+		{"MAX_EXCESS_USAGE_RATIO", &p.MaxExcessUsageRatio},
+		{"MAX_EXCESS_STABLE_TO_TOTAL_DEBT_RATIO", &p.MaxExcessStableToTotalDebtRatio},
+		{"getBaseVariableBorrowRate", &p.BaseVariableBorrowRate},
+		{"getVariableRateSlope1", &p.VariableRateSlope1},
+		{"getVariableRateSlope2", &p.VariableRateSlope2},
+		{"getStableRateSlope1", &p.StableRateSlope1},
+		{"getStableRateSlope2", &p.StableRateSlope2},
+		{"getStableRateExcessOffset", &p.StableRateExcessOffset},
+		{"getBaseStableBorrowRate", &p.BaseStableBorrowRate},
+	}
+	for _, f := range fields {
+		var out []interface{}
+		if err := c.contract.Call(opts, &out, f.method); err != nil {
+			return nil, fmt.Errorf("%s 호출 실패 / %s call failed: %w", f.method, f.method, err)
+		}
+		*f.dst = out[0].(*big.Int)
+	}
+	return p, nil
+}
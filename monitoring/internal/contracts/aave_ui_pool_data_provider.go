@@ -0,0 +1,73 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// aaveUiPoolDataProviderABI는 UiPoolDataProvider.getUserReservesData의 최소 ABI입니다 (V3.0 구조체).
+// V3.1 이후 배포는 고정금리 필드가 빠져 디코딩에 실패하므로, 호출자는 리저브별 조회로 대체해야 합니다.
+// UiPoolDataProvider는 주변부 컨트랙트라 PoolAddressesProvider로 찾을 수 없어 주소를 직접 지정해야 합니다.
+// aaveUiPoolDataProviderABI is a minimal ABI of UiPoolDataProvider.getUserReservesData (V3.0 struct layout).
+// Deployments from V3.1 on dropped the stable-rate fields and fail to decode, so callers should fall back
+// to per-reserve reads. The UiPoolDataProvider is a periphery contract that cannot be resolved via the
+// PoolAddressesProvider, so its address must be given explicitly.
+const aaveUiPoolDataProviderABI = `[
+	{"type":"function","name":"getUserReservesData","stateMutability":"view",
+	 "inputs":[{"name":"provider","type":"address"},{"name":"user","type":"address"}],
+	 "outputs":[
+		{"name":"","type":"tuple[]","components":[
+			{"name":"underlyingAsset","type":"address"},
+			{"name":"scaledATokenBalance","type":"uint256"},
+			{"name":"usageAsCollateralEnabledOnUser","type":"bool"},
+			{"name":"stableBorrowRate","type":"uint256"},
+			{"name":"scaledVariableDebt","type":"uint256"},
+			{"name":"principalStableDebt","type":"uint256"},
+			{"name":"stableBorrowLastUpdateTimestamp","type":"uint256"}]},
+		{"name":"","type":"uint8"}]}
+]`
+
+var parsedAaveUiPoolDataProviderABI = mustParseABI(aaveUiPoolDataProviderABI)
+
+// UiUserReserveData는 getUserReservesData가 반환하는 리저브 하나의 사용자 잔고입니다.
+// 예치금과 변동 부채는 스케일된 값이므로 리저브 인덱스를 곱해야 현재 잔고가 됩니다.
+// UiUserReserveData is one reserve's user balance as returned by getUserReservesData.
+// Supplied balance and variable debt are scaled; multiply by the reserve index to get the current balance.
+type UiUserReserveData struct {
+	UnderlyingAsset                 common.Address `json:"underlyingAsset"`
+	ScaledATokenBalance             *big.Int       `json:"scaledATokenBalance"`
+	UsageAsCollateralEnabledOnUser  bool           `json:"usageAsCollateralEnabledOnUser"`
+	StableBorrowRate                *big.Int       `json:"stableBorrowRate"`
+	ScaledVariableDebt              *big.Int       `json:"scaledVariableDebt"`
+	PrincipalStableDebt             *big.Int       `json:"principalStableDebt"`
+	StableBorrowLastUpdateTimestamp *big.Int       `json:"stableBorrowLastUpdateTimestamp"`
+}
+
+// AaveUiPoolDataProviderCaller는 UiPoolDataProvider를 호출하는 클라이언트입니다.
+// AaveUiPoolDataProviderCaller is a client for calling the UiPoolDataProvider.
+type AaveUiPoolDataProviderCaller struct {
+	contract *bind.BoundContract
+}
+
+// NewAaveUiPoolDataProviderCaller는 새로운 AaveUiPoolDataProviderCaller를 생성합니다.
+// NewAaveUiPoolDataProviderCaller creates a new AaveUiPoolDataProviderCaller.
+func NewAaveUiPoolDataProviderCaller(backend bind.ContractCaller, address common.Address) *AaveUiPoolDataProviderCaller {
+	return &AaveUiPoolDataProviderCaller{
+		contract: bind.NewBoundContract(address, parsedAaveUiPoolDataProviderABI, backend, nil, nil),
+	}
+}
+
+// GetUserReservesData는 사용자의 모든 리저브 잔고와 e-mode 카테고리를 한 번의 호출로 조회합니다.
+// GetUserReservesData retrieves a user's balances in every reserve and their e-mode category in a single call.
+func (c *AaveUiPoolDataProviderCaller) GetUserReservesData(opts *bind.CallOpts, provider, user common.Address) ([]UiUserReserveData, uint8, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getUserReservesData", provider, user); err != nil {
+		return nil, 0, fmt.Errorf("getUserReservesData 호출 실패 / getUserReservesData call failed: %w", err)
+	}
+	data := *abi.ConvertType(out[0], new([]UiUserReserveData)).(*[]UiUserReserveData)
+	return data, out[1].(uint8), nil
+}
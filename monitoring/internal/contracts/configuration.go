@@ -0,0 +1,283 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Aave V3 ReserveConfigurationMap 비트 배치 (ReserveConfiguration.sol)
+// Aave V3 ReserveConfigurationMap bit layout (ReserveConfiguration.sol)
+//
+//	bit 0-15:    LTV (bps)
+//	bit 16-31:   청산 기준 / liquidation threshold (bps)
+//	bit 32-47:   청산 보너스 / liquidation bonus (bps)
+//	bit 48-55:   소수점 / decimals
+//	bit 56:      활성 / active
+//	bit 57:      동결 / frozen
+//	bit 58:      대출 가능 / borrowing enabled
+//	bit 59:      고정금리 대출 가능 / stable rate borrowing enabled
+//	bit 60:      일시 정지 / paused
+//	bit 61:      격리 모드에서 대출 가능 / borrowable in isolation
+//	bit 62:      사일로 대출 / siloed borrowing
+//	bit 63:      플래시론 가능 / flash loaning enabled
+//	bit 64-79:   리저브 팩터 / reserve factor (bps)
+//	bit 80-115:  대출 한도 / borrow cap (whole tokens)
+//	bit 116-151: 예치 한도 / supply cap (whole tokens)
+//	bit 152-167: 청산 프로토콜 수수료 / liquidation protocol fee (bps)
+//	bit 168-175: e-mode 카테고리 / e-mode category
+//	bit 176-211: 무담보 발행 한도 / unbacked mint cap (whole tokens)
+//	bit 212-251: 격리 모드 부채 한도 / isolation mode debt ceiling (2 decimals)
+//	bit 252-255: 미사용 / unused
+type bitField struct {
+	offset uint
+	width  uint
+}
+
+var (
+	fieldLTV                    = bitField{0, 16}
+	fieldLiquidationThreshold   = bitField{16, 16}
+	fieldLiquidationBonus       = bitField{32, 16}
+	fieldDecimals               = bitField{48, 8}
+	fieldActive                 = bitField{56, 1}
+	fieldFrozen                 = bitField{57, 1}
+	fieldBorrowingEnabled       = bitField{58, 1}
+	fieldStableBorrowingEnabled = bitField{59, 1}
+	fieldPaused                 = bitField{60, 1}
+	fieldBorrowableInIsolation  = bitField{61, 1}
+	fieldSiloedBorrowing        = bitField{62, 1}
+	fieldFlashLoanEnabled       = bitField{63, 1}
+	fieldReserveFactor          = bitField{64, 16}
+	fieldBorrowCap              = bitField{80, 36}
+	fieldSupplyCap              = bitField{116, 36}
+	fieldLiquidationProtocolFee = bitField{152, 16}
+	fieldEModeCategory          = bitField{168, 8}
+	fieldUnbackedMintCap        = bitField{176, 36}
+	fieldDebtCeiling            = bitField{212, 40}
+)
+
+// get은 data에서 필드 값을 읽습니다.
+// get reads the field's value from data.
+func (f bitField) get(data *big.Int) uint64 {
+	v := new(big.Int).Rsh(data, f.offset)
+	mask := new(big.Int).Lsh(big.NewInt(1), f.width)
+	return v.And(v, mask.Sub(mask, big.NewInt(1))).Uint64()
+}
+
+// set은 data의 필드 값을 v로 바꿉니다 (폭을 넘는 비트는 버림).
+// set replaces the field's value in data with v (bits beyond the width are dropped).
+func (f bitField) set(data *big.Int, v uint64) {
+	mask := new(big.Int).Lsh(big.NewInt(1), f.width)
+	mask.Sub(mask, big.NewInt(1))
+	value := new(big.Int).And(new(big.Int).SetUint64(v), mask)
+	data.AndNot(data, mask.Lsh(mask, f.offset))
+	data.Or(data, value.Lsh(value, f.offset))
+}
+
+// flag는 1비트 필드를 bool로 읽습니다.
+// flag reads a one-bit field as a bool.
+func (f bitField) flag(data *big.Int) bool {
+	return f.get(data) == 1
+}
+
+// setFlag는 1비트 필드를 설정합니다.
+// setFlag sets a one-bit field.
+func (f bitField) setFlag(data *big.Int, v bool) {
+	if v {
+		f.set(data, 1)
+	} else {
+		f.set(data, 0)
+	}
+}
+
+// ReserveConfigurationMap은 Aave V3 리저브 설정 비트맵을 풀어 놓은 값입니다.
+// Pool.getReserveData 한 번으로 LTV, 청산 기준, 한도, 상태 플래그를 모두 얻을 수 있습니다.
+// ReserveConfigurationMap is an unpacked Aave V3 reserve configuration bitmap.
+// A single Pool.getReserveData call yields LTV, liquidation threshold, caps and status flags.
+type ReserveConfigurationMap struct {
+	LTV                    uint16 // bps
+	LiquidationThreshold   uint16 // bps
+	LiquidationBonus       uint16 // bps (10500 = 5% 보너스 / 5% bonus)
+	Decimals               uint8
+	Active                 bool
+	Frozen                 bool
+	BorrowingEnabled       bool
+	StableBorrowingEnabled bool
+	Paused                 bool
+	BorrowableInIsolation  bool
+	SiloedBorrowing        bool
+	FlashLoanEnabled       bool
+	ReserveFactor          uint16 // bps
+	BorrowCap              uint64 // 토큰 단위, 0 = 무제한 / whole tokens, 0 = unlimited
+	SupplyCap              uint64 // 토큰 단위, 0 = 무제한 / whole tokens, 0 = unlimited
+	LiquidationProtocolFee uint16 // bps
+	EModeCategory          uint8
+	UnbackedMintCap        uint64 // 토큰 단위 / whole tokens
+	DebtCeiling            uint64 // 2 소수점 USD, 0 = 격리 자산 아님 / USD with 2 decimals, 0 = not an isolated asset
+}
+
+// DecodeReserveConfiguration은 리저브 설정 비트맵을 풉니다.
+// DecodeReserveConfiguration unpacks a reserve configuration bitmap.
+func DecodeReserveConfiguration(data *big.Int) ReserveConfigurationMap {
+	return ReserveConfigurationMap{
+		LTV:                    uint16(fieldLTV.get(data)),
+		LiquidationThreshold:   uint16(fieldLiquidationThreshold.get(data)),
+		LiquidationBonus:       uint16(fieldLiquidationBonus.get(data)),
+		Decimals:               uint8(fieldDecimals.get(data)),
+		Active:                 fieldActive.flag(data),
+		Frozen:                 fieldFrozen.flag(data),
+		BorrowingEnabled:       fieldBorrowingEnabled.flag(data),
+		StableBorrowingEnabled: fieldStableBorrowingEnabled.flag(data),
+		Paused:                 fieldPaused.flag(data),
+		BorrowableInIsolation:  fieldBorrowableInIsolation.flag(data),
+		SiloedBorrowing:        fieldSiloedBorrowing.flag(data),
+		FlashLoanEnabled:       fieldFlashLoanEnabled.flag(data),
+		ReserveFactor:          uint16(fieldReserveFactor.get(data)),
+		BorrowCap:              fieldBorrowCap.get(data),
+		SupplyCap:              fieldSupplyCap.get(data),
+		LiquidationProtocolFee: uint16(fieldLiquidationProtocolFee.get(data)),
+		EModeCategory:          uint8(fieldEModeCategory.get(data)),
+		UnbackedMintCap:        fieldUnbackedMintCap.get(data),
+		DebtCeiling:            fieldDebtCeiling.get(data),
+	}
+}
+
+// Encode는 설정을 비트맵으로 묶습니다. 필드 폭을 넘는 값 (36비트 한도 등)은 잘립니다.
+// Encode packs the configuration into a bitmap. Values wider than their field (e.g. 36-bit caps) are truncated.
+func (c ReserveConfigurationMap) Encode() *big.Int {
+	data := new(big.Int)
+	fieldLTV.set(data, uint64(c.LTV))
+	fieldLiquidationThreshold.set(data, uint64(c.LiquidationThreshold))
+	fieldLiquidationBonus.set(data, uint64(c.LiquidationBonus))
+	fieldDecimals.set(data, uint64(c.Decimals))
+	fieldActive.setFlag(data, c.Active)
+	fieldFrozen.setFlag(data, c.Frozen)
+	fieldBorrowingEnabled.setFlag(data, c.BorrowingEnabled)
+	fieldStableBorrowingEnabled.setFlag(data, c.StableBorrowingEnabled)
+	fieldPaused.setFlag(data, c.Paused)
+	fieldBorrowableInIsolation.setFlag(data, c.BorrowableInIsolation)
+	fieldSiloedBorrowing.setFlag(data, c.SiloedBorrowing)
+	fieldFlashLoanEnabled.setFlag(data, c.FlashLoanEnabled)
+	fieldReserveFactor.set(data, uint64(c.ReserveFactor))
+	fieldBorrowCap.set(data, c.BorrowCap)
+	fieldSupplyCap.set(data, c.SupplyCap)
+	fieldLiquidationProtocolFee.set(data, uint64(c.LiquidationProtocolFee))
+	fieldEModeCategory.set(data, uint64(c.EModeCategory))
+	fieldUnbackedMintCap.set(data, c.UnbackedMintCap)
+	fieldDebtCeiling.set(data, c.DebtCeiling)
+	return data
+}
+
+// String은 로그용 요약입니다.
+// String is a summary for logs.
+func (c ReserveConfigurationMap) String() string {
+	return fmt.Sprintf("ltv=%.2f%% lt=%.2f%% bonus=%.2f%% decimals=%d active=%t frozen=%t paused=%t borrow_cap=%d supply_cap=%d emode=%d",
+		float64(c.LTV)/100, float64(c.LiquidationThreshold)/100, float64(c.LiquidationBonus)/100-100,
+		c.Decimals, c.Active, c.Frozen, c.Paused, c.BorrowCap, c.SupplyCap, c.EModeCategory)
+}
+
+// UserConfigLayout은 사용자 설정 비트맵에서 리저브 하나의 비트 쌍 배치입니다.
+// UserConfigLayout is the placement of one reserve's bit pair in a user configuration bitmap.
+type UserConfigLayout struct {
+	borrowingBit  uint
+	collateralBit uint
+}
+
+var (
+	// AaveUserConfigLayout은 Aave V3 배치입니다: bit 2i = 대출 중, bit 2i+1 = 담보 사용.
+	// AaveUserConfigLayout is the Aave V3 layout: bit 2i = borrowing, bit 2i+1 = using as collateral.
+	AaveUserConfigLayout = UserConfigLayout{borrowingBit: 0, collateralBit: 1}
+
+	// StudyUserConfigLayout은 스터디 LendingPool.sol의 배치입니다 (Aave와 반대):
+	// bit 2i = 담보 사용, bit 2i+1 = 대출 중.
+	// StudyUserConfigLayout is the study LendingPool.sol layout (swapped relative to Aave):
+	// bit 2i = using as collateral, bit 2i+1 = borrowing.
+	StudyUserConfigLayout = UserConfigLayout{borrowingBit: 1, collateralBit: 0}
+)
+
+// MaxReserves는 256비트 사용자 설정 비트맵이 표현할 수 있는 리저브 수입니다.
+// MaxReserves is the number of reserves a 256-bit user configuration bitmap can represent.
+const MaxReserves = 128
+
+// UserConfigurationMap은 사용자가 어떤 리저브를 담보로 쓰고 어떤 리저브에서 빌렸는지 나타내는 비트맵입니다.
+// Pool.getUserConfiguration 한 번으로 잔고를 읽어야 할 리저브를 알 수 있습니다.
+// UserConfigurationMap is the bitmap of which reserves a user uses as collateral and borrows from.
+// A single Pool.getUserConfiguration call tells which reserves' balances need reading.
+type UserConfigurationMap struct {
+	Data   *big.Int
+	Layout UserConfigLayout
+}
+
+// NewUserConfiguration은 Aave V3 배치의 사용자 설정 비트맵을 만듭니다.
+// NewUserConfiguration creates a user configuration bitmap in the Aave V3 layout.
+func NewUserConfiguration(data *big.Int) UserConfigurationMap {
+	if data == nil {
+		data = new(big.Int)
+	}
+	return UserConfigurationMap{Data: data, Layout: AaveUserConfigLayout}
+}
+
+// IsBorrowing은 사용자가 리저브 id에서 빌리고 있는지 반환합니다.
+// IsBorrowing reports whether the user borrows from reserve id.
+func (u UserConfigurationMap) IsBorrowing(id uint16) bool {
+	return u.Data.Bit(int(2*uint(id)+u.Layout.borrowingBit)) == 1
+}
+
+// IsUsingAsCollateral은 사용자가 리저브 id를 담보로 쓰는지 반환합니다.
+// IsUsingAsCollateral reports whether the user uses reserve id as collateral.
+func (u UserConfigurationMap) IsUsingAsCollateral(id uint16) bool {
+	return u.Data.Bit(int(2*uint(id)+u.Layout.collateralBit)) == 1
+}
+
+// IsUsingAsCollateralOrBorrowing은 리저브 id의 두 비트 중 하나라도 켜져 있는지 반환합니다.
+// IsUsingAsCollateralOrBorrowing reports whether either bit of reserve id is set.
+func (u UserConfigurationMap) IsUsingAsCollateralOrBorrowing(id uint16) bool {
+	return u.IsBorrowing(id) || u.IsUsingAsCollateral(id)
+}
+
+// IsEmpty는 어떤 리저브도 쓰지 않는지 반환합니다.
+// IsEmpty reports whether no reserve is used.
+func (u UserConfigurationMap) IsEmpty() bool {
+	return u.Data.Sign() == 0
+}
+
+// Touched는 담보로 쓰거나 빌린 리저브 ID를 오름차순으로 반환합니다.
+// Touched returns the IDs of reserves used as collateral or borrowed, in ascending order.
+//
+// 담보 비활성 상태의 예치금은 비트맵에 나타나지 않으므로 예치 잔고 전체가 필요하면 따로 읽어야 합니다.
+// Supplied balances with collateral disabled do not show up in the bitmap, so read them separately
+// when every supplied balance is needed.
+func (u UserConfigurationMap) Touched() []uint16 {
+	var ids []uint16
+	for id := 0; id < MaxReserves && 2*id < u.Data.BitLen(); id++ {
+		if u.IsUsingAsCollateralOrBorrowing(uint16(id)) {
+			ids = append(ids, uint16(id))
+		}
+	}
+	return ids
+}
+
+// SetBorrowing은 리저브 id의 대출 비트를 설정한 사본을 반환합니다.
+// SetBorrowing returns a copy with reserve id's borrowing bit set to v.
+func (u UserConfigurationMap) SetBorrowing(id uint16, v bool) UserConfigurationMap {
+	return u.setBit(2*uint(id)+u.Layout.borrowingBit, v)
+}
+
+// SetUsingAsCollateral은 리저브 id의 담보 비트를 설정한 사본을 반환합니다.
+// SetUsingAsCollateral returns a copy with reserve id's collateral bit set to v.
+func (u UserConfigurationMap) SetUsingAsCollateral(id uint16, v bool) UserConfigurationMap {
+	return u.setBit(2*uint(id)+u.Layout.collateralBit, v)
+}
+
+// setBit은 비트 하나를 바꾼 사본을 반환합니다.
+// setBit returns a copy with one bit changed.
+func (u UserConfigurationMap) setBit(i uint, v bool) UserConfigurationMap {
+	var b uint
+	if v {
+		b = 1
+	}
+	data := new(big.Int)
+	if u.Data != nil {
+		data.Set(u.Data)
+	}
+	return UserConfigurationMap{Data: data.SetBit(data, int(i), b), Layout: u.Layout}
+}
@@ -0,0 +1,143 @@
+package contracts
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// Generate는 각 필드를 비트 폭 안의 임의 값으로 채웁니다 (quick.Generator).
+// Generate fills every field with a random value within its bit width (quick.Generator).
+func (ReserveConfigurationMap) Generate(r *rand.Rand, _ int) reflect.Value {
+	bits := func(f bitField) uint64 { return r.Uint64() & (1<<f.width - 1) }
+	flag := func() bool { return r.Intn(2) == 1 }
+	return reflect.ValueOf(ReserveConfigurationMap{
+		LTV:                    uint16(bits(fieldLTV)),
+		LiquidationThreshold:   uint16(bits(fieldLiquidationThreshold)),
+		LiquidationBonus:       uint16(bits(fieldLiquidationBonus)),
+		Decimals:               uint8(bits(fieldDecimals)),
+		Active:                 flag(),
+		Frozen:                 flag(),
+		BorrowingEnabled:       flag(),
+		StableBorrowingEnabled: flag(),
+		Paused:                 flag(),
+		BorrowableInIsolation:  flag(),
+		SiloedBorrowing:        flag(),
+		FlashLoanEnabled:       flag(),
+		ReserveFactor:          uint16(bits(fieldReserveFactor)),
+		BorrowCap:              bits(fieldBorrowCap),
+		SupplyCap:              bits(fieldSupplyCap),
+		LiquidationProtocolFee: uint16(bits(fieldLiquidationProtocolFee)),
+		EModeCategory:          uint8(bits(fieldEModeCategory)),
+		UnbackedMintCap:        bits(fieldUnbackedMintCap),
+		DebtCeiling:            bits(fieldDebtCeiling),
+	})
+}
+
+// randomWord는 256비트 임의 값입니다.
+// randomWord is a random 256-bit value.
+type randomWord struct{ *big.Int }
+
+func (randomWord) Generate(r *rand.Rand, _ int) reflect.Value {
+	b := make([]byte, 32)
+	r.Read(b)
+	return reflect.ValueOf(randomWord{new(big.Int).SetBytes(b)})
+}
+
+func TestReserveConfigurationRoundTrip(t *testing.T) {
+	f := func(c ReserveConfigurationMap) bool {
+		return DecodeReserveConfiguration(c.Encode()) == c
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReserveConfigurationEncodeDecode(t *testing.T) {
+	// 비트 252..255는 사용하지 않으므로 다시 인코딩하면 사라집니다.
+	// Bits 252..255 are unused and vanish when re-encoded.
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 252), big.NewInt(1))
+	f := func(w randomWord) bool {
+		want := new(big.Int).And(w.Int, mask)
+		return DecodeReserveConfiguration(w.Int).Encode().Cmp(want) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReserveConfigurationKnownValue(t *testing.T) {
+	// LTV 80%, LT 82.5%, 보너스 105%, 18 decimals, active + borrowing + flashloan.
+	// LTV 80%, LT 82.5%, bonus 105%, 18 decimals, active + borrowing + flashloan.
+	data := new(big.Int).SetUint64(8000 | 8250<<16 | 10500<<32 | 18<<48 | 1<<56 | 1<<58 | 1<<63)
+	c := DecodeReserveConfiguration(data)
+	want := ReserveConfigurationMap{
+		LTV:                  8000,
+		LiquidationThreshold: 8250,
+		LiquidationBonus:     10500,
+		Decimals:             18,
+		Active:               true,
+		BorrowingEnabled:     true,
+		FlashLoanEnabled:     true,
+	}
+	if c != want {
+		t.Errorf("decoded %+v, want %+v", c, want)
+	}
+}
+
+func TestUserConfigurationRoundTrip(t *testing.T) {
+	for name, layout := range map[string]UserConfigLayout{"aave": AaveUserConfigLayout, "study": StudyUserConfigLayout} {
+		t.Run(name, func(t *testing.T) {
+			f := func(borrowing, collateral []uint8) bool {
+				u := UserConfigurationMap{Data: new(big.Int), Layout: layout}
+				touched := map[uint16]bool{}
+				for _, id := range borrowing {
+					id := uint16(id) % MaxReserves
+					u = u.SetBorrowing(id, true)
+					touched[id] = true
+				}
+				for _, id := range collateral {
+					id := uint16(id) % MaxReserves
+					u = u.SetUsingAsCollateral(id, true)
+					touched[id] = true
+				}
+				for id := uint16(0); id < MaxReserves; id++ {
+					if u.IsBorrowing(id) != slices.ContainsFunc(borrowing, func(b uint8) bool { return uint16(b)%MaxReserves == id }) ||
+						u.IsUsingAsCollateral(id) != slices.ContainsFunc(collateral, func(c uint8) bool { return uint16(c)%MaxReserves == id }) {
+						return false
+					}
+				}
+				ids := u.Touched()
+				if len(ids) != len(touched) || !slices.IsSorted(ids) {
+					return false
+				}
+				for _, id := range ids {
+					if !touched[id] {
+						return false
+					}
+				}
+				return u.IsEmpty() == (len(touched) == 0)
+			}
+			if err := quick.Check(f, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUserConfigurationClearBit(t *testing.T) {
+	u := NewUserConfiguration(new(big.Int)).SetBorrowing(5, true).SetUsingAsCollateral(5, true)
+	cleared := u.SetBorrowing(5, false)
+	if !u.IsBorrowing(5) {
+		t.Error("SetBorrowing modified the receiver")
+	}
+	if cleared.IsBorrowing(5) || !cleared.IsUsingAsCollateral(5) {
+		t.Errorf("cleared borrowing bit: borrowing=%t collateral=%t", cleared.IsBorrowing(5), cleared.IsUsingAsCollateral(5))
+	}
+	if got := cleared.Data.Uint64(); got != 1<<11 {
+		t.Errorf("data = %#x, want %#x", got, uint64(1<<11))
+	}
+}
@@ -0,0 +1,142 @@
+package contracts
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// debtCeilingDecimals는 격리 모드 부채 한도와 총 부채의 소수점 자리수입니다 (USD 2 소수점).
+// debtCeilingDecimals is the number of decimals of isolation mode debt ceilings and totals (USD with 2 decimals).
+const debtCeilingDecimals = 2
+
+// EModeCategory는 e-mode (효율 모드) 카테고리 하나의 파라미터입니다.
+// 사용자가 카테고리에 들어가면 같은 카테고리 리저브에는 기본 설정 대신 이 값이 적용됩니다.
+// EModeCategory is the parameters of one e-mode (efficiency mode) category.
+// When a user enters a category these values replace the base configuration for reserves in that category.
+type EModeCategory struct {
+	ID uint8
+
+	// LTV, LiquidationThreshold, LiquidationBonus는 bps 단위입니다 (10000 = 100%).
+	// LTV, LiquidationThreshold and LiquidationBonus are in bps (10000 = 100%).
+	LTV                  uint16
+	LiquidationThreshold uint16
+	LiquidationBonus     uint16
+
+	// PriceSource가 0이 아니면 카테고리 리저브 모두 이 자산의 오라클 가격을 씁니다.
+	// When PriceSource is non-zero every reserve in the category uses this asset's oracle price.
+	PriceSource common.Address
+
+	Label string
+}
+
+// ApplyEMode는 사용자의 e-mode 카테고리에 속한 리저브에 카테고리 파라미터를 적용합니다.
+// priceSourcePrice는 PriceSource의 가격이며, nil이거나 0이면 리저브 자체 가격을 유지합니다.
+// ApplyEMode applies the category parameters to the reserves in the user's e-mode category.
+// priceSourcePrice is the price of PriceSource; the reserves keep their own price when it is nil or zero.
+//
+// Aave GenericLogic.calculateUserAccountData와 같이 기본 청산기준이 0인 리저브는
+// e-mode에서도 담보로 계산되지 않으므로 그대로 둡니다.
+// Like Aave's GenericLogic.calculateUserAccountData, reserves with a base liquidation threshold of 0
+// don't count as collateral even in e-mode, so they are left untouched.
+func ApplyEMode(pos *UserPosition, category *EModeCategory, priceSourcePrice *big.Int) {
+	pos.EMode = category
+	if category == nil || category.ID == 0 {
+		return
+	}
+	for i := range pos.Reserves {
+		r := &pos.Reserves[i]
+		if r.Config.EModeCategory != category.ID {
+			continue
+		}
+		r.InEMode = true
+		if priceSourcePrice != nil && priceSourcePrice.Sign() > 0 {
+			r.Price = priceSourcePrice
+		}
+		if r.LiquidationThreshold != nil && r.LiquidationThreshold.Sign() > 0 {
+			r.LiquidationThreshold = big.NewInt(int64(category.LiquidationThreshold))
+			r.LiquidationBonus = big.NewInt(int64(category.LiquidationBonus))
+		}
+	}
+}
+
+// EffectiveLTV는 e-mode를 반영한 리저브의 LTV입니다 (bps).
+// Aave GenericLogic과 같이 기본 LTV가 0인 담보는 e-mode에서도 LTV 0입니다.
+// EffectiveLTV is the reserve's LTV with e-mode applied (bps).
+// Like Aave's GenericLogic, collateral with a base LTV of 0 keeps an LTV of 0 even in e-mode.
+func (p *UserPosition) EffectiveLTV(r ReservePosition) uint16 {
+	if r.Config.LTV == 0 {
+		return 0
+	}
+	if r.InEMode && p.EMode != nil {
+		return p.EMode.LTV
+	}
+	return r.Config.LTV
+}
+
+// Isolation은 격리 모드 계정의 격리 담보와 부채 한도입니다.
+// Isolation is an isolation mode account's isolated collateral and its debt ceiling.
+type Isolation struct {
+	Asset  common.Address
+	Symbol string
+
+	// DebtCeiling과 TotalDebt는 USD 2 소수점입니다. TotalDebt는 리저브를 읽은 시점의 값입니다.
+	// DebtCeiling and TotalDebt are in USD with 2 decimals. TotalDebt is as of when the reserves were read.
+	DebtCeiling *big.Int
+	TotalDebt   *big.Int
+}
+
+// DebtCeilingUSD는 부채 한도입니다 (USD).
+// DebtCeilingUSD is the debt ceiling (USD).
+func (i *Isolation) DebtCeilingUSD() float64 {
+	return centsToUSD(i.DebtCeiling)
+}
+
+// TotalDebtUSD는 이 담보로 빌린 전체 계정의 부채 합계입니다 (USD).
+// TotalDebtUSD is the debt of every account borrowing against this collateral (USD).
+func (i *Isolation) TotalDebtUSD() float64 {
+	return centsToUSD(i.TotalDebt)
+}
+
+// Isolation은 계정이 격리 모드이면 격리 담보 정보를 반환합니다.
+// Aave ValidationLogic.getIsolationModeState와 같이 담보가 정확히 하나이고 그 리저브에
+// 부채 한도가 있으면 격리 모드입니다.
+// Isolation returns the isolated collateral when the account is in isolation mode.
+// Like Aave's ValidationLogic.getIsolationModeState, the account is isolated when it has exactly one
+// collateral and that reserve has a debt ceiling.
+func (p *UserPosition) Isolation() (*Isolation, bool) {
+	var collateral *ReservePosition
+	for i := range p.Reserves {
+		if !p.Reserves[i].UsedAsCollateral {
+			continue
+		}
+		if collateral != nil {
+			return nil, false
+		}
+		collateral = &p.Reserves[i]
+	}
+	if collateral == nil || collateral.Config.DebtCeiling == 0 {
+		return nil, false
+	}
+	total := collateral.IsolationModeTotalDebt
+	if total == nil {
+		total = new(big.Int)
+	}
+	return &Isolation{
+		Asset:       collateral.Asset,
+		Symbol:      collateral.Symbol,
+		DebtCeiling: new(big.Int).SetUint64(collateral.Config.DebtCeiling),
+		TotalDebt:   total,
+	}, true
+}
+
+// centsToUSD는 USD 2 소수점 금액을 달러로 변환합니다.
+// centsToUSD converts an amount in USD with 2 decimals to dollars.
+func centsToUSD(v *big.Int) float64 {
+	if v == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(math.Pow10(debtCeilingDecimals))).Float64()
+	return f
+}
@@ -0,0 +1,84 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// erc20ABI는 ERC20 balanceOf/allowance/approve의 최소 ABI입니다.
+// erc20ABI is a minimal ABI of ERC20 balanceOf/allowance/approve.
+const erc20ABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"address"}],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view",
+	 "inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable",
+	 "inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]}
+]`
+
+var parsedERC20ABI = mustParseABI(erc20ABI)
+
+// ERC20Caller는 ERC20 토큰의 잔고를 조회하는 클라이언트입니다.
+// ERC20Caller is a client for reading ERC20 token balances.
+type ERC20Caller struct {
+	contract *bind.BoundContract
+}
+
+// NewERC20Caller는 새로운 ERC20Caller를 생성합니다.
+// NewERC20Caller creates a new ERC20Caller.
+func NewERC20Caller(backend bind.ContractCaller, token common.Address) *ERC20Caller {
+	return &ERC20Caller{
+		contract: bind.NewBoundContract(token, parsedERC20ABI, backend, nil, nil),
+	}
+}
+
+// BalanceOf는 계정의 토큰 잔고를 조회합니다.
+// BalanceOf retrieves an account's token balance.
+func (c *ERC20Caller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "balanceOf", account); err != nil {
+		return nil, fmt.Errorf("balanceOf 호출 실패 / balanceOf call failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// Allowance는 owner가 spender에게 허용한 토큰 양을 조회합니다.
+// Allowance retrieves how many tokens owner has allowed spender to pull.
+func (c *ERC20Caller) Allowance(opts *bind.CallOpts, owner, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "allowance", owner, spender); err != nil {
+		return nil, fmt.Errorf("allowance 호출 실패 / allowance call failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// ERC20Transactor는 ERC20 토큰 approve 트랜잭션을 보내는 클라이언트입니다.
+// ERC20Transactor is a client for sending ERC20 approve transactions.
+type ERC20Transactor struct {
+	contract *bind.BoundContract
+}
+
+// NewERC20Transactor는 새로운 ERC20Transactor를 생성합니다.
+// NewERC20Transactor creates a new ERC20Transactor.
+func NewERC20Transactor(backend bind.ContractTransactor, token common.Address) *ERC20Transactor {
+	return &ERC20Transactor{
+		contract: bind.NewBoundContract(token, parsedERC20ABI, nil, backend, nil),
+	}
+}
+
+// Approve는 spender가 amount만큼 토큰을 가져갈 수 있도록 허용하는 트랜잭션을 보냅니다.
+// Approve sends a transaction allowing spender to pull up to amount tokens.
+func (t *ERC20Transactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	tx, err := t.contract.Transact(opts, "approve", spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("approve 전송 실패 / approve transaction failed: %w", err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,233 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// lendingPoolABI는 스터디 LendingPool (contracts/src/LendingPool.sol)에서 청산에 필요한 부분만 담은 최소 ABI입니다.
+// lendingPoolABI is a minimal ABI of the study LendingPool (contracts/src/LendingPool.sol) covering what liquidation needs.
+const lendingPoolABI = `[
+	{"type":"function","name":"liquidate","stateMutability":"nonpayable",
+	 "inputs":[
+		{"name":"borrower","type":"address"},
+		{"name":"debtAsset","type":"address"},
+		{"name":"collateralAsset","type":"address"},
+		{"name":"debtToCover","type":"uint256"}
+	 ],
+	 "outputs":[]},
+	{"type":"function","name":"getHealthFactor","stateMutability":"view",
+	 "inputs":[{"name":"user","type":"address"}],
+	 "outputs":[{"name":"healthFactor","type":"uint256"}]},
+	{"type":"function","name":"reserves","stateMutability":"view",
+	 "inputs":[{"name":"","type":"address"}],
+	 "outputs":[
+		{"name":"lToken","type":"address"},
+		{"name":"debtToken","type":"address"},
+		{"name":"collateralFactor","type":"uint256"},
+		{"name":"liquidationThreshold","type":"uint256"},
+		{"name":"totalDeposits","type":"uint256"},
+		{"name":"totalBorrows","type":"uint256"},
+		{"name":"totalReserves","type":"uint256"},
+		{"name":"borrowIndex","type":"uint256"},
+		{"name":"lastUpdateTime","type":"uint256"},
+		{"name":"id","type":"uint16"},
+		{"name":"isActive","type":"bool"}
+	 ]},
+	{"type":"function","name":"oracle","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"CLOSE_FACTOR","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"LIQUIDATION_BONUS","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"event","name":"LiquidationCall","anonymous":false,
+	 "inputs":[
+		{"name":"liquidator","type":"address","indexed":true},
+		{"name":"borrower","type":"address","indexed":true},
+		{"name":"debtAsset","type":"address","indexed":true},
+		{"name":"collateralAsset","type":"address","indexed":false},
+		{"name":"debtCovered","type":"uint256","indexed":false},
+		{"name":"collateralSeized","type":"uint256","indexed":false}
+	 ]}
+]`
+
+// studyOracleABI는 스터디 PriceOracle의 가격 조회 함수 최소 ABI입니다.
+// studyOracleABI is a minimal ABI of the study PriceOracle price getter.
+const studyOracleABI = `[
+	{"type":"function","name":"getAssetPrice","stateMutability":"view",
+	 "inputs":[{"name":"asset","type":"address"}],
+	 "outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var (
+	parsedLendingPoolABI = mustParseABI(lendingPoolABI)
+	parsedStudyOracleABI = mustParseABI(studyOracleABI)
+)
+
+// LendingPoolReserve는 스터디 LendingPool.reserves(asset)의 반환값입니다 (비율은 1e18 = 100%).
+// LendingPoolReserve is the return value of the study LendingPool.reserves(asset) (ratios in 1e18 = 100%).
+type LendingPoolReserve struct {
+	LToken               common.Address
+	DebtToken            common.Address
+	CollateralFactor     *big.Int
+	LiquidationThreshold *big.Int
+	TotalDeposits        *big.Int
+	TotalBorrows         *big.Int
+	TotalReserves        *big.Int
+	BorrowIndex          *big.Int
+	LastUpdateTime       *big.Int
+	ID                   uint16
+	IsActive             bool
+}
+
+// LendingPoolLiquidation은 스터디 LendingPool의 LiquidationCall 이벤트입니다.
+// LendingPoolLiquidation is the study LendingPool's LiquidationCall event.
+type LendingPoolLiquidation struct {
+	Liquidator       common.Address
+	Borrower         common.Address
+	DebtAsset        common.Address
+	CollateralAsset  common.Address
+	DebtCovered      *big.Int
+	CollateralSeized *big.Int
+}
+
+// LendingPool은 스터디 LendingPool 컨트랙트를 조회하고 청산 트랜잭션을 보내는 클라이언트입니다.
+// LendingPool is a client that reads the study LendingPool contract and sends liquidation transactions.
+type LendingPool struct {
+	contract *bind.BoundContract
+	address  common.Address
+}
+
+// NewLendingPool은 새로운 LendingPool을 생성합니다.
+// NewLendingPool creates a new LendingPool.
+func NewLendingPool(backend bind.ContractBackend, address common.Address) *LendingPool {
+	return &LendingPool{
+		contract: bind.NewBoundContract(address, parsedLendingPoolABI, backend, backend, backend),
+		address:  address,
+	}
+}
+
+// Address는 풀 컨트랙트 주소를 반환합니다.
+// Address returns the pool contract address.
+func (p *LendingPool) Address() common.Address {
+	return p.address
+}
+
+// GetHealthFactor는 사용자의 헬스팩터를 조회합니다 (1e18 = 1.0, 부채가 없으면 uint256 최대값).
+// GetHealthFactor retrieves a user's health factor (1e18 = 1.0, max uint256 without debt).
+func (p *LendingPool) GetHealthFactor(opts *bind.CallOpts, user common.Address) (*big.Int, error) {
+	var out []interface{}
+	if err := p.contract.Call(opts, &out, "getHealthFactor", user); err != nil {
+		return nil, fmt.Errorf("getHealthFactor 호출 실패 / getHealthFactor call failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// GetReserve는 자산의 리저브 데이터를 조회합니다.
+// GetReserve retrieves an asset's reserve data.
+func (p *LendingPool) GetReserve(opts *bind.CallOpts, asset common.Address) (*LendingPoolReserve, error) {
+	var out []interface{}
+	if err := p.contract.Call(opts, &out, "reserves", asset); err != nil {
+		return nil, fmt.Errorf("reserves 호출 실패 / reserves call failed: %w", err)
+	}
+	return &LendingPoolReserve{
+		LToken:               out[0].(common.Address),
+		DebtToken:            out[1].(common.Address),
+		CollateralFactor:     out[2].(*big.Int),
+		LiquidationThreshold: out[3].(*big.Int),
+		TotalDeposits:        out[4].(*big.Int),
+		TotalBorrows:         out[5].(*big.Int),
+		TotalReserves:        out[6].(*big.Int),
+		BorrowIndex:          out[7].(*big.Int),
+		LastUpdateTime:       out[8].(*big.Int),
+		ID:                   out[9].(uint16),
+		IsActive:             out[10].(bool),
+	}, nil
+}
+
+// Oracle은 풀이 사용하는 PriceOracle 주소를 조회합니다.
+// Oracle retrieves the address of the PriceOracle the pool uses.
+func (p *LendingPool) Oracle(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	if err := p.contract.Call(opts, &out, "oracle"); err != nil {
+		return common.Address{}, fmt.Errorf("oracle 호출 실패 / oracle call failed: %w", err)
+	}
+	return out[0].(common.Address), nil
+}
+
+// CloseFactor는 한 번에 청산 가능한 부채 비율을 조회합니다 (1e18 = 100%).
+// CloseFactor retrieves the share of debt liquidatable per call (1e18 = 100%).
+func (p *LendingPool) CloseFactor(opts *bind.CallOpts) (*big.Int, error) {
+	return p.constant(opts, "CLOSE_FACTOR")
+}
+
+// LiquidationBonus는 청산 보너스를 조회합니다 (1e18 = 100%).
+// LiquidationBonus retrieves the liquidation bonus (1e18 = 100%).
+func (p *LendingPool) LiquidationBonus(opts *bind.CallOpts) (*big.Int, error) {
+	return p.constant(opts, "LIQUIDATION_BONUS")
+}
+
+func (p *LendingPool) constant(opts *bind.CallOpts, method string) (*big.Int, error) {
+	var out []interface{}
+	if err := p.contract.Call(opts, &out, method); err != nil {
+		return nil, fmt.Errorf("%s 호출 실패 / %s call failed: %w", method, method, err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// Liquidate는 borrower의 debtAsset 부채를 debtToCover만큼 갚고 collateralAsset 담보를 받는 트랜잭션을 보냅니다.
+// 호출 전에 풀에 debtAsset approve가 필요합니다.
+// Liquidate sends a transaction repaying debtToCover of borrower's debtAsset debt for collateralAsset collateral.
+// The pool must be approved for debtAsset beforehand.
+func (p *LendingPool) Liquidate(opts *bind.TransactOpts, borrower, debtAsset, collateralAsset common.Address, debtToCover *big.Int) (*types.Transaction, error) {
+	tx, err := p.contract.Transact(opts, "liquidate", borrower, debtAsset, collateralAsset, debtToCover)
+	if err != nil {
+		return nil, fmt.Errorf("liquidate 전송 실패 / liquidate transaction failed: %w", err)
+	}
+	return tx, nil
+}
+
+// ParseLiquidationCall은 풀이 남긴 LiquidationCall 로그를 디코딩합니다. 다른 로그면 false를 반환합니다.
+// ParseLiquidationCall decodes a LiquidationCall log emitted by the pool. Returns false for any other log.
+func (p *LendingPool) ParseLiquidationCall(vLog types.Log) (*LendingPoolLiquidation, bool, error) {
+	event := parsedLendingPoolABI.Events["LiquidationCall"]
+	if vLog.Address != p.address || len(vLog.Topics) != 4 || vLog.Topics[0] != event.ID {
+		return nil, false, nil
+	}
+	ev := new(LendingPoolLiquidation)
+	if err := p.contract.UnpackLog(ev, "LiquidationCall", vLog); err != nil {
+		return nil, true, fmt.Errorf("LiquidationCall 디코딩 실패 / failed to decode LiquidationCall: %w", err)
+	}
+	return ev, true, nil
+}
+
+// StudyOracleCaller는 스터디 PriceOracle을 호출하는 클라이언트입니다.
+// StudyOracleCaller is a client for calling the study PriceOracle.
+type StudyOracleCaller struct {
+	contract *bind.BoundContract
+}
+
+// NewStudyOracleCaller는 새로운 StudyOracleCaller를 생성합니다.
+// NewStudyOracleCaller creates a new StudyOracleCaller.
+func NewStudyOracleCaller(backend bind.ContractCaller, address common.Address) *StudyOracleCaller {
+	return &StudyOracleCaller{
+		contract: bind.NewBoundContract(address, parsedStudyOracleABI, backend, nil, nil),
+	}
+}
+
+// GetAssetPrice는 피드 소수점 그대로의 자산 가격을 조회합니다. 피드가 오래되면 되돌려집니다.
+// GetAssetPrice retrieves an asset price in the feed's own decimals. Reverts when the feed is stale.
+func (c *StudyOracleCaller) GetAssetPrice(opts *bind.CallOpts, asset common.Address) (*big.Int, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, "getAssetPrice", asset); err != nil {
+		return nil, fmt.Errorf("getAssetPrice 호출 실패 / getAssetPrice call failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
@@ -0,0 +1,64 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParseLiquidationCall(t *testing.T) {
+	poolAddr := common.HexToAddress("0x9001")
+	liquidator := common.HexToAddress("0x11cc")
+	borrower := common.HexToAddress("0xa11ce")
+	usdc, weth := common.HexToAddress("0x05dc"), common.HexToAddress("0x0e7e")
+
+	event := parsedLendingPoolABI.Events["LiquidationCall"]
+	data, err := event.Inputs.NonIndexed().Pack(weth, big.NewInt(7500), big.NewInt(525))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vLog := types.Log{
+		Address: poolAddr,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(liquidator.Bytes()),
+			common.BytesToHash(borrower.Bytes()),
+			common.BytesToHash(usdc.Bytes()),
+		},
+		Data: data,
+	}
+
+	pool := NewLendingPool(nil, poolAddr)
+	ev, ok, err := pool.ParseLiquidationCall(vLog)
+	if err != nil || !ok {
+		t.Fatalf("ParseLiquidationCall = %v, %v", ok, err)
+	}
+	want := LendingPoolLiquidation{
+		Liquidator:       liquidator,
+		Borrower:         borrower,
+		DebtAsset:        usdc,
+		CollateralAsset:  weth,
+		DebtCovered:      big.NewInt(7500),
+		CollateralSeized: big.NewInt(525),
+	}
+	if ev.Liquidator != want.Liquidator || ev.Borrower != want.Borrower || ev.DebtAsset != want.DebtAsset ||
+		ev.CollateralAsset != want.CollateralAsset || ev.DebtCovered.Cmp(want.DebtCovered) != 0 ||
+		ev.CollateralSeized.Cmp(want.CollateralSeized) != 0 {
+		t.Errorf("event = %+v, want %+v", ev, want)
+	}
+
+	// 다른 컨트랙트나 Aave 이벤트는 무시
+	// Logs from other contracts and Aave events are ignored
+	other := vLog
+	other.Address = common.HexToAddress("0xdead")
+	if _, ok, _ := pool.ParseLiquidationCall(other); ok {
+		t.Error("log from another contract was parsed")
+	}
+	aave := vLog
+	aave.Topics = append([]common.Hash{LiquidationCallEventSig}, vLog.Topics[1:]...)
+	if _, ok, _ := pool.ParseLiquidationCall(aave); ok {
+		t.Error("Aave LiquidationCall was parsed")
+	}
+}
@@ -0,0 +1,297 @@
+// Package discovery는 온체인 이벤트로 활성 대출자를 찾아 감시 목록을 유지합니다.
+// Package discovery derives the set of active borrowers from on-chain events and maintains it as a watch list.
+//
+// Supply/Withdraw/Borrow/Repay/LiquidationCall 이벤트로 포지션이 바뀐 계정을 찾고,
+// getUserAccountData로 부채를 확인해 부채가 있는 계정만 추적합니다.
+// 부채가 0이 된 계정은 목록에서 제외됩니다.
+// Accounts whose position changed via Supply/Withdraw/Borrow/Repay/LiquidationCall events are
+// checked with getUserAccountData, and only accounts holding debt are tracked.
+// Accounts whose debt drops to zero are removed from the list.
+//
+// DevOps 관점:
+// - 수동으로 관리하는 --addresses 목록은 대부분의 위험 포지션을 놓칩니다
+// - 부채가 큰 포지션부터 확인하도록 우선순위를 매깁니다
+//
+// DevOps perspective:
+// - A hand-curated --addresses list misses most at-risk positions
+// - Large positions are prioritized so they are checked first
+package discovery
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// LogSource는 이벤트 로그를 제공하는 클라이언트입니다 (*rpcpool.Pool이 구현).
+// LogSource is a client that provides event logs (implemented by *rpcpool.Pool).
+type LogSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Options는 발견 서브시스템 설정입니다.
+// Options configures the discovery subsystem.
+type Options struct {
+	// FromBlock은 스캔 시작 블록입니다 (0 = 최신 블록 - Lookback).
+	// FromBlock is the block to start scanning from (0 = latest block - Lookback).
+	FromBlock uint64
+
+	// Lookback은 FromBlock이 0일 때 거슬러 올라갈 블록 수입니다.
+	// Lookback is how many blocks to look back when FromBlock is 0.
+	Lookback uint64
+
+	// Chunk는 eth_getLogs 요청당 블록 수입니다.
+	// Chunk is the number of blocks per eth_getLogs request.
+	Chunk uint64
+
+	// PollInterval은 새 블록의 이벤트를 확인하는 주기입니다.
+	// PollInterval is how often new blocks are scanned for events.
+	PollInterval time.Duration
+
+	// RefreshInterval은 추적 중인 모든 계정의 부채를 다시 읽는 주기입니다 (0 = 비활성).
+	// 가격 변동으로 바뀐 우선순위를 반영합니다.
+	// RefreshInterval is how often the debt of every tracked account is re-read (0 = disabled).
+	// Keeps priorities current as prices move.
+	RefreshInterval time.Duration
+
+	// MaxAccounts는 감시 목록에 넘길 최대 계정 수입니다 (0 = 무제한).
+	// MaxAccounts caps the number of accounts handed to the watch list (0 = unlimited).
+	MaxAccounts int
+
+	// CallTimeout은 계정 조회 호출별 타임아웃입니다.
+	// CallTimeout is the per-call timeout for account reads.
+	CallTimeout time.Duration
+}
+
+// DefaultOptions는 기본 설정을 반환합니다.
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		Lookback:        50_000, // 약 1주 / about one week
+		Chunk:           2000,
+		PollInterval:    12 * time.Second,
+		RefreshInterval: 30 * time.Minute,
+		MaxAccounts:     1000,
+		CallTimeout:     10 * time.Second,
+	}
+}
+
+// Discovery는 이벤트로 발견한 부채 보유 계정 집합을 점진적으로 유지합니다.
+// Discovery incrementally maintains the set of debt-holding accounts discovered from events.
+type Discovery struct {
+	src    LogSource
+	pool   *contracts.AavePoolCaller
+	opts   Options
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	accounts map[common.Address]*big.Int // 계정 → 부채 (기본 통화) / account → debt (base currency)
+
+	// 아래 필드는 Run 고루틴에서만 사용 / Fields below are only used by the Run goroutine
+	dirty       map[common.Address]struct{}
+	started     bool
+	next        uint64
+	lastRefresh time.Time
+}
+
+// New는 새로운 Discovery를 생성합니다.
+// New creates a new Discovery.
+func New(src LogSource, pool *contracts.AavePoolCaller, opts Options, logger *slog.Logger) *Discovery {
+	if opts.Chunk == 0 {
+		opts.Chunk = 1
+	}
+	return &Discovery{
+		src:      src,
+		pool:     pool,
+		opts:     opts,
+		logger:   logger,
+		accounts: make(map[common.Address]*big.Int),
+		dirty:    make(map[common.Address]struct{}),
+	}
+}
+
+// Addresses는 부채가 큰 순서로 정렬된 추적 계정을 MaxAccounts개까지 반환합니다.
+// Addresses returns tracked accounts ordered by debt (largest first), capped at MaxAccounts.
+func (d *Discovery) Addresses() []common.Address {
+	d.mu.RLock()
+	type entry struct {
+		addr common.Address
+		debt *big.Int
+	}
+	entries := make([]entry, 0, len(d.accounts))
+	for addr, debt := range d.accounts {
+		entries = append(entries, entry{addr, debt})
+	}
+	d.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if c := entries[i].debt.Cmp(entries[j].debt); c != 0 {
+			return c > 0
+		}
+		return entries[i].addr.Cmp(entries[j].addr) < 0
+	})
+	if d.opts.MaxAccounts > 0 && len(entries) > d.opts.MaxAccounts {
+		entries = entries[:d.opts.MaxAccounts]
+	}
+
+	addrs := make([]common.Address, len(entries))
+	for i, e := range entries {
+		addrs[i] = e.addr
+	}
+	return addrs
+}
+
+// Run은 과거 블록을 백필한 뒤 ctx가 끝날 때까지 새 블록을 따라갑니다.
+// Run backfills past blocks, then follows new blocks until ctx is done.
+func (d *Discovery) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	d.lastRefresh = time.Now()
+
+	for {
+		if err := d.scan(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Warn("이벤트 스캔 실패, 다음 주기에 재시도 / Event scan failed, retrying next interval",
+				"next_block", d.next,
+				"error", err,
+			)
+		}
+		if d.opts.RefreshInterval > 0 && time.Since(d.lastRefresh) >= d.opts.RefreshInterval {
+			d.markAllDirty()
+			d.refresh(ctx)
+			d.lastRefresh = time.Now()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Scan은 시작 블록부터 최신 블록까지 한 번 스캔합니다 (Run 대신 일회성 명령에서 사용).
+// 다시 호출하면 지난 스캔 이후의 블록만 읽습니다.
+// Scan scans once from the start block up to the latest block (used by one-shot commands instead of Run).
+// Calling it again only reads blocks since the previous scan.
+func (d *Discovery) Scan(ctx context.Context) error {
+	return d.scan(ctx)
+}
+
+// scan은 다음 블록부터 최신 블록까지 이벤트를 청크 단위로 읽고, 청크마다 바뀐 계정을 갱신합니다.
+// scan reads events from the next block up to the latest in chunks, refreshing touched accounts after each chunk.
+func (d *Discovery) scan(ctx context.Context) error {
+	head, err := d.src.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if !d.started {
+		d.started = true
+		d.next = d.opts.FromBlock
+		if d.next == 0 && head > d.opts.Lookback {
+			d.next = head - d.opts.Lookback
+		}
+		d.logger.Info("계정 발견 시작 / Starting account discovery",
+			"from_block", d.next,
+			"head", head,
+		)
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{d.pool.Address()},
+		Topics:    [][]common.Hash{contracts.PositionEventSigs},
+	}
+	for d.next <= head {
+		end := d.next + d.opts.Chunk - 1
+		if end > head {
+			end = head
+		}
+		query.FromBlock = new(big.Int).SetUint64(d.next)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := d.src.FilterLogs(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, vLog := range logs {
+			if owner, ok := contracts.PositionOwner(vLog); ok {
+				d.dirty[owner] = struct{}{}
+			}
+		}
+		d.next = end + 1
+		metrics.DiscoveryBlock.Set(float64(end))
+		d.refresh(ctx)
+	}
+	return nil
+}
+
+// markAllDirty는 추적 중인 모든 계정을 다시 읽도록 표시합니다.
+// markAllDirty marks every tracked account for a re-read.
+func (d *Discovery) markAllDirty() {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for addr := range d.accounts {
+		d.dirty[addr] = struct{}{}
+	}
+}
+
+// refresh는 표시된 계정의 부채를 읽어 추적 집합을 갱신합니다. 실패한 계정은 다음 갱신에 재시도합니다.
+// refresh reads the debt of marked accounts and updates the tracked set. Failed accounts are retried next time.
+func (d *Discovery) refresh(ctx context.Context) {
+	var added, dropped int
+	for addr := range d.dirty {
+		if ctx.Err() != nil {
+			return
+		}
+		callCtx, cancel := context.WithTimeout(ctx, d.opts.CallTimeout)
+		data, err := d.pool.GetUserAccountData(&bind.CallOpts{Context: callCtx}, addr)
+		cancel()
+		if err != nil {
+			d.logger.Debug("계정 조회 실패, 재시도 예정 / Account read failed, will retry",
+				"address", addr.Hex(),
+				"error", err,
+			)
+			continue
+		}
+		delete(d.dirty, addr)
+
+		d.mu.Lock()
+		_, tracked := d.accounts[addr]
+		switch {
+		case data.TotalDebtBase.Sign() > 0:
+			d.accounts[addr] = data.TotalDebtBase
+			if !tracked {
+				added++
+			}
+		case tracked:
+			delete(d.accounts, addr)
+			dropped++
+		}
+		d.mu.Unlock()
+	}
+
+	d.mu.RLock()
+	total := len(d.accounts)
+	d.mu.RUnlock()
+	metrics.DiscoveryAccounts.Set(float64(total))
+	metrics.DiscoveryDroppedTotal.Add(float64(dropped))
+	if added > 0 || dropped > 0 {
+		d.logger.Info("발견 계정 갱신 / Discovered accounts updated",
+			"added", added,
+			"dropped", dropped,
+			"tracked", total,
+			"next_block", d.next,
+		)
+	}
+}
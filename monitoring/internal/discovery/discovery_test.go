@@ -0,0 +1,296 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// accountDataABI는 가짜 Pool 응답을 인코딩할 getUserAccountData ABI입니다.
+// accountDataABI is the getUserAccountData ABI used to encode the fake Pool's responses.
+var accountDataABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"getUserAccountData","stateMutability":"view",
+		"inputs":[{"name":"user","type":"address"}],
+		"outputs":[{"name":"totalCollateralBase","type":"uint256"},{"name":"totalDebtBase","type":"uint256"},
+		           {"name":"availableBorrowsBase","type":"uint256"},{"name":"currentLiquidationThreshold","type":"uint256"},
+		           {"name":"ltv","type":"uint256"},{"name":"healthFactor","type":"uint256"}]}]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var poolAddr = common.HexToAddress("0x87870Bca3F3fD6335C3F4ce8392D69350B4fA4E2")
+
+// fakeChain은 블록 높이와 로그를 제공하고 조회한 블록 범위를 기록하는 LogSource입니다.
+// 같은 값으로 Pool 역할(bind.ContractCaller)도 하며 계정별 부채를 돌려줍니다.
+// fakeChain is a LogSource that serves a block height and logs and records the block ranges queried.
+// It also plays the Pool (bind.ContractCaller), returning the debt per account.
+type fakeChain struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    []types.Log
+	ranges  [][2]uint64
+	debts   map[common.Address]int64
+	failing map[common.Address]bool
+}
+
+func newFakeChain(head uint64) *fakeChain {
+	return &fakeChain{head: head, debts: make(map[common.Address]int64), failing: make(map[common.Address]bool)}
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.head, nil
+}
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	f.ranges = append(f.ranges, [2]uint64{from, to})
+	var out []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChain) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeChain) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method := accountDataABI.Methods["getUserAccountData"]
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	user := args[0].(common.Address)
+
+	f.mu.Lock()
+	debt, failing := f.debts[user], f.failing[user]
+	f.mu.Unlock()
+	if failing {
+		return nil, fmt.Errorf("rpc unavailable")
+	}
+	d := big.NewInt(debt)
+	return method.Outputs.Pack(new(big.Int).Mul(d, big.NewInt(2)), d, big.NewInt(0), big.NewInt(8250), big.NewInt(8000), big.NewInt(2e18))
+}
+
+// event는 owner의 포지션을 바꾼 이벤트 로그를 block에 추가합니다.
+// event adds a log at block for an event that changed owner's position.
+func (f *fakeChain) event(block uint64, sig common.Hash, owner common.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	topics := []common.Hash{sig, common.HexToHash("0xaa"), common.BytesToHash(owner.Bytes())}
+	if sig == contracts.LiquidationCallEventSig {
+		topics = []common.Hash{sig, common.HexToHash("0xaa"), common.HexToHash("0xbb"), common.BytesToHash(owner.Bytes())}
+	}
+	f.logs = append(f.logs, types.Log{Address: poolAddr, BlockNumber: block, Topics: topics})
+}
+
+func (f *fakeChain) takeRanges() [][2]uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := f.ranges
+	f.ranges = nil
+	return out
+}
+
+func newDiscovery(f *fakeChain, opts Options) *Discovery {
+	return New(f, contracts.NewAavePoolCaller(f, poolAddr), opts, slog.New(slog.DiscardHandler))
+}
+
+func addr(n int64) common.Address {
+	return common.BigToAddress(big.NewInt(n))
+}
+
+func equalAddrs(got, want []common.Address) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScanIncremental(t *testing.T) {
+	f := newFakeChain(100)
+	alice, bob, carol := addr(1), addr(2), addr(3)
+	f.debts[alice] = 1000
+	f.event(15, contracts.BorrowEventSig, alice)
+	f.event(50, contracts.SupplyEventSig, bob) // 예치만, 부채 없음 / supply only, no debt
+
+	opts := DefaultOptions()
+	opts.FromBlock, opts.Chunk = 10, 30
+	d := newDiscovery(f, opts)
+
+	ctx := context.Background()
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]uint64{{10, 39}, {40, 69}, {70, 99}, {100, 100}}
+	if got := f.takeRanges(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("first scan ranges = %v, want %v", got, want)
+	}
+	if got := d.Addresses(); !equalAddrs(got, []common.Address{alice}) {
+		t.Errorf("addresses = %v, want only the borrower", got)
+	}
+
+	// 다음 스캔은 지난 스캔 이후의 블록만 읽음 / The next scan only reads blocks since the previous one
+	f.head = 130
+	f.debts[carol] = 500
+	f.event(120, contracts.BorrowEventSig, carol)
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want = [][2]uint64{{101, 130}}
+	if got := f.takeRanges(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("second scan ranges = %v, want %v", got, want)
+	}
+	if got := d.Addresses(); !equalAddrs(got, []common.Address{alice, carol}) {
+		t.Errorf("addresses = %v, want alice then carol", got)
+	}
+
+	// 새 블록이 없으면 조회하지 않음 / No new blocks means no queries
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.takeRanges(); len(got) != 0 {
+		t.Errorf("idle scan ranges = %v, want none", got)
+	}
+}
+
+func TestScanLookback(t *testing.T) {
+	f := newFakeChain(1000)
+	opts := DefaultOptions()
+	opts.Lookback, opts.Chunk = 100, 1000
+	d := newDiscovery(f, opts)
+	if err := d.Scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]uint64{{900, 1000}}
+	if got := f.takeRanges(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ranges = %v, want %v", got, want)
+	}
+}
+
+func TestDropsRepaidAccounts(t *testing.T) {
+	f := newFakeChain(10)
+	alice, bob := addr(1), addr(2)
+	f.debts[alice], f.debts[bob] = 1000, 2000
+	f.event(5, contracts.BorrowEventSig, alice)
+	f.event(6, contracts.BorrowEventSig, bob)
+
+	opts := DefaultOptions()
+	opts.FromBlock = 1
+	d := newDiscovery(f, opts)
+	ctx := context.Background()
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Addresses(); !equalAddrs(got, []common.Address{bob, alice}) {
+		t.Fatalf("addresses = %v, want bob, alice", got)
+	}
+
+	// 전액 상환과 청산으로 부채가 0이 되면 제외 / Accounts whose debt reaches zero via repay or liquidation are dropped
+	tests := []struct {
+		name string
+		sig  common.Hash
+		who  common.Address
+		want []common.Address
+	}{
+		{"repay", contracts.RepayEventSig, alice, []common.Address{bob}},
+		{"liquidation", contracts.LiquidationCallEventSig, bob, nil},
+	}
+	for _, tt := range tests {
+		f.head++
+		f.debts[tt.who] = 0
+		f.event(f.head, tt.sig, tt.who)
+		if err := d.Scan(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if got := d.Addresses(); !equalAddrs(got, tt.want) {
+			t.Errorf("%s: addresses = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFailedReadsAreRetried(t *testing.T) {
+	f := newFakeChain(10)
+	alice := addr(1)
+	f.debts[alice] = 1000
+	f.failing[alice] = true
+	f.event(5, contracts.BorrowEventSig, alice)
+
+	opts := DefaultOptions()
+	opts.FromBlock = 1
+	d := newDiscovery(f, opts)
+	ctx := context.Background()
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Addresses(); len(got) != 0 {
+		t.Fatalf("addresses = %v, want none while the read fails", got)
+	}
+
+	// 새 이벤트가 없어도 다음 갱신에 다시 읽음 / Re-read on the next refresh even without a new event
+	f.failing[alice] = false
+	f.head++
+	if err := d.Scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Addresses(); !equalAddrs(got, []common.Address{alice}) {
+		t.Errorf("addresses = %v, want alice after the retry", got)
+	}
+}
+
+func TestAddressesPriorityAndCap(t *testing.T) {
+	f := newFakeChain(10)
+	debts := map[common.Address]int64{addr(1): 5, addr(2): 50, addr(3): 20, addr(4): 50, addr(5): 1}
+	for a, debt := range debts {
+		f.debts[a] = debt
+		f.event(5, contracts.BorrowEventSig, a)
+	}
+
+	tests := []struct {
+		name string
+		max  int
+		want []common.Address
+	}{
+		// 부채 큰 순, 같으면 주소 순 / Largest debt first, ties by address
+		{"uncapped", 0, []common.Address{addr(2), addr(4), addr(3), addr(1), addr(5)}},
+		{"capped", 3, []common.Address{addr(2), addr(4), addr(3)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.FromBlock, opts.MaxAccounts = 1, tt.max
+			d := newDiscovery(f, opts)
+			if err := d.Scan(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Addresses(); !equalAddrs(got, tt.want) {
+				t.Errorf("addresses = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
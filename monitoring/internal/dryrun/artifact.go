@@ -0,0 +1,74 @@
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrNoArtifacts는 Foundry 빌드 산출물 디렉터리가 없을 때 반환됩니다 (forge build 필요).
+// ErrNoArtifacts is returned when the Foundry build output directory is missing (run forge build).
+var ErrNoArtifacts = errors.New("컴파일 산출물 없음, contracts에서 forge build 필요 / no compiled artifacts, run forge build in contracts")
+
+// Artifact는 Foundry가 out/<File>.sol/<Contract>.json에 남기는 컴파일 결과입니다.
+// Artifact is the compile output Foundry writes to out/<File>.sol/<Contract>.json.
+type Artifact struct {
+	Name     string
+	ABI      abi.ABI
+	Bytecode []byte
+}
+
+// forgeArtifact는 Foundry 산출물 JSON에서 필요한 필드만 담습니다.
+// forgeArtifact holds only the fields needed from a Foundry artifact JSON.
+type forgeArtifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+// LoadArtifact는 outDir/<file>/<contract>.json을 읽어 ABI와 배포 바이트코드를 파싱합니다.
+// LoadArtifact reads outDir/<file>/<contract>.json and parses its ABI and deployment bytecode.
+func LoadArtifact(outDir, file, contract string) (*Artifact, error) {
+	if _, err := os.Stat(outDir); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNoArtifacts, outDir)
+	}
+	path := filepath.Join(outDir, file, contract+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("산출물 읽기 실패 / failed to read artifact: %w", err)
+	}
+	a, err := ParseArtifact(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	a.Name = contract
+	return a, nil
+}
+
+// ParseArtifact는 Foundry 산출물 JSON을 파싱합니다. 링크되지 않은 라이브러리 자리표시자가 있으면 실패합니다.
+// ParseArtifact parses a Foundry artifact JSON. Fails when unlinked library placeholders remain.
+func ParseArtifact(data []byte) (*Artifact, error) {
+	var raw forgeArtifact
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("산출물 JSON 파싱 실패 / failed to parse artifact JSON: %w", err)
+	}
+	parsed, err := abi.JSON(bytes.NewReader(raw.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("ABI 파싱 실패 / failed to parse ABI: %w", err)
+	}
+	code, err := hexutil.Decode(raw.Bytecode.Object)
+	if err != nil {
+		return nil, fmt.Errorf("바이트코드 디코딩 실패 (링크되지 않은 라이브러리?) / failed to decode bytecode (unlinked library?): %w", err)
+	}
+	if len(code) == 0 {
+		return nil, errors.New("빈 바이트코드 (추상 컨트랙트나 인터페이스?) / empty bytecode (abstract contract or interface?)")
+	}
+	return &Artifact{ABI: parsed, Bytecode: code}, nil
+}
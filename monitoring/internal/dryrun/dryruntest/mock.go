@@ -0,0 +1,231 @@
+// Package dryruntest는 dryrun 시뮬레이션 체인에서 쓰는 테스트 전용 모의 컨트랙트입니다.
+// 테스트 코드에서만 가져오므로 운영 바이너리에는 링크되지 않습니다.
+// Package dryruntest provides a test-only mock contract for the dryrun simulated chain.
+// It is only imported from test code, so production binaries don't link it.
+package dryruntest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/jeongseup/lending-monitor/internal/dryrun"
+)
+
+// Mock은 호출 데이터별로 응답을 프로그래밍할 수 있는 모의 컨트랙트입니다.
+// 컴파일러 없이 배포할 수 있도록 바이트코드를 직접 조립하며, Aave Pool이나 Chainlink 피드처럼
+// 조회 함수만 쓰는 컨트랙트를 흉내 내는 데 사용합니다.
+// Mock is a mock contract whose responses can be programmed per calldata.
+// Its bytecode is assembled by hand so it deploys without a compiler, and it stands in for
+// contracts that are only read from, such as an Aave Pool or a Chainlink feed.
+//
+// 동작 / Behavior:
+//   - 선택자 0xffffffff: keccak256(입력) 키에 응답 워드를 저장 / stores response words under the key keccak256(input)
+//   - 선택자 0xfffffffe: 토픽 4개와 데이터로 LOG4 발생 / emits LOG4 with four topics and data
+//   - 그 외: keccak256(calldata)에 저장된 응답 반환, 없으면 빈 데이터로 revert
+//     / anything else returns the response stored under keccak256(calldata), or reverts with empty data
+type Mock struct {
+	Address common.Address
+
+	env      *dryrun.Env
+	contract *bind.BoundContract
+}
+
+// 관리용 선택자 / Management selectors
+var (
+	mockProgramSelector = []byte{0xff, 0xff, 0xff, 0xff}
+	mockEmitSelector    = []byte{0xff, 0xff, 0xff, 0xfe}
+)
+
+// DeployMock은 e의 배포자 계정으로 Mock을 배포합니다.
+// DeployMock deploys a Mock from the deployer account of e.
+func DeployMock(ctx context.Context, e *dryrun.Env) (*Mock, error) {
+	c, err := e.Deploy(ctx, &dryrun.Artifact{Name: "Mock", ABI: abi.ABI{}, Bytecode: mockCreationCode()})
+	if err != nil {
+		return nil, err
+	}
+	return &Mock{Address: c.Address(), env: e, contract: c}, nil
+}
+
+// Return은 input으로 호출하면 output을 반환하도록 설정합니다. output은 32바이트 워드 단위여야 합니다.
+// Return programs the mock to answer input with output. output must be a whole number of 32-byte words.
+func (m *Mock) Return(ctx context.Context, input, output []byte) error {
+	if len(output)%32 != 0 {
+		return fmt.Errorf("응답 길이가 32의 배수가 아님 / response length %d is not a multiple of 32", len(output))
+	}
+	if len(output) == 0 {
+		return errors.New("빈 응답은 설정할 수 없음 / an empty response cannot be programmed")
+	}
+	data := append(append(append([]byte{}, mockProgramSelector...), crypto.Keccak256(input)...), output...)
+	return m.transact(ctx, data)
+}
+
+// ReturnCall은 ABI 메서드 호출 method(args...)가 results를 반환하도록 설정합니다.
+// ReturnCall programs the ABI method call method(args...) to return results.
+func (m *Mock) ReturnCall(ctx context.Context, parsed abi.ABI, method string, args []interface{}, results ...interface{}) error {
+	input, err := parsed.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("%s 입력 인코딩 실패 / failed to encode %s input: %w", method, method, err)
+	}
+	output, err := parsed.Methods[method].Outputs.Pack(results...)
+	if err != nil {
+		return fmt.Errorf("%s 출력 인코딩 실패 / failed to encode %s output: %w", method, method, err)
+	}
+	return m.Return(ctx, input, output)
+}
+
+// Emit은 Mock 주소에서 토픽 4개짜리 로그를 발생시킵니다 (Aave Pool 이벤트는 모두 인덱스 인자가 3개).
+// Emit emits a log with four topics from the Mock address (every Aave Pool event has three indexed arguments).
+func (m *Mock) Emit(ctx context.Context, topics [4]common.Hash, data []byte) error {
+	payload := append([]byte{}, mockEmitSelector...)
+	for _, t := range topics {
+		payload = append(payload, t.Bytes()...)
+	}
+	return m.transact(ctx, append(payload, data...))
+}
+
+func (m *Mock) transact(ctx context.Context, data []byte) error {
+	if err := m.env.RawTransact(ctx, m.env.Deployer, m.contract, data); err != nil {
+		return fmt.Errorf("Mock 트랜잭션 실패 / mock transaction failed: %w", err)
+	}
+	return nil
+}
+
+// mockCreationCode는 런타임 코드를 복사해 반환하는 생성 코드를 붙인 Mock 배포 바이트코드입니다.
+// mockCreationCode is the Mock deployment bytecode: a constructor that copies out and returns the runtime code.
+func mockCreationCode() []byte {
+	runtime := mockRuntime()
+	var a asm
+	a.push(uint64(len(runtime))).op(vm.DUP1).pushLabel("runtime").push(0).op(vm.CODECOPY)
+	a.push(0).op(vm.RETURN)
+	a.mark("runtime")
+	return append(a.assemble(), runtime...)
+}
+
+// mockRuntime은 Mock 런타임 코드를 조립합니다.
+// mockRuntime assembles the Mock runtime code.
+func mockRuntime() []byte {
+	var a asm
+
+	// 선택자 분기 / Selector dispatch
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.op(vm.DUP1).push(0xffffffff).op(vm.EQ).pushLabel("program").op(vm.JUMPI)
+	a.push(0xfffffffe).op(vm.EQ).pushLabel("emit").op(vm.JUMPI)
+
+	// 조회: key = keccak256(calldata), n = sload(key), 응답 = sload(key+1 .. key+n)
+	// Lookup: key = keccak256(calldata), n = sload(key), response = sload(key+1 .. key+n)
+	a.op(vm.CALLDATASIZE).push(0).push(0).op(vm.CALLDATACOPY)
+	a.op(vm.CALLDATASIZE).push(0).op(vm.KECCAK256) // key
+	a.op(vm.DUP1).op(vm.SLOAD)                     // key n
+	a.op(vm.DUP1).op(vm.ISZERO).pushLabel("revert").op(vm.JUMPI)
+	a.push(0) // key n i
+	a.label("load")
+	a.op(vm.DUP2).op(vm.DUP2).op(vm.LT).op(vm.ISZERO).pushLabel("return").op(vm.JUMPI)
+	a.op(vm.DUP1).op(vm.DUP4).op(vm.ADD).push(1).op(vm.ADD).op(vm.SLOAD) // key n i word
+	a.op(vm.DUP2).push(5).op(vm.SHL).op(vm.MSTORE)                       // mem[i*32] = word
+	a.push(1).op(vm.ADD).pushLabel("load").op(vm.JUMP)
+	a.label("return")
+	a.op(vm.POP).push(5).op(vm.SHL).push(0).op(vm.RETURN)
+	a.label("revert")
+	a.push(0).op(vm.DUP1).op(vm.REVERT)
+
+	// 설정: 0xffffffff ++ key ++ words
+	// Program: 0xffffffff ++ key ++ words
+	a.label("program")
+	a.push(4).op(vm.CALLDATALOAD)                                  // sel key
+	a.push(0x24).op(vm.CALLDATASIZE).op(vm.SUB).push(5).op(vm.SHR) // sel key n
+	a.op(vm.DUP1).op(vm.DUP3).op(vm.SSTORE)                        // sstore(key, n)
+	a.push(0)                                                      // sel key n i
+	a.label("store")
+	a.op(vm.DUP2).op(vm.DUP2).op(vm.LT).op(vm.ISZERO).pushLabel("stop").op(vm.JUMPI)
+	a.op(vm.DUP1).push(5).op(vm.SHL).push(0x24).op(vm.ADD).op(vm.CALLDATALOAD) // ... i word
+	a.op(vm.DUP2).op(vm.DUP5).op(vm.ADD).push(1).op(vm.ADD).op(vm.SSTORE)      // sstore(key+i+1, word)
+	a.push(1).op(vm.ADD).pushLabel("store").op(vm.JUMP)
+	a.label("stop")
+	a.op(vm.STOP)
+
+	// 로그: 0xfffffffe ++ t0 t1 t2 t3 ++ data
+	// Log: 0xfffffffe ++ t0 t1 t2 t3 ++ data
+	a.label("emit")
+	a.push(0x84).op(vm.CALLDATASIZE).op(vm.SUB)                     // len
+	a.op(vm.DUP1).push(0x84).push(0).op(vm.CALLDATACOPY)            // mem[0..len] = data
+	a.push(0x64).op(vm.CALLDATALOAD).push(0x44).op(vm.CALLDATALOAD) // len t3 t2
+	a.push(0x24).op(vm.CALLDATALOAD).push(0x04).op(vm.CALLDATALOAD) // len t3 t2 t1 t0
+	a.op(vm.DUP5).push(0).op(vm.LOG4)
+	a.op(vm.STOP)
+
+	return a.assemble()
+}
+
+// asm은 라벨을 지원하는 최소 EVM 어셈블러입니다. 라벨 참조는 항상 PUSH2로 인코딩됩니다.
+// asm is a minimal EVM assembler with labels. Label references are always encoded as PUSH2.
+type asm struct {
+	code   []byte
+	labels map[string]int
+	refs   map[int]string // PUSH2 인자 위치 → 라벨 / PUSH2 operand offset → label
+}
+
+func (a *asm) op(op vm.OpCode) *asm {
+	a.code = append(a.code, byte(op))
+	return a
+}
+
+// push는 v를 가장 짧은 PUSHn으로 넣습니다.
+// push pushes v with the shortest PUSHn.
+func (a *asm) push(v uint64) *asm {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	b := buf[:]
+	for len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	a.code = append(a.code, byte(vm.PUSH1)+byte(len(b)-1))
+	a.code = append(a.code, b...)
+	return a
+}
+
+func (a *asm) pushLabel(name string) *asm {
+	if a.refs == nil {
+		a.refs = make(map[int]string)
+	}
+	a.code = append(a.code, byte(vm.PUSH2))
+	a.refs[len(a.code)] = name
+	a.code = append(a.code, 0, 0)
+	return a
+}
+
+// mark는 JUMPDEST 없이 현재 위치를 라벨로 정의합니다 (코드 오프셋 참조용).
+// mark defines the current position as a label without a JUMPDEST (for code offset references).
+func (a *asm) mark(name string) *asm {
+	if a.labels == nil {
+		a.labels = make(map[string]int)
+	}
+	a.labels[name] = len(a.code)
+	return a
+}
+
+// label은 현재 위치를 라벨로 정의합니다. 점프 대상이 될 수 있도록 JUMPDEST를 넣습니다.
+// label defines the current position as a label, emitting a JUMPDEST so it can be jumped to.
+func (a *asm) label(name string) *asm {
+	return a.mark(name).op(vm.JUMPDEST)
+}
+
+// assemble은 라벨 참조를 채운 바이트코드를 반환합니다. 정의되지 않은 라벨은 프로그래밍 오류입니다.
+// assemble returns the bytecode with label references filled in. An undefined label is a programming error.
+func (a *asm) assemble() []byte {
+	for at, name := range a.refs {
+		pos, ok := a.labels[name]
+		if !ok {
+			panic("정의되지 않은 라벨 / undefined label: " + name)
+		}
+		binary.BigEndian.PutUint16(a.code[at:], uint16(pos))
+	}
+	return a.code
+}
@@ -0,0 +1,168 @@
+package dryruntest
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/jeongseup/lending-monitor/internal/dryrun"
+)
+
+func deploy(t *testing.T) (*dryrun.Env, *Mock) {
+	t.Helper()
+	ctx := context.Background()
+	e, err := dryrun.NewChain(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.Close() })
+	m, err := DeployMock(ctx, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e, m
+}
+
+func TestMockReturnCall(t *testing.T) {
+	ctx := context.Background()
+	e, m := deploy(t)
+
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"quote","stateMutability":"view",
+		 "inputs":[{"name":"id","type":"uint256"}],
+		 "outputs":[{"name":"a","type":"uint256"},{"name":"b","type":"address"}]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	who := common.HexToAddress("0xa11ce")
+	if err := m.ReturnCall(ctx, parsed, "quote", []interface{}{big.NewInt(7)}, big.NewInt(42), who); err != nil {
+		t.Fatal(err)
+	}
+
+	c := bind.NewBoundContract(m.Address, parsed, e.Client, e.Client, e.Client)
+	var out []interface{}
+	if err := c.Call(nil, &out, "quote", big.NewInt(7)); err != nil {
+		t.Fatal(err)
+	}
+	if out[0].(*big.Int).Int64() != 42 || out[1].(common.Address) != who {
+		t.Errorf("quote(7) = %v, want [42 %s]", out, who.Hex())
+	}
+	// 설정하지 않은 입력은 revert / Unprogrammed input reverts
+	if err := c.Call(nil, new([]interface{}), "quote", big.NewInt(8)); err == nil {
+		t.Error("quote(8): expected revert")
+	}
+	// 다시 설정하면 응답 교체 / Programming again replaces the response
+	if err := m.ReturnCall(ctx, parsed, "quote", []interface{}{big.NewInt(7)}, big.NewInt(43), who); err != nil {
+		t.Fatal(err)
+	}
+	var again []interface{}
+	if err := c.Call(nil, &again, "quote", big.NewInt(7)); err != nil || again[0].(*big.Int).Int64() != 43 {
+		t.Errorf("quote(7) after reprogram = %v, %v, want 43", again, err)
+	}
+}
+
+func TestMockLookup(t *testing.T) {
+	ctx := context.Background()
+	e, m := deploy(t)
+
+	words := func(vs ...byte) []byte {
+		var out []byte
+		for _, v := range vs {
+			out = append(out, common.Hash{31: v}.Bytes()...)
+		}
+		return out
+	}
+	// 선택자 형식이 아닌 입력도 키가 됨 (빈 입력 포함) / Inputs need not look like selectors (empty input included)
+	programs := []struct {
+		input, output []byte
+	}{
+		{[]byte{0x01, 0x02, 0x03, 0x04}, words(1)},
+		{[]byte{0x01, 0x02, 0x03, 0x04, 0x05}, words(2, 3, 4)},
+		{bytes.Repeat([]byte{0xab}, 100), words(5, 6)},
+		{nil, words(7)},
+	}
+	for _, p := range programs {
+		if err := m.Return(ctx, p.input, p.output); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range programs {
+		got, err := e.Client.CallContract(ctx, ethereum.CallMsg{To: &m.Address, Data: p.input}, nil)
+		if err != nil {
+			t.Errorf("call %x: %v", p.input, err)
+			continue
+		}
+		if !bytes.Equal(got, p.output) {
+			t.Errorf("call %x = %x, want %x", p.input, got, p.output)
+		}
+	}
+	if _, err := e.Client.CallContract(ctx, ethereum.CallMsg{To: &m.Address, Data: []byte{0x01, 0x02, 0x03}}, nil); err == nil {
+		t.Error("unprogrammed input: expected revert")
+	}
+
+	for _, bad := range [][]byte{nil, make([]byte, 31), make([]byte, 33)} {
+		if err := m.Return(ctx, []byte{0x01}, bad); err == nil {
+			t.Errorf("Return with %d-byte output: expected error", len(bad))
+		}
+	}
+}
+
+func TestMockEmit(t *testing.T) {
+	ctx := context.Background()
+	e, m := deploy(t)
+
+	emits := []struct {
+		topics [4]common.Hash
+		data   []byte
+	}{
+		{[4]common.Hash{{1}, {2}, {3}, {4}}, []byte("payload-0123456789abcdef0123456")},
+		{[4]common.Hash{{5}, {}, {}, {6}}, nil},
+	}
+	for _, em := range emits {
+		if err := m.Emit(ctx, em.topics, em.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logs, err := e.Client.FilterLogs(ctx, ethereum.FilterQuery{Addresses: []common.Address{m.Address}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != len(emits) {
+		t.Fatalf("got %d logs, want %d", len(logs), len(emits))
+	}
+	for i, em := range emits {
+		l := logs[i]
+		if len(l.Topics) != 4 || [4]common.Hash(l.Topics) != em.topics || !bytes.Equal(l.Data, em.data) {
+			t.Errorf("log %d = topics %v data %q, want %v %q", i, l.Topics, l.Data, em.topics, em.data)
+		}
+	}
+	if logs[0].BlockNumber >= logs[1].BlockNumber {
+		t.Errorf("emits share block %d; each transaction should commit its own block", logs[0].BlockNumber)
+	}
+}
+
+func TestAsm(t *testing.T) {
+	var a asm
+	a.push(0).push(0x100).pushLabel("end").op(vm.JUMP).label("end")
+	want := []byte{byte(vm.PUSH1), 0, byte(vm.PUSH2), 1, 0, byte(vm.PUSH2), 0, 9, byte(vm.JUMP), byte(vm.JUMPDEST)}
+	if got := a.assemble(); !bytes.Equal(got, want) {
+		t.Errorf("assemble = %x, want %x", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("undefined label: expected panic")
+		}
+	}()
+	var b asm
+	b.pushLabel("missing").assemble()
+}
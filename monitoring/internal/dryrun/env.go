@@ -0,0 +1,422 @@
+// Package dryrun은 스터디 컨트랙트를 go-ethereum 시뮬레이션 백엔드에 배포해 청산 코드를 메인넷 없이 검증합니다.
+// Package dryrun deploys the study contracts onto go-ethereum's simulated backend to validate liquidation code without mainnet.
+//
+// Foundry 산출물 (contracts/out)에서 LendingPool, PriceOracle, InterestRateModel과
+// 테스트용 MockERC20, MockPriceFeed를 읽어 배포하고, Liquidation.t.sol과 같은 방식으로 리저브를 설정합니다.
+// 트랜잭션은 보내는 즉시 블록으로 확정되므로 bind.WaitMined가 바로 반환됩니다.
+// LendingPool, PriceOracle, InterestRateModel and the test MockERC20/MockPriceFeed are read from the
+// Foundry artifacts (contracts/out), deployed, and reserves are configured the same way as Liquidation.t.sol.
+// Transactions are committed into a block as soon as they are sent, so bind.WaitMined returns immediately.
+//
+// 사용법 / Usage (monitoring/ 에서 / from monitoring/):
+//
+//	go generate ./internal/dryrun && go test ./internal/dryrun
+//
+// 산출물이 없으면 TestLiquidation이 PATH의 forge로 직접 빌드하며, forge도 없으면 건너뜁니다
+// (CI 환경 변수가 설정되어 있으면 실패). make test는 빌드부터 전체 테스트까지 실행합니다.
+// Without artifacts TestLiquidation builds them with forge from PATH, and is skipped when forge is
+// missing too (fails when the CI environment variable is set). make test runs the build and every test.
+package dryrun
+
+//go:generate forge build --root ../../../contracts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+// Reserve는 배포할 모의 토큰과 리저브 설정입니다. 가격은 18 소수점 피드 값, 비율은 1e18 = 100%입니다.
+// Reserve is a mock token to deploy and its reserve settings. Prices are 18-decimal feed values, ratios are 1e18 = 100%.
+type Reserve struct {
+	Symbol               string
+	Name                 string
+	Decimals             uint8
+	Price                *big.Int
+	CollateralFactor     *big.Int
+	LiquidationThreshold *big.Int
+}
+
+// Config는 시뮬레이션 환경 설정입니다.
+// Config configures the simulated environment.
+type Config struct {
+	// ArtifactsDir은 Foundry 산출물 디렉터리입니다 (contracts/out).
+	// ArtifactsDir is the Foundry artifacts directory (contracts/out).
+	ArtifactsDir string
+
+	// MaxStaleness는 PriceOracle 생성자 인자입니다 (초).
+	// MaxStaleness is the PriceOracle constructor argument (seconds).
+	MaxStaleness *big.Int
+
+	// BaseRate, Multiplier, JumpMultiplier, Kink는 InterestRateModel 생성자 인자입니다 (1e18 = 100%).
+	// BaseRate, Multiplier, JumpMultiplier and Kink are the InterestRateModel constructor arguments (1e18 = 100%).
+	BaseRate, Multiplier, JumpMultiplier, Kink *big.Int
+
+	Reserves []Reserve
+}
+
+// DefaultConfig는 Liquidation.t.sol과 같은 설정을 반환합니다 (ETH $2,000 / USDC $1, 둘 다 18 소수점).
+// DefaultConfig returns the same setup as Liquidation.t.sol (ETH $2,000 / USDC $1, both 18 decimals).
+func DefaultConfig(artifactsDir string) Config {
+	return Config{
+		ArtifactsDir:   artifactsDir,
+		MaxStaleness:   big.NewInt(3600),
+		BaseRate:       big.NewInt(0.02e18),
+		Multiplier:     big.NewInt(0.1e18),
+		JumpMultiplier: big.NewInt(1e18),
+		Kink:           big.NewInt(0.8e18),
+		Reserves: []Reserve{
+			{Symbol: "WETH", Name: "Wrapped Ether", Decimals: 18, Price: ether(2000),
+				CollateralFactor: big.NewInt(0.75e18), LiquidationThreshold: big.NewInt(0.80e18)},
+			{Symbol: "USDC", Name: "USD Coin", Decimals: 18, Price: ether(1),
+				CollateralFactor: big.NewInt(0.80e18), LiquidationThreshold: big.NewInt(0.85e18)},
+		},
+	}
+}
+
+// Account는 시뮬레이션 체인의 서명 계정입니다.
+// Account is a signing account on the simulated chain.
+type Account struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+}
+
+// Token은 배포된 모의 토큰과 가격 피드입니다.
+// Token is a deployed mock token and its price feed.
+type Token struct {
+	Reserve
+	Address common.Address
+	Feed    common.Address
+
+	token *bind.BoundContract
+	feed  *bind.BoundContract
+}
+
+// Env는 스터디 컨트랙트가 배포된 시뮬레이션 체인입니다.
+// Env is a simulated chain with the study contracts deployed.
+type Env struct {
+	Backend *simulated.Backend
+
+	// Client는 보낸 트랜잭션을 즉시 블록으로 확정하는 클라이언트입니다 (liquidator.Backend 구현).
+	// Client commits every sent transaction into a block immediately (implements liquidator.Backend).
+	Client  *Client
+	ChainID *big.Int
+
+	Deployer  Account
+	Pool      common.Address
+	Oracle    common.Address
+	RateModel common.Address
+	Tokens    map[string]*Token
+
+	pool *bind.BoundContract
+}
+
+// Client는 SendTransaction마다 블록을 확정하는 simulated.Client입니다.
+// Client is a simulated.Client that commits a block on every SendTransaction.
+type Client struct {
+	simulated.Client
+	backend *simulated.Backend
+}
+
+// SendTransaction은 트랜잭션을 보내고 바로 블록을 확정합니다.
+// SendTransaction sends the transaction and commits a block right away.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	c.backend.Commit()
+	return nil
+}
+
+// ether는 n × 1e18을 반환합니다.
+// ether returns n × 1e18.
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// deployerBalance는 배포자에게 제네시스로 주는 ETH입니다 (다른 계정 가스비도 여기서 충당).
+// deployerBalance is the ETH given to the deployer at genesis (it also funds other accounts' gas).
+var deployerBalance = ether(1_000_000)
+
+// New는 시뮬레이션 백엔드를 띄우고 산출물로 컨트랙트를 배포한 뒤 리저브를 초기화합니다.
+// New starts a simulated backend, deploys the contracts from artifacts and initializes the reserves.
+func New(ctx context.Context, cfg Config) (*Env, error) {
+	load := func(file, contract string) (*Artifact, error) {
+		return LoadArtifact(cfg.ArtifactsDir, file, contract)
+	}
+	poolArt, err := load("LendingPool.sol", "LendingPool")
+	if err != nil {
+		return nil, err
+	}
+	oracleArt, err := load("PriceOracle.sol", "PriceOracle")
+	if err != nil {
+		return nil, err
+	}
+	rateArt, err := load("InterestRateModel.sol", "InterestRateModel")
+	if err != nil {
+		return nil, err
+	}
+	tokenArt, err := load("LendingPool.invariant.t.sol", "MockERC20")
+	if err != nil {
+		return nil, err
+	}
+	feedArt, err := load("LendingPool.invariant.t.sol", "MockPriceFeed")
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewChain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.setup(ctx, cfg, poolArt, oracleArt, rateArt, tokenArt, feedArt); err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewChain은 배포자만 자금을 가진 빈 시뮬레이션 체인을 띄웁니다 (스터디 컨트랙트 없음, dryruntest.Mock 배포용).
+// NewChain starts an empty simulated chain where only the deployer holds funds (no study contracts; for deploying dryruntest.Mock).
+func NewChain(ctx context.Context) (*Env, error) {
+	deployer, err := NewAccount()
+	if err != nil {
+		return nil, err
+	}
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		deployer.Address: {Balance: deployerBalance},
+	})
+	e := &Env{
+		Backend:  backend,
+		Client:   &Client{Client: backend.Client(), backend: backend},
+		Deployer: deployer,
+		Tokens:   make(map[string]*Token),
+	}
+	if e.ChainID, err = e.Client.ChainID(ctx); err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("체인 ID 조회 실패 / failed to read chain ID: %w", err)
+	}
+	return e, nil
+}
+
+func (e *Env) setup(ctx context.Context, cfg Config, poolArt, oracleArt, rateArt, tokenArt, feedArt *Artifact) error {
+	oracle, err := e.Deploy(ctx, oracleArt, cfg.MaxStaleness)
+	if err != nil {
+		return err
+	}
+	e.Oracle = oracle.Address()
+	rate, err := e.Deploy(ctx, rateArt, cfg.BaseRate, cfg.Multiplier, cfg.JumpMultiplier, cfg.Kink)
+	if err != nil {
+		return err
+	}
+	e.RateModel = rate.Address()
+	if e.pool, err = e.Deploy(ctx, poolArt, e.Oracle, e.RateModel); err != nil {
+		return err
+	}
+	e.Pool = e.pool.Address()
+
+	for _, r := range cfg.Reserves {
+		token, err := e.Deploy(ctx, tokenArt, r.Name, r.Symbol, r.Decimals)
+		if err != nil {
+			return err
+		}
+		feed, err := e.Deploy(ctx, feedArt, r.Price, uint8(18))
+		if err != nil {
+			return err
+		}
+		if err := e.Transact(ctx, e.Deployer, oracle, "setPriceFeed", token.Address(), feed.Address()); err != nil {
+			return err
+		}
+		if err := e.Transact(ctx, e.Deployer, e.pool, "initReserve", token.Address(), r.CollateralFactor, r.LiquidationThreshold); err != nil {
+			return err
+		}
+		e.Tokens[r.Symbol] = &Token{Reserve: r, Address: token.Address(), Feed: feed.Address(), token: token, feed: feed}
+	}
+	return nil
+}
+
+// Close는 시뮬레이션 백엔드를 종료합니다.
+// Close shuts down the simulated backend.
+func (e *Env) Close() error {
+	return e.Backend.Close()
+}
+
+// NewAccount는 새 키로 계정을 만듭니다. 가스비가 필요하면 Env.Fund로 ETH를 보내세요.
+// NewAccount creates an account with a fresh key. Send it ETH with Env.Fund when it needs gas.
+func NewAccount() (Account, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return Account{}, fmt.Errorf("키 생성 실패 / failed to generate key: %w", err)
+	}
+	return Account{Key: key, Address: crypto.PubkeyToAddress(key.PublicKey)}, nil
+}
+
+// Fund는 배포자 계정에서 to로 ETH(wei)를 보냅니다.
+// Fund sends ETH (wei) from the deployer account to to.
+func (e *Env) Fund(ctx context.Context, to common.Address, amount *big.Int) error {
+	nonce, err := e.Client.PendingNonceAt(ctx, e.Deployer.Address)
+	if err != nil {
+		return fmt.Errorf("논스 조회 실패 / failed to read nonce: %w", err)
+	}
+	head, err := e.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("헤더 조회 실패 / failed to read header: %w", err)
+	}
+	tip := big.NewInt(1e9)
+	tx, err := types.SignNewTx(e.Deployer.Key, types.LatestSignerForChainID(e.ChainID), &types.DynamicFeeTx{
+		ChainID:   e.ChainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip),
+		Gas:       21_000,
+		To:        &to,
+		Value:     amount,
+	})
+	if err != nil {
+		return fmt.Errorf("트랜잭션 서명 실패 / failed to sign transaction: %w", err)
+	}
+	if err := e.Client.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("ETH 전송 실패 / failed to send ETH: %w", err)
+	}
+	_, err = e.wait(ctx, tx)
+	return err
+}
+
+// Mint는 모의 토큰을 to에게 발행합니다 (MockERC20.mint는 누구나 호출 가능).
+// Mint mints mock tokens to to (anyone may call MockERC20.mint).
+func (e *Env) Mint(ctx context.Context, symbol string, to common.Address, amount *big.Int) error {
+	t, err := e.token(symbol)
+	if err != nil {
+		return err
+	}
+	return e.Transact(ctx, e.Deployer, t.token, "mint", to, amount)
+}
+
+// Deposit은 from이 풀을 approve한 뒤 amount를 예치하게 합니다.
+// Deposit has from approve the pool and deposit amount.
+func (e *Env) Deposit(ctx context.Context, from Account, symbol string, amount *big.Int) error {
+	t, err := e.token(symbol)
+	if err != nil {
+		return err
+	}
+	if err := e.Transact(ctx, from, t.token, "approve", e.Pool, amount); err != nil {
+		return err
+	}
+	return e.Transact(ctx, from, e.pool, "deposit", t.Address, amount)
+}
+
+// Borrow는 from이 amount를 빌리게 합니다.
+// Borrow has from borrow amount.
+func (e *Env) Borrow(ctx context.Context, from Account, symbol string, amount *big.Int) error {
+	t, err := e.token(symbol)
+	if err != nil {
+		return err
+	}
+	return e.Transact(ctx, from, e.pool, "borrow", t.Address, amount)
+}
+
+// SetPrice는 모의 피드 가격을 바꿉니다 (18 소수점).
+// SetPrice changes the mock feed price (18 decimals).
+func (e *Env) SetPrice(ctx context.Context, symbol string, price *big.Int) error {
+	t, err := e.token(symbol)
+	if err != nil {
+		return err
+	}
+	return e.Transact(ctx, e.Deployer, t.feed, "setPrice", price)
+}
+
+// BalanceOf는 계정의 모의 토큰 잔고를 조회합니다.
+// BalanceOf retrieves an account's mock token balance.
+func (e *Env) BalanceOf(ctx context.Context, symbol string, account common.Address) (*big.Int, error) {
+	t, err := e.token(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	if err := t.token.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", account); err != nil {
+		return nil, fmt.Errorf("balanceOf 호출 실패 / balanceOf call failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// Transact는 from으로 서명한 트랜잭션을 보내고, 포함되지 않거나 실패하면 오류를 반환합니다.
+// Transact sends a transaction signed by from and returns an error unless it is mined successfully.
+func (e *Env) Transact(ctx context.Context, from Account, c *bind.BoundContract, method string, args ...interface{}) error {
+	opts, err := e.transactOpts(ctx, from)
+	if err != nil {
+		return err
+	}
+	tx, err := c.Transact(opts, method, args...)
+	if err != nil {
+		return fmt.Errorf("%s 전송 실패 / %s transaction failed: %w", method, method, err)
+	}
+	_, err = e.wait(ctx, tx)
+	return err
+}
+
+// RawTransact는 from으로 서명한 원시 호출 데이터 트랜잭션을 보내고, 포함되지 않거나 실패하면 오류를 반환합니다.
+// RawTransact sends a raw-calldata transaction signed by from and returns an error unless it is mined successfully.
+func (e *Env) RawTransact(ctx context.Context, from Account, c *bind.BoundContract, data []byte) error {
+	opts, err := e.transactOpts(ctx, from)
+	if err != nil {
+		return err
+	}
+	tx, err := c.RawTransact(opts, data)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 전송 실패 / transaction failed: %w", err)
+	}
+	_, err = e.wait(ctx, tx)
+	return err
+}
+
+// Deploy는 배포자 계정으로 산출물을 배포합니다.
+// Deploy deploys an artifact from the deployer account.
+func (e *Env) Deploy(ctx context.Context, a *Artifact, args ...interface{}) (*bind.BoundContract, error) {
+	opts, err := e.transactOpts(ctx, e.Deployer)
+	if err != nil {
+		return nil, err
+	}
+	_, tx, c, err := bind.DeployContract(opts, a.ABI, a.Bytecode, e.Client, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s 배포 실패 / failed to deploy %s: %w", a.Name, a.Name, err)
+	}
+	if _, err := e.wait(ctx, tx); err != nil {
+		return nil, fmt.Errorf("%s 배포 실패 / failed to deploy %s: %w", a.Name, a.Name, err)
+	}
+	return c, nil
+}
+
+func (e *Env) transactOpts(ctx context.Context, from Account) (*bind.TransactOpts, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(from.Key, e.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("서명자 생성 실패 / failed to create signer: %w", err)
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+func (e *Env) wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, e.Client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("영수증 대기 실패 / failed to wait for receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("트랜잭션 실패 / transaction reverted: %s", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+func (e *Env) token(symbol string) (*Token, error) {
+	t, ok := e.Tokens[symbol]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 토큰 / unknown token: %s", symbol)
+	}
+	return t, nil
+}
@@ -0,0 +1,113 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Cache는 한 (체인, Pool, 계정)의 블록별 Point를 JSON 파일에 보관합니다.
+// Cache keeps the per-block Points of one (chain, Pool, account) in a JSON file.
+type Cache struct {
+	path string
+
+	mu     sync.Mutex
+	points map[uint64]Point
+	dirty  bool
+}
+
+// CachePath는 dir 안에서 (체인, Pool, 계정)의 캐시 파일 경로를 만듭니다.
+// CachePath builds the cache file path of a (chain, Pool, account) inside dir.
+func CachePath(dir string, chainID *big.Int, pool, user common.Address) string {
+	name := fmt.Sprintf("%s-%s-%s.json", chainID, strings.ToLower(pool.Hex()), strings.ToLower(user.Hex()))
+	return filepath.Join(dir, name)
+}
+
+// OpenCache는 캐시 파일을 읽습니다. 파일이 없으면 빈 캐시를 반환합니다.
+// OpenCache reads a cache file. Returns an empty cache when the file does not exist.
+func OpenCache(path string) (*Cache, error) {
+	c := &Cache{path: path, points: make(map[uint64]Point)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("캐시 읽기 실패 / failed to read cache: %w", err)
+	}
+	var points []Point
+	if err := json.Unmarshal(data, &points); err != nil {
+		return nil, fmt.Errorf("캐시 파싱 실패 %s / failed to parse cache %s: %w", path, path, err)
+	}
+	for _, p := range points {
+		c.points[p.Block] = p
+	}
+	return c, nil
+}
+
+// Len은 캐시된 블록 수를 반환합니다.
+// Len returns the number of cached blocks.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.points)
+}
+
+// Get은 캐시된 블록의 Point를 반환합니다.
+// Get returns the cached Point of a block.
+func (c *Cache) Get(block uint64) (Point, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.points[block]
+	return p, ok
+}
+
+// Put은 성공한 Point를 캐시에 넣습니다 (실패한 조회는 캐시하지 않음).
+// Put adds a successful Point to the cache (failed reads are never cached).
+func (c *Cache) Put(p Point) {
+	if p.Error != "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.points[p.Block] = p
+	c.dirty = true
+}
+
+// Save는 바뀐 내용이 있으면 캐시를 파일에 씁니다 (임시 파일 후 이름 변경).
+// Save writes the cache to its file when it changed (temporary file, then rename).
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	points := make([]Point, 0, len(c.points))
+	for _, p := range c.points {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Block < points[j].Block })
+	data, err := json.Marshal(points)
+	if err != nil {
+		return fmt.Errorf("캐시 직렬화 실패 / failed to marshal cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("캐시 디렉터리 생성 실패 / failed to create cache directory: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("캐시 쓰기 실패 / failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("캐시 쓰기 실패 / failed to write cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
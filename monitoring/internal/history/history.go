@@ -0,0 +1,315 @@
+// Package history는 과거 블록의 getUserAccountData로 계정의 헬스팩터 시계열을 재구성합니다.
+// Package history reconstructs an account's health factor time series from getUserAccountData at past blocks.
+//
+// 청산을 조사할 때 직전 며칠 동안 HF가 어떻게 움직였는지 보기 위한 도구입니다.
+// 과거 상태 조회에는 아카이브 노드가 필요하므로, 시작 전에 가장 오래된 블록을 한 번 조회해 확인합니다.
+// 과거 블록의 결과는 바뀌지 않으므로 확정된 블록은 파일 캐시에 보관해 다시 조회하지 않습니다.
+// A tool for seeing how HF moved over the days leading up to a liquidation.
+// Historical state needs an archive node, so the oldest block is probed once before starting.
+// Results at past blocks never change, so finalized blocks are kept in a file cache and never re-read.
+//
+// DevOps 관점:
+// - 전체 노드는 최근 128블록 정도의 상태만 보관하므로 "missing trie node" 오류가 납니다
+// - 아카이브 조회는 RPC 제공자의 컴퓨트 유닛을 많이 쓰므로 step과 속도 제한을 함께 조절합니다
+//
+// DevOps perspective:
+// - Full nodes keep only about the last 128 blocks of state and fail with "missing trie node"
+// - Archive reads cost RPC provider compute units, so tune step together with the rate limit
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// ErrNoArchive는 노드가 요청한 블록의 상태를 보관하지 않을 때 반환됩니다.
+// ErrNoArchive is returned when the node does not keep state for the requested block.
+var ErrNoArchive = errors.New("과거 상태 조회 불가, 아카이브 노드 필요 / historical state unavailable, an archive node is required")
+
+// ErrNotDeployed는 요청한 블록에 Pool 컨트랙트가 아직 없을 때 반환됩니다.
+// ErrNotDeployed is returned when the Pool contract did not exist yet at the requested block.
+var ErrNotDeployed = errors.New("해당 블록에 Pool 컨트랙트 없음 / no Pool contract at that block")
+
+// missingStateErrors는 클라이언트별 "상태 없음" 오류 문구입니다 (소문자).
+// missingStateErrors are the per-client "state not available" error messages (lower case).
+var missingStateErrors = []string{
+	"missing trie node",      // geth
+	"historical state",       // geth (path scheme), reth: "historical state not available"
+	"state histories",        // erigon: "state histories haven't been fully indexed"
+	"state is not available", // nethermind
+	"state at block",         // besu: "state at block ... is pruned"
+	"pruned",
+	"archive", // 제공자 안내 문구 / provider hints such as "upgrade to an archive plan"
+}
+
+// IsMissingState는 오류가 과거 상태가 없어서 난 것인지 판단합니다.
+// IsMissingState reports whether an error was caused by missing historical state.
+func IsMissingState(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range missingStateErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Source는 과거 블록을 조회할 수 있는 클라이언트입니다 (*rpcpool.Pool이 구현).
+// Source is a client that can read past blocks (implemented by *rpcpool.Pool).
+type Source interface {
+	bind.ContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Point는 한 블록의 계정 상태입니다. 금액은 Aave 기본 통화(USD) 단위입니다.
+// Point is an account's state at one block. Amounts are in the Aave base currency (USD).
+type Point struct {
+	Block                uint64    `json:"block"`
+	Time                 time.Time `json:"time"`
+	CollateralUSD        float64   `json:"collateral_usd"`
+	DebtUSD              float64   `json:"debt_usd"`
+	AvailableBorrowsUSD  float64   `json:"available_borrows_usd"`
+	LiquidationThreshold float64   `json:"liquidation_threshold"`
+	LTV                  float64   `json:"ltv"`
+
+	// HealthFactor는 부채가 없으면 nil입니다 (Aave는 uint256 최대값을 반환).
+	// HealthFactor is nil when there is no debt (Aave returns the uint256 maximum).
+	HealthFactor *float64 `json:"health_factor"`
+
+	// Error는 이 블록 조회가 실패한 이유입니다 (성공하면 빈 값).
+	// Error is why reading this block failed (empty on success).
+	Error string `json:"error,omitempty"`
+}
+
+// Blocks는 from부터 to까지 step 간격의 블록 목록을 반환합니다. to는 항상 포함됩니다.
+// Blocks returns the blocks from `from` to `to` at step intervals. `to` is always included.
+func Blocks(from, to, step uint64) []uint64 {
+	if step == 0 {
+		step = 1
+	}
+	var out []uint64
+	for b := from; b <= to; b += step {
+		out = append(out, b)
+		if b+step < b { // 오버플로 / overflow
+			break
+		}
+	}
+	if len(out) > 0 && out[len(out)-1] != to {
+		out = append(out, to)
+	}
+	return out
+}
+
+// Options는 조회 설정입니다.
+// Options configures fetching.
+type Options struct {
+	// Workers는 동시에 조회할 최대 블록 수입니다.
+	// Workers is the maximum number of blocks read concurrently.
+	Workers int
+
+	// CallTimeout은 블록 하나를 조회하는 타임아웃입니다 (헤더 + 계정 데이터).
+	// CallTimeout is the timeout for reading one block (header + account data).
+	CallTimeout time.Duration
+
+	// Finalized는 캐시에 넣을 수 있는 가장 최근 블록입니다 (이후 블록은 재구성될 수 있음).
+	// Finalized is the most recent block that may be cached (later blocks could still reorg).
+	Finalized uint64
+}
+
+// DefaultOptions는 기본 설정을 반환합니다.
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		Workers:     8,
+		CallTimeout: 30 * time.Second,
+	}
+}
+
+// Fetcher는 과거 블록의 계정 데이터를 병렬로 읽습니다.
+// Fetcher reads account data at past blocks in parallel.
+type Fetcher struct {
+	src    Source
+	pool   *contracts.AavePoolCaller
+	cache  *Cache
+	opts   Options
+	logger *slog.Logger
+}
+
+// New는 새로운 Fetcher를 생성합니다. cache가 nil이면 캐시 없이 조회합니다.
+// New creates a new Fetcher. Without a cache (nil) every block is read.
+func New(src Source, pool common.Address, cache *Cache, opts Options, logger *slog.Logger) *Fetcher {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	return &Fetcher{
+		src:    src,
+		pool:   contracts.NewAavePoolCaller(src, pool),
+		cache:  cache,
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// CheckArchive는 block 시점의 Pool 코드를 조회해 노드가 그 상태를 보관하는지 확인합니다.
+// 상태가 없으면 ErrNoArchive, 그 블록에 Pool이 없으면 ErrNotDeployed를 감싸 반환합니다.
+// CheckArchive reads the Pool code at block to verify the node keeps that state.
+// Returns an error wrapping ErrNoArchive when the state is missing, ErrNotDeployed when the Pool did not exist yet.
+func (f *Fetcher) CheckArchive(ctx context.Context, block uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.CallTimeout)
+	defer cancel()
+	code, err := f.src.CodeAt(ctx, f.pool.Address(), new(big.Int).SetUint64(block))
+	if err != nil {
+		if IsMissingState(err) {
+			return fmt.Errorf("%w (블록 %d / block %d): %v", ErrNoArchive, block, block, err)
+		}
+		return fmt.Errorf("블록 %d 코드 조회 실패 / failed to read code at block %d: %w", block, block, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w: %s @ %d", ErrNotDeployed, f.pool.Address().Hex(), block)
+	}
+	return nil
+}
+
+// Fetch는 user의 blocks 시점 상태를 blocks 순서대로 반환합니다.
+// 블록별 실패는 Point.Error에 기록하고 계속하며, 새로 읽은 확정 블록은 캐시에 넣습니다.
+// 과거 상태가 없어 실패한 블록이 있으면 ErrNoArchive를 감싼 오류도 함께 반환합니다.
+// Fetch returns user's state at blocks, in the order given.
+// Per-block failures are recorded in Point.Error and fetching continues; newly read finalized blocks are cached.
+// When some blocks failed for lack of historical state, an error wrapping ErrNoArchive is returned as well.
+func (f *Fetcher) Fetch(ctx context.Context, user common.Address, blocks []uint64) ([]Point, error) {
+	points := make([]Point, len(blocks))
+	jobs := make(chan int)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		missing int
+		cached  int
+	)
+	for range min(f.opts.Workers, max(len(blocks), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b := blocks[i]
+				if f.cache != nil {
+					if p, ok := f.cache.Get(b); ok {
+						points[i] = p
+						mu.Lock()
+						cached++
+						mu.Unlock()
+						continue
+					}
+				}
+				p, err := f.read(ctx, user, b)
+				if err != nil {
+					if IsMissingState(err) {
+						mu.Lock()
+						missing++
+						mu.Unlock()
+					}
+					f.logger.Warn("과거 블록 조회 실패 / Failed to read historical block",
+						"block", b,
+						"error", err,
+					)
+					points[i] = Point{Block: b, Error: err.Error()}
+					continue
+				}
+				points[i] = p
+				if f.cache != nil && b <= f.opts.Finalized {
+					f.cache.Put(p)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := range blocks {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.logger.Info("과거 헬스팩터 조회 완료 / Historical health factors read",
+		"user", user.Hex(),
+		"blocks", len(blocks),
+		"cached", cached,
+		"missing_state", missing,
+	)
+	if missing > 0 {
+		return points, fmt.Errorf("%w: %d/%d 블록 / %d/%d blocks", ErrNoArchive, missing, len(blocks), missing, len(blocks))
+	}
+	return points, nil
+}
+
+// read는 블록 하나의 헤더와 계정 데이터를 읽습니다.
+// read reads one block's header and account data.
+func (f *Fetcher) read(ctx context.Context, user common.Address, block uint64) (Point, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.CallTimeout)
+	defer cancel()
+	number := new(big.Int).SetUint64(block)
+
+	header, err := f.src.HeaderByNumber(ctx, number)
+	if err != nil {
+		return Point{}, fmt.Errorf("헤더 조회 실패 / failed to get header: %w", err)
+	}
+	data, err := f.pool.GetUserAccountData(&bind.CallOpts{Context: ctx, BlockNumber: number}, user)
+	if err != nil {
+		return Point{}, err
+	}
+	return NewPoint(block, time.Unix(int64(header.Time), 0).UTC(), data), nil
+}
+
+// NewPoint는 getUserAccountData 결과를 Point로 변환합니다.
+// NewPoint converts a getUserAccountData result into a Point.
+func NewPoint(block uint64, at time.Time, data *contracts.UserAccountData) Point {
+	p := Point{
+		Block:                block,
+		Time:                 at,
+		CollateralUSD:        baseToUSD(data.TotalCollateralBase),
+		DebtUSD:              baseToUSD(data.TotalDebtBase),
+		AvailableBorrowsUSD:  baseToUSD(data.AvailableBorrowsBase),
+		LiquidationThreshold: bpsValue(data.CurrentLiquidationThreshold),
+		LTV:                  bpsValue(data.Ltv),
+	}
+	if data.TotalDebtBase.Sign() > 0 {
+		hf, _ := new(big.Float).Quo(new(big.Float).SetInt(data.HealthFactor), big.NewFloat(1e18)).Float64()
+		p.HealthFactor = &hf
+	}
+	return p
+}
+
+// baseToUSD는 Aave 기본 통화(USD, 8 소수점) 금액을 float64 달러로 변환합니다.
+// baseToUSD converts an Aave base currency amount (USD, 8 decimals) to float64 dollars.
+func baseToUSD(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e8)).Float64()
+	return f
+}
+
+// bpsValue는 베이시스 포인트(1e4 = 100%)를 비율로 변환합니다.
+// bpsValue converts basis points (1e4 = 100%) to a ratio.
+func bpsValue(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e4)).Float64()
+	return f
+}
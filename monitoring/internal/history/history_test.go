@@ -0,0 +1,223 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/dryrun"
+	"github.com/jeongseup/lending-monitor/internal/dryrun/dryruntest"
+)
+
+func TestBlocks(t *testing.T) {
+	cases := []struct {
+		from, to, step uint64
+		want           []uint64
+	}{
+		{10, 20, 5, []uint64{10, 15, 20}},
+		{10, 22, 5, []uint64{10, 15, 20, 22}},
+		{5, 5, 0, []uint64{5}},
+		{6, 5, 1, nil},
+	}
+	for _, c := range cases {
+		if got := Blocks(c.from, c.to, c.step); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Blocks(%d, %d, %d) = %v, want %v", c.from, c.to, c.step, got, c.want)
+		}
+	}
+}
+
+func TestIsMissingState(t *testing.T) {
+	missing := []string{
+		"missing trie node 1f2e3d (path ) state 0xabc is not available, not found",
+		"historical state not available in path scheme yet",
+		"state histories haven't been fully indexed yet",
+		"Block 0xabc is not available or state is not available",
+		"World state for block 123 is not available (pruned)",
+	}
+	for _, msg := range missing {
+		if !IsMissingState(errors.New(msg)) {
+			t.Errorf("IsMissingState(%q) = false", msg)
+		}
+	}
+	for _, err := range []error{nil, errors.New("execution reverted"), context.DeadlineExceeded} {
+		if IsMissingState(err) {
+			t.Errorf("IsMissingState(%v) = true", err)
+		}
+	}
+}
+
+var poolABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"getUserAccountData","stateMutability":"view",
+		"inputs":[{"name":"user","type":"address"}],
+		"outputs":[{"name":"","type":"uint256"},{"name":"","type":"uint256"},{"name":"","type":"uint256"},
+		           {"name":"","type":"uint256"},{"name":"","type":"uint256"},{"name":"","type":"uint256"}]}]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// countingSource는 계정 데이터 호출 수를 셉니다.
+// countingSource counts account data calls.
+type countingSource struct {
+	Source
+	calls atomic.Int64
+}
+
+func (s *countingSource) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	s.calls.Add(1)
+	return s.Source.CallContract(ctx, call, blockNumber)
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	e, err := dryrun.NewChain(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	pool, err := dryruntest.DeployMock(ctx, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployed, err := e.Client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 블록마다 HF가 떨어지고 마지막에 상환으로 부채가 0이 되는 계정
+	// An account whose HF drops block by block and whose debt is repaid in the end
+	user := common.HexToAddress("0xa11ce")
+	maxUint := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	steps := []struct {
+		collateral, debt int64
+		hf               *big.Int
+	}{
+		{20000, 10000, big.NewInt(1.6e18)},
+		{15000, 10000, big.NewInt(1.2e18)},
+		{11000, 10000, big.NewInt(0.88e18)},
+		{5000, 0, maxUint},
+	}
+	var blocks []uint64
+	for _, s := range steps {
+		err := pool.ReturnCall(ctx, poolABI, "getUserAccountData", []interface{}{user},
+			big.NewInt(s.collateral*1e8), big.NewInt(s.debt*1e8), big.NewInt(0), big.NewInt(8000), big.NewInt(7500), s.hf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := e.Client.BlockNumber(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	opts := DefaultOptions()
+	opts.Finalized = blocks[2]
+	cachePath := CachePath(t.TempDir(), e.ChainID, pool.Address, user)
+	cache, err := OpenCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := New(e.Client, pool.Address, cache, opts, logger)
+
+	if err := f.CheckArchive(ctx, deployed-1); !errors.Is(err, ErrNotDeployed) {
+		t.Errorf("CheckArchive before deployment = %v, want ErrNotDeployed", err)
+	}
+	if err := f.CheckArchive(ctx, deployed); err != nil {
+		t.Errorf("CheckArchive(%d) = %v", deployed, err)
+	}
+
+	// 배포 블록에는 응답이 없어 revert → 실패 기록 (상태 없음은 아님)
+	// Nothing is programmed at the deployment block, so the call reverts → recorded failure (not missing state)
+	query := append([]uint64{deployed}, blocks...)
+	points, err := f.Fetch(ctx, user, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != len(query) {
+		t.Fatalf("got %d points, want %d", len(points), len(query))
+	}
+	if points[0].Block != deployed || points[0].Error == "" {
+		t.Errorf("point at deployment = %+v, want a recorded failure", points[0])
+	}
+	wantHF := []float64{1.6, 1.2, 0.88}
+	for i, want := range wantHF {
+		p := points[i+1]
+		if p.Error != "" || p.Block != blocks[i] || p.HealthFactor == nil || *p.HealthFactor != want {
+			t.Errorf("point %d = %+v, want HF %v at block %d", i, p, want, blocks[i])
+			continue
+		}
+		if p.DebtUSD != 10000 || p.CollateralUSD != float64(steps[i].collateral) || p.LiquidationThreshold != 0.8 || p.LTV != 0.75 {
+			t.Errorf("point %d amounts = %+v", i, p)
+		}
+		if p.Time.IsZero() {
+			t.Errorf("point %d has no block time", i)
+		}
+	}
+	if last := points[len(points)-1]; last.HealthFactor != nil || last.DebtUSD != 0 {
+		t.Errorf("repaid point = %+v, want no health factor", last)
+	}
+
+	// 확정 블록 중 성공한 것만 캐시 / Only successful finalized blocks are cached
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 3 {
+		t.Errorf("cached blocks = %d, want 3", reopened.Len())
+	}
+	src := &countingSource{Source: e.Client}
+	again, err := New(src, pool.Address, reopened, opts, logger).Fetch(ctx, user, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := src.calls.Load(); n != 2 {
+		t.Errorf("calls with cache = %d, want 2 (failed and unfinalized blocks)", n)
+	}
+	if !reflect.DeepEqual(again[1:4], points[1:4]) {
+		t.Errorf("cached points = %+v, want %+v", again[1:4], points[1:4])
+	}
+
+	var csvOut bytes.Buffer
+	if err := WriteCSV(&csvOut, points); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != len(points)+1 || lines[0] != strings.Join(csvHeader, ",") {
+		t.Fatalf("csv = %q", csvOut.String())
+	}
+	if !strings.HasSuffix(lines[3], ",10000,0,0.8,0.75,1.2,") || !strings.HasSuffix(lines[5], ",0,0,0.8,0.75,,") {
+		t.Errorf("csv rows = %q / %q", lines[3], lines[5])
+	}
+}
+
+func TestCacheMissingFile(t *testing.T) {
+	c, err := OpenCache(filepath.Join(t.TempDir(), "none", "cache.json"))
+	if err != nil || c.Len() != 0 {
+		t.Fatalf("OpenCache = %v, %v", c, err)
+	}
+	c.Put(Point{Block: 1, Error: "boom"})
+	if c.Len() != 0 {
+		t.Error("failed point was cached")
+	}
+	// 바뀐 것이 없으면 파일을 만들지 않음 / Nothing changed, so no file is written
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvHeader는 CSV 열 이름입니다 (Point의 JSON 이름과 같음).
+// csvHeader are the CSV column names (the same as Point's JSON names).
+var csvHeader = []string{
+	"block", "time", "collateral_usd", "debt_usd", "available_borrows_usd",
+	"liquidation_threshold", "ltv", "health_factor", "error",
+}
+
+// WriteCSV는 시계열을 CSV로 씁니다. 부채가 없는 블록의 health_factor는 빈 값입니다.
+// WriteCSV writes the series as CSV. health_factor is empty at blocks without debt.
+func WriteCSV(w io.Writer, points []Point) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, p := range points {
+		var at, hf string
+		if !p.Time.IsZero() {
+			at = p.Time.UTC().Format(time.RFC3339)
+		}
+		if p.HealthFactor != nil {
+			hf = f(*p.HealthFactor)
+		}
+		row := []string{strconv.FormatUint(p.Block, 10), at, "", "", "", "", "", hf, p.Error}
+		if p.Error == "" {
+			row[2], row[3], row[4] = f(p.CollateralUSD), f(p.DebtUSD), f(p.AvailableBorrowsUSD)
+			row[5], row[6] = f(p.LiquidationThreshold), f(p.LTV)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON은 시계열을 JSON 배열로 씁니다.
+// WriteJSON writes the series as a JSON array.
+func WriteJSON(w io.Writer, points []Point) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(points)
+}
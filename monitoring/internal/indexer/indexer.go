@@ -0,0 +1,369 @@
+// Package indexer는 Aave Pool 이벤트를 디코딩해 저장하고 청산 메트릭을 갱신합니다.
+// Package indexer decodes Aave Pool events, stores them and updates the liquidation metrics.
+//
+// 과거 구간은 Backfill로 블록 범위를 나눠 조회하고, 실시간 로그는 Process로 하나씩 넘깁니다.
+// 백필과 구독이 겹쳐 같은 로그가 두 번 들어와도 (트랜잭션, 로그 인덱스)로 한 번만 저장·집계됩니다.
+// 중복 제거 키는 구독이 백필을 따라잡으면 버리므로, 오래 실행해도 키가 쌓이지 않습니다.
+// Historical ranges are fetched in chunks with Backfill, and live logs are handed over one by one with Process.
+// When backfill and subscription overlap, a log seen twice is stored and counted once, keyed by (transaction, log index).
+// Deduplication keys are dropped once the subscription has caught up with the backfill, so they don't pile up in long runs.
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// LogSource는 이벤트 로그를 제공하는 클라이언트입니다 (*rpcpool.Pool이 구현).
+// LogSource is a client that provides event logs (implemented by *rpcpool.Pool).
+type LogSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Kind는 이벤트 종류입니다.
+// Kind is the event kind.
+type Kind string
+
+// Pool 이벤트 종류 (이벤트 이름과 같음) / Pool event kinds (same as the event names)
+const (
+	KindSupply      Kind = "Supply"
+	KindWithdraw    Kind = "Withdraw"
+	KindBorrow      Kind = "Borrow"
+	KindRepay       Kind = "Repay"
+	KindLiquidation Kind = "LiquidationCall"
+)
+
+// kinds는 이벤트 토픽 → 종류입니다.
+// kinds maps an event topic to its kind.
+var kinds = map[common.Hash]Kind{
+	contracts.SupplyEventSig:          KindSupply,
+	contracts.WithdrawEventSig:        KindWithdraw,
+	contracts.BorrowEventSig:          KindBorrow,
+	contracts.RepayEventSig:           KindRepay,
+	contracts.LiquidationCallEventSig: KindLiquidation,
+}
+
+// Event는 디코딩된 Pool 이벤트입니다.
+// Event is a decoded Pool event.
+type Event struct {
+	Kind     Kind
+	Block    uint64
+	TxHash   common.Hash
+	LogIndex uint
+
+	// User는 포지션이 바뀐 계정입니다 (contracts.PositionOwner).
+	// User is the account whose position changed (contracts.PositionOwner).
+	User common.Address
+
+	// Reserve는 Supply/Withdraw/Borrow/Repay의 리저브, LiquidationCall의 담보 자산입니다.
+	// Reserve is the reserve of Supply/Withdraw/Borrow/Repay and the collateral asset of LiquidationCall.
+	Reserve common.Address
+
+	// 아래는 LiquidationCall만 채웁니다 / The fields below are only set for LiquidationCall
+	DebtAsset            *common.Address
+	DebtToCover          *big.Int
+	LiquidatedCollateral *big.Int
+	Liquidator           *common.Address
+}
+
+// Decode는 Pool 로그를 Event로 변환합니다. 관심 없는 이벤트나 형식이 맞지 않으면 false입니다.
+// Decode converts a Pool log into an Event. Returns false for uninteresting or malformed logs.
+func Decode(vLog types.Log) (*Event, bool) {
+	if len(vLog.Topics) < 2 {
+		return nil, false
+	}
+	kind, ok := kinds[vLog.Topics[0]]
+	if !ok {
+		return nil, false
+	}
+	user, ok := contracts.PositionOwner(vLog)
+	if !ok {
+		return nil, false
+	}
+	ev := &Event{
+		Kind:     kind,
+		Block:    vLog.BlockNumber,
+		TxHash:   vLog.TxHash,
+		LogIndex: vLog.Index,
+		User:     user,
+		Reserve:  common.BytesToAddress(vLog.Topics[1].Bytes()),
+	}
+	if kind == KindLiquidation {
+		// 데이터: debtToCover, liquidatedCollateralAmount, liquidator, receiveAToken
+		// Data: debtToCover, liquidatedCollateralAmount, liquidator, receiveAToken
+		if len(vLog.Data) < 3*32 {
+			return nil, false
+		}
+		debt := common.BytesToAddress(vLog.Topics[2].Bytes())
+		liquidator := common.BytesToAddress(vLog.Data[64:96])
+		ev.DebtAsset = &debt
+		ev.DebtToCover = new(big.Int).SetBytes(vLog.Data[:32])
+		ev.LiquidatedCollateral = new(big.Int).SetBytes(vLog.Data[32:64])
+		ev.Liquidator = &liquidator
+	}
+	return ev, true
+}
+
+// Store는 인덱싱된 이벤트 저장소입니다.
+// Store is where indexed events are kept.
+type Store interface {
+	// Put은 이벤트를 저장하고, 이미 저장된 (트랜잭션, 로그 인덱스)면 false를 반환합니다.
+	// Put stores an event and returns false when the (transaction, log index) was already stored.
+	Put(ev *Event) (bool, error)
+
+	// Forget은 block 미만 블록의 중복 제거 키를 버립니다 (저장된 이벤트는 유지).
+	// Forget drops the deduplication keys of blocks below block (stored events are kept).
+	Forget(block uint64)
+}
+
+// eventKey는 로그 하나를 식별합니다.
+// eventKey identifies one log.
+type eventKey struct {
+	tx    common.Hash
+	index uint
+}
+
+// seenSet은 중복 제거 키와 그 블록입니다. Forget으로 오래된 키를 버려 크기를 제한합니다.
+// seenSet holds deduplication keys with their blocks. Forget drops old keys to bound its size.
+type seenSet map[eventKey]uint64
+
+func (s seenSet) has(ev *Event) bool {
+	_, ok := s[eventKey{ev.TxHash, ev.LogIndex}]
+	return ok
+}
+
+func (s seenSet) add(ev *Event) {
+	s[eventKey{ev.TxHash, ev.LogIndex}] = ev.Block
+}
+
+func (s seenSet) forget(block uint64) {
+	for k, b := range s {
+		if b < block {
+			delete(s, k)
+		}
+	}
+}
+
+// MemoryStore는 메모리에 이벤트를 보관하는 Store입니다.
+// MemoryStore is a Store that keeps events in memory.
+type MemoryStore struct {
+	mu     sync.Mutex
+	seen   seenSet
+	events []*Event
+}
+
+// NewMemoryStore는 빈 MemoryStore를 생성합니다.
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{seen: make(seenSet)}
+}
+
+// Put은 Store를 구현합니다.
+// Put implements Store.
+func (s *MemoryStore) Put(ev *Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen.has(ev) {
+		return false, nil
+	}
+	s.seen.add(ev)
+	s.events = append(s.events, ev)
+	return true, nil
+}
+
+// Forget은 Store를 구현합니다.
+// Forget implements Store.
+func (s *MemoryStore) Forget(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen.forget(block)
+}
+
+// Events는 저장 순서대로 이벤트 사본 목록을 반환합니다.
+// Events returns the stored events in insertion order.
+func (s *MemoryStore) Events() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Event(nil), s.events...)
+}
+
+// DedupStore는 중복 제거 키만 보관하는 Store입니다. 이벤트는 Indexer의 로그로만 남습니다.
+// DedupStore is a Store that keeps only the deduplication keys. Events are only reported in the Indexer's logs.
+type DedupStore struct {
+	mu   sync.Mutex
+	seen seenSet
+}
+
+// NewDedupStore는 빈 DedupStore를 생성합니다.
+// NewDedupStore creates an empty DedupStore.
+func NewDedupStore() *DedupStore {
+	return &DedupStore{seen: make(seenSet)}
+}
+
+// Put은 Store를 구현합니다.
+// Put implements Store.
+func (s *DedupStore) Put(ev *Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen.has(ev) {
+		return false, nil
+	}
+	s.seen.add(ev)
+	return true, nil
+}
+
+// Forget은 Store를 구현합니다.
+// Forget implements Store.
+func (s *DedupStore) Forget(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen.forget(block)
+}
+
+// Indexer는 Pool 로그를 조회·디코딩해 저장소에 넣습니다.
+// Indexer fetches and decodes Pool logs and puts them into a store.
+type Indexer struct {
+	src      LogSource
+	query    ethereum.FilterQuery
+	store    Store
+	protocol string
+	logger   *slog.Logger
+
+	// backfilled는 Backfill이 처리한 마지막 블록입니다.
+	// backfilled is the last block processed by Backfill.
+	backfilled uint64
+}
+
+// New는 pool의 포지션 이벤트를 인덱싱하는 Indexer를 생성합니다.
+// protocol은 메트릭 라벨입니다 (예: "aave-v3").
+// New creates an Indexer for the position events of pool.
+// protocol is the metric label (e.g. "aave-v3").
+func New(src LogSource, pool common.Address, store Store, protocol string, logger *slog.Logger) *Indexer {
+	return &Indexer{
+		src: src,
+		query: ethereum.FilterQuery{
+			Addresses: []common.Address{pool},
+			Topics:    [][]common.Hash{contracts.PositionEventSigs},
+		},
+		store:    store,
+		protocol: protocol,
+		logger:   logger,
+	}
+}
+
+// Query는 실시간 구독에 쓸 필터를 반환합니다 (블록 범위 없음).
+// Query returns the filter to use for a live subscription (no block range).
+func (ix *Indexer) Query() ethereum.FilterQuery {
+	return ix.query
+}
+
+// Backfill은 from부터 최신 블록까지 chunk 단위로 과거 로그를 처리하고, 처리한 최신 블록을 반환합니다.
+// Backfill processes historical logs from `from` up to the latest block in chunks and returns the last block processed.
+func (ix *Indexer) Backfill(ctx context.Context, from, chunk uint64) (uint64, error) {
+	latest, err := ix.src.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("최신 블록 조회 실패 / failed to get latest block: %w", err)
+	}
+	if chunk == 0 {
+		chunk = 1
+	}
+
+	total := 0
+	for start := from; start <= latest; start += chunk {
+		end := min(start+chunk-1, latest)
+		q := ix.query
+		q.FromBlock = new(big.Int).SetUint64(start)
+		q.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := ix.src.FilterLogs(ctx, q)
+		if err != nil {
+			return 0, fmt.Errorf("블록 %d-%d 로그 조회 실패 / failed to get logs for blocks %d-%d: %w", start, end, start, end, err)
+		}
+		for _, vLog := range logs {
+			if err := ix.process(vLog); err != nil {
+				return 0, err
+			}
+		}
+		total += len(logs)
+	}
+	ix.backfilled = max(ix.backfilled, latest)
+
+	ix.logger.Info("과거 로그 조회 완료 / Historical logs retrieved",
+		"count", total,
+		"to_block", latest,
+	)
+	return latest, nil
+}
+
+// Process는 구독으로 받은 실시간 로그 하나를 처리합니다.
+// 구독이 백필한 블록을 넘어서면 백필과 겹칠 일이 없으므로, 그 블록 이전의 중복 제거 키를 버립니다.
+// Process handles one live log received from the subscription.
+// Once the subscription is past the backfilled blocks it can no longer overlap the backfill,
+// so the deduplication keys of earlier blocks are dropped.
+func (ix *Indexer) Process(vLog types.Log) error {
+	if !vLog.Removed && vLog.BlockNumber > ix.backfilled {
+		ix.store.Forget(vLog.BlockNumber)
+	}
+	return ix.process(vLog)
+}
+
+// process는 로그 하나를 디코딩해 저장하고, 새 청산 이벤트면 카운터를 올립니다.
+// 관심 없는 로그와 재구성으로 제거된 로그는 무시합니다.
+// process decodes and stores one log, incrementing the counter for a new liquidation.
+// Uninteresting logs and logs removed by a reorg are ignored.
+func (ix *Indexer) process(vLog types.Log) error {
+	if vLog.Removed {
+		ix.logger.Warn("재구성으로 제거된 로그 / Log removed by reorg",
+			"block", vLog.BlockNumber,
+			"tx", vLog.TxHash.Hex(),
+		)
+		return nil
+	}
+	ev, ok := Decode(vLog)
+	if !ok {
+		if len(vLog.Topics) > 0 {
+			ix.logger.Debug("알 수 없는 이벤트 / Unknown event", "topic", vLog.Topics[0].Hex())
+		}
+		return nil
+	}
+
+	added, err := ix.store.Put(ev)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	if ev.Kind == KindLiquidation {
+		// 청산 이벤트 — 가장 중요한 이벤트! / Liquidation event — the most important event!
+		metrics.LiquidationEventsTotal.WithLabelValues(ix.protocol).Inc()
+		ix.logger.Warn("청산 이벤트 감지! / Liquidation event detected!",
+			"block", ev.Block,
+			"tx", ev.TxHash.Hex(),
+			"collateral_asset", ev.Reserve.Hex(),
+			"debt_asset", ev.DebtAsset.Hex(),
+			"user", ev.User.Hex(),
+			"debt_to_cover", ev.DebtToCover.String(),
+		)
+		return nil
+	}
+	ix.logger.Info(string(ev.Kind)+" 이벤트 감지 / "+string(ev.Kind)+" event detected",
+		"block", ev.Block,
+		"tx", ev.TxHash.Hex(),
+		"reserve", ev.Reserve.Hex(),
+		"user", ev.User.Hex(),
+	)
+	return nil
+}
@@ -0,0 +1,158 @@
+package indexer
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+var (
+	pool       = common.HexToAddress("0x9001")
+	weth       = common.HexToAddress("0xee01")
+	usdc       = common.HexToAddress("0xee02")
+	alice      = common.HexToAddress("0xa11ce")
+	liquidator = common.HexToAddress("0x11c0")
+)
+
+func topic(a common.Address) common.Hash { return common.BytesToHash(a.Bytes()) }
+
+func word(v int64) []byte { return common.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+
+func supplyLog(block uint64, tx byte, index uint) types.Log {
+	return types.Log{
+		Address:     pool,
+		Topics:      []common.Hash{contracts.SupplyEventSig, topic(weth), topic(alice), {}},
+		Data:        append(topic(alice).Bytes(), word(10)...),
+		BlockNumber: block,
+		TxHash:      common.Hash{tx},
+		Index:       index,
+	}
+}
+
+func liquidationLog(block uint64, tx byte, index uint) types.Log {
+	data := append(append(append(word(7500), word(5)...), topic(liquidator).Bytes()...), word(0)...)
+	return types.Log{
+		Address:     pool,
+		Topics:      []common.Hash{contracts.LiquidationCallEventSig, topic(weth), topic(usdc), topic(alice)},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.Hash{tx},
+		Index:       index,
+	}
+}
+
+func TestDecode(t *testing.T) {
+	ev, ok := Decode(supplyLog(7, 1, 3))
+	if !ok || ev.Kind != KindSupply || ev.User != alice || ev.Reserve != weth || ev.Block != 7 || ev.LogIndex != 3 || ev.DebtAsset != nil {
+		t.Errorf("Decode(supply) = %+v, %v", ev, ok)
+	}
+
+	ev, ok = Decode(liquidationLog(8, 2, 0))
+	if !ok || ev.Kind != KindLiquidation || ev.User != alice || ev.Reserve != weth {
+		t.Fatalf("Decode(liquidation) = %+v, %v", ev, ok)
+	}
+	if *ev.DebtAsset != usdc || *ev.Liquidator != liquidator || ev.DebtToCover.Int64() != 7500 || ev.LiquidatedCollateral.Int64() != 5 {
+		t.Errorf("liquidation fields = %+v", ev)
+	}
+
+	short := liquidationLog(8, 2, 0)
+	short.Data = short.Data[:64]
+	unknown := supplyLog(7, 1, 0)
+	unknown.Topics[0] = common.Hash{0xde, 0xad}
+	for name, l := range map[string]types.Log{"short data": short, "unknown topic": unknown, "no topics": {}} {
+		if ev, ok := Decode(l); ok {
+			t.Errorf("Decode(%s) = %+v, want false", name, ev)
+		}
+	}
+}
+
+// fakeSource는 고정된 로그를 블록 범위로 걸러 반환합니다.
+// fakeSource returns a fixed set of logs filtered by block range.
+type fakeSource struct {
+	head uint64
+	logs []types.Log
+}
+
+func (s *fakeSource) BlockNumber(context.Context) (uint64, error) { return s.head, nil }
+
+func (s *fakeSource) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var out []types.Log
+	for _, l := range s.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func TestDedupWindow(t *testing.T) {
+	src := &fakeSource{head: 9, logs: []types.Log{supplyLog(5, 1, 0), liquidationLog(8, 2, 1)}}
+	store := NewMemoryStore()
+	ix := New(src, pool, store, "indexer-test", slog.New(slog.DiscardHandler))
+
+	latest, err := ix.Backfill(context.Background(), 0, 4)
+	if err != nil || latest != 9 {
+		t.Fatalf("Backfill = %d, %v, want 9", latest, err)
+	}
+	if n := len(store.Events()); n != 2 {
+		t.Fatalf("events after backfill = %d, want 2", n)
+	}
+
+	// 구독이 백필 구간을 다시 보내면 중복으로 무시 / The subscription replaying the backfilled range is deduplicated
+	if err := ix.Process(liquidationLog(8, 2, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(store.Events()); n != 2 {
+		t.Errorf("events after overlapping log = %d, want 2", n)
+	}
+	if n := len(store.seen); n != 2 {
+		t.Errorf("keys during overlap = %d, want 2", n)
+	}
+
+	// 백필 이후 블록이 오면 따라잡은 것 → 이전 키를 버림
+	// A block past the backfill means the subscription caught up → earlier keys are dropped
+	live := supplyLog(10, 3, 0)
+	for range 2 {
+		if err := ix.Process(live); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(store.Events()); n != 3 {
+		t.Errorf("events after live log = %d, want 3", n)
+	}
+	if n := len(store.seen); n != 1 {
+		t.Errorf("keys after catching up = %d, want 1 (block 10 only)", n)
+	}
+	if err := ix.Process(supplyLog(11, 4, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(store.seen); n != 1 {
+		t.Errorf("keys after the next block = %d, want 1", n)
+	}
+}
+
+func TestDedupStore(t *testing.T) {
+	s := NewDedupStore()
+	ev, _ := Decode(liquidationLog(8, 2, 1))
+	for i, want := range []bool{true, false} {
+		added, err := s.Put(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added != want {
+			t.Errorf("put %d: added = %v, want %v", i, added, want)
+		}
+	}
+
+	s.Forget(9)
+	if len(s.seen) != 0 {
+		t.Errorf("keys after Forget(9) = %d, want 0", len(s.seen))
+	}
+}
@@ -0,0 +1,13 @@
+// Package integration은 시뮬레이션 체인 위에서 모니터링 구성 요소를 함께 검증하는 통합 테스트입니다.
+// Package integration holds integration tests that exercise the monitoring components together on a simulated chain.
+//
+// ethclient/simulated에 dryruntest.Mock을 Aave Pool, 데이터 제공자, Chainlink 피드 가격을 돌려주는 오라클로
+// 배포하고 응답을 프로그래밍한 뒤, 모니터 사이클, 알림 평가, 가격 조회, 이벤트 인덱서를 실제 RPC 경로로 실행해
+// Prometheus 메트릭 값, httptest 서버가 받은 웹훅 페이로드, 피드 갱신 후 헬스팩터, 저장된 이벤트를 확인합니다.
+// dryruntest.Mock contracts are deployed on ethclient/simulated as an Aave Pool, a data provider and an oracle
+// returning Chainlink feed prices, with programmed responses; the monitor cycle, alert evaluation, price reads and
+// event indexer then run over the real RPC path, and the tests assert on Prometheus metric values, webhook payloads
+// received by an httptest server, health factors after a feed update and stored events.
+//
+//	go test ./internal/integration -v
+package integration
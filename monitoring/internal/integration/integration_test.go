@@ -0,0 +1,619 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/jeongseup/lending-monitor/internal/alert"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/dryrun"
+	"github.com/jeongseup/lending-monitor/internal/dryrun/dryruntest"
+	"github.com/jeongseup/lending-monitor/internal/indexer"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+	"github.com/jeongseup/lending-monitor/internal/monitor"
+	"github.com/jeongseup/lending-monitor/internal/risk"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+	"github.com/jeongseup/lending-monitor/internal/sim"
+)
+
+// testProtocol은 테스트마다 고유한 protocol 메트릭 라벨을 반환하고, 테스트가 끝나면 그 라벨의 시계열을 지웁니다.
+// 메트릭은 전역 벡터이므로 실행 순서 (-shuffle, -count)와 무관하게 자기 시계열만 확인하기 위함입니다.
+// testProtocol returns a protocol metric label unique to the test and deletes that label's series when the test ends.
+// The metrics are global vectors, so this keeps each test looking only at its own series whatever the run order
+// (-shuffle, -count).
+func testProtocol(t *testing.T) string {
+	t.Helper()
+	protocol := "integration/" + t.Name()
+	t.Cleanup(func() {
+		match := prometheus.Labels{"protocol": protocol}
+		for _, vec := range []interface{ DeletePartialMatch(prometheus.Labels) int }{
+			metrics.HealthFactor,
+			metrics.GroupPositions,
+			metrics.GroupPositionsAtRisk,
+			metrics.GroupHealthFactorMin,
+			metrics.HealthFactorRangePositions,
+			metrics.LiquidationEventsTotal,
+		} {
+			vec.DeletePartialMatch(match)
+		}
+	})
+	return protocol
+}
+
+// healthFactorSeries는 protocol 라벨의 health_factor 시계열 수를 셉니다.
+// healthFactorSeries counts the health_factor series with the protocol label.
+func healthFactorSeries(t *testing.T, protocol string) int {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(metrics.HealthFactor)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "protocol" && l.GetValue() == protocol {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// Mock 응답을 인코딩할 ABI (contracts 패키지와 독립적으로 작성해 인코딩도 함께 검증)
+// ABIs used to encode Mock responses (written independently of the contracts package so decoding is checked too)
+var (
+	poolABI = mustABI(`[{"type":"function","name":"getUserAccountData","stateMutability":"view",
+		"inputs":[{"name":"user","type":"address"}],
+		"outputs":[{"name":"totalCollateralBase","type":"uint256"},{"name":"totalDebtBase","type":"uint256"},
+		           {"name":"availableBorrowsBase","type":"uint256"},{"name":"currentLiquidationThreshold","type":"uint256"},
+		           {"name":"ltv","type":"uint256"},{"name":"healthFactor","type":"uint256"}]},
+		{"type":"function","name":"getUserConfiguration","stateMutability":"view",
+		"inputs":[{"name":"user","type":"address"}],
+		"outputs":[{"name":"","type":"tuple","components":[{"name":"data","type":"uint256"}]}]},
+		{"type":"function","name":"getUserEMode","stateMutability":"view",
+		"inputs":[{"name":"user","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}]`)
+	dataProviderABI = mustABI(`[{"type":"function","name":"getUserReserveData","stateMutability":"view",
+		"inputs":[{"name":"asset","type":"address"},{"name":"user","type":"address"}],
+		"outputs":[{"name":"currentATokenBalance","type":"uint256"},{"name":"currentStableDebt","type":"uint256"},
+		           {"name":"currentVariableDebt","type":"uint256"},{"name":"principalStableDebt","type":"uint256"},
+		           {"name":"scaledVariableDebt","type":"uint256"},{"name":"stableBorrowRate","type":"uint256"},
+		           {"name":"liquidityRate","type":"uint256"},{"name":"stableRateLastUpdated","type":"uint40"},
+		           {"name":"usageAsCollateralEnabled","type":"bool"}]}]`)
+	oracleABI = mustABI(`[{"type":"function","name":"getAssetsPrices","stateMutability":"view",
+		"inputs":[{"name":"assets","type":"address[]"}],"outputs":[{"name":"","type":"uint256[]"}]}]`)
+)
+
+func mustABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func units(t *testing.T, s string, decimals uint8) *big.Int {
+	t.Helper()
+	v, err := sim.ParseUnits(s, decimals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func newChain(t *testing.T) *dryrun.Env {
+	t.Helper()
+	e, err := dryrun.NewChain(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func deployMock(t *testing.T, e *dryrun.Env) *dryruntest.Mock {
+	t.Helper()
+	m, err := dryruntest.DeployMock(context.Background(), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// webhook은 받은 알림을 모아두는 httptest 서버입니다.
+// webhook is an httptest server that collects the alerts it receives.
+type webhook struct {
+	srv *httptest.Server
+
+	mu     sync.Mutex
+	alerts []alert.Alert
+}
+
+func newWebhook(t *testing.T) *webhook {
+	t.Helper()
+	w := &webhook{}
+	w.srv = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var a alert.Alert
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+			t.Errorf("decode alert: %v", err)
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.mu.Lock()
+		w.alerts = append(w.alerts, a)
+		w.mu.Unlock()
+	}))
+	t.Cleanup(w.srv.Close)
+	return w
+}
+
+// take는 지금까지 받은 알림을 metadata[key] 기준으로 반환하고 비웁니다.
+// take returns the alerts received so far keyed by metadata[key] and clears them.
+func (w *webhook) take(t *testing.T, key string) map[string]alert.Alert {
+	t.Helper()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make(map[string]alert.Alert, len(w.alerts))
+	for _, a := range w.alerts {
+		k := a.Metadata[key]
+		if _, dup := out[k]; dup {
+			t.Errorf("duplicate alert for %s=%s", key, k)
+		}
+		out[k] = a
+	}
+	w.alerts = nil
+	return out
+}
+
+// account는 getUserAccountData 응답 하나입니다 (부채는 기본 통화 8자리, HF는 1e18 스케일).
+// account is one getUserAccountData response (debt in the 8-decimal base currency, HF scaled by 1e18).
+type account struct {
+	addr   common.Address
+	label  string
+	debt   string
+	hf     string
+	hfUnit *big.Int // hf 대신 원시 값 (부채 없는 계정의 uint256 최대값) / raw value instead of hf (uint256 max for debt-free accounts)
+}
+
+func program(t *testing.T, pool *dryruntest.Mock, a account) {
+	t.Helper()
+	hf := a.hfUnit
+	if hf == nil {
+		hf = units(t, a.hf, 18)
+	}
+	debt := units(t, a.debt, 8)
+	collateral := new(big.Int).Mul(debt, big.NewInt(2))
+	err := pool.ReturnCall(context.Background(), poolABI, "getUserAccountData", []interface{}{a.addr},
+		collateral, debt, big.NewInt(0), big.NewInt(8250), big.NewInt(8000), hf)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMonitorCycle(t *testing.T) {
+	ctx := context.Background()
+	protocol := testProtocol(t)
+	e := newChain(t)
+	pool := deployMock(t, e)
+	hook := newWebhook(t)
+
+	maxUint := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	accounts := []account{
+		{addr: common.HexToAddress("0x1001"), label: "healthy", debt: "1000", hf: "2"},
+		{addr: common.HexToAddress("0x1002"), label: "warning", debt: "5000", hf: "1.1"},
+		{addr: common.HexToAddress("0x1003"), label: "critical", debt: "20000", hf: "0.95"},
+		{addr: common.HexToAddress("0x1004"), label: "no-debt", debt: "0", hfUnit: maxUint},
+	}
+	for _, a := range accounts {
+		program(t, pool, a)
+	}
+	// 0x1005는 설정하지 않음 → 호출이 revert되어 failed / 0x1005 is not programmed → the call reverts and fails
+	unprogrammed := common.HexToAddress("0x1005")
+
+	targets := []monitor.Target{{Address: unprogrammed, Label: "unknown", Group: "treasury", Pinned: true}}
+	for _, a := range accounts {
+		targets = append(targets, monitor.Target{Address: a.addr, Label: a.label, Group: "treasury", Pinned: true})
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	opts := monitor.DefaultOptions()
+	opts.Protocol = protocol
+	m := monitor.New(contracts.NewAavePoolCaller(e.Client, pool.Address), nil, alert.NewWebhookAlerter(hook.srv.URL, logger), opts, logger)
+
+	header, err := e.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := monitor.BlockRefFromHeader(header)
+	res := m.RunCycle(ctx, targets, block)
+
+	if res.Counts[monitor.OutcomeSucceeded] != 4 || res.Counts[monitor.OutcomeFailed] != 1 || res.Counts[monitor.OutcomeTimedOut] != 0 {
+		t.Errorf("counts = %v, want 4 succeeded, 1 failed", res.Counts)
+	}
+	if len(res.Positions) != 3 {
+		t.Errorf("positions = %v, want the 3 debt-holding accounts", res.Positions)
+	}
+
+	// 메트릭 / Metrics
+	for _, a := range accounts[:3] {
+		want, _ := new(big.Float).SetInt(units(t, a.hf, 18)).Float64()
+		want /= 1e18
+		if got := testutil.ToFloat64(metrics.HealthFactor.WithLabelValues(protocol, a.addr.Hex(), "treasury")); got != want {
+			t.Errorf("health_factor{%s} = %v, want %v", a.label, got, want)
+		}
+	}
+	gauges := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"monitor_cycle_addresses{succeeded}", testutil.ToFloat64(metrics.MonitorCycleAddresses.WithLabelValues("succeeded")), 4},
+		{"monitor_cycle_addresses{failed}", testutil.ToFloat64(metrics.MonitorCycleAddresses.WithLabelValues("failed")), 1},
+		{"monitor_block_number", testutil.ToFloat64(metrics.MonitorBlockNumber), float64(header.Number.Uint64())},
+		{"group_positions", testutil.ToFloat64(metrics.GroupPositions.WithLabelValues(protocol, "treasury")), 3},
+		{"group_positions_at_risk", testutil.ToFloat64(metrics.GroupPositionsAtRisk.WithLabelValues(protocol, "treasury")), 2},
+		{"group_health_factor_min", testutil.ToFloat64(metrics.GroupHealthFactorMin.WithLabelValues(protocol, "treasury")), 0.95},
+		{"health_factor_range_positions{<1}", testutil.ToFloat64(metrics.HealthFactorRangePositions.WithLabelValues(protocol, "<1")), 1},
+		{"health_factor_range_positions{1.05-1.1}", testutil.ToFloat64(metrics.HealthFactorRangePositions.WithLabelValues(protocol, "1.05-1.1")), 0},
+		{"health_factor_range_positions{1.1-1.25}", testutil.ToFloat64(metrics.HealthFactorRangePositions.WithLabelValues(protocol, "1.1-1.25")), 1},
+		{"health_factor_range_positions{>=2}", testutil.ToFloat64(metrics.HealthFactorRangePositions.WithLabelValues(protocol, ">=2")), 1},
+	}
+	for _, g := range gauges {
+		if g.got != g.want {
+			t.Errorf("%s = %v, want %v", g.name, g.got, g.want)
+		}
+	}
+
+	// 웹훅: 경고 임계값(1.2) 미만 계정만, 수준은 HF에 따라
+	// Webhook: only accounts below the warning threshold (1.2), level by HF
+	alerts := hook.take(t, "user")
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2: %+v", len(alerts), alerts)
+	}
+	want := map[common.Address]alert.AlertLevel{
+		accounts[1].addr: alert.AlertWarning,
+		accounts[2].addr: alert.AlertCritical,
+	}
+	for addr, level := range want {
+		a, ok := alerts[addr.Hex()]
+		if !ok {
+			t.Errorf("no alert for %s", addr.Hex())
+			continue
+		}
+		if a.Level != level {
+			t.Errorf("%s level = %s, want %s", addr.Hex(), a.Level, level)
+		}
+		md := a.Metadata
+		if md["group"] != "treasury" || md["label"] == "" || md["block"] != header.Number.String() || md["block_time"] == "" {
+			t.Errorf("%s metadata = %v", addr.Hex(), md)
+		}
+		if !strings.Contains(a.Message, md["label"]) {
+			t.Errorf("%s message %q does not name the label %q", addr.Hex(), a.Message, md["label"])
+		}
+	}
+	if hf := alerts[accounts[2].addr.Hex()].Metadata["health_factor"]; hf != "0.950000" {
+		t.Errorf("critical health_factor = %q, want 0.950000", hf)
+	}
+
+	// 청산 후 회복: 응답을 바꾸고 다시 돌리면 메트릭은 새 블록 기준, 수준이 그대로인 경고 계정은 다시 알리지 않음
+	// Recovery after a liquidation: reprogram and rerun; metrics come from the new block and the
+	// warning account, whose level is unchanged, is not alerted again
+	accounts[2].debt, accounts[2].hf = "10000", "1.5"
+	program(t, pool, accounts[2])
+	header, err = e.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.RunCycle(ctx, targets[1:], monitor.BlockRefFromHeader(header))
+
+	if got := testutil.ToFloat64(metrics.HealthFactor.WithLabelValues(protocol, accounts[2].addr.Hex(), "treasury")); got != 1.5 {
+		t.Errorf("health_factor{critical} after recovery = %v, want 1.5", got)
+	}
+	if got := testutil.ToFloat64(metrics.GroupPositionsAtRisk.WithLabelValues(protocol, "treasury")); got != 1 {
+		t.Errorf("group_positions_at_risk after recovery = %v, want 1", got)
+	}
+	// 목록에서 빠진 주소의 사용자별 시계열은 삭제 / The per-user series of an address dropped from the list is deleted
+	if n := healthFactorSeries(t, protocol); n != 4 {
+		t.Errorf("health_factor series = %d, want 4", n)
+	}
+	if alerts = hook.take(t, "user"); len(alerts) != 0 {
+		t.Errorf("alerts after recovery = %+v, want none", alerts)
+	}
+
+	// 회복한 계정이 다시 청산 가능해지면 곧바로 긴급 알림 / A recovered account that becomes liquidatable again alerts right away
+	accounts[2].hf = "0.97"
+	program(t, pool, accounts[2])
+	if header, err = e.Client.HeaderByNumber(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	m.RunCycle(ctx, targets[1:], monitor.BlockRefFromHeader(header))
+	alerts = hook.take(t, "user")
+	if len(alerts) != 1 || alerts[accounts[2].addr.Hex()].Level != alert.AlertCritical {
+		t.Errorf("alerts after relapse = %+v, want one CRITICAL for %s", alerts, accounts[2].addr.Hex())
+	}
+}
+
+// failingCaller는 모든 호출에 같은 오류를 돌려주는 쿼럼 대역입니다.
+// failingCaller is a quorum stand-in that returns the same error for every call.
+type failingCaller struct{ err error }
+
+func (f failingCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return nil, f.err
+}
+
+func (f failingCaller) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, f.err
+}
+
+func TestMonitorQuorum(t *testing.T) {
+	ctx := context.Background()
+	protocol := testProtocol(t)
+	e := newChain(t)
+	pool := deployMock(t, e)
+	critical := account{addr: common.HexToAddress("0x2001"), label: "critical", debt: "20000", hf: "0.95"}
+	program(t, pool, critical)
+	targets := []monitor.Target{{Address: critical.addr, Label: critical.label, Group: "treasury", Pinned: true}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAlert  bool
+		wantMarker bool
+	}{
+		// 제공자 장애: 단일 제공자 판정으로 알리되 미확인 표시 / Provider outage: alert on the single-provider verdict, marked unconfirmed
+		{"outage", rpcpool.ErrQuorumUnavailable, true, true},
+		// 제공자 불일치: 알림 보류 / Providers disagree: withhold the alert
+		{"mismatch", rpcpool.ErrQuorumMismatch, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := newWebhook(t)
+			logger := slog.New(slog.DiscardHandler)
+			opts := monitor.DefaultOptions()
+			opts.Protocol = protocol
+			quorum := contracts.NewAavePoolCaller(failingCaller{tt.err}, pool.Address)
+			m := monitor.New(contracts.NewAavePoolCaller(e.Client, pool.Address), quorum, alert.NewWebhookAlerter(hook.srv.URL, logger), opts, logger)
+			m.RunCycle(ctx, targets, nil)
+
+			alerts := hook.take(t, "user")
+			a, ok := alerts[critical.addr.Hex()]
+			if ok != tt.wantAlert {
+				t.Fatalf("alerted = %v, want %v", ok, tt.wantAlert)
+			}
+			if !ok {
+				return
+			}
+			if a.Level != alert.AlertCritical {
+				t.Errorf("level = %s, want %s", a.Level, alert.AlertCritical)
+			}
+			if got := a.Metadata["quorum_unconfirmed"] == "true"; got != tt.wantMarker {
+				t.Errorf("quorum_unconfirmed marker = %v, want %v (metadata %v)", got, tt.wantMarker, a.Metadata)
+			}
+		})
+	}
+}
+
+// setPrices는 Mock 오라클의 getAssetsPrices(assets)가 피드 응답 prices (USD 8 소수점)를 반환하도록 설정합니다.
+// Aave 오라클은 자산별 Chainlink 피드의 최신 응답을 그대로 돌려주므로 피드 갱신을 흉내 냅니다.
+// setPrices programs the Mock oracle's getAssetsPrices(assets) to return the feed answers prices (USD with 8 decimals).
+// The Aave oracle passes through each asset's latest Chainlink feed answer, so this stands in for a feed update.
+func setPrices(t *testing.T, oracle *dryruntest.Mock, assets []common.Address, prices ...string) {
+	t.Helper()
+	answers := make([]*big.Int, len(prices))
+	for i, p := range prices {
+		answers[i] = units(t, p, 8)
+	}
+	if err := oracle.ReturnCall(context.Background(), oracleABI, "getAssetsPrices", []interface{}{assets}, answers); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOracleFeeds(t *testing.T) {
+	ctx := context.Background()
+	e := newChain(t)
+	pool, provider, oracle := deployMock(t, e), deployMock(t, e), deployMock(t, e)
+
+	weth := contracts.Reserve{Asset: common.HexToAddress("0xee01"), Symbol: "WETH", Decimals: 18, ID: 0, LiquidationThreshold: big.NewInt(8250)}
+	usdc := contracts.Reserve{Asset: common.HexToAddress("0xee02"), Symbol: "USDC", Decimals: 6, ID: 1, LiquidationThreshold: big.NewInt(8750)}
+	reserves := []contracts.Reserve{weth, usdc}
+	assets := []common.Address{weth.Asset, usdc.Asset}
+	alice := common.HexToAddress("0xa11ce")
+
+	// alice: WETH 10개 담보 (리저브 0 담보 비트), USDC 12,000 부채 (리저브 1 차입 비트)
+	// alice: 10 WETH collateral (reserve 0 collateral bit), 12,000 USDC debt (reserve 1 borrowing bit)
+	userConfig := struct{ Data *big.Int }{big.NewInt(0b0110)}
+	if err := pool.ReturnCall(ctx, poolABI, "getUserConfiguration", []interface{}{alice}, userConfig); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.ReturnCall(ctx, poolABI, "getUserEMode", []interface{}{alice}, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	balances := []struct {
+		asset      common.Address
+		collateral *big.Int
+		debt       *big.Int
+	}{
+		{weth.Asset, units(t, "10", 18), big.NewInt(0)},
+		{usdc.Asset, big.NewInt(0), units(t, "12000", 6)},
+	}
+	for _, b := range balances {
+		zero := big.NewInt(0)
+		err := provider.ReturnCall(ctx, dataProviderABI, "getUserReserveData", []interface{}{b.asset, alice},
+			b.collateral, zero, b.debt, zero, b.debt, zero, zero, zero, b.collateral.Sign() > 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reader := contracts.NewPositionReader(e.Client, &contracts.AaveAddresses{Pool: pool.Address, DataProvider: provider.Address, Oracle: oracle.Address})
+	opts := &bind.CallOpts{Context: ctx}
+
+	// 피드 가격이 바뀌면 같은 잔고의 헬스팩터도 바뀜 / A feed price change moves the health factor of the same balances
+	tests := []struct {
+		name   string
+		prices []string
+		wantHF float64
+	}{
+		// 10 × $2,000 × 0.825 / $12,000
+		{"initial", []string{"2000", "1"}, 1.375},
+		// ETH 하락: 10 × $1,500 × 0.825 / $12,000 / ETH drop
+		{"eth drop", []string{"1500", "1"}, 1.03125},
+		// USDC 디페그로 부채 가치도 줄어듦: 10 × $1,500 × 0.825 / $11,400 / A USDC depeg shrinks the debt value too
+		{"usdc depeg", []string{"1500", "0.95"}, 12375.0 / 11400},
+	}
+	for _, tt := range tests {
+		setPrices(t, oracle, assets, tt.prices...)
+
+		prices, err := reader.Prices(opts, reserves)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for i, a := range assets {
+			if want := units(t, tt.prices[i], 8); prices[a] == nil || prices[a].Cmp(want) != 0 {
+				t.Errorf("%s: price[%s] = %v, want %s", tt.name, reserves[i].Symbol, prices[a], want)
+			}
+		}
+
+		pos, err := reader.UserPosition(opts, alice, reserves, prices)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(pos.Reserves) != 2 {
+			t.Fatalf("%s: reserves = %+v, want WETH and USDC", tt.name, pos.Reserves)
+		}
+		if hf, _ := risk.ShockedHealthFactor(pos, common.Address{}, 0); math.Abs(hf-tt.wantHF) > 1e-9 {
+			t.Errorf("%s: health factor = %v, want %v", tt.name, hf, tt.wantHF)
+		}
+	}
+
+	// 피드에 없는 자산은 오라클 호출이 revert되어 오류 / An asset without a feed makes the oracle call revert
+	dai := contracts.Reserve{Asset: common.HexToAddress("0xee03"), Symbol: "DAI", Decimals: 18}
+	if _, err := reader.Prices(opts, append(reserves, dai)); err == nil {
+		t.Error("expected an error for an asset without a feed")
+	}
+}
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+	protocol := testProtocol(t)
+	e := newChain(t)
+	pool := deployMock(t, e)
+	other := deployMock(t, e)
+
+	weth := common.HexToAddress("0xee01")
+	usdc := common.HexToAddress("0xee02")
+	alice := common.HexToAddress("0xa11ce")
+	liquidator := common.HexToAddress("0x11c0")
+	topic := func(a common.Address) common.Hash { return common.BytesToHash(a.Bytes()) }
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+
+	debtToCover := units(t, "7500", 6)
+	seized := units(t, "5.25", 18)
+	liqData := append(append(append(word(debtToCover), word(seized)...), topic(liquidator).Bytes()...), word(big.NewInt(0))...)
+	emits := []struct {
+		from   *dryruntest.Mock
+		topics [4]common.Hash
+		data   []byte
+	}{
+		// Supply(reserve, user, onBehalfOf, amount, referralCode): 인덱스 reserve, onBehalfOf, referralCode
+		{pool, [4]common.Hash{contracts.SupplyEventSig, topic(weth), topic(alice), {}}, append(topic(alice).Bytes(), word(units(t, "10", 18))...)},
+		// Borrow(reserve, user, onBehalfOf, amount, mode, rate, referralCode)
+		{pool, [4]common.Hash{contracts.BorrowEventSig, topic(usdc), topic(alice), {}}, append(topic(alice).Bytes(), word(units(t, "15000", 6))...)},
+		// 다른 컨트랙트의 같은 이벤트는 무시 / The same event from another contract is ignored
+		{other, [4]common.Hash{contracts.BorrowEventSig, topic(usdc), topic(alice), {}}, nil},
+		// 관심 없는 이벤트는 무시 / Uninteresting events are ignored
+		{pool, [4]common.Hash{{0xde, 0xad}, {}, {}, {}}, nil},
+		// LiquidationCall(collateralAsset, debtAsset, user, debtToCover, liquidatedCollateralAmount, liquidator, receiveAToken)
+		{pool, [4]common.Hash{contracts.LiquidationCallEventSig, topic(weth), topic(usdc), topic(alice)}, liqData},
+	}
+	for i, em := range emits {
+		if err := em.from.Emit(ctx, em.topics, em.data); err != nil {
+			t.Fatalf("emit %d: %v", i, err)
+		}
+	}
+
+	before := testutil.ToFloat64(metrics.LiquidationEventsTotal.WithLabelValues(protocol))
+	store := indexer.NewMemoryStore()
+	ix := indexer.New(e.Client, pool.Address, store, protocol, slog.New(slog.DiscardHandler))
+	latest, err := ix.Backfill(ctx, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := e.Client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != head {
+		t.Errorf("Backfill returned %d, want head %d", latest, head)
+	}
+
+	events := store.Events()
+	kinds := make([]indexer.Kind, len(events))
+	for i, ev := range events {
+		kinds[i] = ev.Kind
+		if ev.User != alice {
+			t.Errorf("%s user = %s, want alice", ev.Kind, ev.User.Hex())
+		}
+	}
+	wantKinds := []indexer.Kind{indexer.KindSupply, indexer.KindBorrow, indexer.KindLiquidation}
+	if len(kinds) != len(wantKinds) {
+		t.Fatalf("stored kinds = %v, want %v", kinds, wantKinds)
+	}
+	for i := range wantKinds {
+		if kinds[i] != wantKinds[i] {
+			t.Fatalf("stored kinds = %v, want %v", kinds, wantKinds)
+		}
+	}
+	if events[0].Reserve != weth || events[1].Reserve != usdc || events[0].Block >= events[1].Block {
+		t.Errorf("supply/borrow = %+v / %+v", events[0], events[1])
+	}
+
+	liq := events[2]
+	if liq.Reserve != weth || liq.DebtAsset == nil || *liq.DebtAsset != usdc || liq.Liquidator == nil || *liq.Liquidator != liquidator {
+		t.Errorf("liquidation = %+v", liq)
+	}
+	if liq.DebtToCover.Cmp(debtToCover) != 0 || liq.LiquidatedCollateral.Cmp(seized) != 0 {
+		t.Errorf("liquidation amounts = %s / %s, want %s / %s", liq.DebtToCover, liq.LiquidatedCollateral, debtToCover, seized)
+	}
+	receipt, err := e.Client.TransactionReceipt(ctx, liq.TxHash)
+	if err != nil || receipt.BlockNumber.Uint64() != liq.Block {
+		t.Errorf("liquidation tx %s not found at block %d: %v", liq.TxHash.Hex(), liq.Block, err)
+	}
+
+	if got := testutil.ToFloat64(metrics.LiquidationEventsTotal.WithLabelValues(protocol)) - before; got != 1 {
+		t.Errorf("liquidation_events_total delta = %v, want 1", got)
+	}
+
+	// 같은 구간을 다시 백필해도 (재시작, 구독과 겹침) 중복 저장·집계되지 않음
+	// Backfilling the same range again (restart, overlap with the subscription) does not store or count twice
+	if _, err := ix.Backfill(ctx, 0, 100); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(store.Events()); n != 3 {
+		t.Errorf("events after second backfill = %d, want 3", n)
+	}
+	if got := testutil.ToFloat64(metrics.LiquidationEventsTotal.WithLabelValues(protocol)) - before; got != 1 {
+		t.Errorf("liquidation_events_total delta after second backfill = %v, want 1", got)
+	}
+}
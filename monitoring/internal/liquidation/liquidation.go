@@ -0,0 +1,299 @@
+// Package liquidation은 HF < 1인 포지션의 청산 기회를 찾고 청산자 수익을 추정합니다.
+// Package liquidation finds liquidation opportunities on positions with HF < 1 and estimates liquidator profit.
+//
+// 읽기 전용 분석입니다. 트랜잭션을 만들거나 보내지 않습니다.
+// 청산 금액은 Aave V3 LiquidationLogic과 같은 정수 연산으로 계산합니다:
+// - Close Factor: HF > 0.95이면 부채의 50%, 이하이면 100%까지 상환 가능
+// - 압류 담보 = 상환액 × 부채 가격 / 담보 가격 × 청산 보너스 (담보가 모자라면 담보 전액, 상환액을 역산)
+// - 보너스 중 청산 프로토콜 수수료만큼은 청산자가 아니라 트레저리로 감
+//
+// This is read-only analysis. It never builds or sends transactions.
+// Liquidation amounts use the same integer arithmetic as Aave V3 LiquidationLogic:
+// - Close factor: up to 50% of the debt can be repaid while HF > 0.95, and up to 100% at or below it
+// - Collateral seized = repaid × debt price / collateral price × liquidation bonus (all collateral when short, with the repaid amount derived back)
+// - The liquidation protocol fee share of the bonus goes to the treasury, not the liquidator
+package liquidation
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/risk"
+)
+
+// bpsDenominator는 bps 값의 분모입니다 (10000 = 100%).
+// bpsDenominator is the denominator of bps values (10000 = 100%).
+const bpsDenominator = 10000
+
+var (
+	// wad는 헬스팩터의 고정소수점 단위입니다 (1e18).
+	// wad is the fixed-point unit of the health factor (1e18).
+	wad = big.NewInt(1e18)
+
+	// baseUnit은 기본 통화 (USD 8 소수점)의 단위입니다.
+	// baseUnit is the unit of the base currency (USD with 8 decimals).
+	baseUnit = big.NewInt(1e8)
+)
+
+// Options는 청산 규칙과 비용 가정입니다.
+// Options are the liquidation rules and cost assumptions.
+type Options struct {
+	// CloseFactor는 HF가 FullCloseThreshold보다 클 때 상환할 수 있는 부채 비율입니다 (bps).
+	// CloseFactor is the share of the debt that can be repaid while HF is above FullCloseThreshold (bps).
+	CloseFactor uint16
+
+	// FullCloseThreshold 이하의 HF에서는 부채 전액을 상환할 수 있습니다 (wad).
+	// At or below FullCloseThreshold (wad) the whole debt can be repaid.
+	FullCloseThreshold *big.Int
+
+	// GasUsed는 liquidationCall 한 번의 가스 사용량 추정치입니다.
+	// GasUsed is the estimated gas used by one liquidationCall.
+	GasUsed uint64
+
+	// Slippage는 압류 담보를 부채 자산으로 바꿀 때 잃는 비율입니다 (0.005 = 0.5%).
+	// Slippage is the share lost when swapping seized collateral back to the debt asset (0.005 = 0.5%).
+	Slippage float64
+}
+
+// DefaultOptions는 Aave V3 기본 규칙을 반환합니다.
+// DefaultOptions returns the Aave V3 default rules.
+func DefaultOptions() Options {
+	return Options{
+		CloseFactor:        5000,
+		FullCloseThreshold: big.NewInt(0.95e18),
+		GasUsed:            400_000, // 보수적 추정 (플래시론·스왑 제외) / conservative estimate (no flash loan or swap)
+	}
+}
+
+// Gas는 현재 가스 가격과 네이티브 토큰 가격입니다.
+// Gas is the current gas price and the native token price.
+type Gas struct {
+	// Price는 가스당 wei입니다 (기본 수수료 + 우선순위 팁).
+	// Price is wei per gas (base fee + priority tip).
+	Price *big.Int
+
+	// NativePrice는 네이티브 토큰 1개의 기본 통화 가격입니다 (USD 8 소수점).
+	// NativePrice is the base currency price of one native token (USD with 8 decimals).
+	NativePrice *big.Int
+}
+
+// USD는 가스 gasUsed의 비용입니다 (USD).
+// USD is the cost of gasUsed gas (USD).
+func (g Gas) USD(gasUsed uint64) float64 {
+	if g.Price == nil || g.NativePrice == nil {
+		return 0
+	}
+	wei := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), g.Price)
+	base := new(big.Int).Mul(wei, g.NativePrice)
+	return baseToUSD(base.Quo(base, wad))
+}
+
+// Opportunity는 계정 하나의 가장 수익성 높은 청산입니다.
+// Opportunity is the most profitable liquidation of one account.
+type Opportunity struct {
+	User         common.Address `json:"user"`
+	HealthFactor float64        `json:"health_factor"`
+
+	// CloseFactor는 적용된 Close Factor입니다 (bps, 5000 또는 10000).
+	// CloseFactor is the close factor that applies (bps, 5000 or 10000).
+	CloseFactor uint16 `json:"close_factor"`
+
+	TotalCollateralUSD float64 `json:"total_collateral_usd"`
+	TotalDebtUSD       float64 `json:"total_debt_usd"`
+
+	DebtAsset        common.Address `json:"debt_asset"`
+	DebtSymbol       string         `json:"debt_symbol"`
+	CollateralAsset  common.Address `json:"collateral_asset"`
+	CollateralSymbol string         `json:"collateral_symbol"`
+
+	// DebtToCover는 liquidationCall에 넘길 최대 상환액입니다 (부채 자산 단위).
+	// DebtToCover is the largest amount to pass to liquidationCall (debt asset units).
+	DebtToCover    *big.Int `json:"debt_to_cover"`
+	DebtToCoverUSD float64  `json:"debt_to_cover_usd"`
+
+	// CollateralSeized는 사용자에게서 빠지는 담보이고, CollateralReceived는 프로토콜 수수료를 뺀 청산자 몫입니다 (담보 자산 단위).
+	// CollateralSeized leaves the user and CollateralReceived is the liquidator's share after the protocol fee (collateral asset units).
+	CollateralSeized      *big.Int `json:"collateral_seized"`
+	CollateralReceived    *big.Int `json:"collateral_received"`
+	CollateralReceivedUSD float64  `json:"collateral_received_usd"`
+	ProtocolFeeUSD        float64  `json:"protocol_fee_usd"`
+
+	// GrossProfitUSD는 받은 담보 가치 - 상환액이고, NetProfitUSD는 여기서 슬리피지와 가스를 뺀 값입니다.
+	// GrossProfitUSD is received collateral value - repaid, and NetProfitUSD subtracts slippage and gas from it.
+	GrossProfitUSD float64 `json:"gross_profit_usd"`
+	SlippageUSD    float64 `json:"slippage_usd"`
+	GasUSD         float64 `json:"gas_usd"`
+	NetProfitUSD   float64 `json:"net_profit_usd"`
+}
+
+// Evaluate는 포지션이 청산 가능하면 (HF < 1) 순이익이 가장 큰 (부채 자산, 담보 자산) 쌍의 청산을 반환합니다.
+// 청산할 수 없으면 nil입니다.
+// Evaluate returns the liquidation of the (debt asset, collateral asset) pair with the largest net profit
+// when the position is liquidatable (HF < 1). It returns nil otherwise.
+//
+// 포지션의 청산 기준, 보너스, 가격에는 e-mode가 이미 반영돼 있어야 합니다 (contracts.ApplyEMode).
+// The position's liquidation thresholds, bonuses and prices must already have e-mode applied (contracts.ApplyEMode).
+func Evaluate(pos *contracts.UserPosition, gas Gas, opts Options) *Opportunity {
+	data := risk.AccountData(pos)
+	if data.TotalDebtBase.Sign() == 0 || data.HealthFactor.Cmp(wad) >= 0 {
+		return nil
+	}
+	closeFactor := opts.CloseFactor
+	if opts.FullCloseThreshold == nil || data.HealthFactor.Cmp(opts.FullCloseThreshold) <= 0 {
+		closeFactor = bpsDenominator
+	}
+	gasUSD := gas.USD(opts.GasUsed)
+
+	var best *Opportunity
+	for _, d := range pos.Reserves {
+		if !liquidatable(d) || d.Debt == nil || d.Debt.Sign() == 0 {
+			continue
+		}
+		for _, c := range pos.Reserves {
+			if !liquidatable(c) || !c.UsedAsCollateral || c.Collateral == nil || c.Collateral.Sign() == 0 ||
+				c.LiquidationThreshold == nil || c.LiquidationThreshold.Sign() == 0 {
+				continue
+			}
+			o := pair(d, c, closeFactor)
+			if o == nil {
+				continue
+			}
+			o.SlippageUSD = o.CollateralReceivedUSD * opts.Slippage
+			o.GasUSD = gasUSD
+			o.NetProfitUSD = o.GrossProfitUSD - o.SlippageUSD - o.GasUSD
+			if best == nil || o.NetProfitUSD > best.NetProfitUSD {
+				best = o
+			}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	best.User = pos.User
+	best.HealthFactor = wadToFloat(data.HealthFactor)
+	best.CloseFactor = closeFactor
+	best.TotalCollateralUSD = baseToUSD(data.TotalCollateralBase)
+	best.TotalDebtUSD = baseToUSD(data.TotalDebtBase)
+	return best
+}
+
+// Rank는 순이익이 큰 순서로 정렬합니다 (같으면 상환액이 큰 순).
+// Rank orders opportunities by net profit, largest first (then by repaid value).
+func Rank(ops []*Opportunity) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].NetProfitUSD != ops[j].NetProfitUSD {
+			return ops[i].NetProfitUSD > ops[j].NetProfitUSD
+		}
+		return ops[i].DebtToCoverUSD > ops[j].DebtToCoverUSD
+	})
+}
+
+// pair는 LiquidationLogic._calculateAvailableCollateralToLiquidate로 한 쌍의 최대 청산을 계산합니다.
+// pair computes the largest liquidation of one pair with LiquidationLogic._calculateAvailableCollateralToLiquidate.
+func pair(debt, collateral contracts.ReservePosition, closeFactor uint16) *Opportunity {
+	if debt.Price == nil || debt.Price.Sign() == 0 || collateral.Price == nil || collateral.Price.Sign() == 0 {
+		return nil
+	}
+	bonus := collateral.LiquidationBonus
+	if bonus == nil || bonus.Sign() == 0 {
+		return nil
+	}
+	debtUnit := pow10(debt.Decimals)
+	collateralUnit := pow10(collateral.Decimals)
+
+	debtToCover := percentMul(debt.Debt, big.NewInt(int64(closeFactor)))
+
+	// baseCollateral = 부채 가격 × 상환액 × 담보 단위 / (담보 가격 × 부채 단위)
+	// baseCollateral = debt price × repaid × collateral unit / (collateral price × debt unit)
+	base := new(big.Int).Mul(debt.Price, debtToCover)
+	base.Mul(base, collateralUnit)
+	base.Quo(base, new(big.Int).Mul(collateral.Price, debtUnit))
+	seized := percentMul(base, bonus)
+
+	if seized.Cmp(collateral.Collateral) > 0 {
+		seized = new(big.Int).Set(collateral.Collateral)
+		needed := new(big.Int).Mul(collateral.Price, seized)
+		needed.Mul(needed, debtUnit)
+		needed.Quo(needed, new(big.Int).Mul(debt.Price, collateralUnit))
+		debtToCover = percentDiv(needed, bonus)
+	}
+	if debtToCover.Sign() == 0 || seized.Sign() == 0 {
+		return nil
+	}
+
+	// 보너스 부분 = 압류 담보 - 압류 담보 / 보너스, 그중 프로토콜 수수료 비율이 트레저리 몫
+	// Bonus part = seized - seized / bonus, of which the protocol fee share goes to the treasury
+	fee := new(big.Int)
+	if pf := collateral.Config.LiquidationProtocolFee; pf > 0 {
+		bonusPart := new(big.Int).Sub(seized, percentDiv(seized, bonus))
+		fee = percentMul(bonusPart, big.NewInt(int64(pf)))
+	}
+	received := new(big.Int).Sub(seized, fee)
+
+	o := &Opportunity{
+		DebtAsset:             debt.Asset,
+		DebtSymbol:            debt.Symbol,
+		CollateralAsset:       collateral.Asset,
+		CollateralSymbol:      collateral.Symbol,
+		DebtToCover:           debtToCover,
+		DebtToCoverUSD:        baseToUSD(toBase(debtToCover, debt.Price, debtUnit)),
+		CollateralSeized:      seized,
+		CollateralReceived:    received,
+		CollateralReceivedUSD: baseToUSD(toBase(received, collateral.Price, collateralUnit)),
+		ProtocolFeeUSD:        baseToUSD(toBase(fee, collateral.Price, collateralUnit)),
+	}
+	o.GrossProfitUSD = o.CollateralReceivedUSD - o.DebtToCoverUSD
+	return o
+}
+
+// liquidatable은 리저브가 청산에 쓰일 수 있는지 (활성, 일시정지 아님) 확인합니다.
+// liquidatable reports whether a reserve can take part in a liquidation (active and not paused).
+func liquidatable(r contracts.ReservePosition) bool {
+	return r.Config.Active && !r.Config.Paused
+}
+
+// percentMul은 value × bps / 10000을 반올림해 계산합니다 (Aave PercentageMath.percentMul과 같음).
+// percentMul computes value × bps / 10000 rounded half up (same as Aave PercentageMath.percentMul).
+func percentMul(value, bps *big.Int) *big.Int {
+	v := new(big.Int).Mul(value, bps)
+	v.Add(v, big.NewInt(bpsDenominator/2))
+	return v.Quo(v, big.NewInt(bpsDenominator))
+}
+
+// percentDiv는 value × 10000 / bps를 반올림해 계산합니다 (Aave PercentageMath.percentDiv와 같음).
+// percentDiv computes value × 10000 / bps rounded half up (same as Aave PercentageMath.percentDiv).
+func percentDiv(value, bps *big.Int) *big.Int {
+	v := new(big.Int).Mul(value, big.NewInt(bpsDenominator))
+	v.Add(v, new(big.Int).Rsh(bps, 1))
+	return v.Quo(v, bps)
+}
+
+// pow10은 10^decimals입니다.
+// pow10 is 10^decimals.
+func pow10(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// toBase는 자산 금액 × 가격 / 단위를 계산합니다 (기본 통화, 내림).
+// toBase computes amount × price / unit (base currency, truncated).
+func toBase(amount, price, unit *big.Int) *big.Int {
+	v := new(big.Int).Mul(amount, price)
+	return v.Quo(v, unit)
+}
+
+// baseToUSD는 기본 통화 금액 (USD 8 소수점)을 달러로 변환합니다.
+// baseToUSD converts a base currency amount (USD with 8 decimals) to dollars.
+func baseToUSD(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(baseUnit)).Float64()
+	return f
+}
+
+// wadToFloat는 wad 값을 float64로 변환합니다.
+// wadToFloat converts a wad value to float64.
+func wadToFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(wad)).Float64()
+	return f
+}
@@ -0,0 +1,208 @@
+package liquidation
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// reserve는 테스트용 리저브 포지션을 만듭니다. 금액은 자산 단위 (소수점 적용 전) 입니다.
+// reserve builds a reserve position for tests. Amounts are in whole asset units (before decimals).
+type reserve struct {
+	symbol      string
+	decimals    uint8
+	price       float64 // USD
+	lt, bonus   uint16
+	protocolFee uint16
+	collateral  float64
+	debt        float64
+	paused      bool
+}
+
+func (r reserve) position() contracts.ReservePosition {
+	amount := func(v float64) *big.Int {
+		n, _ := new(big.Float).Mul(big.NewFloat(v), new(big.Float).SetInt(pow10(r.decimals))).Int(nil)
+		return n
+	}
+	price, _ := new(big.Float).Mul(big.NewFloat(r.price), big.NewFloat(1e8)).Int(nil)
+	return contracts.ReservePosition{
+		Reserve: contracts.Reserve{
+			Asset:                common.BytesToAddress([]byte(r.symbol)),
+			Symbol:               r.symbol,
+			Decimals:             r.decimals,
+			LiquidationThreshold: big.NewInt(int64(r.lt)),
+			LiquidationBonus:     big.NewInt(int64(r.bonus)),
+			Config: contracts.ReserveConfigurationMap{
+				LiquidationThreshold:   r.lt,
+				LiquidationBonus:       r.bonus,
+				Decimals:               r.decimals,
+				Active:                 true,
+				Paused:                 r.paused,
+				LiquidationProtocolFee: r.protocolFee,
+			},
+		},
+		Collateral:       amount(r.collateral),
+		Debt:             amount(r.debt),
+		UsedAsCollateral: r.collateral > 0,
+		Price:            price,
+	}
+}
+
+func position(reserves ...reserve) *contracts.UserPosition {
+	pos := &contracts.UserPosition{User: common.HexToAddress("0xa11ce")}
+	for _, r := range reserves {
+		pos.Reserves = append(pos.Reserves, r.position())
+	}
+	return pos
+}
+
+func weth(collateral float64) reserve {
+	return reserve{symbol: "WETH", decimals: 18, price: 2000, lt: 8250, bonus: 10500, protocolFee: 1000, collateral: collateral}
+}
+
+func usdc(debt float64) reserve {
+	return reserve{symbol: "USDC", decimals: 6, price: 1, lt: 8600, bonus: 10450, debt: debt}
+}
+
+func approx(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-4 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+// 20 gwei × 400,000 가스 × $2,000 = $16
+// 20 gwei × 400,000 gas × $2,000 = $16
+var testGas = Gas{Price: big.NewInt(20e9), NativePrice: big.NewInt(2000e8)}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		pos         *contracts.UserPosition
+		closeFactor uint16
+		debtToCover float64 // USD
+		received    float64 // USD
+		protocolFee float64 // USD
+		gross       float64
+	}{
+		{
+			// HF = 20,000 × 0.825 / 17,000 = 0.9706 > 0.95 → 50%, 8,500 상환, 4.4625 ETH 압류, 수수료 0.02125 ETH
+			// HF = 20,000 × 0.825 / 17,000 = 0.9706 > 0.95 → 50%, 8,500 repaid, 4.4625 ETH seized, 0.02125 ETH fee
+			name:        "half close",
+			pos:         position(weth(10), usdc(17_000)),
+			closeFactor: 5000,
+			debtToCover: 8_500,
+			received:    8_882.5,
+			protocolFee: 42.5,
+			gross:       382.5,
+		},
+		{
+			// HF = 0.8684 ≤ 0.95 → 100%, 19,000 상환, 9.975 ETH 압류
+			// HF = 0.8684 ≤ 0.95 → 100%, 19,000 repaid, 9.975 ETH seized
+			name:        "full close",
+			pos:         position(weth(10), usdc(19_000)),
+			closeFactor: 10000,
+			debtToCover: 19_000,
+			received:    19_855,
+			protocolFee: 95,
+			gross:       855,
+		},
+		{
+			// 담보 10 ETH 전액 압류, 상환액은 20,000 / 1.05로 역산
+			// All 10 ETH seized, repaid amount derived back as 20,000 / 1.05
+			name:        "collateral short",
+			pos:         position(weth(10), usdc(20_000)),
+			closeFactor: 10000,
+			debtToCover: 19_047.619048,
+			received:    20_000 - (20_000-20_000/1.05)*0.1,
+			protocolFee: (20_000 - 20_000/1.05) * 0.1,
+			gross:       (20_000 - 20_000/1.05) * 0.9,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o := Evaluate(tc.pos, testGas, DefaultOptions())
+			if o == nil {
+				t.Fatal("expected an opportunity")
+			}
+			if o.CloseFactor != tc.closeFactor {
+				t.Errorf("CloseFactor = %d, want %d", o.CloseFactor, tc.closeFactor)
+			}
+			if o.DebtSymbol != "USDC" || o.CollateralSymbol != "WETH" {
+				t.Errorf("pair = %s/%s, want USDC/WETH", o.DebtSymbol, o.CollateralSymbol)
+			}
+			approx(t, "DebtToCoverUSD", o.DebtToCoverUSD, tc.debtToCover)
+			approx(t, "CollateralReceivedUSD", o.CollateralReceivedUSD, tc.received)
+			approx(t, "ProtocolFeeUSD", o.ProtocolFeeUSD, tc.protocolFee)
+			approx(t, "GrossProfitUSD", o.GrossProfitUSD, tc.gross)
+			approx(t, "GasUSD", o.GasUSD, 16)
+			approx(t, "NetProfitUSD", o.NetProfitUSD, tc.gross-16)
+		})
+	}
+}
+
+func TestEvaluateBestPair(t *testing.T) {
+	// HF = (10,000 × 0.825 + 10,000 × 0.7) / 16,000 = 0.953 → 50%, 8,000 상환
+	// WETH 보너스 5% → 총이익 400, WBTC 보너스 10% → 총이익 800
+	// HF = (10,000 × 0.825 + 10,000 × 0.7) / 16,000 = 0.953 → 50%, 8,000 repaid
+	// WETH bonus 5% → gross 400, WBTC bonus 10% → gross 800
+	eth := weth(5)
+	eth.protocolFee = 0
+	wbtc := reserve{symbol: "WBTC", decimals: 8, price: 40_000, lt: 7000, bonus: 11000, collateral: 0.25}
+	pos := position(eth, wbtc, usdc(16_000))
+
+	o := Evaluate(pos, Gas{}, DefaultOptions())
+	if o == nil || o.CollateralSymbol != "WBTC" {
+		t.Fatalf("opportunity = %+v, want WBTC collateral", o)
+	}
+	approx(t, "GrossProfitUSD", o.GrossProfitUSD, 800)
+	if o.CollateralSeized.Cmp(big.NewInt(0.22e8)) != 0 {
+		t.Errorf("CollateralSeized = %s, want 0.22 WBTC", o.CollateralSeized)
+	}
+
+	// 일시정지된 리저브는 청산에 쓸 수 없음
+	// A paused reserve cannot be liquidated
+	wbtc.paused = true
+	o = Evaluate(position(eth, wbtc, usdc(16_000)), Gas{}, DefaultOptions())
+	if o == nil || o.CollateralSymbol != "WETH" {
+		t.Fatalf("opportunity = %+v, want WETH collateral", o)
+	}
+	approx(t, "GrossProfitUSD", o.GrossProfitUSD, 400)
+
+	// 슬리피지 1%는 받은 담보 가치에서 빠짐
+	// 1% slippage comes out of the received collateral value
+	opts := DefaultOptions()
+	opts.Slippage = 0.01
+	o = Evaluate(position(eth, wbtc, usdc(16_000)), Gas{}, opts)
+	approx(t, "NetProfitUSD", o.NetProfitUSD, 400-84)
+}
+
+func TestEvaluateHealthy(t *testing.T) {
+	if o := Evaluate(position(weth(10), usdc(10_000)), testGas, DefaultOptions()); o != nil {
+		t.Errorf("healthy position returned %+v", o)
+	}
+	if o := Evaluate(position(weth(10)), testGas, DefaultOptions()); o != nil {
+		t.Errorf("position without debt returned %+v", o)
+	}
+}
+
+func TestRank(t *testing.T) {
+	ops := []*Opportunity{
+		{NetProfitUSD: 10, DebtToCoverUSD: 1},
+		{NetProfitUSD: 50},
+		{NetProfitUSD: 10, DebtToCoverUSD: 5},
+		{NetProfitUSD: -3},
+	}
+	Rank(ops)
+	got := []float64{ops[0].NetProfitUSD, ops[1].NetProfitUSD, ops[1].DebtToCoverUSD, ops[3].NetProfitUSD}
+	want := []float64{50, 10, 5, -3}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("ranked = %v, want %v", got, want)
+		}
+	}
+}
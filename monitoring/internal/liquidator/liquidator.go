@@ -0,0 +1,303 @@
+// Package liquidator는 스터디 LendingPool에 청산 트랜잭션을 만들어 서명하고 보냅니다.
+// Package liquidator builds, signs and submits liquidation transactions to the study LendingPool.
+//
+// 온체인 상태(헬스팩터, 부채/담보 토큰 잔고, 오라클 가격, CLOSE_FACTOR, LIQUIDATION_BONUS)로
+// 컨트랙트와 같은 식으로 상환액과 압류량을 계산한 뒤, 필요하면 approve를 먼저 보내고 liquidate를 보냅니다.
+// 영수증의 LiquidationCall 이벤트로 실제 결과를 확인합니다.
+// Repay and seize amounts are computed from on-chain state (health factor, debt/collateral token
+// balances, oracle prices, CLOSE_FACTOR, LIQUIDATION_BONUS) with the same formula as the contract,
+// then approve is sent first if needed, followed by liquidate.
+// The LiquidationCall event in the receipt confirms the actual outcome.
+//
+// DevOps 관점:
+// - 메인넷에 보내기 전에 시뮬레이션 백엔드(internal/dryrun)에서 같은 코드를 검증합니다
+// - 되돌려진 트랜잭션은 오류로 보고하고 가스 사용량을 남깁니다
+//
+// DevOps perspective:
+// - The same code is validated on a simulated backend (internal/dryrun) before it touches mainnet
+// - Reverted transactions are reported as errors along with their gas usage
+package liquidator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// wad는 스터디 풀의 정밀도입니다 (1e18 = 1.0).
+// wad is the study pool's precision (1e18 = 1.0).
+var wad = big.NewInt(1e18)
+
+var (
+	// ErrHealthy는 헬스팩터가 1 이상이라 청산할 수 없을 때 반환됩니다.
+	// ErrHealthy is returned when the health factor is at least 1 and the position cannot be liquidated.
+	ErrHealthy = errors.New("헬스팩터가 1 이상 / health factor is not below 1")
+
+	// ErrInactiveReserve는 부채나 담보 리저브가 활성화되지 않았을 때 반환됩니다.
+	// ErrInactiveReserve is returned when the debt or collateral reserve is not active.
+	ErrInactiveReserve = errors.New("비활성 리저브 / reserve is not active")
+
+	// ErrNothingToLiquidate는 상환할 부채나 압류할 담보가 없을 때 반환됩니다.
+	// ErrNothingToLiquidate is returned when there is no debt to repay or no collateral to seize.
+	ErrNothingToLiquidate = errors.New("청산할 금액 없음 / nothing to liquidate")
+
+	// ErrReverted는 트랜잭션이 블록에 포함됐지만 실패했을 때 반환됩니다.
+	// ErrReverted is returned when a transaction was mined but failed.
+	ErrReverted = errors.New("트랜잭션 실패 / transaction reverted")
+)
+
+// Backend는 호출, 전송, 영수증 조회를 모두 지원하는 클라이언트입니다
+// (*ethclient.Client와 simulated.Client가 구현).
+// Backend is a client supporting calls, sends and receipt lookups
+// (implemented by *ethclient.Client and simulated.Client).
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Plan은 한 번의 liquidate 호출 계획입니다. 금액은 토큰 최소 단위입니다.
+// Plan is a single liquidate call. Amounts are in token base units.
+type Plan struct {
+	Borrower        common.Address
+	DebtAsset       common.Address
+	CollateralAsset common.Address
+
+	// HealthFactor는 계획 시점의 헬스팩터입니다 (1e18 = 1.0).
+	// HealthFactor is the health factor when the plan was made (1e18 = 1.0).
+	HealthFactor *big.Int
+
+	// Debt와 Collateral은 차입자의 부채 토큰과 담보 LToken 잔고입니다.
+	// Debt and Collateral are the borrower's debt token and collateral LToken balances.
+	Debt       *big.Int
+	Collateral *big.Int
+
+	DebtToCover       *big.Int
+	CollateralToSeize *big.Int
+}
+
+// Result는 실행된 청산의 결과입니다.
+// Result is the outcome of an executed liquidation.
+type Result struct {
+	TxHash  common.Hash
+	GasUsed uint64
+	Event   *contracts.LendingPoolLiquidation
+}
+
+// Liquidator는 하나의 키로 스터디 LendingPool 청산을 실행합니다.
+// Liquidator executes study LendingPool liquidations with a single key.
+type Liquidator struct {
+	backend Backend
+	pool    *contracts.LendingPool
+	auth    *bind.TransactOpts
+	logger  *slog.Logger
+}
+
+// New는 새로운 Liquidator를 생성합니다. chainID는 EIP-155 서명에 사용됩니다.
+// New creates a new Liquidator. chainID is used for EIP-155 signing.
+func New(backend Backend, pool common.Address, key *ecdsa.PrivateKey, chainID *big.Int, logger *slog.Logger) (*Liquidator, error) {
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("서명자 생성 실패 / failed to create signer: %w", err)
+	}
+	return &Liquidator{
+		backend: backend,
+		pool:    contracts.NewLendingPool(backend, pool),
+		auth:    auth,
+		logger:  logger,
+	}, nil
+}
+
+// Address는 청산자 계정 주소를 반환합니다.
+// Address returns the liquidator account address.
+func (l *Liquidator) Address() common.Address {
+	return l.auth.From
+}
+
+// Plan은 현재 온체인 상태로 borrower의 debtAsset 부채와 collateralAsset 담보에 대한 청산 계획을 세웁니다.
+// Plan builds a liquidation plan for borrower's debtAsset debt and collateralAsset collateral from current on-chain state.
+func (l *Liquidator) Plan(ctx context.Context, borrower, debtAsset, collateralAsset common.Address) (*Plan, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	hf, err := l.pool.GetHealthFactor(opts, borrower)
+	if err != nil {
+		return nil, err
+	}
+	if hf.Cmp(wad) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHealthy, hf)
+	}
+
+	debtReserve, err := l.pool.GetReserve(opts, debtAsset)
+	if err != nil {
+		return nil, err
+	}
+	collReserve, err := l.pool.GetReserve(opts, collateralAsset)
+	if err != nil {
+		return nil, err
+	}
+	if !debtReserve.IsActive || !collReserve.IsActive {
+		return nil, ErrInactiveReserve
+	}
+
+	debt, err := contracts.NewERC20Caller(l.backend, debtReserve.DebtToken).BalanceOf(opts, borrower)
+	if err != nil {
+		return nil, err
+	}
+	collateral, err := contracts.NewERC20Caller(l.backend, collReserve.LToken).BalanceOf(opts, borrower)
+	if err != nil {
+		return nil, err
+	}
+
+	closeFactor, err := l.pool.CloseFactor(opts)
+	if err != nil {
+		return nil, err
+	}
+	bonus, err := l.pool.LiquidationBonus(opts)
+	if err != nil {
+		return nil, err
+	}
+	oracleAddr, err := l.pool.Oracle(opts)
+	if err != nil {
+		return nil, err
+	}
+	oracle := contracts.NewStudyOracleCaller(l.backend, oracleAddr)
+	debtPrice, err := oracle.GetAssetPrice(opts, debtAsset)
+	if err != nil {
+		return nil, err
+	}
+	collPrice, err := oracle.GetAssetPrice(opts, collateralAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	toCover, seized := Size(debt, collateral, debtPrice, collPrice, closeFactor, bonus)
+	if toCover.Sign() == 0 || seized.Sign() == 0 {
+		return nil, ErrNothingToLiquidate
+	}
+	return &Plan{
+		Borrower:          borrower,
+		DebtAsset:         debtAsset,
+		CollateralAsset:   collateralAsset,
+		HealthFactor:      hf,
+		Debt:              debt,
+		Collateral:        collateral,
+		DebtToCover:       toCover,
+		CollateralToSeize: seized,
+	}, nil
+}
+
+// Size는 컨트랙트와 같은 식으로 상환액과 압류량을 계산합니다.
+// 상환액은 부채 × closeFactor까지이며, 압류량이 담보를 넘으면 담보에 맞춰 상환액을 줄입니다.
+// Size computes the repay and seize amounts with the same formula as the contract.
+// The repay amount is capped at debt × closeFactor and shrunk to fit when the seize amount would exceed the collateral.
+//
+//	seized = debtToCover × debtPrice × (1e18 + bonus) / (collateralPrice × 1e18)
+func Size(debt, collateral, debtPrice, collateralPrice, closeFactor, bonus *big.Int) (debtToCover, seized *big.Int) {
+	premium := new(big.Int).Add(wad, bonus)
+	seize := func(amount *big.Int) *big.Int {
+		n := new(big.Int).Mul(amount, debtPrice)
+		n.Mul(n, premium)
+		return n.Div(n, new(big.Int).Mul(collateralPrice, wad))
+	}
+
+	debtToCover = new(big.Int).Mul(debt, closeFactor)
+	debtToCover.Div(debtToCover, wad)
+	seized = seize(debtToCover)
+	if seized.Cmp(collateral) > 0 {
+		// 내림으로 역산하므로 다시 계산한 압류량은 담보를 넘지 않음
+		// Deriving back with floor division keeps the recomputed seize amount within the collateral
+		debtToCover = new(big.Int).Mul(collateral, collateralPrice)
+		debtToCover.Mul(debtToCover, wad)
+		debtToCover.Div(debtToCover, new(big.Int).Mul(debtPrice, premium))
+		seized = seize(debtToCover)
+	}
+	return debtToCover, seized
+}
+
+// Execute는 필요하면 debtAsset approve를 먼저 보내고 liquidate 트랜잭션을 보낸 뒤 영수증을 기다립니다.
+// Execute sends a debtAsset approve first if needed, then the liquidate transaction, and waits for its receipt.
+func (l *Liquidator) Execute(ctx context.Context, plan *Plan) (*Result, error) {
+	if err := l.ensureAllowance(ctx, plan.DebtAsset, plan.DebtToCover); err != nil {
+		return nil, err
+	}
+
+	tx, err := l.pool.Liquidate(l.transactOpts(ctx), plan.Borrower, plan.DebtAsset, plan.CollateralAsset, plan.DebtToCover)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := l.wait(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{TxHash: tx.Hash(), GasUsed: receipt.GasUsed}
+	for _, vLog := range receipt.Logs {
+		ev, ok, err := l.pool.ParseLiquidationCall(*vLog)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res.Event = ev
+			break
+		}
+	}
+	if res.Event == nil {
+		return nil, fmt.Errorf("LiquidationCall 이벤트 없음 / no LiquidationCall event in %s", tx.Hash().Hex())
+	}
+
+	l.logger.Info("청산 실행 / Liquidation executed",
+		"tx", tx.Hash().Hex(),
+		"borrower", plan.Borrower.Hex(),
+		"debt_covered", res.Event.DebtCovered,
+		"collateral_seized", res.Event.CollateralSeized,
+		"gas_used", receipt.GasUsed,
+	)
+	return res, nil
+}
+
+// ensureAllowance는 풀의 허용량이 amount보다 적으면 amount만큼 approve하고 포함될 때까지 기다립니다.
+// ensureAllowance approves amount when the pool's allowance is below it and waits for inclusion.
+func (l *Liquidator) ensureAllowance(ctx context.Context, token common.Address, amount *big.Int) error {
+	allowance, err := contracts.NewERC20Caller(l.backend, token).Allowance(&bind.CallOpts{Context: ctx}, l.auth.From, l.pool.Address())
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+	tx, err := contracts.NewERC20Transactor(l.backend, token).Approve(l.transactOpts(ctx), l.pool.Address(), amount)
+	if err != nil {
+		return err
+	}
+	_, err = l.wait(ctx, tx)
+	return err
+}
+
+// wait는 트랜잭션 영수증을 기다리고 실패한 트랜잭션을 ErrReverted로 바꿉니다.
+// wait waits for a transaction receipt and turns a failed transaction into ErrReverted.
+func (l *Liquidator) wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, l.backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("영수증 대기 실패 / failed to wait for receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: %s (gas used %d)", ErrReverted, tx.Hash().Hex(), receipt.GasUsed)
+	}
+	return receipt, nil
+}
+
+// transactOpts는 ctx를 담은 서명 옵션 복사본을 반환합니다.
+// transactOpts returns a copy of the signing options carrying ctx.
+func (l *Liquidator) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *l.auth
+	opts.Context = ctx
+	return &opts
+}
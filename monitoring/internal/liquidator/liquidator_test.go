@@ -0,0 +1,428 @@
+package liquidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func amount(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic(s)
+	}
+	return v
+}
+
+func TestSize(t *testing.T) {
+	closeFactor, bonus := amount("500000000000000000"), amount("50000000000000000")
+	tests := []struct {
+		name                 string
+		debt, collateral     string
+		debtPrice, collPrice string
+		cover, seize         string
+	}{
+		{
+			// Liquidation.t.sol: 15,000 USDC 부채의 절반 7,500 상환 → 7,500 × 1.05 / 1,500 = 5.25 WETH
+			// Liquidation.t.sol: half of 15,000 USDC debt, 7,500 repaid → 7,500 × 1.05 / 1,500 = 5.25 WETH
+			name:       "close factor",
+			debt:       "15000000000000000000000",
+			collateral: "10000000000000000000",
+			debtPrice:  "1000000000000000000",
+			collPrice:  "1500000000000000000000",
+			cover:      "7500000000000000000000",
+			seize:      "5250000000000000000",
+		},
+		{
+			// 담보 2 WETH × $1,000 = $2,000 → 상환액은 2,000 / 1.05로 역산 (내림), 압류량은 담보 이하
+			// 2 WETH × $1,000 = $2,000 of collateral → repay derived back as 2,000 / 1.05 (floored), seize stays within collateral
+			name:       "collateral short",
+			debt:       "15000000000000000000000",
+			collateral: "2000000000000000000",
+			debtPrice:  "1000000000000000000",
+			collPrice:  "1000000000000000000000",
+			cover:      "1904761904761904761904",
+			seize:      "1999999999999999999",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cover, seize := Size(amount(tc.debt), amount(tc.collateral), amount(tc.debtPrice), amount(tc.collPrice), closeFactor, bonus)
+			if cover.Cmp(amount(tc.cover)) != 0 || seize.Cmp(amount(tc.seize)) != 0 {
+				t.Errorf("Size = %s / %s, want %s / %s", cover, seize, tc.cover, tc.seize)
+			}
+		})
+	}
+}
+
+// studyABI는 가짜 체인이 호출을 디코딩하고 응답을 인코딩할 스터디 LendingPool, PriceOracle, ERC20 ABI입니다.
+// studyABI is the study LendingPool, PriceOracle and ERC20 ABI the fake chain uses to decode calls and encode responses.
+var studyABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"getHealthFactor","stateMutability":"view",
+		 "inputs":[{"name":"user","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"reserves","stateMutability":"view","inputs":[{"name":"","type":"address"}],
+		 "outputs":[{"name":"lToken","type":"address"},{"name":"debtToken","type":"address"},
+		            {"name":"collateralFactor","type":"uint256"},{"name":"liquidationThreshold","type":"uint256"},
+		            {"name":"totalDeposits","type":"uint256"},{"name":"totalBorrows","type":"uint256"},
+		            {"name":"totalReserves","type":"uint256"},{"name":"borrowIndex","type":"uint256"},
+		            {"name":"lastUpdateTime","type":"uint256"},{"name":"id","type":"uint16"},{"name":"isActive","type":"bool"}]},
+		{"type":"function","name":"oracle","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]},
+		{"type":"function","name":"CLOSE_FACTOR","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"LIQUIDATION_BONUS","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"liquidate","stateMutability":"nonpayable",
+		 "inputs":[{"name":"borrower","type":"address"},{"name":"debtAsset","type":"address"},
+		           {"name":"collateralAsset","type":"address"},{"name":"debtToCover","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"getAssetPrice","stateMutability":"view",
+		 "inputs":[{"name":"asset","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"balanceOf","stateMutability":"view",
+		 "inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"allowance","stateMutability":"view",
+		 "inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"approve","stateMutability":"nonpayable",
+		 "inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"event","name":"LiquidationCall","anonymous":false,
+		 "inputs":[{"name":"liquidator","type":"address","indexed":true},{"name":"borrower","type":"address","indexed":true},
+		           {"name":"debtAsset","type":"address","indexed":true},{"name":"collateralAsset","type":"address","indexed":false},
+		           {"name":"debtCovered","type":"uint256","indexed":false},{"name":"collateralSeized","type":"uint256","indexed":false}]}
+	]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var (
+	chainID    = big.NewInt(1337)
+	poolAddr   = common.HexToAddress("0x9001")
+	oracleAddr = common.HexToAddress("0x9002")
+	usdc       = common.HexToAddress("0x05dc")
+	weth       = common.HexToAddress("0x0e7e")
+	borrower   = common.HexToAddress("0xa11ce")
+)
+
+// fakeReserve는 스터디 풀의 리저브 하나입니다.
+// fakeReserve is one reserve of the study pool.
+type fakeReserve struct {
+	lToken, debtToken common.Address
+	active            bool
+}
+
+// fakeChain은 스터디 LendingPool, PriceOracle, ERC20 토큰을 흉내 내는 Backend입니다.
+// 보낸 트랜잭션은 바로 영수증으로 확정되며, liquidate는 허용량을 확인한 뒤 Size로 압류량을 계산해
+// LiquidationCall 로그를 남깁니다.
+// fakeChain is a Backend standing in for the study LendingPool, PriceOracle and ERC20 tokens.
+// Sent transactions get a receipt right away; liquidate checks the allowance, sizes the seize amount with
+// Size and emits a LiquidationCall log.
+type fakeChain struct {
+	mu sync.Mutex
+
+	healthFactor *big.Int
+	reserves     map[common.Address]fakeReserve
+	prices       map[common.Address]*big.Int
+	balances     map[common.Address]map[common.Address]*big.Int // token → holder → balance
+	allowances   map[common.Address]*big.Int                    // token → liquidator's allowance for the pool
+	revert       bool
+
+	nonce    uint64
+	sent     []string
+	receipts map[common.Hash]*types.Receipt
+}
+
+// newFakeChain은 Liquidation.t.sol과 같은 상태를 만듭니다: ETH가 $1,500로 떨어져
+// 10 WETH 담보와 15,000 USDC 부채의 헬스팩터가 0.8입니다.
+// newFakeChain sets up the same state as Liquidation.t.sol: ETH dropped to $1,500, leaving
+// 10 WETH of collateral against 15,000 USDC of debt at health factor 0.8.
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		healthFactor: amount("800000000000000000"),
+		reserves: map[common.Address]fakeReserve{
+			usdc: {lToken: common.HexToAddress("0x1dc"), debtToken: common.HexToAddress("0xd0dc"), active: true},
+			weth: {lToken: common.HexToAddress("0x1e7e"), debtToken: common.HexToAddress("0xde7e"), active: true},
+		},
+		prices: map[common.Address]*big.Int{
+			usdc: amount("1000000000000000000"),
+			weth: amount("1500000000000000000000"),
+		},
+		balances: map[common.Address]map[common.Address]*big.Int{
+			common.HexToAddress("0xd0dc"): {borrower: amount("15000000000000000000000")},
+			common.HexToAddress("0x1e7e"): {borrower: amount("10000000000000000000")},
+		},
+		allowances: make(map[common.Address]*big.Int),
+		receipts:   make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (f *fakeChain) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeChain) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := studyABI.MethodById(call.Data)
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zero := big.NewInt(0)
+	switch method.Name {
+	case "getHealthFactor":
+		return method.Outputs.Pack(f.healthFactor)
+	case "reserves":
+		r, ok := f.reserves[args[0].(common.Address)]
+		if !ok {
+			return nil, errors.New("execution reverted")
+		}
+		return method.Outputs.Pack(r.lToken, r.debtToken, zero, zero, zero, zero, zero, zero, zero, uint16(0), r.active)
+	case "oracle":
+		return method.Outputs.Pack(oracleAddr)
+	case "CLOSE_FACTOR":
+		return method.Outputs.Pack(amount("500000000000000000"))
+	case "LIQUIDATION_BONUS":
+		return method.Outputs.Pack(amount("50000000000000000"))
+	case "getAssetPrice":
+		return method.Outputs.Pack(f.prices[args[0].(common.Address)])
+	case "balanceOf":
+		if b := f.balances[*call.To][args[0].(common.Address)]; b != nil {
+			return method.Outputs.Pack(b)
+		}
+		return method.Outputs.Pack(zero)
+	case "allowance":
+		if a := f.allowances[*call.To]; a != nil {
+			return method.Outputs.Pack(a)
+		}
+		return method.Outputs.Pack(zero)
+	}
+	return nil, fmt.Errorf("unexpected call %s", method.Name)
+}
+
+func (f *fakeChain) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1e9)}, nil
+}
+
+func (f *fakeChain) PendingCodeAt(context.Context, common.Address) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeChain) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.nonce, nil
+}
+
+func (f *fakeChain) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (f *fakeChain) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (f *fakeChain) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 100_000, nil
+}
+
+func (f *fakeChain) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, errors.New("not supported")
+}
+
+func (f *fakeChain) SubscribeFilterLogs(context.Context, ethereum.FilterQuery, chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+// SendTransaction은 트랜잭션을 바로 실행하고 영수증을 남깁니다.
+// SendTransaction executes the transaction right away and records its receipt.
+func (f *fakeChain) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return err
+	}
+	method, err := studyABI.MethodById(tx.Data())
+	if err != nil {
+		return err
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nonce++
+	f.sent = append(f.sent, method.Name)
+	receipt := &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful, GasUsed: 50_000}
+	switch method.Name {
+	case "approve":
+		if args[0].(common.Address) != poolAddr {
+			return fmt.Errorf("approve for %s, want the pool", args[0].(common.Address).Hex())
+		}
+		f.allowances[*tx.To()] = args[1].(*big.Int)
+	case "liquidate":
+		debtAsset, collateralAsset, toCover := args[1].(common.Address), args[2].(common.Address), args[3].(*big.Int)
+		allowance := f.allowances[debtAsset]
+		if f.revert || allowance == nil || allowance.Cmp(toCover) < 0 {
+			receipt.Status = types.ReceiptStatusFailed
+			break
+		}
+		debt := f.balances[f.reserves[debtAsset].debtToken][borrower]
+		collateral := f.balances[f.reserves[collateralAsset].lToken][borrower]
+		_, seized := Size(toCover, collateral, f.prices[debtAsset], f.prices[collateralAsset], amount("1000000000000000000"), amount("50000000000000000"))
+		debt.Sub(debt, toCover)
+		collateral.Sub(collateral, seized)
+		allowance.Sub(allowance, toCover)
+
+		event := studyABI.Events["LiquidationCall"]
+		data, err := event.Inputs.NonIndexed().Pack(collateralAsset, toCover, seized)
+		if err != nil {
+			return err
+		}
+		receipt.Logs = []*types.Log{
+			// 다른 컨트랙트의 로그는 건너뜀 / A log from another contract is skipped
+			{Address: debtAsset, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))}},
+			{Address: poolAddr, Topics: []common.Hash{event.ID, common.BytesToHash(from.Bytes()),
+				common.BytesToHash(args[0].(common.Address).Bytes()), common.BytesToHash(debtAsset.Bytes())}, Data: data},
+		}
+	default:
+		return fmt.Errorf("unexpected transaction %s", method.Name)
+	}
+	f.receipts[tx.Hash()] = receipt
+	return nil
+}
+
+func (f *fakeChain) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r, ok := f.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeChain) takeSent() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := f.sent
+	f.sent = nil
+	return out
+}
+
+func newLiquidator(t *testing.T, f *fakeChain) *Liquidator {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := New(f, poolAddr, key, chainID, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(f *fakeChain)
+		wantErr      error
+		cover, seize string
+	}{
+		// Liquidation.t.sol과 같은 결과 / Same outcome as Liquidation.t.sol
+		{name: "liquidatable", cover: "7500000000000000000000", seize: "5250000000000000000"},
+		{name: "healthy", setup: func(f *fakeChain) { f.healthFactor = amount("1000000000000000000") }, wantErr: ErrHealthy},
+		{name: "inactive reserve", setup: func(f *fakeChain) {
+			r := f.reserves[weth]
+			r.active = false
+			f.reserves[weth] = r
+		}, wantErr: ErrInactiveReserve},
+		{name: "no collateral", setup: func(f *fakeChain) {
+			f.balances[common.HexToAddress("0x1e7e")][borrower] = big.NewInt(0)
+		}, wantErr: ErrNothingToLiquidate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeChain()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			plan, err := newLiquidator(t, f).Plan(context.Background(), borrower, usdc, weth)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Borrower != borrower || plan.DebtAsset != usdc || plan.CollateralAsset != weth || plan.HealthFactor.Cmp(f.healthFactor) != 0 {
+				t.Errorf("plan = %+v", plan)
+			}
+			if plan.DebtToCover.Cmp(amount(tt.cover)) != 0 || plan.CollateralToSeize.Cmp(amount(tt.seize)) != 0 {
+				t.Errorf("plan = %s / %s, want %s / %s", plan.DebtToCover, plan.CollateralToSeize, tt.cover, tt.seize)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeChain()
+	l := newLiquidator(t, f)
+	plan, err := l.Plan(ctx, borrower, usdc, weth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 허용량이 없으면 approve 후 liquidate / Without an allowance, approve and then liquidate
+	res, err := l.Execute(ctx, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent := f.takeSent(); fmt.Sprint(sent) != "[approve liquidate]" {
+		t.Errorf("sent = %v, want [approve liquidate]", sent)
+	}
+	ev := res.Event
+	if ev == nil || ev.Liquidator != l.Address() || ev.Borrower != borrower || ev.DebtAsset != usdc || ev.CollateralAsset != weth {
+		t.Fatalf("event = %+v", ev)
+	}
+	if ev.DebtCovered.Cmp(plan.DebtToCover) != 0 || ev.CollateralSeized.Cmp(plan.CollateralToSeize) != 0 {
+		t.Errorf("event amounts = %s / %s, want %s / %s", ev.DebtCovered, ev.CollateralSeized, plan.DebtToCover, plan.CollateralToSeize)
+	}
+	if res.GasUsed != 50_000 || res.TxHash == (common.Hash{}) {
+		t.Errorf("result = %+v", res)
+	}
+
+	// 허용량이 충분하면 approve를 건너뜀 / With enough allowance, approve is skipped
+	f.allowances[usdc] = amount("100000000000000000000000")
+	if plan, err = l.Plan(ctx, borrower, usdc, weth); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Execute(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+	if sent := f.takeSent(); fmt.Sprint(sent) != "[liquidate]" {
+		t.Errorf("sent = %v, want [liquidate]", sent)
+	}
+
+	// 실패한 트랜잭션은 ErrReverted / A failed transaction is ErrReverted
+	f.revert = true
+	if _, err := l.Execute(ctx, plan); !errors.Is(err, ErrReverted) {
+		t.Errorf("err = %v, want %v", err, ErrReverted)
+	}
+}
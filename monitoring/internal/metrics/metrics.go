@@ -28,7 +28,76 @@ var (
 			Name:      "health_factor",
 			Help:      "사용자의 헬스팩터 (1.0 미만이면 청산 가능) / User's health factor (< 1.0 = liquidatable)",
 		},
-		[]string{"protocol", "user"},
+		[]string{"protocol", "user", "group"},
+	)
+
+	// HealthFactorTop은 위험도 상위 N개 계정의 헬스팩터입니다. 매 사이클 교체되며 빠진 계정의 시계열은 삭제됩니다.
+	// HealthFactorTop is the health factor of the N riskiest accounts. Recycled every cycle;
+	// series of accounts that drop out are deleted.
+	HealthFactorTop = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "health_factor_top",
+			Help:      "위험도 상위 N개 계정의 헬스팩터 / Health factor of the N riskiest accounts",
+		},
+		[]string{"protocol", "user", "group"},
+	)
+
+	// HealthFactorRangePositions는 헬스팩터 구간별 부채 보유 포지션 수입니다 (예: range="1-1.05").
+	// HealthFactorRangePositions is the number of debt-holding positions per health factor range (e.g. range="1-1.05").
+	HealthFactorRangePositions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "health_factor_range_positions",
+			Help:      "헬스팩터 구간별 포지션 수 / Positions per health factor range",
+		},
+		[]string{"protocol", "range"},
+	)
+
+	// HealthFactorRangeDebtUSD는 헬스팩터 구간별 총 부채 (USD)입니다.
+	// HealthFactorRangeDebtUSD is the total debt (USD) per health factor range.
+	HealthFactorRangeDebtUSD = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "health_factor_range_debt_usd",
+			Help:      "헬스팩터 구간별 총 부채 (USD) / Total debt in USD per health factor range",
+		},
+		[]string{"protocol", "range"},
+	)
+
+	// GroupHealthFactorMin은 감시 그룹 내 최저 헬스팩터입니다 (부채 보유 포지션만).
+	// 그룹 단위로 집계하므로 사용자 수와 무관하게 시계열 수가 일정합니다.
+	// GroupHealthFactorMin is the lowest health factor within a watch group (debt-holding positions only).
+	// Aggregated per group, so the series count does not grow with the number of users.
+	GroupHealthFactorMin = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "group_health_factor_min",
+			Help:      "그룹 내 최저 헬스팩터 / Lowest health factor in the group",
+		},
+		[]string{"protocol", "group"},
+	)
+
+	// GroupPositions는 그룹 내 부채 보유 포지션 수입니다.
+	// GroupPositions is the number of debt-holding positions in a group.
+	GroupPositions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "group_positions",
+			Help:      "그룹 내 부채 보유 포지션 수 / Debt-holding positions in the group",
+		},
+		[]string{"protocol", "group"},
+	)
+
+	// GroupPositionsAtRisk는 그룹 내 경고 임계값 미만 포지션 수입니다.
+	// GroupPositionsAtRisk is the number of positions below the warning threshold in a group.
+	GroupPositionsAtRisk = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "group_positions_at_risk",
+			Help:      "그룹 내 경고 임계값 미만 포지션 수 / Positions below the warning threshold in the group",
+		},
+		[]string{"protocol", "group"},
 	)
 
 	// UtilizationRate는 자산별 사용률을 추적합니다.
@@ -144,4 +213,278 @@ var (
 			Buckets:   prometheus.DefBuckets,
 		},
 	)
+
+	// RPCEndpointUp는 RPC 엔드포인트의 건강 상태입니다 (1 = 정상, 0 = 비정상).
+	// RPCEndpointUp is the health status of an RPC endpoint (1 = healthy, 0 = unhealthy).
+	RPCEndpointUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "rpc_endpoint_up",
+			Help:      "RPC 엔드포인트 건강 상태 (1 = 정상) / RPC endpoint health (1 = healthy)",
+		},
+		[]string{"endpoint"},
+	)
+
+	// RPCEndpointBlockLag는 가장 앞선 엔드포인트 대비 블록 지연입니다.
+	// RPCEndpointBlockLag is the block lag behind the most advanced endpoint.
+	RPCEndpointBlockLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "rpc_endpoint_block_lag",
+			Help:      "최고 블록 대비 지연 블록 수 / Blocks behind the highest known head",
+		},
+		[]string{"endpoint"},
+	)
+
+	// RPCEndpointScore는 엔드포인트 라우팅 점수입니다 (낮을수록 우선).
+	// RPCEndpointScore is the endpoint routing score (lower is preferred).
+	RPCEndpointScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "rpc_endpoint_score",
+			Help:      "엔드포인트 라우팅 점수 (낮을수록 우선) / Endpoint routing score (lower is preferred)",
+		},
+		[]string{"endpoint"},
+	)
+
+	// RPCRequestDuration은 엔드포인트별 RPC 요청 지연 시간입니다.
+	// RPCRequestDuration is the RPC request latency per endpoint.
+	RPCRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "lending",
+			Name:      "rpc_request_duration_seconds",
+			Help:      "RPC 요청 지연 시간 (초) / RPC request latency in seconds",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"endpoint", "method"},
+	)
+
+	// RPCRequestsTotal은 엔드포인트별 RPC 요청 수입니다 (result = ok|error).
+	// RPCRequestsTotal is the number of RPC requests per endpoint (result = ok|error).
+	RPCRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "rpc_requests_total",
+			Help:      "RPC 요청 수 / Total RPC requests",
+		},
+		[]string{"endpoint", "method", "result"},
+	)
+
+	// RPCFailoversTotal은 다른 엔드포인트로 재시도한 횟수입니다.
+	// RPCFailoversTotal is the number of retries routed to another endpoint.
+	RPCFailoversTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "rpc_failovers_total",
+			Help:      "RPC 페일오버 횟수 / Total RPC failovers",
+		},
+		[]string{"method"},
+	)
+
+	// RPCQuorumChecksTotal은 쿼럼 검증 결과 수입니다 (result = agree|mismatch|unavailable).
+	// RPCQuorumChecksTotal counts quorum check outcomes (result = agree|mismatch|unavailable).
+	RPCQuorumChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "rpc_quorum_checks_total",
+			Help:      "쿼럼 검증 결과 수 / Total quorum check outcomes",
+		},
+		[]string{"result"},
+	)
+
+	// RPCBudgetRemainingUnits는 오늘 남은 RPC 예산(컴퓨트 유닛)입니다.
+	// RPCBudgetRemainingUnits is today's remaining RPC budget in compute units.
+	RPCBudgetRemainingUnits = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "rpc_budget_remaining_units",
+			Help:      "오늘 남은 RPC 예산 (컴퓨트 유닛) / Remaining RPC budget today in compute units",
+		},
+	)
+
+	// RPCBudgetSpentUnits는 오늘 사용한 RPC 컴퓨트 유닛입니다.
+	// RPCBudgetSpentUnits is the RPC compute units spent today.
+	RPCBudgetSpentUnits = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "rpc_budget_spent_units",
+			Help:      "오늘 사용한 RPC 컴퓨트 유닛 / RPC compute units spent today",
+		},
+	)
+
+	// RPCRateLimitWaitSeconds는 속도 제한으로 대기한 시간입니다.
+	// RPCRateLimitWaitSeconds is the time spent waiting on the rate limiter.
+	RPCRateLimitWaitSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "lending",
+			Name:      "rpc_rate_limit_wait_seconds",
+			Help:      "속도 제한 대기 시간 (초) / Time spent waiting on the rate limiter in seconds",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	// MonitorIntervalSeconds는 예산 역압이 반영된 실제 모니터링 주기입니다.
+	// MonitorIntervalSeconds is the effective monitoring interval after budget backpressure.
+	MonitorIntervalSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "monitor_interval_seconds",
+			Help:      "실제 모니터링 주기 (초) / Effective monitoring interval in seconds",
+		},
+		[]string{"service"},
+	)
+
+	// MonitorCycleAddresses는 마지막 사이클의 결과별 주소 수입니다 (succeeded|failed|timed_out).
+	// MonitorCycleAddresses is the number of addresses per outcome in the last cycle (succeeded|failed|timed_out).
+	MonitorCycleAddresses = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "monitor_cycle_addresses",
+			Help:      "마지막 사이클의 결과별 주소 수 / Addresses per outcome in the last cycle",
+		},
+		[]string{"result"},
+	)
+
+	// MonitorCyclesSkippedTotal은 이전 사이클이 주기를 넘겨 누락된 사이클 수입니다.
+	// MonitorCyclesSkippedTotal is the number of cycles missed because the previous one overran.
+	MonitorCyclesSkippedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "monitor_cycles_skipped_total",
+			Help:      "주기 초과로 누락된 사이클 수 / Cycles skipped because a cycle overran the interval",
+		},
+	)
+
+	// MonitorBlockNumber는 마지막 사이클이 고정된 블록 번호입니다.
+	// MonitorBlockNumber is the block number the last cycle was pinned to.
+	MonitorBlockNumber = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "monitor_block_number",
+			Help:      "마지막 사이클이 고정된 블록 번호 / Block number the last cycle was pinned to",
+		},
+	)
+
+	// MonitorBlockTimestamp는 마지막 사이클이 고정된 블록의 타임스탬프(유닉스 초)입니다.
+	// MonitorBlockTimestamp is the timestamp (unix seconds) of the block the last cycle was pinned to.
+	MonitorBlockTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "monitor_block_timestamp_seconds",
+			Help:      "마지막 사이클 블록의 타임스탬프 (유닉스 초) / Timestamp of the last cycle's block in unix seconds",
+		},
+	)
+	// ConfigGeneration은 현재 적용된 설정의 세대 번호입니다 (성공한 리로드마다 1 증가).
+	// ConfigGeneration is the generation of the active config (incremented on every successful reload).
+	ConfigGeneration = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "config_generation",
+			Help:      "현재 적용된 설정 세대 / Generation of the active configuration",
+		},
+	)
+
+	// ConfigReloadsTotal은 결과별 설정 리로드 횟수입니다 (success|failure).
+	// ConfigReloadsTotal counts config reloads by result (success|failure).
+	ConfigReloadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "config_reloads_total",
+			Help:      "결과별 설정 리로드 횟수 / Config reloads by result",
+		},
+		[]string{"result"},
+	)
+
+	// ConfigLastReloadSuccess는 마지막 리로드 성공 여부입니다 (1 = 성공, 0 = 실패, 이전 설정 유지).
+	// ConfigLastReloadSuccess reports whether the last reload succeeded (1 = success, 0 = failure, old config kept).
+	ConfigLastReloadSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "config_last_reload_success",
+			Help:      "마지막 설정 리로드 성공 여부 / Whether the last config reload succeeded",
+		},
+	)
+
+	// ConfigLastReloadTimestamp는 마지막 리로드 시도 시각(유닉스 초)입니다.
+	// ConfigLastReloadTimestamp is the time of the last reload attempt (unix seconds).
+	ConfigLastReloadTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "config_last_reload_timestamp_seconds",
+			Help:      "마지막 설정 리로드 시도 시각 (유닉스 초) / Time of the last config reload attempt in unix seconds",
+		},
+	)
+	// DiscoveryAccounts는 이벤트로 발견해 추적 중인 부채 보유 계정 수입니다.
+	// DiscoveryAccounts is the number of debt-holding accounts discovered from events and being tracked.
+	DiscoveryAccounts = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "discovery_accounts",
+			Help:      "이벤트로 발견한 부채 보유 계정 수 / Debt-holding accounts discovered from events",
+		},
+	)
+
+	// DiscoveryBlock은 발견 서브시스템이 처리한 마지막 블록입니다.
+	// DiscoveryBlock is the last block processed by the discovery subsystem.
+	DiscoveryBlock = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "discovery_block_number",
+			Help:      "발견 서브시스템이 처리한 마지막 블록 / Last block processed by discovery",
+		},
+	)
+
+	// DiscoveryDroppedTotal은 부채가 0이 되어 추적에서 제외된 계정 수입니다.
+	// DiscoveryDroppedTotal counts accounts dropped from tracking because their debt went to zero.
+	DiscoveryDroppedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "lending",
+			Name:      "discovery_dropped_total",
+			Help:      "부채가 0이 되어 제외된 계정 수 / Accounts dropped because their debt went to zero",
+		},
+	)
+
+	// DebtAtRiskUSD는 담보 자산 가격 충격 시 헬스팩터가 1 미만으로 떨어지는 부채 (USD)입니다.
+	// DebtAtRiskUSD is the debt (USD) that falls below health factor 1 under a collateral asset price shock.
+	DebtAtRiskUSD = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "debt_at_risk_usd",
+			Help:      "가격 충격 시 청산 가능해지는 부채 (USD) / Debt in USD that becomes liquidatable under a price shock",
+		},
+		[]string{"protocol", "asset", "shock"},
+	)
+
+	// RiskAccountsAnalyzed는 마지막 충격 분석에 포함된 계정 수입니다.
+	// RiskAccountsAnalyzed is the number of accounts included in the last shock analysis.
+	RiskAccountsAnalyzed = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "risk_accounts_analyzed",
+			Help:      "마지막 충격 분석의 계정 수 / Accounts in the last shock analysis",
+		},
+	)
+
+	// LiquidationPriceUSD는 고정 계정의 담보 자산별 청산 가격입니다 (다른 자산 가격은 고정).
+	// LiquidationPriceUSD is a pinned account's liquidation price per collateral asset (other prices held fixed).
+	LiquidationPriceUSD = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "liquidation_price_usd",
+			Help:      "헬스팩터가 1.0이 되는 담보 자산 가격 (USD) / Collateral asset price in USD at which the health factor reaches 1.0",
+		},
+		[]string{"protocol", "user", "group", "asset"},
+	)
+
+	// LiquidationPriceDistance는 현재 오라클 가격에서 청산 가격까지의 거리입니다 (%, 음수 = 하락 필요).
+	// LiquidationPriceDistance is the distance from the current oracle price to the liquidation price (%, negative = drop needed).
+	LiquidationPriceDistance = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "lending",
+			Name:      "liquidation_price_distance_percent",
+			Help:      "현재 가격에서 청산 가격까지의 거리 (%) / Distance from the current price to the liquidation price in percent",
+		},
+		[]string{"protocol", "user", "group", "asset"},
+	)
 )
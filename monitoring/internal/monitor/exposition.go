@@ -0,0 +1,216 @@
+package monitor
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// publish는 사이클 결과로 헬스팩터 메트릭을 갱신하고 더 이상 유효하지 않은 시계열을 삭제합니다.
+// publish updates health factor metrics from the cycle results and deletes series that are no longer valid.
+//
+//   - 사용자별: Exposition에 해당하는 대상만 / per user: only targets selected by Exposition
+//   - 상위 N개: 헬스팩터가 가장 낮은 부채 보유 계정 / top N: debt-holding accounts with the lowest health factor
+//   - 구간별: 헬스팩터 구간별 포지션 수와 부채 (USD) / per range: positions and debt (USD) per health factor range
+//   - 그룹별: 최저 헬스팩터, 포지션 수, 위험 포지션 수 / per group: min health factor, positions, positions at risk
+//   - 청산 가격: 고정 계정의 담보 자산별 / liquidation prices: per collateral asset of pinned accounts
+func (m *Monitor) publish(targets []Target, checked []checkResult) {
+	var positions []checkResult
+	for _, r := range checked {
+		if r.outcome == OutcomeSucceeded && r.hasDebt {
+			positions = append(positions, r)
+		}
+	}
+
+	m.publishPerUser(targets, checked)
+	m.publishTop(positions)
+	m.publishRanges(positions)
+	m.publishGroups(targets, positions)
+	m.publishLiquidation(targets, checked)
+}
+
+// publishLiquidation은 고정 계정의 청산 가격과 거리를 갱신합니다.
+// 이번 사이클에 실패한 계정은 이전 값을 유지하고, 빠진 계정이나 더 이상 담보가 아닌 자산의 시계열은 삭제합니다.
+// publishLiquidation updates liquidation prices and distances of pinned accounts. Accounts that failed this
+// cycle keep their previous values; series of removed accounts or assets no longer used as collateral are deleted.
+func (m *Monitor) publishLiquidation(targets []Target, checked []checkResult) {
+	pinned := make(map[seriesKey]bool)
+	for _, t := range targets {
+		if t.Pinned {
+			pinned[seriesKey{t.Address.Hex(), t.Group}] = true
+		}
+	}
+	keep := make(map[liquidationKey]bool)
+	for key := range m.liq {
+		if pinned[key.seriesKey] {
+			keep[key] = true
+		}
+	}
+	for _, r := range checked {
+		if !r.target.Pinned || !r.liquidationRead {
+			continue
+		}
+		sk := seriesKey{r.target.Address.Hex(), r.target.Group}
+		// 새 결과가 있으면 이 계정의 이전 자산 목록을 교체 / A fresh result replaces this account's previous assets
+		for key := range keep {
+			if key.seriesKey == sk {
+				delete(keep, key)
+			}
+		}
+		for _, lp := range r.liquidation {
+			key := liquidationKey{sk, lp.Symbol}
+			keep[key] = true
+			metrics.LiquidationPriceUSD.WithLabelValues(m.opts.Protocol, sk.user, sk.group, lp.Symbol).Set(lp.LiquidationPrice)
+			metrics.LiquidationPriceDistance.WithLabelValues(m.opts.Protocol, sk.user, sk.group, lp.Symbol).Set(lp.DistancePct)
+		}
+	}
+	for key := range m.liq {
+		if !keep[key] {
+			metrics.LiquidationPriceUSD.DeleteLabelValues(m.opts.Protocol, key.user, key.group, key.asset)
+			metrics.LiquidationPriceDistance.DeleteLabelValues(m.opts.Protocol, key.user, key.group, key.asset)
+		}
+	}
+	m.liq = keep
+}
+
+// exposed는 대상이 사용자별 시계열을 가져야 하는지 반환합니다.
+// exposed reports whether a target gets a per-user series.
+func (m *Monitor) exposed(t Target) bool {
+	switch m.opts.Exposition {
+	case ExposeAll:
+		return true
+	case ExposePinned:
+		return t.Pinned
+	}
+	return false
+}
+
+// publishPerUser는 사용자별 헬스팩터를 갱신합니다.
+// 이번 사이클에 실패한 대상은 이전 값을 유지하고, 목록에서 빠진 대상의 시계열은 삭제합니다.
+// publishPerUser updates per-user health factors. Targets that failed this cycle keep their
+// previous value; series of targets no longer in the list are deleted.
+func (m *Monitor) publishPerUser(targets []Target, checked []checkResult) {
+	keep := make(map[seriesKey]bool)
+	for _, t := range targets {
+		if m.exposed(t) {
+			keep[seriesKey{t.Address.Hex(), t.Group}] = true
+		}
+	}
+	for _, r := range checked {
+		key := seriesKey{r.target.Address.Hex(), r.target.Group}
+		if r.outcome == OutcomeSucceeded && keep[key] {
+			metrics.HealthFactor.WithLabelValues(m.opts.Protocol, key.user, key.group).Set(r.hf)
+		}
+	}
+	for key := range m.perUser {
+		if !keep[key] {
+			metrics.HealthFactor.DeleteLabelValues(m.opts.Protocol, key.user, key.group)
+		}
+	}
+	m.perUser = keep
+}
+
+// publishTop은 헬스팩터가 가장 낮은 N개 포지션으로 상위 게이지 집합을 교체합니다.
+// publishTop replaces the top gauge set with the N positions that have the lowest health factor.
+func (m *Monitor) publishTop(positions []checkResult) {
+	if m.opts.TopN <= 0 {
+		return
+	}
+	sorted := append([]checkResult(nil), positions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].hf < sorted[j].hf })
+	if len(sorted) > m.opts.TopN {
+		sorted = sorted[:m.opts.TopN]
+	}
+
+	top := make(map[seriesKey]bool, len(sorted))
+	for _, r := range sorted {
+		key := seriesKey{r.target.Address.Hex(), r.target.Group}
+		top[key] = true
+		metrics.HealthFactorTop.WithLabelValues(m.opts.Protocol, key.user, key.group).Set(r.hf)
+	}
+	for key := range m.top {
+		if !top[key] {
+			metrics.HealthFactorTop.DeleteLabelValues(m.opts.Protocol, key.user, key.group)
+		}
+	}
+	m.top = top
+}
+
+// publishRanges는 헬스팩터 구간별 포지션 수와 부채를 갱신합니다. 빈 구간은 0으로 냅니다.
+// publishRanges updates positions and debt per health factor range. Empty ranges are published as 0.
+func (m *Monitor) publishRanges(positions []checkResult) {
+	if len(m.opts.HFBuckets) == 0 {
+		return
+	}
+	labels := rangeLabels(m.opts.HFBuckets)
+	counts := make([]int, len(labels))
+	debt := make([]float64, len(labels))
+	for _, r := range positions {
+		i := sort.SearchFloat64s(m.opts.HFBuckets, r.hf)
+		// 경계값은 위 구간에 속함 (1.0은 "1-1.05") / A boundary belongs to the upper range (1.0 is "1-1.05")
+		if i < len(m.opts.HFBuckets) && r.hf == m.opts.HFBuckets[i] {
+			i++
+		}
+		counts[i]++
+		debt[i] += r.debtUSD
+	}
+	for i, l := range labels {
+		metrics.HealthFactorRangePositions.WithLabelValues(m.opts.Protocol, l).Set(float64(counts[i]))
+		metrics.HealthFactorRangeDebtUSD.WithLabelValues(m.opts.Protocol, l).Set(debt[i])
+	}
+}
+
+// rangeLabels는 경계값으로 구간 라벨을 만듭니다 (예: [1, 1.05] → "<1", "1-1.05", ">=1.05").
+// rangeLabels builds range labels from boundaries (e.g. [1, 1.05] → "<1", "1-1.05", ">=1.05").
+func rangeLabels(bounds []float64) []string {
+	format := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	labels := make([]string, 0, len(bounds)+1)
+	labels = append(labels, "<"+format(bounds[0]))
+	for i := 1; i < len(bounds); i++ {
+		labels = append(labels, format(bounds[i-1])+"-"+format(bounds[i]))
+	}
+	return append(labels, ">="+format(bounds[len(bounds)-1]))
+}
+
+// publishGroups는 그룹별 집계 메트릭을 갱신하고 사라진 그룹의 시계열을 삭제합니다.
+// publishGroups updates per-group aggregate metrics and deletes series of groups that disappeared.
+func (m *Monitor) publishGroups(targets []Target, positions []checkResult) {
+	groups := make(map[string]bool)
+	for _, t := range targets {
+		groups[t.Group] = true
+	}
+
+	minHF := make(map[string]float64)
+	count := make(map[string]int)
+	atRisk := make(map[string]int)
+	warning := m.thresholds.Load().warning
+	for _, r := range positions {
+		g := r.target.Group
+		count[g]++
+		if v, ok := minHF[g]; !ok || r.hf < v {
+			minHF[g] = r.hf
+		}
+		if r.hf < warning {
+			atRisk[g]++
+		}
+	}
+
+	for g := range groups {
+		metrics.GroupPositions.WithLabelValues(m.opts.Protocol, g).Set(float64(count[g]))
+		metrics.GroupPositionsAtRisk.WithLabelValues(m.opts.Protocol, g).Set(float64(atRisk[g]))
+		if v, ok := minHF[g]; ok {
+			metrics.GroupHealthFactorMin.WithLabelValues(m.opts.Protocol, g).Set(v)
+		} else {
+			metrics.GroupHealthFactorMin.DeleteLabelValues(m.opts.Protocol, g)
+		}
+	}
+	for g := range m.groups {
+		if !groups[g] {
+			metrics.GroupPositions.DeleteLabelValues(m.opts.Protocol, g)
+			metrics.GroupPositionsAtRisk.DeleteLabelValues(m.opts.Protocol, g)
+			metrics.GroupHealthFactorMin.DeleteLabelValues(m.opts.Protocol, g)
+		}
+	}
+	m.groups = groups
+}
@@ -0,0 +1,126 @@
+package monitor
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlockRef는 사이클의 모든 호출이 고정되는 블록입니다.
+// BlockRef is the block every call in a cycle is pinned to.
+type BlockRef struct {
+	// Number는 블록 번호입니다.
+	// Number is the block number.
+	Number *big.Int
+
+	// Time은 블록 타임스탬프입니다.
+	// Time is the block timestamp.
+	Time time.Time
+}
+
+// BlockRefFromHeader는 헤더에서 BlockRef를 만듭니다.
+// BlockRefFromHeader builds a BlockRef from a header.
+func BlockRefFromHeader(h *types.Header) *BlockRef {
+	return &BlockRef{
+		Number: new(big.Int).Set(h.Number),
+		Time:   time.Unix(int64(h.Time), 0).UTC(),
+	}
+}
+
+// HeadSource는 새 블록 헤더를 제공하는 클라이언트입니다 (*rpcpool.Pool이 구현).
+// HeadSource is a client that provides new block headers (implemented by *rpcpool.Pool).
+type HeadSource interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// WatchHeads는 새 블록 헤더를 전달하는 채널을 반환합니다.
+// WatchHeads returns a channel delivering new block headers.
+//
+// newHeads 구독을 먼저 시도하고, 실패하면 (HTTP RPC 등) eth_blockNumber 폴링으로 전환합니다.
+// 채널은 최신 헤더 하나만 보관하므로 사이클이 느려도 오래된 헤더가 쌓이지 않습니다.
+// Tries a newHeads subscription first and falls back to eth_blockNumber polling when that
+// fails (e.g. HTTP RPC). The channel keeps only the latest header, so a slow cycle never
+// builds up a backlog of stale heads.
+func WatchHeads(ctx context.Context, src HeadSource, pollInterval time.Duration, logger *slog.Logger) <-chan *types.Header {
+	out := make(chan *types.Header, 1)
+	go func() {
+		defer close(out)
+		err := subscribeHeads(ctx, src, out)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("newHeads 구독 불가, 블록 번호 폴링으로 전환 / newHeads subscription unavailable, falling back to block number polling",
+			"error", err,
+			"poll_interval", pollInterval.String(),
+		)
+		pollHeads(ctx, src, pollInterval, out, logger)
+	}()
+	return out
+}
+
+// subscribeHeads는 구독이 끝날 때까지 헤더를 전달합니다.
+// subscribeHeads forwards headers until the subscription ends.
+func subscribeHeads(ctx context.Context, src HeadSource, out chan *types.Header) error {
+	ch := make(chan *types.Header)
+	sub, err := src.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case h := <-ch:
+			publishLatest(out, h)
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pollHeads는 주기적으로 최신 블록 번호를 확인하고 새 블록이면 헤더를 전달합니다.
+// pollHeads periodically checks the latest block number and forwards the header of new blocks.
+func pollHeads(ctx context.Context, src HeadSource, pollInterval time.Duration, out chan *types.Header, logger *slog.Logger) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last uint64
+	for {
+		n, err := src.BlockNumber(ctx)
+		if err != nil {
+			logger.Warn("블록 번호 조회 실패 / Failed to get block number", "error", err)
+		} else if n > last {
+			h, err := src.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+			if err != nil {
+				logger.Warn("블록 헤더 조회 실패 / Failed to get block header", "block", n, "error", err)
+			} else {
+				last = n
+				publishLatest(out, h)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publishLatest는 보관 중인 이전 헤더를 버리고 최신 헤더를 넣습니다.
+// publishLatest drops any buffered older header and stores the latest one.
+func publishLatest(out chan *types.Header, h *types.Header) {
+	select {
+	case <-out:
+	default:
+	}
+	out <- h
+}
@@ -0,0 +1,652 @@
+// Package monitor는 헬스팩터 모니터링 사이클을 실행합니다.
+// Package monitor runs health factor monitoring cycles.
+//
+// 한 사이클은 제한된 수의 워커가 주소를 병렬로 조회하며,
+// 사이클 전체와 개별 RPC 호출 모두에 데드라인이 걸립니다.
+// A cycle checks addresses in parallel with a bounded number of workers,
+// with deadlines on both the whole cycle and each RPC call.
+//
+// DevOps 관점:
+// - 하나의 느린 RPC 호출이 전체 루프를 멈추지 않도록 격리합니다
+// - 사이클이 주기를 넘기면 건너뛴 사이클로 기록합니다
+//
+// DevOps perspective:
+// - Isolates one hung RPC call so it can't stall the whole loop
+// - Records skipped cycles when a cycle overruns its interval
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/alert"
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+	"github.com/jeongseup/lending-monitor/internal/risk"
+	"github.com/jeongseup/lending-monitor/internal/rpcpool"
+)
+
+// topContributors는 알림에 표시할 담보/부채 상위 자산 수입니다.
+// topContributors is the number of top collateral/debt assets shown in alerts.
+const topContributors = 3
+
+// Options는 모니터 설정입니다.
+// Options configures a Monitor.
+type Options struct {
+	// Protocol은 메트릭 라벨에 사용할 프로토콜 이름입니다.
+	// Protocol is the protocol name used in metric labels.
+	Protocol string
+
+	// Workers는 동시에 조회할 최대 주소 수입니다.
+	// Workers is the maximum number of addresses checked concurrently.
+	Workers int
+
+	// CycleTimeout은 한 사이클 전체의 데드라인입니다.
+	// CycleTimeout is the deadline for a whole cycle.
+	CycleTimeout time.Duration
+
+	// CallTimeout은 개별 RPC 호출의 타임아웃입니다.
+	// CallTimeout is the timeout for an individual RPC call.
+	CallTimeout time.Duration
+
+	// WarningThreshold는 경고 헬스팩터 임계값입니다.
+	// WarningThreshold is the warning health factor threshold.
+	WarningThreshold float64
+
+	// CriticalThreshold는 긴급(청산 가능) 헬스팩터 임계값입니다.
+	// CriticalThreshold is the critical (liquidatable) health factor threshold.
+	CriticalThreshold float64
+
+	// Exposition은 사용자별 헬스팩터 메트릭을 낼 대상입니다.
+	// Exposition selects which targets get a per-user health factor series.
+	Exposition Exposition
+
+	// TopN은 위험도 상위 N개 계정 게이지 수입니다 (0 = 비활성).
+	// TopN is the number of riskiest-account gauges (0 = disabled).
+	TopN int
+
+	// HFBuckets는 포지션/부채 집계용 헬스팩터 구간 경계입니다 (오름차순).
+	// HFBuckets are the health factor range boundaries for position/debt counts (ascending).
+	HFBuckets []float64
+}
+
+// Exposition은 사용자별 헬스팩터 메트릭 노출 방식입니다.
+// Exposition is how per-user health factor metrics are exposed.
+//
+// 발견된 계정이 수만 개가 되면 user 라벨이 Prometheus 카디널리티를 폭증시키므로,
+// 기본값은 명시적으로 지정한 주소만 사용자별로 노출하고 나머지는 상위 N개와 구간 집계로 봅니다.
+// With tens of thousands of discovered accounts a user label explodes Prometheus cardinality,
+// so by default only explicitly pinned addresses get per-user series; the rest are covered by
+// the top-N gauges and range counts.
+type Exposition string
+
+const (
+	// ExposeAll은 모든 대상을 사용자별로 노출합니다 (소규모 감시 목록용).
+	// ExposeAll exposes every target per user (for small watch lists).
+	ExposeAll Exposition = "all"
+
+	// ExposePinned는 고정 주소만 사용자별로 노출합니다.
+	// ExposePinned exposes only pinned addresses per user.
+	ExposePinned Exposition = "pinned"
+
+	// ExposeNone은 사용자별 메트릭을 내지 않습니다.
+	// ExposeNone exposes no per-user series.
+	ExposeNone Exposition = "none"
+)
+
+// ParseExposition은 문자열을 Exposition으로 변환합니다.
+// ParseExposition converts a string into an Exposition.
+func ParseExposition(s string) (Exposition, error) {
+	switch e := Exposition(s); e {
+	case ExposeAll, ExposePinned, ExposeNone:
+		return e, nil
+	}
+	return "", fmt.Errorf("알 수 없는 노출 방식 %q (all|pinned|none) / unknown exposition %q (all|pinned|none)", s, s)
+}
+
+// ParseHFBuckets는 쉼표로 구분된 헬스팩터 구간 경계를 파싱합니다. 경계는 양수이고 오름차순이어야 하며,
+// 빈 문자열은 구간 집계를 끕니다.
+// ParseHFBuckets parses comma-separated health factor range boundaries. Boundaries must be positive and
+// strictly ascending; an empty string disables the range counts.
+func ParseHFBuckets(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var bounds []float64
+	for _, part := range strings.Split(s, ",") {
+		b, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || b <= 0 || math.IsInf(b, 0) {
+			return nil, fmt.Errorf("잘못된 HF 구간 경계 %q / invalid HF bucket boundary %q", part, part)
+		}
+		if n := len(bounds); n > 0 && b <= bounds[n-1] {
+			return nil, fmt.Errorf("HF 구간 경계는 오름차순이어야 함 (%v 다음 %v) / HF bucket boundaries must be ascending (%v after %v)",
+				bounds[n-1], b, b, bounds[n-1])
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds, nil
+}
+
+// DefaultOptions는 기본 모니터 설정을 반환합니다.
+// DefaultOptions returns the default monitor options.
+func DefaultOptions() Options {
+	return Options{
+		Protocol:          "aave-v3",
+		Workers:           8,
+		CycleTimeout:      30 * time.Second,
+		CallTimeout:       10 * time.Second,
+		WarningThreshold:  1.2,
+		CriticalThreshold: 1.0,
+		Exposition:        ExposePinned,
+		TopN:              20,
+		HFBuckets:         []float64{1, 1.05, 1.1, 1.25, 1.5, 2},
+	}
+}
+
+// Outcome은 주소 하나의 조회 결과입니다.
+// Outcome is the result of checking one address.
+type Outcome string
+
+const (
+	// OutcomeSucceeded는 조회에 성공한 경우입니다.
+	// OutcomeSucceeded means the check succeeded.
+	OutcomeSucceeded Outcome = "succeeded"
+
+	// OutcomeFailed는 RPC 오류 등으로 실패한 경우입니다.
+	// OutcomeFailed means the check failed (e.g. RPC error).
+	OutcomeFailed Outcome = "failed"
+
+	// OutcomeTimedOut은 호출 또는 사이클 데드라인을 넘긴 경우입니다.
+	// OutcomeTimedOut means the call or cycle deadline was exceeded.
+	OutcomeTimedOut Outcome = "timed_out"
+)
+
+// 기본 그룹 이름 / Default group names
+const (
+	// GroupPinned는 설정/플래그로 지정했지만 그룹이 없는 주소의 그룹입니다.
+	// GroupPinned is the group of addresses given by config/flags without a group.
+	GroupPinned = "pinned"
+
+	// GroupDiscovered는 이벤트로 자동 발견된 주소의 그룹입니다.
+	// GroupDiscovered is the group of addresses discovered automatically from events.
+	GroupDiscovered = "discovered"
+)
+
+// Target은 사이클에서 확인할 주소와 그 표시 정보입니다.
+// Target is an address to check in a cycle along with its display information.
+type Target struct {
+	// Address는 계정 주소입니다.
+	// Address is the account address.
+	Address common.Address
+
+	// Label은 사람이 읽을 수 있는 이름입니다 (빈 값 가능). 로그와 알림에 표시됩니다.
+	// Label is a human-readable name (may be empty). Shown in logs and alerts.
+	Label string
+
+	// Group은 메트릭 집계 그룹입니다.
+	// Group is the group used to aggregate metrics.
+	Group string
+
+	// Pinned는 설정/플래그로 명시한 주소인지 여부입니다 (발견된 주소는 false).
+	// Pinned reports whether the address was given explicitly by config/flags (false for discovered ones).
+	Pinned bool
+}
+
+// checkResult는 주소 하나의 조회 결과와 집계에 필요한 값입니다.
+// checkResult is the result of checking one address plus what aggregation needs.
+type checkResult struct {
+	target  Target
+	outcome Outcome
+	hf      float64
+	debtUSD float64
+	hasDebt bool
+
+	// liquidation은 담보 자산별 청산 가격이고, liquidationRead는 이번 사이클에 포지션을 읽었는지 여부입니다.
+	// liquidation holds the per-collateral liquidation prices; liquidationRead reports whether the position was read this cycle.
+	liquidation     []risk.LiquidationPrice
+	liquidationRead bool
+
+	// position은 리저브별 포지션입니다 (읽은 경우만, 알림의 상위 자산 표시용).
+	// position is the per-reserve position (only when read; used for the top assets in alerts).
+	position *contracts.UserPosition
+
+	// quorumUnconfirmed는 쿼럼 확인이 실패해 단일 제공자 값으로 판정했는지 여부입니다.
+	// quorumUnconfirmed reports that the quorum check failed and the verdict rests on a single provider.
+	quorumUnconfirmed bool
+}
+
+// liquidationKey는 청산 가격 시계열 하나를 식별합니다.
+// liquidationKey identifies one liquidation price series.
+type liquidationKey struct {
+	seriesKey
+	asset string
+}
+
+// priceSnapshot은 한 사이클 동안 공유하는 리저브 설정과 오라클 가격입니다.
+// priceSnapshot is the reserve configuration and oracle prices shared for one cycle.
+type priceSnapshot struct {
+	reserves []contracts.Reserve
+	prices   map[common.Address]*big.Int
+}
+
+// seriesKey는 사용자별 시계열 하나를 식별합니다.
+// seriesKey identifies one per-user series.
+type seriesKey struct {
+	user  string
+	group string
+}
+
+// CycleResult는 한 사이클의 요약입니다.
+// CycleResult summarizes one cycle.
+type CycleResult struct {
+	// Counts는 결과별 주소 수입니다.
+	// Counts is the number of addresses per outcome.
+	Counts map[Outcome]int
+
+	// Duration은 사이클 소요 시간입니다.
+	// Duration is how long the cycle took.
+	Duration time.Duration
+
+	// Positions는 조회에 성공한 부채 보유 계정의 헬스팩터입니다.
+	// Positions holds the health factor of every debt-holding account read successfully.
+	Positions map[common.Address]float64
+}
+
+// Monitor는 주소 목록의 헬스팩터를 주기적으로 확인합니다.
+// Monitor periodically checks the health factor of a list of addresses.
+type Monitor struct {
+	poolCaller   *contracts.AavePoolCaller
+	quorumCaller *contracts.AavePoolCaller
+	alerter      *alert.WebhookAlerter
+	opts         Options
+	logger       *slog.Logger
+
+	// thresholds는 리로드로 교체될 수 있는 임계값입니다.
+	// thresholds holds the thresholds that a reload may swap.
+	thresholds atomic.Pointer[thresholds]
+
+	// 지난 사이클에 낸 시계열 (사라진 시계열 정리용) / Series published last cycle (to clean up stale ones)
+	groups  map[string]bool
+	perUser map[seriesKey]bool
+	top     map[seriesKey]bool
+	liq     map[liquidationKey]bool
+
+	// positions가 있으면 고정 계정과 경고 계정의 청산 가격을 계산합니다.
+	// When positions is set, liquidation prices are computed for pinned and warning accounts.
+	positions *contracts.PositionReader
+}
+
+// thresholds는 헬스팩터 경고/긴급 임계값 쌍입니다.
+// thresholds is a pair of health factor warning/critical thresholds.
+type thresholds struct {
+	warning  float64
+	critical float64
+}
+
+// New는 새로운 Monitor를 생성합니다.
+// New creates a new Monitor.
+//
+// quorumCaller가 nil이면 쿼럼 확인을, alerter가 nil이면 웹훅 알림을 생략합니다.
+// Quorum confirmation is skipped when quorumCaller is nil, webhook alerts when alerter is nil.
+func New(
+	poolCaller *contracts.AavePoolCaller,
+	quorumCaller *contracts.AavePoolCaller,
+	alerter *alert.WebhookAlerter,
+	opts Options,
+	logger *slog.Logger,
+) *Monitor {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	m := &Monitor{
+		poolCaller:   poolCaller,
+		quorumCaller: quorumCaller,
+		alerter:      alerter,
+		opts:         opts,
+		logger:       logger,
+	}
+	m.SetThresholds(opts.WarningThreshold, opts.CriticalThreshold)
+	return m
+}
+
+// SetThresholds는 헬스팩터 임계값을 원자적으로 교체합니다. 진행 중인 사이클에도 안전합니다.
+// SetThresholds atomically swaps the health factor thresholds. Safe during a running cycle.
+func (m *Monitor) SetThresholds(warning, critical float64) {
+	m.thresholds.Store(&thresholds{warning: warning, critical: critical})
+}
+
+// SetPositionReader는 청산 가격 계산에 쓸 포지션 리더를 설정합니다. 첫 사이클 전에 호출해야 합니다.
+// SetPositionReader sets the position reader used for liquidation prices. Must be called before the first cycle.
+func (m *Monitor) SetPositionReader(reader *contracts.PositionReader) {
+	m.positions = reader
+}
+
+// RunCycle은 한 번의 모니터링 사이클을 실행합니다.
+// RunCycle executes one monitoring cycle.
+//
+// block이 주어지면 모든 호출이 같은 블록에 고정되어 주소 간 판독이 일관됩니다.
+// 주소는 목록 순서대로 분배되며, 사이클 데드라인까지 처리하지 못한 주소는 timed_out으로 집계됩니다.
+// When block is given every call is pinned to it, so readings are consistent across addresses.
+// Targets are dispatched in list order; those not reached before the cycle deadline are counted as timed_out.
+//
+// RunCycle은 한 번에 하나만 실행되어야 합니다.
+// Only one RunCycle may run at a time.
+func (m *Monitor) RunCycle(ctx context.Context, targets []Target, block *BlockRef) CycleResult {
+	start := time.Now()
+	logger := m.logger
+	if block != nil {
+		logger = logger.With("block", block.Number.String(), "block_time", block.Time.Format(time.RFC3339))
+		metrics.MonitorBlockNumber.Set(float64(block.Number.Uint64()))
+		metrics.MonitorBlockTimestamp.Set(float64(block.Time.Unix()))
+	}
+
+	cycleCtx, cancel := context.WithTimeout(ctx, m.opts.CycleTimeout)
+	defer cancel()
+	snap := m.snapshot(cycleCtx, logger, block)
+
+	jobs := make(chan Target)
+	results := make(chan checkResult, len(targets))
+
+	// 워커 풀 시작 / Start worker pool
+	var wg sync.WaitGroup
+	for i := 0; i < m.opts.Workers && i < len(targets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range jobs {
+				results <- m.checkAddress(cycleCtx, logger, t, block, snap)
+			}
+		}()
+	}
+
+	// 작업 분배: 사이클 데드라인이 지나면 남은 주소는 건너뜀
+	// Dispatch jobs: once the cycle deadline passes, remaining addresses are skipped
+	dispatched := 0
+dispatch:
+	for _, t := range targets {
+		select {
+		case jobs <- t:
+			dispatched++
+		case <-cycleCtx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	result := CycleResult{Counts: map[Outcome]int{
+		OutcomeSucceeded: 0,
+		OutcomeFailed:    0,
+		OutcomeTimedOut:  len(targets) - dispatched,
+	}}
+	result.Positions = make(map[common.Address]float64)
+	var checked []checkResult
+	for r := range results {
+		result.Counts[r.outcome]++
+		checked = append(checked, r)
+		if r.outcome == OutcomeSucceeded && r.hasDebt {
+			result.Positions[r.target.Address] = r.hf
+		}
+	}
+	result.Duration = time.Since(start)
+	m.publish(targets, checked)
+
+	metrics.MonitorCycleDuration.Observe(result.Duration.Seconds())
+	for o, n := range result.Counts {
+		metrics.MonitorCycleAddresses.WithLabelValues(string(o)).Set(float64(n))
+	}
+	logger.Info("모니터링 사이클 완료 / Monitor cycle complete",
+		"duration_ms", result.Duration.Milliseconds(),
+		"addresses_checked", len(targets),
+		"succeeded", result.Counts[OutcomeSucceeded],
+		"failed", result.Counts[OutcomeFailed],
+		"timed_out", result.Counts[OutcomeTimedOut],
+	)
+	return result
+}
+
+// checkAddress는 주소 하나의 헬스팩터를 조회하고 메트릭/로그를 갱신합니다.
+// checkAddress reads one address's health factor and updates metrics/logs.
+//
+// snap이 있으면 고정 계정과 경고 임계값 미만 계정의 청산 가격도 계산합니다.
+// When snap is given, liquidation prices are also computed for pinned accounts and those below the warning threshold.
+func (m *Monitor) checkAddress(ctx context.Context, logger *slog.Logger, t Target, block *BlockRef, snap *priceSnapshot) checkResult {
+	addr := t.Address
+	res := checkResult{target: t}
+	if t.Label != "" {
+		logger = logger.With("label", t.Label)
+	}
+	logger = logger.With("group", t.Group)
+
+	// 사용자 계정 데이터 조회 / Get user account data
+	data, err := m.call(ctx, m.poolCaller, addr, block)
+	if err != nil {
+		logger.Error("계정 데이터 조회 실패 / Failed to get account data",
+			"address", addr.Hex(),
+			"error", err,
+		)
+		res.outcome = outcomeOf(err)
+		return res
+	}
+
+	// 헬스팩터를 사람이 읽을 수 있는 형식으로 변환
+	// Convert health factor to human-readable format
+	// 헬스팩터는 18 소수점 (1e18 = 1.0)
+	// Health factor has 18 decimals (1e18 = 1.0)
+	hfValue := healthFactorValue(data.HealthFactor)
+	th := m.thresholds.Load()
+
+	// 청산 가능 판정은 쿼럼으로 재확인 / Re-check a liquidatable verdict under quorum
+	if hfValue < th.critical && hfValue > 0 && m.quorumCaller != nil {
+		confirmed, err := m.call(ctx, m.quorumCaller, addr, block)
+		switch {
+		case errors.Is(err, rpcpool.ErrQuorumMismatch):
+			// 제공자들이 서로 다른 값을 주면 판정 보류 / Withhold the verdict when providers positively disagree
+			logger.Warn("쿼럼 불일치, 판정 보류 / Quorum mismatch, withholding verdict",
+				"address", addr.Hex(),
+				"health_factor", hfValue,
+				"error", err,
+			)
+			res.outcome = outcomeOf(err)
+			return res
+		case err != nil:
+			// 제공자 장애로 확인할 수 없으면 단일 제공자 판정을 표시와 함께 유지
+			// When an outage prevents confirmation, keep the single-provider verdict and mark it
+			logger.Warn("쿼럼 확인 실패, 미확인 판정 유지 / Quorum check failed, keeping unconfirmed verdict",
+				"address", addr.Hex(),
+				"health_factor", hfValue,
+				"error", err,
+			)
+			res.quorumUnconfirmed = true
+		default:
+			data = confirmed
+			hfValue = healthFactorValue(data.HealthFactor)
+		}
+	}
+
+	// 메트릭은 사이클 끝에 노출 방식에 따라 한 번에 갱신 (publish 참고)
+	// Metrics are updated at the end of the cycle according to the exposition mode (see publish)
+	res.hf = hfValue
+	res.hasDebt = data.TotalDebtBase.Sign() > 0
+	res.debtUSD = baseToUSD(data.TotalDebtBase)
+	if snap != nil && res.hasDebt && (t.Pinned || hfValue < th.warning) {
+		if res.position = m.readPosition(ctx, logger, addr, block, snap); res.position != nil {
+			res.liquidation, res.liquidationRead = risk.LiquidationPrices(res.position), true
+		}
+	}
+
+	// 로깅 / Logging
+	attrs := []any{
+		"address", addr.Hex(),
+		"health_factor", fmt.Sprintf("%.4f", hfValue),
+		"total_collateral", data.TotalCollateralBase.String(),
+		"total_debt", data.TotalDebtBase.String(),
+	}
+	if len(res.liquidation) > 0 {
+		nearest := res.liquidation[0]
+		attrs = append(attrs,
+			"liquidation_asset", nearest.Symbol,
+			"liquidation_price", fmt.Sprintf("%.4f", nearest.LiquidationPrice),
+			"liquidation_distance_pct", fmt.Sprintf("%.2f", nearest.DistancePct),
+		)
+	}
+	logger.Info("포지션 상태 / Position status", attrs...)
+
+	// 헬스팩터 알림 확인 / Check health factor alerts
+	// < 1.0: 즉시 청산 가능 / immediately liquidatable
+	// < 1.2: 경고 (곧 청산될 수 있음) / warning (may become liquidatable)
+	// 알림은 계정별 수준이 바뀔 때만 전송 (WebhookAlerter가 중복 억제)
+	// Alerts go out only when an account's level changes (WebhookAlerter suppresses repeats)
+	if hfValue < th.warning && hfValue > 0 && m.alerter != nil {
+		logger.Warn("낮은 헬스팩터 감지! / Low health factor detected!",
+			"address", addr.Hex(),
+			"health_factor", hfValue,
+		)
+		hfFloat := new(big.Float).Quo(new(big.Float).SetInt(data.HealthFactor), big.NewFloat(1e18))
+		if err := m.alerter.AlertOnLowHealthFactor(ctx, addr.Hex(), hfFloat, alertMetadata(t, block, res)); err != nil {
+			logger.Error("알림 전송 실패 / Failed to send alert", "error", err)
+		}
+	} else if m.alerter != nil {
+		m.alerter.ResolveHealthFactor(addr.Hex())
+	}
+	res.outcome = OutcomeSucceeded
+	return res
+}
+
+// call은 호출별 타임아웃을 걸고 계정 데이터를 조회합니다.
+// call reads account data with a per-call timeout.
+func (m *Monitor) call(ctx context.Context, caller *contracts.AavePoolCaller, addr common.Address, block *BlockRef) (*contracts.UserAccountData, error) {
+	callCtx, cancel := context.WithTimeout(ctx, m.opts.CallTimeout)
+	defer cancel()
+	opts := &bind.CallOpts{Context: callCtx}
+	if block != nil {
+		opts.BlockNumber = block.Number
+	}
+	return caller.GetUserAccountData(opts, addr)
+}
+
+// snapshot은 사이클에서 쓸 리저브 설정과 오라클 가격을 읽습니다. 포지션 리더가 없거나 실패하면 nil입니다.
+// snapshot reads the reserve configuration and oracle prices for the cycle. Nil without a position reader or on failure.
+func (m *Monitor) snapshot(ctx context.Context, logger *slog.Logger, block *BlockRef) *priceSnapshot {
+	if m.positions == nil {
+		return nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	if block != nil {
+		opts.BlockNumber = block.Number
+	}
+	reserves, err := m.positions.CachedReserves(opts, contracts.ReservesTTL)
+	if err != nil {
+		logger.Warn("리저브 조회 실패, 청산 가격 생략 / Failed to read reserves, skipping liquidation prices", "error", err)
+		return nil
+	}
+	prices, err := m.positions.Prices(opts, reserves)
+	if err != nil {
+		logger.Warn("오라클 가격 조회 실패, 청산 가격 생략 / Failed to read oracle prices, skipping liquidation prices", "error", err)
+		return nil
+	}
+	return &priceSnapshot{reserves: reserves, prices: prices}
+}
+
+// readPosition은 계정의 리저브별 포지션을 읽습니다. 실패하면 nil입니다.
+// readPosition reads an account's per-reserve position. Nil on failure.
+func (m *Monitor) readPosition(ctx context.Context, logger *slog.Logger, addr common.Address, block *BlockRef, snap *priceSnapshot) *contracts.UserPosition {
+	callCtx, cancel := context.WithTimeout(ctx, m.opts.CallTimeout)
+	defer cancel()
+	opts := &bind.CallOpts{Context: callCtx}
+	if block != nil {
+		opts.BlockNumber = block.Number
+	}
+	pos, err := m.positions.UserPosition(opts, addr, snap.reserves, snap.prices)
+	if err != nil {
+		logger.Warn("포지션 조회 실패, 청산 가격 생략 / Failed to read position, skipping liquidation price",
+			"address", addr.Hex(),
+			"error", err,
+		)
+		return nil
+	}
+	return pos
+}
+
+// alertMetadata는 알림에 첨부할 라벨, 그룹, 블록, 가장 가까운 청산 가격, 상위 담보/부채 자산 정보를 만듭니다.
+// alertMetadata builds the label, group, block, nearest liquidation price and top collateral/debt asset
+// information attached to alerts.
+func alertMetadata(t Target, block *BlockRef, res checkResult) map[string]string {
+	md := map[string]string{"group": t.Group}
+	if t.Label != "" {
+		md["label"] = t.Label
+	}
+	if res.quorumUnconfirmed {
+		md["quorum_unconfirmed"] = "true"
+	}
+	if block != nil {
+		md["block"] = block.Number.String()
+		md["block_time"] = block.Time.Format(time.RFC3339)
+	}
+	if len(res.liquidation) > 0 {
+		nearest := res.liquidation[0]
+		md["liquidation_asset"] = nearest.Symbol
+		md["liquidation_price_usd"] = fmt.Sprintf("%.4f", nearest.LiquidationPrice)
+		md["oracle_price_usd"] = fmt.Sprintf("%.4f", nearest.Price)
+		md["liquidation_distance_pct"] = fmt.Sprintf("%.2f", nearest.DistancePct)
+	}
+	if res.position != nil {
+		risk.AddBreakdown(md, res.position, topContributors)
+	}
+	return md
+}
+
+// outcomeOf는 오류를 실패/타임아웃으로 분류합니다.
+// outcomeOf classifies an error as failed or timed out.
+func outcomeOf(err error) Outcome {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return OutcomeTimedOut
+	}
+	return OutcomeFailed
+}
+
+// healthFactorValue는 1e18 스케일의 헬스팩터를 float64로 변환합니다.
+// healthFactorValue converts a 1e18-scaled health factor to float64.
+func healthFactorValue(hf *big.Int) float64 {
+	v, _ := new(big.Float).Quo(new(big.Float).SetInt(hf), big.NewFloat(1e18)).Float64()
+	return v
+}
+
+// baseToUSD는 Aave 기본 통화(USD, 8 소수점) 금액을 float64 달러로 변환합니다.
+// baseToUSD converts an Aave base currency amount (USD, 8 decimals) to float64 dollars.
+func baseToUSD(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e8)).Float64()
+	return f
+}
+
+// RecordOverrun은 사이클이 주기를 넘겼는지 확인하고 건너뛴 사이클 수를 기록합니다.
+// RecordOverrun checks whether a cycle overran its interval and records skipped cycles.
+//
+// time.Ticker는 느린 수신자를 위해 틱을 버리므로, 넘긴 주기 수만큼 사이클이 누락됩니다.
+// time.Ticker drops ticks for slow receivers, so each overrun interval is a missed cycle.
+func RecordOverrun(logger *slog.Logger, duration, interval time.Duration) int {
+	if interval <= 0 || duration <= interval {
+		return 0
+	}
+	skipped := int(duration / interval)
+	metrics.MonitorCyclesSkippedTotal.Add(float64(skipped))
+	logger.Warn("사이클이 주기를 초과함, 사이클 누락 / Cycle overran interval, cycles skipped",
+		"duration", duration.String(),
+		"interval", interval.String(),
+		"skipped", skipped,
+	)
+	return skipped
+}
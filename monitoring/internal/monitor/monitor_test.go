@@ -0,0 +1,32 @@
+package monitor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseHFBuckets(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []float64
+		wantErr bool
+	}{
+		{"1,1.05,1.1,1.25,1.5,2", []float64{1, 1.05, 1.1, 1.25, 1.5, 2}, false},
+		{" 1 , 2 ", []float64{1, 2}, false},
+		{"", nil, false},
+		{"1,1.5,1.2", nil, true},
+		{"1,1", nil, true},
+		{"0,1", nil, true},
+		{"1,x", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHFBuckets(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHFBuckets(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("ParseHFBuckets(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
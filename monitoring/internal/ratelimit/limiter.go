@@ -0,0 +1,283 @@
+// Package ratelimit은 RPC 요청을 위한 클라이언트 측 토큰 버킷과 일일 예산을 제공합니다.
+// Package ratelimit provides a client-side token bucket and daily budget for RPC requests.
+//
+// RPC 제공자는 컴퓨트 유닛(CU) 단위로 과금하고 버스트를 제한합니다.
+// 메서드마다 비용이 다르므로 (eth_getLogs > eth_call) 요청마다 가중치를 적용합니다.
+// RPC providers bill per compute unit (CU) and throttle bursts.
+// Methods cost differently (eth_getLogs > eth_call), so each request is weighted.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// DefaultWeights는 메서드별 기본 비용(컴퓨트 유닛)입니다.
+// DefaultWeights returns the default per-method cost in compute units.
+//
+// 값은 일반적인 제공자 요금표를 참고했습니다 (예: eth_call 26 CU, eth_getLogs 75 CU).
+// Values follow typical provider pricing (e.g. eth_call 26 CU, eth_getLogs 75 CU).
+func DefaultWeights() map[string]float64 {
+	return map[string]float64{
+		"eth_chainId":          0,
+		"eth_blockNumber":      10,
+		"eth_getBlockByNumber": 16,
+		"eth_getCode":          26,
+		"eth_call":             26,
+		"eth_getLogs":          75,
+		"eth_subscribe":        10,
+	}
+}
+
+// Config는 리미터 설정입니다.
+// Config configures a Limiter.
+type Config struct {
+	// RatePerSecond는 초당 보충되는 유닛 수입니다 (0 = 속도 제한 없음).
+	// RatePerSecond is the number of units refilled per second (0 = no rate limit).
+	RatePerSecond float64
+
+	// Burst는 버킷의 최대 유닛 수입니다.
+	// Burst is the maximum number of units in the bucket.
+	Burst float64
+
+	// DailyBudget은 UTC 하루 동안 사용할 수 있는 유닛 수입니다 (0 = 무제한).
+	// DailyBudget is the number of units available per UTC day (0 = unlimited).
+	DailyBudget float64
+
+	// Weights는 메서드별 비용입니다. 없는 메서드는 DefaultWeight를 사용합니다.
+	// Weights is the per-method cost. Unknown methods use DefaultWeight.
+	Weights map[string]float64
+
+	// DefaultWeight는 Weights에 없는 메서드의 비용입니다.
+	// DefaultWeight is the cost of methods missing from Weights.
+	DefaultWeight float64
+
+	// MaxStretch는 모니터링 주기를 늘릴 수 있는 최대 배수입니다.
+	// MaxStretch is the maximum factor by which a monitoring interval may be stretched.
+	MaxStretch float64
+
+	// Now는 현재 시각을 반환합니다 (nil = time.Now, 테스트에서 시계 주입용).
+	// Now returns the current time (nil = time.Now; lets tests inject a clock).
+	Now func() time.Time
+}
+
+// DefaultConfig는 제한 없는 기본 설정을 반환합니다 (가중치만 설정).
+// DefaultConfig returns an unlimited default config (weights only).
+func DefaultConfig() Config {
+	return Config{
+		Weights:       DefaultWeights(),
+		DefaultWeight: 20,
+		MaxStretch:    10,
+	}
+}
+
+// Limiter는 가중치 토큰 버킷과 일일 예산을 함께 관리합니다.
+// Limiter manages a weighted token bucket together with a daily budget.
+//
+// 예산이 부족해도 요청을 실패시키지 않습니다. 대신 Stretch로 주기를 늘려
+// 남은 예산 안에서 하루를 버티도록 역압(backpressure)을 겁니다.
+// Running low on budget never fails a request. Instead, Stretch lengthens the
+// interval so the remaining budget lasts the rest of the day (backpressure).
+type Limiter struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	tokens   float64
+	last     time.Time
+	dayStart time.Time
+	spent    float64
+}
+
+// New는 새로운 Limiter를 생성합니다.
+// New creates a new Limiter.
+func New(cfg Config) *Limiter {
+	if cfg.Weights == nil {
+		cfg.Weights = DefaultWeights()
+	}
+	if cfg.Burst < cfg.RatePerSecond {
+		cfg.Burst = cfg.RatePerSecond
+	}
+	if cfg.MaxStretch < 1 {
+		cfg.MaxStretch = 1
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	t := cfg.Now()
+	l := &Limiter{
+		cfg:      cfg,
+		now:      cfg.Now,
+		tokens:   cfg.Burst,
+		last:     t,
+		dayStart: startOfDay(t),
+	}
+	l.publish()
+	return l
+}
+
+// Weight는 메서드의 비용을 반환합니다.
+// Weight returns the cost of a method.
+func (l *Limiter) Weight(method string) float64 {
+	if w, ok := l.cfg.Weights[method]; ok {
+		return w
+	}
+	return l.cfg.DefaultWeight
+}
+
+// Wait는 메서드 비용만큼 토큰이 생길 때까지 기다린 뒤 예산에서 차감합니다.
+// Wait blocks until enough tokens exist for the method, then charges the budget.
+//
+// 컨텍스트가 취소된 경우에만 오류를 반환합니다.
+// Returns an error only when the context is cancelled.
+func (l *Limiter) Wait(ctx context.Context, method string) error {
+	if l == nil {
+		return nil
+	}
+	weight := l.Weight(method)
+	start := l.now()
+
+	for {
+		delay := l.reserve(weight)
+		if delay == 0 {
+			break
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	if waited := l.now().Sub(start); waited > 0 {
+		metrics.RPCRateLimitWaitSeconds.WithLabelValues(method).Observe(waited.Seconds())
+	}
+	return nil
+}
+
+// reserve는 토큰을 차감하거나, 부족하면 기다려야 할 시간을 반환합니다.
+// reserve takes tokens, or returns how long to wait when there are not enough.
+func (l *Limiter) reserve(weight float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := l.now()
+	l.rollDay(t)
+
+	if l.cfg.RatePerSecond > 0 {
+		l.tokens += t.Sub(l.last).Seconds() * l.cfg.RatePerSecond
+		if l.tokens > l.cfg.Burst {
+			l.tokens = l.cfg.Burst
+		}
+		l.last = t
+
+		// 버스트보다 큰 요청은 버킷이 가득 찼을 때 통과시킴 (영원히 막히지 않도록)
+		// Requests larger than the burst pass on a full bucket (so they never block forever)
+		need := weight
+		if need > l.cfg.Burst {
+			need = l.cfg.Burst
+		}
+		if l.tokens < need {
+			missing := need - l.tokens
+			return time.Duration(missing / l.cfg.RatePerSecond * float64(time.Second))
+		}
+		l.tokens -= weight
+	}
+
+	l.spent += weight
+	l.publish()
+	return 0
+}
+
+// rollDay는 UTC 날짜가 바뀌면 예산을 초기화합니다 (잠금 상태에서 호출).
+// rollDay resets the budget when the UTC day changes (called with lock held).
+func (l *Limiter) rollDay(t time.Time) {
+	if day := startOfDay(t); day.After(l.dayStart) {
+		l.dayStart = day
+		l.spent = 0
+	}
+}
+
+// Remaining은 오늘 남은 예산 유닛을 반환합니다 (예산 없으면 -1).
+// Remaining returns the budget units left today (-1 when there is no budget).
+func (l *Limiter) Remaining() float64 {
+	if l == nil || l.cfg.DailyBudget <= 0 {
+		return -1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollDay(l.now())
+	return l.remainingLocked()
+}
+
+// remainingLocked는 잠금 상태에서 남은 예산을 계산합니다.
+// remainingLocked computes the remaining budget with the lock held.
+func (l *Limiter) remainingLocked() float64 {
+	remaining := l.cfg.DailyBudget - l.spent
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Stretch는 현재 소비 속도로 예산이 하루를 버티도록 늘린 주기를 반환합니다.
+// Stretch returns the interval lengthened so the budget lasts the day at the current spend rate.
+//
+// 늘림 배수 = (소비 속도 × 남은 시간) / 남은 예산, [1, MaxStretch] 범위로 제한
+// stretch factor = (spend rate × time left) / remaining budget, clamped to [1, MaxStretch]
+func (l *Limiter) Stretch(base time.Duration) time.Duration {
+	if l == nil || l.cfg.DailyBudget <= 0 {
+		return base
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := l.now()
+	l.rollDay(t)
+	if l.spent == 0 {
+		return base
+	}
+
+	// 하루 초반의 과대 추정을 피하기 위해 최소 1분 경과로 계산
+	// Assume at least one minute elapsed to avoid overestimating early in the day
+	elapsed := t.Sub(l.dayStart)
+	if elapsed < time.Minute {
+		elapsed = time.Minute
+	}
+	left := l.dayStart.Add(24 * time.Hour).Sub(t)
+
+	remaining := l.remainingLocked()
+	if remaining == 0 {
+		return time.Duration(float64(base) * l.cfg.MaxStretch)
+	}
+
+	projected := l.spent / elapsed.Seconds() * left.Seconds()
+	factor := projected / remaining
+	if factor < 1 {
+		factor = 1
+	}
+	if factor > l.cfg.MaxStretch {
+		factor = l.cfg.MaxStretch
+	}
+	return time.Duration(float64(base) * factor)
+}
+
+// publish는 남은 예산 메트릭을 갱신합니다 (잠금 상태에서 호출).
+// publish updates the remaining budget metric (called with lock held).
+func (l *Limiter) publish() {
+	if l.cfg.DailyBudget > 0 {
+		metrics.RPCBudgetRemainingUnits.Set(l.remainingLocked())
+	}
+	metrics.RPCBudgetSpentUnits.Set(l.spent)
+}
+
+// startOfDay는 UTC 자정을 반환합니다.
+// startOfDay returns UTC midnight of the given time's day.
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
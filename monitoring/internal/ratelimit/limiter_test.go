@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock은 테스트에서 직접 움직이는 시계입니다.
+// clock is a clock the test advances by hand.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newLimiter(c *clock, cfg Config) *Limiter {
+	cfg.Now = c.now
+	return New(cfg)
+}
+
+func TestWeight(t *testing.T) {
+	l := New(DefaultConfig())
+	if w := l.Weight("eth_getLogs"); w != 75 {
+		t.Errorf("Weight(eth_getLogs) = %v, want 75", w)
+	}
+	if w := l.Weight("debug_traceCall"); w != 20 {
+		t.Errorf("Weight(unknown) = %v, want DefaultWeight 20", w)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	c := &clock{time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	l := newLimiter(c, Config{RatePerSecond: 10, Burst: 20})
+
+	if d := l.reserve(15); d != 0 {
+		t.Fatalf("first reserve waits %v, want 0 (full bucket)", d)
+	}
+	// 5개 남음, 15개 필요 → 1초 / 5 left, 15 needed → 1s
+	if d := l.reserve(15); d != time.Second {
+		t.Errorf("second reserve waits %v, want 1s", d)
+	}
+	c.advance(time.Second)
+	if d := l.reserve(15); d != 0 {
+		t.Errorf("reserve after refill waits %v, want 0", d)
+	}
+
+	// 버스트보다 큰 요청은 가득 찬 버킷에서 통과 / Requests over the burst pass on a full bucket
+	c.advance(time.Hour)
+	if d := l.reserve(50); d != 0 {
+		t.Errorf("oversized reserve on a full bucket waits %v, want 0", d)
+	}
+	if d := l.reserve(1); d != 3100*time.Millisecond {
+		t.Errorf("reserve after oversized request waits %v, want 3.1s (bucket at -30)", d)
+	}
+}
+
+func TestUnlimited(t *testing.T) {
+	var nilLimiter *Limiter
+	if err := nilLimiter.Wait(context.Background(), "eth_call"); err != nil {
+		t.Errorf("nil Wait = %v", err)
+	}
+	if nilLimiter.Remaining() != -1 || nilLimiter.Stretch(time.Minute) != time.Minute {
+		t.Error("nil limiter should report no budget and not stretch")
+	}
+
+	c := &clock{time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	l := newLimiter(c, Config{})
+	for range 100 {
+		if d := l.reserve(1e6); d != 0 {
+			t.Fatalf("reserve without a rate waits %v", d)
+		}
+	}
+	if l.Remaining() != -1 || l.Stretch(time.Minute) != time.Minute {
+		t.Error("limiter without a budget should report no budget and not stretch")
+	}
+}
+
+func TestStretch(t *testing.T) {
+	noon := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		at    time.Time
+		spent int // 250 단위 호출 수 / calls of 250 units
+		want  time.Duration
+	}{
+		{"nothing spent", noon, 0, time.Minute},
+		// 12시간에 500 → 남은 12시간에 500 예상, 남은 예산 500 → 1배
+		// 500 in 12h → 500 projected for the remaining 12h, 500 left → 1x
+		{"on pace", noon, 2, time.Minute},
+		// 12시간에 750 → 750 예상, 남은 250 → 3배 / 750 in 12h → 750 projected, 250 left → 3x
+		{"over pace", noon, 3, 3 * time.Minute},
+		{"exhausted", noon, 4, 10 * time.Minute},
+		// 18시에 750 → 남은 6시간에 250 예상, 남은 250 → 1배 / 750 by 18:00 → 250 projected, 250 left → 1x
+		{"late in the day", noon.Add(6 * time.Hour), 3, time.Minute},
+		// 하루 시작 직후에는 최소 1분 경과로 보고 MaxStretch로 제한
+		// Right after midnight at least a minute counts as elapsed, clamped to MaxStretch
+		{"just after midnight", time.Date(2024, 6, 1, 0, 0, 10, 0, time.UTC), 1, 10 * time.Minute},
+	}
+	for _, tc := range cases {
+		c := &clock{tc.at}
+		l := newLimiter(c, Config{DailyBudget: 1000, MaxStretch: 10})
+		for range tc.spent {
+			l.reserve(250)
+		}
+		if got := l.Stretch(time.Minute); got != tc.want {
+			t.Errorf("%s: Stretch(1m) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDailyRollover(t *testing.T) {
+	c := &clock{time.Date(2024, 6, 1, 23, 59, 0, 0, time.UTC)}
+	l := newLimiter(c, Config{DailyBudget: 1000, MaxStretch: 10})
+	for range 5 {
+		l.reserve(250)
+	}
+	if r := l.Remaining(); r != 0 {
+		t.Errorf("Remaining before midnight = %v, want 0 (overspent clamps to 0)", r)
+	}
+	if s := l.Stretch(time.Minute); s != 10*time.Minute {
+		t.Errorf("Stretch before midnight = %v, want MaxStretch", s)
+	}
+
+	// UTC 자정이 지나면 예산과 늘림이 초기화 / Past UTC midnight the budget and stretch reset
+	c.advance(time.Minute)
+	if r := l.Remaining(); r != 1000 {
+		t.Errorf("Remaining after midnight = %v, want 1000", r)
+	}
+	if s := l.Stretch(time.Minute); s != time.Minute {
+		t.Errorf("Stretch after midnight = %v, want 1m", s)
+	}
+	l.reserve(250)
+	if r := l.Remaining(); r != 750 {
+		t.Errorf("Remaining after one call = %v, want 750", r)
+	}
+
+	// 다른 시간대의 자정은 경계가 아님 (KST 자정 = UTC 15시)
+	// Midnight in another time zone is not a boundary (KST midnight = 15:00 UTC)
+	c.t = time.Date(2024, 6, 3, 0, 0, 0, 0, time.FixedZone("KST", 9*3600))
+	if r := l.Remaining(); r != 750 {
+		t.Errorf("Remaining at KST midnight = %v, want 750 (same UTC day)", r)
+	}
+}
@@ -0,0 +1,400 @@
+package ratemodel
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// AaveStrategy는 Aave V3 DefaultReserveInterestRateStrategy (V3.0/V3.1)의 Go 구현입니다.
+// AaveStrategy is the Go implementation of Aave V3's DefaultReserveInterestRateStrategy (V3.0/V3.1).
+//
+// 대출 이자율은 사용률 (부채 / (가용 유동성 + 부채))이 최적 사용률을 넘으면 slope2로 가파르게 오릅니다.
+// 고정금리는 고정 부채 비중이 최적 비율을 넘으면 추가 오프셋이 붙습니다.
+// 예치 이자율 = 전체 평균 대출 이자율 × 공급 사용률 × (1 - 준비금 비율).
+// The borrow rate climbs steeply with slope2 once usage (debt / (available liquidity + debt)) passes the optimal ratio.
+// The stable rate gets an extra offset once the stable share of debt passes its optimal ratio.
+// Supply rate = overall average borrow rate × supply usage × (1 - reserve factor).
+type AaveStrategy struct {
+	p contracts.AaveRateStrategyParams
+}
+
+// NewAaveStrategy는 전략 파라미터로 AaveStrategy를 생성합니다.
+// NewAaveStrategy creates an AaveStrategy from strategy parameters.
+func NewAaveStrategy(p contracts.AaveRateStrategyParams) (*AaveStrategy, error) {
+	if err := checkInputs(p.OptimalUsageRatio, p.OptimalStableToTotalDebtRatio, p.MaxExcessUsageRatio,
+		p.MaxExcessStableToTotalDebtRatio, p.BaseVariableBorrowRate, p.VariableRateSlope1, p.VariableRateSlope2,
+		p.StableRateSlope1, p.StableRateSlope2, p.StableRateExcessOffset, p.BaseStableBorrowRate); err != nil {
+		return nil, fmt.Errorf("잘못된 전략 파라미터 / invalid strategy parameters: %w", err)
+	}
+	if p.OptimalUsageRatio.Cmp(Ray) > 0 || p.OptimalStableToTotalDebtRatio.Cmp(Ray) > 0 {
+		return nil, errors.New("최적 비율이 1 ray 초과 / optimal ratio above 1 ray")
+	}
+	return &AaveStrategy{p: p}, nil
+}
+
+// Params는 전략 파라미터를 반환합니다.
+// Params returns the strategy parameters.
+func (s *AaveStrategy) Params() contracts.AaveRateStrategyParams {
+	return s.p
+}
+
+// AaveRateInputs는 calculateInterestRates의 입력입니다 (CalculateInterestRatesParams).
+// 금액은 자산 단위, 이자율은 ray, ReserveFactor는 bps입니다.
+// AaveRateInputs are the inputs of calculateInterestRates (CalculateInterestRatesParams).
+// Amounts are in asset units, rates in ray, ReserveFactor in bps.
+type AaveRateInputs struct {
+	// AvailableLiquidity는 aToken이 가진 기초 자산 잔고입니다 (balanceOf(aToken)).
+	// AvailableLiquidity is the underlying balance held by the aToken (balanceOf(aToken)).
+	AvailableLiquidity *big.Int
+
+	Unbacked                *big.Int
+	LiquidityAdded          *big.Int
+	LiquidityTaken          *big.Int
+	TotalStableDebt         *big.Int
+	TotalVariableDebt       *big.Int
+	AverageStableBorrowRate *big.Int
+	ReserveFactor           *big.Int
+}
+
+// AaveRates는 리저브의 현재 이자율입니다 (ray, 1e27 = 100% APR).
+// AaveRates are a reserve's current rates (ray, 1e27 = 100% APR).
+type AaveRates struct {
+	LiquidityRate      *big.Int
+	StableBorrowRate   *big.Int
+	VariableBorrowRate *big.Int
+}
+
+// CalculateInterestRates는 DefaultReserveInterestRateStrategy.calculateInterestRates와 같은 값을 계산합니다.
+// CalculateInterestRates computes the same values as DefaultReserveInterestRateStrategy.calculateInterestRates.
+func (s *AaveStrategy) CalculateInterestRates(in AaveRateInputs) (*AaveRates, error) {
+	zero := new(big.Int)
+	orZero := func(v *big.Int) *big.Int {
+		if v == nil {
+			return zero
+		}
+		return v
+	}
+	available, unbacked := orZero(in.AvailableLiquidity), orZero(in.Unbacked)
+	added, taken := orZero(in.LiquidityAdded), orZero(in.LiquidityTaken)
+	stableDebt, variableDebt := orZero(in.TotalStableDebt), orZero(in.TotalVariableDebt)
+	avgStable, reserveFactor := orZero(in.AverageStableBorrowRate), orZero(in.ReserveFactor)
+	if err := checkInputs(available, unbacked, added, taken, stableDebt, variableDebt, avgStable, reserveFactor); err != nil {
+		return nil, err
+	}
+
+	totalDebt, err := add(stableDebt, variableDebt)
+	if err != nil {
+		return nil, err
+	}
+	variableRate := new(big.Int).Set(s.p.BaseVariableBorrowRate)
+	stableRate := new(big.Int).Set(s.p.BaseStableBorrowRate)
+	stableToTotal, borrowUsage, supplyUsage := new(big.Int), new(big.Int), new(big.Int)
+
+	if totalDebt.Sign() != 0 {
+		if stableToTotal, err = rayDiv(stableDebt, totalDebt); err != nil {
+			return nil, err
+		}
+		liquidity, err := add(available, added)
+		if err != nil {
+			return nil, err
+		}
+		if liquidity, err = sub(liquidity, taken); err != nil {
+			return nil, err
+		}
+		liquidityPlusDebt, err := add(liquidity, totalDebt)
+		if err != nil {
+			return nil, err
+		}
+		if borrowUsage, err = rayDiv(totalDebt, liquidityPlusDebt); err != nil {
+			return nil, err
+		}
+		withUnbacked, err := add(liquidityPlusDebt, unbacked)
+		if err != nil {
+			return nil, err
+		}
+		if supplyUsage, err = rayDiv(totalDebt, withUnbacked); err != nil {
+			return nil, err
+		}
+	}
+
+	if borrowUsage.Cmp(s.p.OptimalUsageRatio) > 0 {
+		excess, err := sub(borrowUsage, s.p.OptimalUsageRatio)
+		if err != nil {
+			return nil, err
+		}
+		if excess, err = rayDiv(excess, s.p.MaxExcessUsageRatio); err != nil {
+			return nil, err
+		}
+		if stableRate, err = addSlopes(stableRate, s.p.StableRateSlope1, s.p.StableRateSlope2, excess); err != nil {
+			return nil, err
+		}
+		if variableRate, err = addSlopes(variableRate, s.p.VariableRateSlope1, s.p.VariableRateSlope2, excess); err != nil {
+			return nil, err
+		}
+	} else {
+		if stableRate, err = addSlope1(stableRate, s.p.StableRateSlope1, borrowUsage, s.p.OptimalUsageRatio); err != nil {
+			return nil, err
+		}
+		if variableRate, err = addSlope1(variableRate, s.p.VariableRateSlope1, borrowUsage, s.p.OptimalUsageRatio); err != nil {
+			return nil, err
+		}
+	}
+
+	if stableToTotal.Cmp(s.p.OptimalStableToTotalDebtRatio) > 0 {
+		excess, err := sub(stableToTotal, s.p.OptimalStableToTotalDebtRatio)
+		if err != nil {
+			return nil, err
+		}
+		if excess, err = rayDiv(excess, s.p.MaxExcessStableToTotalDebtRatio); err != nil {
+			return nil, err
+		}
+		offset, err := rayMul(s.p.StableRateExcessOffset, excess)
+		if err != nil {
+			return nil, err
+		}
+		if stableRate, err = add(stableRate, offset); err != nil {
+			return nil, err
+		}
+	}
+
+	overall, err := overallBorrowRate(stableDebt, variableDebt, variableRate, avgStable)
+	if err != nil {
+		return nil, err
+	}
+	liquidityRate, err := rayMul(overall, supplyUsage)
+	if err != nil {
+		return nil, err
+	}
+	share, err := sub(percentage, reserveFactor)
+	if err != nil {
+		return nil, err
+	}
+	if liquidityRate, err = percentMul(liquidityRate, share); err != nil {
+		return nil, err
+	}
+	return &AaveRates{LiquidityRate: liquidityRate, StableBorrowRate: stableRate, VariableBorrowRate: variableRate}, nil
+}
+
+// addSlopes는 rate + slope1 + slope2.rayMul(excess) 입니다 (최적 사용률 초과 구간).
+// addSlopes is rate + slope1 + slope2.rayMul(excess) (above the optimal usage ratio).
+func addSlopes(rate, slope1, slope2, excess *big.Int) (*big.Int, error) {
+	jump, err := rayMul(slope2, excess)
+	if err != nil {
+		return nil, err
+	}
+	inc, err := add(slope1, jump)
+	if err != nil {
+		return nil, err
+	}
+	return add(rate, inc)
+}
+
+// addSlope1은 rate + slope1.rayMul(usage).rayDiv(optimal) 입니다 (최적 사용률 이하 구간).
+// addSlope1 is rate + slope1.rayMul(usage).rayDiv(optimal) (at or below the optimal usage ratio).
+func addSlope1(rate, slope1, usage, optimal *big.Int) (*big.Int, error) {
+	inc, err := rayMul(slope1, usage)
+	if err != nil {
+		return nil, err
+	}
+	if inc, err = rayDiv(inc, optimal); err != nil {
+		return nil, err
+	}
+	return add(rate, inc)
+}
+
+// overallBorrowRate는 변동/고정 부채 가중 평균 대출 이자율입니다 (_getOverallBorrowRate).
+// overallBorrowRate is the variable/stable debt weighted average borrow rate (_getOverallBorrowRate).
+func overallBorrowRate(stableDebt, variableDebt, variableRate, avgStableRate *big.Int) (*big.Int, error) {
+	totalDebt, err := add(stableDebt, variableDebt)
+	if err != nil {
+		return nil, err
+	}
+	if totalDebt.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	weighted := func(debt, rate *big.Int) (*big.Int, error) {
+		r, err := wadToRay(debt)
+		if err != nil {
+			return nil, err
+		}
+		return rayMul(r, rate)
+	}
+	weightedVariable, err := weighted(variableDebt, variableRate)
+	if err != nil {
+		return nil, err
+	}
+	weightedStable, err := weighted(stableDebt, avgStableRate)
+	if err != nil {
+		return nil, err
+	}
+	sum, err := add(weightedVariable, weightedStable)
+	if err != nil {
+		return nil, err
+	}
+	totalRay, err := wadToRay(totalDebt)
+	if err != nil {
+		return nil, err
+	}
+	return rayDiv(sum, totalRay)
+}
+
+// AaveReserveState는 이자율 계산에 필요한 리저브 상태입니다.
+// AaveReserveState is the reserve state needed to calculate rates.
+type AaveReserveState struct {
+	AvailableLiquidity      *big.Int
+	Unbacked                *big.Int
+	TotalStableDebt         *big.Int
+	TotalVariableDebt       *big.Int
+	AverageStableBorrowRate *big.Int
+
+	// ReserveFactor는 bps입니다 (1000 = 10%).
+	// ReserveFactor is in bps (1000 = 10%).
+	ReserveFactor *big.Int
+
+	// Current는 마지막 상태 갱신 때 온체인에 저장된 이자율입니다.
+	// Current are the rates stored on chain at the last state update.
+	Current AaveRates
+}
+
+// Action은 가상의 리저브 작업입니다.
+// Action is a hypothetical reserve operation.
+type Action int
+
+const (
+	// Supply는 예치입니다 (유동성 증가).
+	// Supply is a deposit (adds liquidity).
+	Supply Action = iota
+
+	// Withdraw는 출금입니다 (유동성 감소).
+	// Withdraw is a withdrawal (takes liquidity).
+	Withdraw
+
+	// Borrow는 변동금리 대출입니다 (유동성 감소, 변동 부채 증가).
+	// Borrow is a variable-rate borrow (takes liquidity, adds variable debt).
+	Borrow
+
+	// Repay는 변동금리 상환입니다 (유동성 증가, 변동 부채 감소).
+	// Repay is a variable-rate repayment (adds liquidity, reduces variable debt).
+	Repay
+)
+
+// String은 작업 이름입니다.
+// String is the action name.
+func (a Action) String() string {
+	switch a {
+	case Supply:
+		return "supply"
+	case Withdraw:
+		return "withdraw"
+	case Borrow:
+		return "borrow"
+	case Repay:
+		return "repay"
+	}
+	return fmt.Sprintf("action(%d)", int(a))
+}
+
+// ParseAction은 작업 이름을 파싱합니다 (supply, deposit, withdraw, borrow, repay).
+// ParseAction parses an action name (supply, deposit, withdraw, borrow, repay).
+func ParseAction(s string) (Action, error) {
+	switch s {
+	case "supply", "deposit":
+		return Supply, nil
+	case "withdraw":
+		return Withdraw, nil
+	case "borrow":
+		return Borrow, nil
+	case "repay":
+		return Repay, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 작업 / unknown action: %q", s)
+}
+
+// Predict는 amount만큼의 가상 작업 직후의 이자율을 예측합니다.
+// Pool이 작업 끝에 updateInterestRates를 호출할 때와 같은 입력을 만듭니다
+// (대출이면 부채를 먼저 늘리고 liquidityTaken = amount).
+// Predict predicts the rates right after a hypothetical operation of amount.
+// It builds the same inputs the Pool passes to updateInterestRates at the end of the operation
+// (for a borrow the debt grows first and liquidityTaken = amount).
+func (s *AaveStrategy) Predict(state *AaveReserveState, action Action, amount *big.Int) (*AaveRates, error) {
+	in := AaveRateInputs{
+		AvailableLiquidity:      state.AvailableLiquidity,
+		Unbacked:                state.Unbacked,
+		TotalStableDebt:         state.TotalStableDebt,
+		TotalVariableDebt:       state.TotalVariableDebt,
+		AverageStableBorrowRate: state.AverageStableBorrowRate,
+		ReserveFactor:           state.ReserveFactor,
+	}
+	variableDebt := state.TotalVariableDebt
+	if variableDebt == nil {
+		variableDebt = new(big.Int)
+	}
+	switch action {
+	case Supply:
+		in.LiquidityAdded = amount
+	case Withdraw:
+		in.LiquidityTaken = amount
+	case Borrow:
+		in.LiquidityTaken = amount
+		in.TotalVariableDebt = new(big.Int).Add(variableDebt, amount)
+	case Repay:
+		repaid := amount
+		if repaid.Cmp(variableDebt) > 0 {
+			repaid = variableDebt
+		}
+		in.LiquidityAdded = repaid
+		in.TotalVariableDebt = new(big.Int).Sub(variableDebt, repaid)
+	default:
+		return nil, fmt.Errorf("알 수 없는 작업 / unknown action: %v", action)
+	}
+	return s.CalculateInterestRates(in)
+}
+
+// ReadAaveReserve는 리저브의 이자율 전략 파라미터와 현재 상태를 체인에서 읽습니다.
+// ReadAaveReserve reads a reserve's rate strategy parameters and current state from chain.
+//
+// 호출: Pool.getReserveData, 전략 getter 11개, DataProvider.getReserveData, balanceOf(aToken).
+// 전략 파라미터는 거버넌스로만 바뀌므로 여러 번 예측할 때는 한 번만 읽으면 됩니다.
+// Calls: Pool.getReserveData, 11 strategy getters, DataProvider.getReserveData, balanceOf(aToken).
+// Strategy parameters only change via governance, so read them once when predicting repeatedly.
+func ReadAaveReserve(opts *bind.CallOpts, backend bind.ContractCaller, pool *contracts.AavePoolCaller, data *contracts.AaveDataProviderCaller, asset common.Address) (*AaveStrategy, *AaveReserveState, error) {
+	rd, err := pool.GetReserveData(opts, asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	params, err := contracts.NewAaveRateStrategyCaller(backend, rd.InterestRateStrategyAddress).GetParams(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("이자율 전략 조회 실패 / failed to read rate strategy %s: %w", rd.InterestRateStrategyAddress.Hex(), err)
+	}
+	strategy, err := NewAaveStrategy(*params)
+	if err != nil {
+		return nil, nil, err
+	}
+	totals, err := data.GetReserveData(opts, asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	available, err := contracts.NewERC20Caller(backend, asset).BalanceOf(opts, rd.ATokenAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	return strategy, &AaveReserveState{
+		AvailableLiquidity:      available,
+		Unbacked:                totals.Unbacked,
+		TotalStableDebt:         totals.TotalStableDebt,
+		TotalVariableDebt:       totals.TotalVariableDebt,
+		AverageStableBorrowRate: totals.AverageStableBorrowRate,
+		ReserveFactor:           big.NewInt(int64(rd.Configuration.ReserveFactor)),
+		Current: AaveRates{
+			LiquidityRate:      rd.CurrentLiquidityRate,
+			StableBorrowRate:   rd.CurrentStableBorrowRate,
+			VariableBorrowRate: rd.CurrentVariableBorrowRate,
+		},
+	}, nil
+}
@@ -0,0 +1,195 @@
+package ratemodel
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// usdcStrategy는 메인넷 USDC와 비슷한 파라미터입니다 (최적 사용률 90%, slope1 4%, slope2 60%).
+// usdcStrategy has parameters similar to mainnet USDC (optimal usage 90%, slope1 4%, slope2 60%).
+func usdcStrategy(t *testing.T) *AaveStrategy {
+	t.Helper()
+	pct := func(num, den int64) *big.Int {
+		return new(big.Int).Quo(new(big.Int).Mul(Ray, big.NewInt(num)), big.NewInt(den))
+	}
+	s, err := NewAaveStrategy(contracts.AaveRateStrategyParams{
+		OptimalUsageRatio:               pct(90, 100),
+		OptimalStableToTotalDebtRatio:   pct(20, 100),
+		MaxExcessUsageRatio:             pct(10, 100),
+		MaxExcessStableToTotalDebtRatio: pct(80, 100),
+		BaseVariableBorrowRate:          new(big.Int),
+		VariableRateSlope1:              pct(4, 100),
+		VariableRateSlope2:              pct(60, 100),
+		StableRateSlope1:                pct(5, 1000),
+		StableRateSlope2:                pct(60, 100),
+		StableRateExcessOffset:          pct(8, 100),
+		BaseStableBorrowRate:            pct(5, 100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// usdc는 USDC 금액 (6 소수점)입니다.
+// usdc is a USDC amount (6 decimals).
+func usdc(v int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(v), big.NewInt(1e6))
+}
+
+func rayInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	return mustInt(t, s)
+}
+
+// 기대값은 컨트랙트 식을 그대로 옮긴 독립적인 정수 연산으로 계산했습니다.
+// Expected values were computed with an independent integer transcription of the contract formulas.
+func TestAaveCalculateInterestRates(t *testing.T) {
+	s := usdcStrategy(t)
+	avg11 := new(big.Int).Quo(new(big.Int).Mul(Ray, big.NewInt(11)), big.NewInt(100))
+	avg9 := new(big.Int).Quo(new(big.Int).Mul(Ray, big.NewInt(9)), big.NewInt(100))
+
+	tests := []struct {
+		name                      string
+		in                        AaveRateInputs
+		liquidity, stable, variab string
+	}{
+		{
+			name:      "no debt",
+			in:        AaveRateInputs{AvailableLiquidity: usdc(100_000_000), ReserveFactor: big.NewInt(1000)},
+			liquidity: "0", stable: "50000000000000000000000000", variab: "0",
+		},
+		{
+			name: "below optimal usage",
+			in: AaveRateInputs{AvailableLiquidity: usdc(200_000_000), TotalStableDebt: usdc(1_000_000),
+				TotalVariableDebt: usdc(600_000_000), AverageStableBorrowRate: avg11, ReserveFactor: big.NewInt(1000)},
+			liquidity: "22604857224349712671894382", stable: "54168400610348175891247052", variab: "33347204882785407129976419",
+		},
+		{
+			name: "above optimal usage",
+			in: AaveRateInputs{AvailableLiquidity: usdc(50_000_000), TotalVariableDebt: usdc(950_000_000),
+				ReserveFactor: big.NewInt(1000)},
+			liquidity: "290700000000000000000000000", stable: "355000000000000000000000000", variab: "340000000000000000000000000",
+		},
+		{
+			name: "stable debt above optimal ratio with unbacked",
+			in: AaveRateInputs{AvailableLiquidity: usdc(300_000_000), Unbacked: usdc(5_000_000),
+				TotalStableDebt: usdc(300_000_000), TotalVariableDebt: usdc(400_000_000),
+				AverageStableBorrowRate: avg9, ReserveFactor: big.NewInt(1500)},
+			liquidity: "33360972913211719181868060", stable: "76746031746031746031746032", variab: "31111111111111111111111111",
+		},
+		{
+			name: "rounding with odd amounts",
+			in: AaveRateInputs{AvailableLiquidity: big.NewInt(123456789012), Unbacked: big.NewInt(987),
+				TotalStableDebt: big.NewInt(3333333), TotalVariableDebt: big.NewInt(77777777777),
+				AverageStableBorrowRate: new(big.Int).Add(new(big.Int).Quo(Ray, big.NewInt(10)), big.NewInt(7)),
+				ReserveFactor:           big.NewInt(1234)},
+			liquidity: "5821533504785761158767761", stable: "52147295731482361747978867", variab: "17178365851858893983830931",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.CalculateInterestRates(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []struct {
+				field     string
+				got, want *big.Int
+			}{
+				{"LiquidityRate", got.LiquidityRate, rayInt(t, tt.liquidity)},
+				{"StableBorrowRate", got.StableBorrowRate, rayInt(t, tt.stable)},
+				{"VariableBorrowRate", got.VariableBorrowRate, rayInt(t, tt.variab)},
+			} {
+				if c.got.Cmp(c.want) != 0 {
+					t.Errorf("%s = %s, want %s", c.field, c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAavePredict(t *testing.T) {
+	s := usdcStrategy(t)
+	below := &AaveReserveState{
+		AvailableLiquidity: usdc(200_000_000), TotalStableDebt: usdc(1_000_000), TotalVariableDebt: usdc(600_000_000),
+		AverageStableBorrowRate: new(big.Int).Quo(new(big.Int).Mul(Ray, big.NewInt(11)), big.NewInt(100)),
+		ReserveFactor:           big.NewInt(1000),
+	}
+	above := &AaveReserveState{
+		AvailableLiquidity: usdc(50_000_000), TotalVariableDebt: usdc(950_000_000), ReserveFactor: big.NewInt(1000),
+	}
+
+	tests := []struct {
+		name              string
+		state             *AaveReserveState
+		action            Action
+		amount            *big.Int
+		liquidity, variab string
+		wantErr           bool
+	}{
+		{"borrow 100M", below, Borrow, usdc(100_000_000), "30715817151157806798929213", "38895824663614925787210431", false},
+		{"supply 100M", above, Supply, usdc(100_000_000), "29834710743801652892562273", "38383838383838383838383839", false},
+		{"repay 200M", above, Repay, usdc(200_000_000), "22500000000000000000000000", "33333333333333333333333333", false},
+		{"withdraw more than available", above, Withdraw, usdc(60_000_000), "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Predict(tt.state, tt.action, tt.amount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.LiquidityRate.Cmp(rayInt(t, tt.liquidity)) != 0 {
+				t.Errorf("LiquidityRate = %s, want %s", got.LiquidityRate, tt.liquidity)
+			}
+			if got.VariableBorrowRate.Cmp(rayInt(t, tt.variab)) != 0 {
+				t.Errorf("VariableBorrowRate = %s, want %s", got.VariableBorrowRate, tt.variab)
+			}
+		})
+	}
+}
+
+func TestRayMath(t *testing.T) {
+	half := new(big.Int).Rsh(Ray, 1)
+	tests := []struct {
+		name string
+		fn   func(a, b *big.Int) (*big.Int, error)
+		a, b *big.Int
+		want *big.Int
+	}{
+		// 0.5 ray × 1 wei = 0.5 → 반올림 1 / rounds half up to 1
+		{"rayMul half up", rayMul, half, big.NewInt(1), big.NewInt(1)},
+		{"rayMul below half", rayMul, new(big.Int).Sub(half, big.NewInt(1)), big.NewInt(1), big.NewInt(0)},
+		// 1 / 2 ray = 0.5 ray, 1 / 3 ray는 반올림 / 1 / 3 in ray rounds half up
+		{"rayDiv exact", rayDiv, big.NewInt(1), big.NewInt(2), half},
+		{"rayDiv round", rayDiv, big.NewInt(2), big.NewInt(3), mustInt(t, "666666666666666666666666667")},
+		{"percentMul half up", percentMul, big.NewInt(1), big.NewInt(5000), big.NewInt(1)},
+		{"percentMul below half", percentMul, big.NewInt(1), big.NewInt(4999), big.NewInt(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := rayMul(maxUint256, big.NewInt(1)); err != ErrOverflow {
+		t.Errorf("rayMul overflow: err = %v, want ErrOverflow", err)
+	}
+	if _, err := rayDiv(big.NewInt(1), new(big.Int)); err != ErrDivisionByZero {
+		t.Errorf("rayDiv by zero: err = %v, want ErrDivisionByZero", err)
+	}
+}
@@ -0,0 +1,73 @@
+package ratemodel
+
+import "math/big"
+
+// InterestRateModel은 contracts/src/InterestRateModel.sol의 Go 구현입니다 (LendingPool이 쓰는 예치금/대출금 기준).
+// InterestRateModel is the Go implementation of contracts/src/InterestRateModel.sol (deposits/borrows, as used by LendingPool).
+type InterestRateModel struct {
+	params Params
+}
+
+// NewInterestRateModel은 새로운 InterestRateModel을 생성합니다.
+// NewInterestRateModel creates a new InterestRateModel.
+func NewInterestRateModel(p Params) (*InterestRateModel, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &InterestRateModel{params: p}, nil
+}
+
+// Params는 모델 파라미터를 반환합니다.
+// Params returns the model parameters.
+func (m *InterestRateModel) Params() Params {
+	return m.params
+}
+
+// Utilization은 totalBorrows * PRECISION / totalDeposits 입니다 (예치금이나 대출금이 0이면 0).
+// Utilization is totalBorrows * PRECISION / totalDeposits (0 when deposits or borrows are zero).
+func (m *InterestRateModel) Utilization(totalDeposits, totalBorrows *big.Int) (*big.Int, error) {
+	if err := checkInputs(totalDeposits, totalBorrows); err != nil {
+		return nil, err
+	}
+	if totalDeposits.Sign() == 0 || totalBorrows.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	return mulDiv(totalBorrows, Precision, totalDeposits)
+}
+
+// BorrowRate는 연간 대출 이자율입니다 (getBorrowRate).
+// BorrowRate is the annual borrow rate (getBorrowRate).
+func (m *InterestRateModel) BorrowRate(totalDeposits, totalBorrows *big.Int) (*big.Int, error) {
+	utilization, err := m.Utilization(totalDeposits, totalBorrows)
+	if err != nil {
+		return nil, err
+	}
+	return m.params.borrowRate(utilization)
+}
+
+// BorrowRatePerSecond는 초당 대출 이자율입니다 (getBorrowRatePerSecond, 연간 이자율 / 365일 버림).
+// BorrowRatePerSecond is the per-second borrow rate (getBorrowRatePerSecond, annual rate / 365 days truncated).
+func (m *InterestRateModel) BorrowRatePerSecond(totalDeposits, totalBorrows *big.Int) (*big.Int, error) {
+	rate, err := m.BorrowRate(totalDeposits, totalBorrows)
+	if err != nil {
+		return nil, err
+	}
+	return div(rate, SecondsPerYear)
+}
+
+// SupplyRate는 준비금 비율을 뺀 연간 예치 이자율입니다 (getSupplyRate).
+// SupplyRate is the annual supply rate net of the reserve factor (getSupplyRate).
+func (m *InterestRateModel) SupplyRate(totalDeposits, totalBorrows, reserveFactor *big.Int) (*big.Int, error) {
+	if err := checkInputs(reserveFactor); err != nil {
+		return nil, err
+	}
+	borrowRate, err := m.BorrowRate(totalDeposits, totalBorrows)
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := m.Utilization(totalDeposits, totalBorrows)
+	if err != nil {
+		return nil, err
+	}
+	return supplyRate(borrowRate, utilization, reserveFactor)
+}
@@ -0,0 +1,88 @@
+package ratemodel
+
+import "math/big"
+
+// JumpRateModel은 contracts/src/JumpRateModel.sol의 Go 구현입니다 (Compound V2식 현금/대출금/준비금 기준).
+// JumpRateModel is the Go implementation of contracts/src/JumpRateModel.sol (Compound V2 style cash/borrows/reserves).
+type JumpRateModel struct {
+	params Params
+}
+
+// NewJumpRateModel은 새로운 JumpRateModel을 생성합니다.
+// NewJumpRateModel creates a new JumpRateModel.
+func NewJumpRateModel(p Params) (*JumpRateModel, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &JumpRateModel{params: p}, nil
+}
+
+// Params는 모델 파라미터를 반환합니다.
+// Params returns the model parameters.
+func (m *JumpRateModel) Params() Params {
+	return m.params
+}
+
+// Utilization은 borrows * PRECISION / (cash + borrows - reserves) 입니다 (대출금이 0이면 0).
+// 준비금이 현금 + 대출금보다 크면 ErrUnderflow, 같으면 ErrZeroLiquidity입니다.
+// Utilization is borrows * PRECISION / (cash + borrows - reserves) (0 when borrows are zero).
+// ErrUnderflow when reserves exceed cash + borrows, ErrZeroLiquidity when they are equal.
+func (m *JumpRateModel) Utilization(cash, borrows, reserves *big.Int) (*big.Int, error) {
+	if err := checkInputs(cash, borrows, reserves); err != nil {
+		return nil, err
+	}
+	if borrows.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	gross, err := add(cash, borrows)
+	if err != nil {
+		return nil, err
+	}
+	totalLiquidity, err := sub(gross, reserves)
+	if err != nil {
+		return nil, err
+	}
+	if totalLiquidity.Sign() == 0 {
+		return nil, ErrZeroLiquidity
+	}
+	return mulDiv(borrows, Precision, totalLiquidity)
+}
+
+// BorrowRate는 연간 대출 이자율입니다 (getBorrowRate).
+// BorrowRate is the annual borrow rate (getBorrowRate).
+func (m *JumpRateModel) BorrowRate(cash, borrows, reserves *big.Int) (*big.Int, error) {
+	utilization, err := m.Utilization(cash, borrows, reserves)
+	if err != nil {
+		return nil, err
+	}
+	return m.params.borrowRate(utilization)
+}
+
+// BorrowRatePerSecond는 초당 대출 이자율입니다 (연간 이자율 / 365일 버림).
+// 컨트랙트에는 없지만 InterestRateModel.getBorrowRatePerSecond와 같은 방식입니다.
+// BorrowRatePerSecond is the per-second borrow rate (annual rate / 365 days truncated).
+// Not on the contract, but computed like InterestRateModel.getBorrowRatePerSecond.
+func (m *JumpRateModel) BorrowRatePerSecond(cash, borrows, reserves *big.Int) (*big.Int, error) {
+	rate, err := m.BorrowRate(cash, borrows, reserves)
+	if err != nil {
+		return nil, err
+	}
+	return div(rate, SecondsPerYear)
+}
+
+// SupplyRate는 준비금 비율을 뺀 연간 예치 이자율입니다 (getSupplyRate).
+// SupplyRate is the annual supply rate net of the reserve factor (getSupplyRate).
+func (m *JumpRateModel) SupplyRate(cash, borrows, reserves, reserveFactor *big.Int) (*big.Int, error) {
+	if err := checkInputs(reserveFactor); err != nil {
+		return nil, err
+	}
+	borrowRate, err := m.BorrowRate(cash, borrows, reserves)
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := m.Utilization(cash, borrows, reserves)
+	if err != nil {
+		return nil, err
+	}
+	return supplyRate(borrowRate, utilization, reserveFactor)
+}
@@ -0,0 +1,134 @@
+// Package ratemodel은 스터디 컨트랙트의 이자율 모델을 Go로 옮긴 것입니다.
+// Package ratemodel is a Go port of the study contracts' interest rate models.
+//
+// contracts/src/InterestRateModel.sol (예치금/대출금 기준)과 JumpRateModel.sol (현금/대출금/준비금 기준)을
+// *big.Int 위에서 uint256 의미론 그대로 구현합니다:
+// - 모든 나눗셈은 Solidity처럼 버림
+// - 연산 순서도 Solidity 식과 같음 (예: a * b / PRECISION)
+// - Solidity 0.8에서 revert하는 경우 (오버플로, 언더플로, 0으로 나눔, require 실패)는 에러로 반환
+//
+// It implements contracts/src/InterestRateModel.sol (deposits/borrows) and JumpRateModel.sol
+// (cash/borrows/reserves) on *big.Int with uint256 semantics:
+// - Every division truncates like Solidity
+// - Operations are evaluated in the same order as the Solidity expressions (e.g. a * b / PRECISION)
+// - Cases that revert in Solidity 0.8 (overflow, underflow, division by zero, failed require) return errors
+//
+// 이자율은 연간 1e18 스케일입니다 (1e18 = 100%).
+// Rates are annual on a 1e18 scale (1e18 = 100%).
+//
+// AaveStrategy는 Aave V3 DefaultReserveInterestRateStrategy를 같은 방식으로 옮긴 것으로,
+// ray (1e27) 스케일과 반올림 WadRayMath/PercentageMath를 사용합니다.
+// AaveStrategy ports the Aave V3 DefaultReserveInterestRateStrategy the same way,
+// on the ray (1e27) scale with half-up WadRayMath/PercentageMath rounding.
+package ratemodel
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Precision은 사용률과 이자율의 고정소수점 단위입니다 (PRECISION = 1e18).
+// Precision is the fixed-point unit of utilization and rates (PRECISION = 1e18).
+var Precision = big.NewInt(1e18)
+
+// SecondsPerYear는 연간 이자율을 초당 이자율로 바꾸는 분모입니다 (365 days).
+// SecondsPerYear is the denominator converting annual rates to per-second rates (365 days).
+var SecondsPerYear = big.NewInt(365 * 24 * 60 * 60)
+
+// ErrZeroLiquidity는 JumpRateModel에서 현금 + 대출금 - 준비금이 0일 때 반환됩니다
+// (require(totalLiquidity > 0, "Total liquidity must be > 0")).
+// ErrZeroLiquidity is returned by JumpRateModel when cash + borrows - reserves is zero
+// (require(totalLiquidity > 0, "Total liquidity must be > 0")).
+var ErrZeroLiquidity = errors.New("총 유동성이 0 / total liquidity must be > 0")
+
+// Params는 두 모델이 공유하는 점프 곡선 파라미터입니다 (모두 1e18 스케일).
+// Params are the jump curve parameters shared by both models (all on a 1e18 scale).
+type Params struct {
+	// BaseRate는 사용률 0%의 연간 이자율입니다.
+	// BaseRate is the annual rate at 0% utilization.
+	BaseRate *big.Int
+
+	// Multiplier는 kink 이하의 기울기입니다.
+	// Multiplier is the slope below the kink.
+	Multiplier *big.Int
+
+	// JumpMultiplier는 kink 이상의 기울기입니다.
+	// JumpMultiplier is the slope above the kink.
+	JumpMultiplier *big.Int
+
+	// Kink는 최적 사용률입니다 (예: 0.8e18 = 80%).
+	// Kink is the optimal utilization (e.g. 0.8e18 = 80%).
+	Kink *big.Int
+}
+
+// validate는 파라미터가 모두 uint256 값인지 확인합니다.
+// validate checks that every parameter is a uint256 value.
+func (p Params) validate() error {
+	for _, v := range []*big.Int{p.BaseRate, p.Multiplier, p.JumpMultiplier, p.Kink} {
+		if v == nil {
+			return ErrNegative
+		}
+		if err := checkUint256(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// borrowRate는 사용률에 대한 점프 곡선 연간 대출 이자율입니다 (두 컨트랙트의 getBorrowRate 본문).
+// borrowRate is the jump curve's annual borrow rate for a utilization (the body of both contracts' getBorrowRate).
+func (p Params) borrowRate(utilization *big.Int) (*big.Int, error) {
+	if utilization.Cmp(p.Kink) <= 0 {
+		slope, err := mulDiv(utilization, p.Multiplier, Precision)
+		if err != nil {
+			return nil, err
+		}
+		return add(p.BaseRate, slope)
+	}
+
+	slope, err := mulDiv(p.Kink, p.Multiplier, Precision)
+	if err != nil {
+		return nil, err
+	}
+	normalRate, err := add(p.BaseRate, slope)
+	if err != nil {
+		return nil, err
+	}
+	excessUtil, err := sub(utilization, p.Kink)
+	if err != nil {
+		return nil, err
+	}
+	jump, err := mulDiv(excessUtil, p.JumpMultiplier, Precision)
+	if err != nil {
+		return nil, err
+	}
+	return add(normalRate, jump)
+}
+
+// supplyRate는 borrowRate * utilization / PRECISION * (PRECISION - reserveFactor) / PRECISION 입니다.
+// supplyRate is borrowRate * utilization / PRECISION * (PRECISION - reserveFactor) / PRECISION.
+func supplyRate(borrowRate, utilization, reserveFactor *big.Int) (*big.Int, error) {
+	rateToPool, err := mulDiv(borrowRate, utilization, Precision)
+	if err != nil {
+		return nil, err
+	}
+	share, err := sub(Precision, reserveFactor)
+	if err != nil {
+		return nil, err
+	}
+	return mulDiv(rateToPool, share, Precision)
+}
+
+// checkInputs는 입력이 모두 uint256 값인지 확인합니다.
+// checkInputs verifies that every input is a uint256 value.
+func checkInputs(vs ...*big.Int) error {
+	for _, v := range vs {
+		if v == nil {
+			return ErrNegative
+		}
+		if err := checkUint256(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package ratemodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// golden은 testdata/golden.json의 구조입니다.
+// 벡터는 contracts/script/golden_vectors.py가 컨트랙트 식을 그대로 옮긴 독립적인 정수 연산
+// (임의 정밀도 + uint256 범위 검사)으로 만들고, contracts/test/GoldenVectors.t.sol이 실제 컨트랙트로
+// 다시 확인합니다. "error"는 같은 입력에서 컨트랙트가 revert하는 이유입니다.
+// golden is the layout of testdata/golden.json.
+// contracts/script/golden_vectors.py produces the vectors from an independent integer transcription of
+// the contract formulas (arbitrary precision + uint256 range checks), and contracts/test/GoldenVectors.t.sol
+// re-checks them against the real contracts. "error" is why the contract reverts on that input.
+//
+// 재생성 / Regenerate (contracts/ 에서 / from contracts/):
+//
+//	python3 script/golden_vectors.py && forge test --match-contract GoldenVectors
+type golden struct {
+	Params map[string]struct {
+		BaseRate       string `json:"baseRate"`
+		Multiplier     string `json:"multiplier"`
+		JumpMultiplier string `json:"jumpMultiplier"`
+		Kink           string `json:"kink"`
+	} `json:"params"`
+	Cases []struct {
+		Model  string   `json:"model"`
+		Params string   `json:"params"`
+		Fn     string   `json:"fn"`
+		Args   []string `json:"args"`
+		Want   string   `json:"want"`
+		Error  string   `json:"error"`
+	} `json:"cases"`
+}
+
+var goldenErrors = map[string]error{
+	"overflow":         ErrOverflow,
+	"underflow":        ErrUnderflow,
+	"division by zero": ErrDivisionByZero,
+	"zero liquidity":   ErrZeroLiquidity,
+}
+
+func mustInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return v
+}
+
+func TestGolden(t *testing.T) {
+	raw, err := os.ReadFile("testdata/golden.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g golden
+	if err := json.Unmarshal(raw, &g); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range g.Cases {
+		gp, ok := g.Params[c.Params]
+		if !ok {
+			t.Fatalf("unknown params %q", c.Params)
+		}
+		p := Params{
+			BaseRate:       mustInt(t, gp.BaseRate),
+			Multiplier:     mustInt(t, gp.Multiplier),
+			JumpMultiplier: mustInt(t, gp.JumpMultiplier),
+			Kink:           mustInt(t, gp.Kink),
+		}
+		args := make([]*big.Int, len(c.Args))
+		for i, a := range c.Args {
+			args[i] = mustInt(t, a)
+		}
+
+		got, err := callGolden(p, c.Model, c.Fn, args)
+
+		name := c.Model + "." + c.Fn + "/" + c.Params
+		if c.Error != "" {
+			if want := goldenErrors[c.Error]; !errors.Is(err, want) {
+				t.Errorf("%s%v: error = %v, want %v", name, c.Args, err, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s%v: unexpected error %v", name, c.Args, err)
+			continue
+		}
+		if got.String() != c.Want {
+			t.Errorf("%s%v = %s, want %s", name, c.Args, got, c.Want)
+		}
+	}
+}
+
+// callGolden은 골든 케이스 하나의 모델 함수를 호출합니다.
+// callGolden calls the model function of one golden case.
+func callGolden(p Params, model, fn string, args []*big.Int) (*big.Int, error) {
+	switch model {
+	case "InterestRateModel":
+		m, err := NewInterestRateModel(p)
+		if err != nil {
+			return nil, err
+		}
+		switch fn {
+		case "utilization":
+			return m.Utilization(args[0], args[1])
+		case "borrowRate":
+			return m.BorrowRate(args[0], args[1])
+		case "borrowRatePerSecond":
+			return m.BorrowRatePerSecond(args[0], args[1])
+		case "supplyRate":
+			return m.SupplyRate(args[0], args[1], args[2])
+		}
+	case "JumpRateModel":
+		m, err := NewJumpRateModel(p)
+		if err != nil {
+			return nil, err
+		}
+		switch fn {
+		case "utilization":
+			return m.Utilization(args[0], args[1], args[2])
+		case "borrowRate":
+			return m.BorrowRate(args[0], args[1], args[2])
+		case "borrowRatePerSecond":
+			return m.BorrowRatePerSecond(args[0], args[1], args[2])
+		case "supplyRate":
+			return m.SupplyRate(args[0], args[1], args[2], args[3])
+		}
+	}
+	return nil, fmt.Errorf("unknown golden case %s.%s", model, fn)
+}
+
+// TestDocumentedValues는 contracts/test/JumpRateModel.t.sol의 단언을 그대로 확인합니다.
+// TestDocumentedValues checks the assertions from contracts/test/JumpRateModel.t.sol as-is.
+func TestDocumentedValues(t *testing.T) {
+	// 소수를 정확히 1e18 스케일로 변환 (float 곱셈은 0.07 같은 값에서 오차가 남)
+	// Convert decimals to the 1e18 scale exactly (float multiplication is off for values like 0.07)
+	e18 := func(f float64) *big.Int {
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+		r.Mul(r, new(big.Rat).SetInt(Precision))
+		return new(big.Int).Quo(r.Num(), r.Denom())
+	}
+	m, err := NewJumpRateModel(Params{BaseRate: e18(0.02), Multiplier: e18(0.1), JumpMultiplier: e18(1), Kink: e18(0.8)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                   string
+		cash, borrows, reserve float64
+		utilization, borrow    float64
+	}{
+		{"0%", 1000, 0, 0, 0, 0.02},
+		{"50%", 500, 500, 0, 0.5, 0.07},
+		{"kink", 200, 800, 0, 0.8, 0.10},
+		{"90%", 100, 900, 0, 0.9, 0.20},
+		{"100%", 0, 1000, 0, 1, 0.30},
+		{"with reserves", 400, 500, 100, 0.625, 0.0825},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cash, borrows, reserves := e18(tt.cash), e18(tt.borrows), e18(tt.reserve)
+			u, err := m.Utilization(cash, borrows, reserves)
+			if err != nil || u.Cmp(e18(tt.utilization)) != 0 {
+				t.Errorf("Utilization = %v (%v), want %v", u, err, e18(tt.utilization))
+			}
+			r, err := m.BorrowRate(cash, borrows, reserves)
+			if err != nil || r.Cmp(e18(tt.borrow)) != 0 {
+				t.Errorf("BorrowRate = %v (%v), want %v", r, err, e18(tt.borrow))
+			}
+		})
+	}
+
+	// 7% × 50% × 90% = 3.15%
+	s, err := m.SupplyRate(e18(500), e18(500), new(big.Int), e18(0.1))
+	if err != nil || s.Cmp(e18(0.0315)) != 0 {
+		t.Errorf("SupplyRate = %v (%v), want %v", s, err, e18(0.0315))
+	}
+}
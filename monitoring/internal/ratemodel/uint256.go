@@ -0,0 +1,88 @@
+package ratemodel
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrOverflow는 결과가 uint256 범위를 넘을 때 반환됩니다 (Solidity 0.8의 산술 오버플로 revert).
+	// ErrOverflow is returned when a result exceeds the uint256 range (Solidity 0.8's arithmetic overflow revert).
+	ErrOverflow = errors.New("uint256 오버플로 / uint256 overflow")
+
+	// ErrUnderflow는 뺄셈 결과가 음수일 때 반환됩니다 (Solidity 0.8의 산술 언더플로 revert).
+	// ErrUnderflow is returned when a subtraction goes negative (Solidity 0.8's arithmetic underflow revert).
+	ErrUnderflow = errors.New("uint256 언더플로 / uint256 underflow")
+
+	// ErrDivisionByZero는 0으로 나눌 때 반환됩니다 (Solidity의 division by zero panic).
+	// ErrDivisionByZero is returned on division by zero (Solidity's division by zero panic).
+	ErrDivisionByZero = errors.New("0으로 나눔 / division by zero")
+
+	// ErrNegative는 음수 입력에 대해 반환됩니다 (uint256으로 표현할 수 없음).
+	// ErrNegative is returned for negative inputs (not representable as uint256).
+	ErrNegative = errors.New("음수 입력 / negative input")
+)
+
+// maxUint256은 2^256 - 1입니다.
+// maxUint256 is 2^256 - 1.
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// checkUint256은 값이 uint256 범위 안인지 확인합니다.
+// checkUint256 verifies that a value lies within the uint256 range.
+func checkUint256(v *big.Int) error {
+	if v.Sign() < 0 {
+		return ErrNegative
+	}
+	if v.Cmp(maxUint256) > 0 {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// add는 검사된 uint256 덧셈입니다.
+// add is checked uint256 addition.
+func add(a, b *big.Int) (*big.Int, error) {
+	v := new(big.Int).Add(a, b)
+	if v.Cmp(maxUint256) > 0 {
+		return nil, ErrOverflow
+	}
+	return v, nil
+}
+
+// sub는 검사된 uint256 뺄셈입니다.
+// sub is checked uint256 subtraction.
+func sub(a, b *big.Int) (*big.Int, error) {
+	if a.Cmp(b) < 0 {
+		return nil, ErrUnderflow
+	}
+	return new(big.Int).Sub(a, b), nil
+}
+
+// mul은 검사된 uint256 곱셈입니다.
+// mul is checked uint256 multiplication.
+func mul(a, b *big.Int) (*big.Int, error) {
+	v := new(big.Int).Mul(a, b)
+	if v.Cmp(maxUint256) > 0 {
+		return nil, ErrOverflow
+	}
+	return v, nil
+}
+
+// div는 Solidity와 같이 버림하는 uint256 나눗셈입니다.
+// div is uint256 division truncating like Solidity.
+func div(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Int).Quo(a, b), nil
+}
+
+// mulDiv는 a * b / c를 Solidity의 평가 순서대로 계산합니다 (곱셈 오버플로 검사 후 버림).
+// mulDiv computes a * b / c in Solidity's evaluation order (overflow-checked multiply, then truncate).
+func mulDiv(a, b, c *big.Int) (*big.Int, error) {
+	v, err := mul(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return div(v, c)
+}
@@ -0,0 +1,64 @@
+package ratemodel
+
+import "math/big"
+
+var (
+	// Ray는 Aave 이자율과 비율의 고정소수점 단위입니다 (1e27).
+	// Ray is the fixed-point unit of Aave rates and ratios (1e27).
+	Ray = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
+
+	halfRay     = new(big.Int).Rsh(Ray, 1)
+	wadRayRatio = big.NewInt(1e9)
+	percentage  = big.NewInt(1e4)
+	halfPercent = big.NewInt(5e3)
+)
+
+// rayMul은 a × b / RAY를 반올림해 계산합니다 (Aave WadRayMath.rayMul).
+// a × b + HALF_RAY가 uint256을 넘으면 Aave와 같이 ErrOverflow입니다.
+// rayMul computes a × b / RAY rounded half up (Aave WadRayMath.rayMul).
+// ErrOverflow when a × b + HALF_RAY exceeds uint256, like Aave.
+func rayMul(a, b *big.Int) (*big.Int, error) {
+	v, err := mul(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = add(v, halfRay); err != nil {
+		return nil, err
+	}
+	return v.Quo(v, Ray), nil
+}
+
+// rayDiv는 a × RAY / b를 반올림해 계산합니다 (Aave WadRayMath.rayDiv).
+// rayDiv computes a × RAY / b rounded half up (Aave WadRayMath.rayDiv).
+func rayDiv(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	v, err := mul(a, Ray)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = add(v, new(big.Int).Rsh(b, 1)); err != nil {
+		return nil, err
+	}
+	return v.Quo(v, b), nil
+}
+
+// wadToRay는 wad 값을 ray로 바꿉니다 (× 1e9).
+// wadToRay converts a wad value to ray (× 1e9).
+func wadToRay(a *big.Int) (*big.Int, error) {
+	return mul(a, wadRayRatio)
+}
+
+// percentMul은 value × bps / 10000을 반올림해 계산합니다 (Aave PercentageMath.percentMul).
+// percentMul computes value × bps / 10000 rounded half up (Aave PercentageMath.percentMul).
+func percentMul(value, bps *big.Int) (*big.Int, error) {
+	v, err := mul(value, bps)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = add(v, halfPercent); err != nil {
+		return nil, err
+	}
+	return v.Quo(v, percentage), nil
+}
@@ -0,0 +1,74 @@
+package risk
+
+import (
+	"math/big"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// wad는 헬스팩터의 고정소수점 단위입니다 (1e18).
+// wad is the fixed-point unit of the health factor (1e18).
+var wad = big.NewInt(1e18)
+
+// AccountData는 리저브별 포지션으로 Pool.getUserAccountData를 다시 계산합니다.
+// AccountData recomputes Pool.getUserAccountData from a per-reserve position.
+//
+// Aave V3 GenericLogic.calculateUserAccountData와 같은 정수 연산을 씁니다:
+// - 청산기준이 0이 아니고 담보로 쓰는 리저브만 담보에 포함
+// - e-mode 카테고리 리저브는 카테고리의 LTV/청산기준 사용 (contracts.ApplyEMode로 미리 반영)
+// - 평균 LTV/청산기준은 담보 가치 가중 평균 (내림)
+// - HF = percentMul(총담보, 평균청산기준) wadDiv 총부채 (반올림)
+// 격리 모드는 부채 한도만 제한할 뿐 헬스팩터 계산에는 영향이 없습니다.
+//
+// It uses the same integer arithmetic as Aave V3 GenericLogic.calculateUserAccountData:
+// - Only reserves used as collateral with a non-zero liquidation threshold count as collateral
+// - Reserves in the e-mode category use the category LTV/threshold (applied beforehand by contracts.ApplyEMode)
+// - Average LTV/threshold are collateral-value-weighted averages (truncated)
+// - HF = percentMul(total collateral, average threshold) wadDiv total debt (rounded half up)
+// Isolation mode only caps borrowing via the debt ceiling and doesn't affect the health factor.
+func AccountData(pos *contracts.UserPosition) *contracts.UserAccountData {
+	collateral, debt := new(big.Int), new(big.Int)
+	ltvSum, ltSum := new(big.Int), new(big.Int)
+	for _, r := range pos.Reserves {
+		if r.UsedAsCollateral && r.LiquidationThreshold != nil && r.LiquidationThreshold.Sign() > 0 {
+			v := r.CollateralBase()
+			collateral.Add(collateral, v)
+			ltvSum.Add(ltvSum, new(big.Int).Mul(v, big.NewInt(int64(pos.EffectiveLTV(r)))))
+			ltSum.Add(ltSum, new(big.Int).Mul(v, r.LiquidationThreshold))
+		}
+		debt.Add(debt, r.DebtBase())
+	}
+
+	out := &contracts.UserAccountData{
+		TotalCollateralBase:         collateral,
+		TotalDebtBase:               debt,
+		AvailableBorrowsBase:        new(big.Int),
+		CurrentLiquidationThreshold: new(big.Int),
+		Ltv:                         new(big.Int),
+	}
+	if collateral.Sign() > 0 {
+		out.Ltv.Quo(ltvSum, collateral)
+		out.CurrentLiquidationThreshold.Quo(ltSum, collateral)
+	}
+
+	if borrowable := percentMul(collateral, out.Ltv); borrowable.Cmp(debt) > 0 {
+		out.AvailableBorrowsBase.Sub(borrowable, debt)
+	}
+
+	if debt.Sign() == 0 {
+		out.HealthFactor = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		return out
+	}
+	hf := new(big.Int).Mul(percentMul(collateral, out.CurrentLiquidationThreshold), wad)
+	hf.Add(hf, new(big.Int).Rsh(debt, 1))
+	out.HealthFactor = hf.Quo(hf, debt)
+	return out
+}
+
+// percentMul은 value × bps / 10000을 반올림해 계산합니다 (Aave PercentageMath.percentMul과 같음).
+// percentMul computes value × bps / 10000 rounded half up (same as Aave PercentageMath.percentMul).
+func percentMul(value, bps *big.Int) *big.Int {
+	v := new(big.Int).Mul(value, bps)
+	v.Add(v, big.NewInt(bpsDenominator/2))
+	return v.Quo(v, big.NewInt(bpsDenominator))
+}
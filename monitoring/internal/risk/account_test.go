@@ -0,0 +1,238 @@
+package risk
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// reserve는 테스트용 리저브 포지션을 만듭니다. 금액은 자산 단위 (소수점 적용 전) 입니다.
+// reserve builds a reserve position for tests. Amounts are in whole asset units (before decimals).
+type reserve struct {
+	symbol      string
+	decimals    uint8
+	price       float64 // USD
+	ltv, lt     uint16
+	emode       uint8
+	debtCeiling uint64 // USD 2 소수점 / USD with 2 decimals
+	collateral  int64
+	debt        int64
+	disabled    bool // 담보 비활성 / collateral disabled
+}
+
+func (r reserve) position() contracts.ReservePosition {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(r.decimals)), nil)
+	price, _ := new(big.Float).Mul(big.NewFloat(r.price), big.NewFloat(1e8)).Int(nil)
+	return contracts.ReservePosition{
+		Reserve: contracts.Reserve{
+			Asset:                common.BytesToAddress([]byte(r.symbol)),
+			Symbol:               r.symbol,
+			Decimals:             r.decimals,
+			LiquidationThreshold: big.NewInt(int64(r.lt)),
+			LiquidationBonus:     big.NewInt(10500),
+			Config: contracts.ReserveConfigurationMap{
+				LTV:                  r.ltv,
+				LiquidationThreshold: r.lt,
+				Decimals:             r.decimals,
+				Active:               true,
+				EModeCategory:        r.emode,
+				DebtCeiling:          r.debtCeiling,
+			},
+		},
+		Collateral:       new(big.Int).Mul(big.NewInt(r.collateral), unit),
+		Debt:             new(big.Int).Mul(big.NewInt(r.debt), unit),
+		UsedAsCollateral: r.collateral > 0 && !r.disabled,
+		Price:            price,
+	}
+}
+
+var (
+	ethCategory    = &contracts.EModeCategory{ID: 1, LTV: 9300, LiquidationThreshold: 9500, LiquidationBonus: 10100, Label: "ETH correlated"}
+	stableCategory = &contracts.EModeCategory{ID: 2, LTV: 9700, LiquidationThreshold: 9750, LiquidationBonus: 10100, PriceSource: common.HexToAddress("0x01"), Label: "Stablecoins"}
+)
+
+func TestAccountData(t *testing.T) {
+	tests := []struct {
+		name        string
+		reserves    []reserve
+		emode       *contracts.EModeCategory
+		sourcePrice int64 // 기본 통화 / base currency
+
+		// getUserAccountData 기대값 / expected getUserAccountData values
+		collateral, debt, available int64
+		lt, ltv                     int64
+		hf                          string
+		isolated                    bool
+	}{
+		{
+			name: "base mode",
+			reserves: []reserve{
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, emode: 1, collateral: 10},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 15000},
+			},
+			collateral: 2_000_000_000_000, debt: 1_500_000_000_000, available: 100_000_000_000,
+			lt: 8250, ltv: 8000, hf: "1100000000000000000",
+		},
+		{
+			// e-mode가 없으면 HF 0.9315로 청산 가능 / Liquidatable at HF 0.9315 without e-mode
+			name: "e-mode category applies",
+			reserves: []reserve{
+				{symbol: "wstETH", decimals: 18, price: 2300, ltv: 7850, lt: 8100, emode: 1, collateral: 10},
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, emode: 1, debt: 10},
+			},
+			emode:      ethCategory,
+			collateral: 2_300_000_000_000, debt: 2_000_000_000_000, available: 139_000_000_000,
+			lt: 9500, ltv: 9300, hf: "1092500000000000000",
+		},
+		{
+			name: "e-mode mixed with reserve outside the category",
+			reserves: []reserve{
+				{symbol: "wstETH", decimals: 18, price: 2300, ltv: 7850, lt: 8100, emode: 1, collateral: 10},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, emode: 2, collateral: 10000},
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, emode: 1, debt: 10},
+			},
+			emode:      ethCategory,
+			collateral: 3_300_000_000_000, debt: 2_000_000_000_000, available: 908_950_000_000,
+			lt: 8984, ltv: 8815, hf: "1482360000000000000",
+		},
+		{
+			name: "e-mode ignores collateral with zero base threshold",
+			reserves: []reserve{
+				{symbol: "wstETH", decimals: 18, price: 2300, ltv: 0, lt: 0, emode: 1, collateral: 10},
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, emode: 1, collateral: 5},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 5000},
+			},
+			emode:      ethCategory,
+			collateral: 1_000_000_000_000, debt: 500_000_000_000, available: 430_000_000_000,
+			lt: 9500, ltv: 9300, hf: "1900000000000000000",
+		},
+		{
+			// LTV 0 담보는 청산기준에는 들어가지만 e-mode에서도 대출 한도에는 기여하지 않음
+			// Collateral with LTV 0 counts towards the liquidation threshold but adds no borrowing power, even in e-mode
+			name: "e-mode keeps zero LTV collateral at zero",
+			reserves: []reserve{
+				{symbol: "wstETH", decimals: 18, price: 2300, ltv: 0, lt: 8100, emode: 1, collateral: 10},
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, emode: 1, collateral: 5},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 5000},
+			},
+			emode:      ethCategory,
+			collateral: 3_300_000_000_000, debt: 500_000_000_000, available: 429_940_000_000,
+			lt: 9500, ltv: 2818, hf: "6270000000000000000",
+		},
+		{
+			name: "e-mode price source overrides reserve prices",
+			reserves: []reserve{
+				{symbol: "DAI", decimals: 18, price: 1.01, ltv: 6300, lt: 7700, emode: 2, collateral: 10000},
+				{symbol: "USDC", decimals: 6, price: 0.99, ltv: 7700, lt: 7800, emode: 2, debt: 9000},
+			},
+			emode: stableCategory, sourcePrice: 100_000_000,
+			collateral: 1_000_000_000_000, debt: 900_000_000_000, available: 70_000_000_000,
+			lt: 9750, ltv: 9700, hf: "1083333333333333333",
+		},
+		{
+			name: "collateral disabled does not count",
+			reserves: []reserve{
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, collateral: 10},
+				{symbol: "WBTC", decimals: 8, price: 60000, ltv: 7300, lt: 7800, collateral: 1, disabled: true},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 15000},
+			},
+			collateral: 2_000_000_000_000, debt: 1_500_000_000_000, available: 100_000_000_000,
+			lt: 8250, ltv: 8000, hf: "1100000000000000000",
+		},
+		{
+			// 격리 모드는 헬스팩터를 바꾸지 않음 / Isolation mode doesn't change the health factor
+			name: "isolated collateral",
+			reserves: []reserve{
+				{symbol: "CRV", decimals: 18, price: 0.5, ltv: 3500, lt: 4100, debtCeiling: 500_000_000, collateral: 100000},
+				{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 10000},
+			},
+			collateral: 5_000_000_000_000, debt: 1_000_000_000_000, available: 750_000_000_000,
+			lt: 4100, ltv: 3500, hf: "2050000000000000000", isolated: true,
+		},
+		{
+			name: "no debt",
+			reserves: []reserve{
+				{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, collateral: 1},
+			},
+			collateral: 200_000_000_000, available: 160_000_000_000,
+			lt: 8250, ltv: 8000, hf: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)).String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := &contracts.UserPosition{}
+			for _, r := range tt.reserves {
+				pos.Reserves = append(pos.Reserves, r.position())
+			}
+			if tt.emode != nil {
+				contracts.ApplyEMode(pos, tt.emode, big.NewInt(tt.sourcePrice))
+			}
+
+			got := AccountData(pos)
+			check := func(field string, got *big.Int, want int64) {
+				t.Helper()
+				if got.Cmp(big.NewInt(want)) != 0 {
+					t.Errorf("%s = %s, want %d", field, got, want)
+				}
+			}
+			check("TotalCollateralBase", got.TotalCollateralBase, tt.collateral)
+			check("TotalDebtBase", got.TotalDebtBase, tt.debt)
+			check("AvailableBorrowsBase", got.AvailableBorrowsBase, tt.available)
+			check("CurrentLiquidationThreshold", got.CurrentLiquidationThreshold, tt.lt)
+			check("Ltv", got.Ltv, tt.ltv)
+			if got.HealthFactor.String() != tt.hf {
+				t.Errorf("HealthFactor = %s, want %s", got.HealthFactor, tt.hf)
+			}
+
+			// 충격 없는 부동소수점 HF도 거의 같아야 함 (온체인은 평균 청산기준을 bps로 내림)
+			// The unshocked float HF must nearly agree (on-chain truncates the average threshold to bps)
+			if tt.debt > 0 {
+				hf, _ := ShockedHealthFactor(pos, common.Address{}, 0)
+				want, _ := new(big.Float).Quo(new(big.Float).SetInt(got.HealthFactor), big.NewFloat(1e18)).Float64()
+				if math.Abs(hf-want) > 1e-3 {
+					t.Errorf("ShockedHealthFactor = %v, want %v", hf, want)
+				}
+			}
+
+			if _, isolated := pos.Isolation(); isolated != tt.isolated {
+				t.Errorf("Isolation() = %t, want %t", isolated, tt.isolated)
+			}
+		})
+	}
+}
+
+func TestLiquidationPricesEMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		emode *contracts.EModeCategory
+		want  float64 // wstETH 청산 가격 / wstETH liquidation price
+	}{
+		// 10 × p × 0.81 = 20000 → p = 2469.14
+		{name: "base mode", want: 20000 / (10 * 0.81)},
+		// 10 × p × 0.95 = 20000 → p = 2105.26
+		{name: "e-mode", emode: ethCategory, want: 20000 / (10 * 0.95)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := &contracts.UserPosition{Reserves: []contracts.ReservePosition{
+				reserve{symbol: "wstETH", decimals: 18, price: 2300, ltv: 7850, lt: 8100, emode: 1, collateral: 10}.position(),
+				reserve{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 20000}.position(),
+			}}
+			if tt.emode != nil {
+				contracts.ApplyEMode(pos, tt.emode, nil)
+			}
+			prices := LiquidationPrices(pos)
+			if len(prices) != 1 {
+				t.Fatalf("got %d liquidation prices, want 1", len(prices))
+			}
+			if math.Abs(prices[0].LiquidationPrice-tt.want) > 1e-6 {
+				t.Errorf("LiquidationPrice = %v, want %v", prices[0].LiquidationPrice, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package risk
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+// Options는 충격 분석기 설정입니다.
+// Options configures the shock analyzer.
+type Options struct {
+	// Protocol은 메트릭 라벨에 사용할 프로토콜 이름입니다.
+	// Protocol is the protocol name used in metric labels.
+	Protocol string
+
+	// Shocks는 가격 충격 시나리오입니다 (음수 = 하락).
+	// Shocks are the price shock scenarios (negative = drop).
+	Shocks []float64
+
+	// Interval은 분석 주기입니다.
+	// Interval is how often the analysis runs.
+	Interval time.Duration
+
+	// MaxAccounts는 분석할 최대 계정 수입니다 (헬스팩터 낮은 순).
+	// MaxAccounts caps the number of accounts analyzed (lowest health factor first).
+	MaxAccounts int
+
+	// CallTimeout은 계정 하나의 포지션 조회 타임아웃입니다.
+	// CallTimeout is the timeout for reading one account's position.
+	CallTimeout time.Duration
+}
+
+// DefaultOptions는 기본 설정을 반환합니다.
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		Protocol:    "aave-v3",
+		Shocks:      DefaultShocks,
+		Interval:    5 * time.Minute,
+		MaxAccounts: 200,
+		CallTimeout: 30 * time.Second,
+	}
+}
+
+// seriesKey는 DebtAtRiskUSD 시계열 하나를 식별합니다.
+// seriesKey identifies one DebtAtRiskUSD series.
+type seriesKey struct {
+	asset string
+	shock string
+}
+
+// Analyzer는 주기적으로 후보 계정의 리저브별 포지션을 읽어 충격 시 위험 부채를 계산합니다.
+// Analyzer periodically reads candidates' per-reserve positions and computes the debt at risk under shocks.
+type Analyzer struct {
+	reader *contracts.PositionReader
+	opts   Options
+	logger *slog.Logger
+	series map[seriesKey]bool
+}
+
+// NewAnalyzer는 새로운 Analyzer를 생성합니다.
+// NewAnalyzer creates a new Analyzer.
+func NewAnalyzer(reader *contracts.PositionReader, opts Options, logger *slog.Logger) *Analyzer {
+	return &Analyzer{
+		reader: reader,
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// Run은 ctx가 끝날 때까지 Interval마다 분석을 실행합니다.
+// candidates는 마지막 모니터링 사이클의 주소 → 헬스팩터입니다.
+// Run executes the analysis every Interval until ctx is done.
+// candidates returns address → health factor from the latest monitoring cycle.
+func (a *Analyzer) Run(ctx context.Context, candidates func() map[common.Address]float64) {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.Analyze(ctx, candidates()); err != nil && ctx.Err() == nil {
+				a.logger.Warn("충격 분석 실패 / Shock analysis failed", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Analyze는 한 번의 충격 분석을 실행하고 메트릭을 갱신합니다.
+// Analyze runs one shock analysis and updates the metrics.
+//
+// 자산 하나의 가격이 s만큼 떨어지면 헬스팩터는 최대 (1 - s)배로 줄어들므로,
+// HF ≥ 1/(1 - 최대 충격)인 계정은 어떤 시나리오에서도 청산 가능해질 수 없어 건너뜁니다.
+// When one asset's price drops by s the health factor shrinks by at most a factor of (1 - s), so
+// accounts with HF ≥ 1/(1 - max shock) cannot become liquidatable in any scenario and are skipped.
+func (a *Analyzer) Analyze(ctx context.Context, candidates map[common.Address]float64) error {
+	start := time.Now()
+	accounts := a.selectCandidates(candidates)
+
+	opts := &bind.CallOpts{Context: ctx}
+	reserves, err := a.reader.CachedReserves(opts, contracts.ReservesTTL)
+	if err != nil {
+		return err
+	}
+	prices, err := a.reader.Prices(opts, reserves)
+	if err != nil {
+		return err
+	}
+
+	var positions []*contracts.UserPosition
+	for _, addr := range accounts {
+		callCtx, cancel := context.WithTimeout(ctx, a.opts.CallTimeout)
+		pos, err := a.reader.UserPosition(&bind.CallOpts{Context: callCtx}, addr, reserves, prices)
+		cancel()
+		if err != nil {
+			a.logger.Warn("포지션 조회 실패 / Failed to read position", "address", addr.Hex(), "error", err)
+			continue
+		}
+		positions = append(positions, pos)
+	}
+
+	a.publish(reserves, DebtAtRisk(positions, a.opts.Shocks))
+	metrics.RiskAccountsAnalyzed.Set(float64(len(positions)))
+	a.logger.Info("충격 분석 완료 / Shock analysis complete",
+		"accounts", len(positions),
+		"candidates", len(candidates),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return nil
+}
+
+// selectCandidates는 충격으로 청산 가능해질 수 있는 계정을 헬스팩터 낮은 순으로 고릅니다.
+// selectCandidates picks the accounts a shock could make liquidatable, lowest health factor first.
+func (a *Analyzer) selectCandidates(candidates map[common.Address]float64) []common.Address {
+	maxDrop := 0.0
+	for _, s := range a.opts.Shocks {
+		if -s > maxDrop {
+			maxDrop = -s
+		}
+	}
+	limit := 1.0
+	if maxDrop < 1 {
+		limit = 1 / (1 - maxDrop)
+	}
+
+	var accounts []common.Address
+	for addr, hf := range candidates {
+		if hf >= 1 && hf < limit {
+			accounts = append(accounts, addr)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return candidates[accounts[i]] < candidates[accounts[j]] })
+	if a.opts.MaxAccounts > 0 && len(accounts) > a.opts.MaxAccounts {
+		accounts = accounts[:a.opts.MaxAccounts]
+	}
+	return accounts
+}
+
+// publish는 모든 리저브 × 충격 조합을 (위험 부채가 없으면 0으로) 내고 사라진 리저브의 시계열을 삭제합니다.
+// publish exports every reserve × shock combination (0 when nothing is at risk) and deletes series of removed reserves.
+func (a *Analyzer) publish(reserves []contracts.Reserve, atRisk map[common.Address]map[float64]float64) {
+	series := make(map[seriesKey]bool)
+	for _, r := range reserves {
+		for _, shock := range a.opts.Shocks {
+			key := seriesKey{r.Symbol, ShockLabel(shock)}
+			series[key] = true
+			metrics.DebtAtRiskUSD.WithLabelValues(a.opts.Protocol, key.asset, key.shock).Set(atRisk[r.Asset][shock])
+		}
+	}
+	for key := range a.series {
+		if !series[key] {
+			metrics.DebtAtRiskUSD.DeleteLabelValues(a.opts.Protocol, key.asset, key.shock)
+		}
+	}
+	a.series = series
+}
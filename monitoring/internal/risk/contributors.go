@@ -0,0 +1,99 @@
+package risk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// Contribution은 포지션의 담보 또는 부채에서 자산 하나가 차지하는 몫입니다.
+// Contribution is one asset's share of a position's collateral or debt.
+type Contribution struct {
+	Symbol string
+	USD    float64
+
+	// Share는 담보 또는 부채 합계에서 차지하는 비율입니다 (0..1).
+	// Share is the fraction of the collateral or debt total (0..1).
+	Share float64
+}
+
+// TopContributors는 담보와 부채 각각에서 가치가 큰 순서로 최대 n개의 자산을 반환합니다.
+// 담보는 담보로 쓰는 예치금만 셉니다.
+// TopContributors returns up to n assets each for collateral and debt, largest value first.
+// Only supplied balances used as collateral count towards collateral.
+func TopContributors(pos *contracts.UserPosition, n int) (collateral, debt []Contribution) {
+	var collateralTotal, debtTotal float64
+	for _, r := range pos.Reserves {
+		if r.UsedAsCollateral {
+			if v := r.CollateralUSD(); v > 0 {
+				collateral = append(collateral, Contribution{Symbol: r.Symbol, USD: v})
+				collateralTotal += v
+			}
+		}
+		if v := r.DebtUSD(); v > 0 {
+			debt = append(debt, Contribution{Symbol: r.Symbol, USD: v})
+			debtTotal += v
+		}
+	}
+	return rank(collateral, collateralTotal, n), rank(debt, debtTotal, n)
+}
+
+// rank는 기여분을 가치 순으로 정렬하고 비율을 채운 뒤 n개로 자릅니다.
+// rank sorts contributions by value, fills in the shares and truncates to n.
+func rank(cs []Contribution, total float64, n int) []Contribution {
+	sort.Slice(cs, func(i, j int) bool { return cs[i].USD > cs[j].USD })
+	for i := range cs {
+		cs[i].Share = cs[i].USD / total
+	}
+	if n > 0 && len(cs) > n {
+		cs = cs[:n]
+	}
+	return cs
+}
+
+// FormatContributions는 기여분을 알림용 문자열로 만듭니다 (예: "WETH $1.20M (82%), WBTC $250.0K (17%)").
+// FormatContributions renders contributions for alerts (e.g. "WETH $1.20M (82%), WBTC $250.0K (17%)").
+func FormatContributions(cs []Contribution) string {
+	parts := make([]string, len(cs))
+	for i, c := range cs {
+		parts[i] = fmt.Sprintf("%s %s (%.0f%%)", c.Symbol, formatUSD(c.USD), c.Share*100)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatUSD는 달러 금액을 K/M/B 단위로 줄여 표시합니다.
+// formatUSD renders a dollar amount abbreviated with K/M/B.
+func formatUSD(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("$%.2fB", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("$%.2fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("$%.1fK", v/1e3)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+// AddBreakdown은 알림 메타데이터에 담보/부채 상위 자산을 추가합니다 (top_collateral, top_debt).
+// e-mode 카테고리와 격리 모드 부채 한도도 있으면 함께 추가합니다.
+// AddBreakdown adds the top collateral and debt assets to alert metadata (top_collateral, top_debt).
+// The e-mode category and isolation mode debt ceiling are added too when present.
+func AddBreakdown(md map[string]string, pos *contracts.UserPosition, n int) {
+	collateral, debt := TopContributors(pos, n)
+	if len(collateral) > 0 {
+		md["top_collateral"] = FormatContributions(collateral)
+	}
+	if len(debt) > 0 {
+		md["top_debt"] = FormatContributions(debt)
+	}
+	if pos.EMode != nil {
+		md["emode_category"] = fmt.Sprintf("%d (%s, lt=%.2f%%)", pos.EMode.ID, pos.EMode.Label, float64(pos.EMode.LiquidationThreshold)/100)
+	}
+	if iso, ok := pos.Isolation(); ok {
+		md["isolation_asset"] = iso.Symbol
+		md["isolation_debt_usd"] = fmt.Sprintf("%s / %s", formatUSD(iso.TotalDebtUSD()), formatUSD(iso.DebtCeilingUSD()))
+	}
+}
@@ -0,0 +1,86 @@
+package risk
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// year는 Aave가 이자율 계산에 쓰는 1년입니다 (365일).
+// year is the year Aave uses for rate math (365 days).
+const year = 365 * 24 * time.Hour
+
+// InterestDrift는 가격이 고정일 때 이자만으로 변하는 헬스팩터의 연간 로그 변화율입니다.
+// InterestDrift is the annual log rate of change of the health factor from interest alone, prices held fixed.
+//
+// 담보는 예치 이자율, 부채는 대출 이자율로 불어나므로 (각각 가치 가중 평균):
+// Collateral grows at the supply rate and debt at the borrow rate (each value-weighted):
+//
+//	d ln(HF) / dt ≈ 담보 예치 이자율 - 부채 대출 이자율
+//	d ln(HF) / dt ≈ collateral supply rate - debt borrow rate
+//
+// 음수면 이자만으로 헬스팩터가 떨어집니다. 부채는 모두 변동금리로 계산합니다
+// (V3에서 고정금리 대출은 사실상 중단됨).
+// Negative means interest alone pushes the health factor down. All debt is treated as variable-rate
+// (stable borrowing is effectively retired on V3).
+//
+// 이자율이 없는 리저브는 계산에서 빠지고 그 심볼을 missing으로 반환합니다. 빠진 부채는 부채 증가를
+// 과소평가하므로 호출자가 기록해야 합니다.
+// Reserves without a rate are left out of the calculation and their symbols are returned in missing.
+// Leaving out debt understates debt growth, so the caller should report them.
+func InterestDrift(pos *contracts.UserPosition, rates map[common.Address]*contracts.ReserveData) (drift float64, missing []string) {
+	var collateral, supplyWeighted, debt, borrowWeighted float64
+	for _, r := range pos.Reserves {
+		rate := rates[r.Asset]
+		if rate == nil {
+			if adjustedCollateralUSD(r) > 0 || baseToUSD(r.DebtBase()) > 0 {
+				missing = append(missing, r.Symbol)
+			}
+			continue
+		}
+		if c := adjustedCollateralUSD(r); c > 0 {
+			collateral += c
+			supplyWeighted += c * rayToFloat(rate.LiquidityRate)
+		}
+		if d := baseToUSD(r.DebtBase()); d > 0 {
+			debt += d
+			borrowWeighted += d * rayToFloat(rate.VariableBorrowRate)
+		}
+	}
+	if collateral == 0 || debt == 0 {
+		return 0, missing
+	}
+	return supplyWeighted/collateral - borrowWeighted/debt, missing
+}
+
+// InterestTimeToLiquidation은 이자만으로 헬스팩터가 1.0에 도달하는 예상 시간입니다.
+// 이미 1.0 미만이면 0, 이자로 헬스팩터가 떨어지지 않으면 false를 반환합니다.
+// InterestTimeToLiquidation is the projected time until interest alone brings the health factor to 1.0.
+// Returns 0 when already below 1.0, and false when interest does not push the health factor down.
+func InterestTimeToLiquidation(hf, drift float64) (time.Duration, bool) {
+	if hf <= 1 {
+		return 0, true
+	}
+	if drift >= 0 {
+		return 0, false
+	}
+	years := math.Log(hf) / -drift
+	if years*float64(year) > math.MaxInt64 {
+		return 0, false
+	}
+	return time.Duration(years * float64(year)), true
+}
+
+// rayToFloat은 ray (1e27) 값을 float64로 변환합니다.
+// rayToFloat converts a ray (1e27) value to float64.
+func rayToFloat(v *big.Int) float64 {
+	if v == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e27)).Float64()
+	return f
+}
@@ -0,0 +1,87 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// rate는 연이율 (%)을 ray로 변환합니다.
+// rate converts an annual rate in percent to a ray.
+func rate(pct int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(pct), new(big.Int).Exp(big.NewInt(10), big.NewInt(25), nil))
+}
+
+func TestInterestDrift(t *testing.T) {
+	weth := reserve{symbol: "WETH", decimals: 18, price: 2000, ltv: 8000, lt: 8250, collateral: 10}
+	usdc := reserve{symbol: "USDC", decimals: 6, price: 1, ltv: 7700, lt: 7800, debt: 15000}
+	dai := reserve{symbol: "DAI", decimals: 18, price: 1, ltv: 7500, lt: 8000, debt: 5000}
+	rates := map[common.Address]*contracts.ReserveData{
+		weth.position().Asset: {LiquidityRate: rate(2), VariableBorrowRate: rate(3)},
+		usdc.position().Asset: {LiquidityRate: rate(4), VariableBorrowRate: rate(5)},
+		dai.position().Asset:  {LiquidityRate: rate(6), VariableBorrowRate: rate(9)},
+	}
+
+	tests := []struct {
+		name        string
+		reserves    []reserve
+		drop        []reserve // 이자율을 뺄 리저브 / reserves whose rate is removed
+		want        float64
+		wantMissing []string
+	}{
+		{"single debt", []reserve{weth, usdc}, nil, 0.02 - 0.05, nil},
+		{"debt weighted by value", []reserve{weth, usdc, dai}, nil, 0.02 - (15000*0.05+5000*0.09)/20000, nil},
+		{"debt reserve without rate", []reserve{weth, usdc, dai}, []reserve{dai}, 0.02 - 0.05, []string{"DAI"}},
+		{"collateral reserve without rate", []reserve{weth, usdc}, []reserve{weth}, 0, []string{"WETH"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := &contracts.UserPosition{}
+			for _, r := range tt.reserves {
+				pos.Reserves = append(pos.Reserves, r.position())
+			}
+			available := make(map[common.Address]*contracts.ReserveData, len(rates))
+			for asset, rd := range rates {
+				available[asset] = rd
+			}
+			for _, r := range tt.drop {
+				delete(available, r.position().Asset)
+			}
+
+			got, missing := InterestDrift(pos, available)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("drift = %v, want %v", got, tt.want)
+			}
+			if fmt.Sprint(missing) != fmt.Sprint(tt.wantMissing) {
+				t.Errorf("missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestInterestTimeToLiquidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		hf     float64
+		drift  float64
+		want   time.Duration
+		wantOK bool
+	}{
+		{"already liquidatable", 0.99, -0.05, 0, true},
+		{"rising", 1.5, 0.01, 0, false},
+		{"flat", 1.5, 0, 0, false},
+		{"falling", math.E, -1, year, true},
+	}
+	for _, tt := range tests {
+		got, ok := InterestTimeToLiquidation(tt.hf, tt.drift)
+		if ok != tt.wantOK || (ok && (got-tt.want).Abs() > time.Second) {
+			t.Errorf("%s: InterestTimeToLiquidation(%v, %v) = %v, %v; want %v, %v", tt.name, tt.hf, tt.drift, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package risk
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// LiquidationPrice는 담보 자산 하나의 청산 가격입니다 (다른 자산 가격은 고정).
+// LiquidationPrice is the liquidation price of one collateral asset (other prices held fixed).
+type LiquidationPrice struct {
+	// Asset은 담보 자산 주소입니다.
+	// Asset is the collateral asset address.
+	Asset common.Address
+
+	// Symbol은 자산 심볼입니다.
+	// Symbol is the asset symbol.
+	Symbol string
+
+	// Price는 현재 오라클 가격입니다 (USD).
+	// Price is the current oracle price (USD).
+	Price float64
+
+	// LiquidationPrice는 헬스팩터가 1.0이 되는 가격입니다 (USD).
+	// LiquidationPrice is the price at which the health factor reaches 1.0 (USD).
+	LiquidationPrice float64
+
+	// DistancePct는 현재 가격에서 청산 가격까지의 거리입니다 (%, 음수 = 하락 필요).
+	// DistancePct is the distance from the current price to the liquidation price (%, negative = drop needed).
+	DistancePct float64
+}
+
+// LiquidationPrices는 담보 자산별로 헬스팩터가 1.0이 되는 가격을 계산합니다.
+// 거리가 가까운 (절댓값이 작은) 순서로 정렬됩니다.
+// LiquidationPrices computes, per collateral asset, the price at which the health factor reaches 1.0.
+// Results are ordered by distance (smallest absolute value first).
+//
+// 스터디 LendingPool.sol의 _getTotalCollateralAdjusted와 같이 담보로 쓰는 리저브만
+// 잔고 × 가격 × 청산기준으로 합산합니다. 자산 a의 가격을 p로 두면:
+// Like _getTotalCollateralAdjusted in the study LendingPool.sol, only reserves used as collateral
+// are summed as balance × price × liquidation_threshold. With asset a priced at p:
+//
+//	HF(p) = (C_other + amount_a × lt_a × p) / (D_other + debt_a × p) = 1
+//	p     = (D_other - C_other) / (amount_a × lt_a - debt_a)
+//
+// 분모가 0 이하이거나 (가격 하락이 HF를 낮추지 않음) p가 0 이하인 (다른 담보만으로 충분한) 자산은 제외합니다.
+// Assets whose denominator is ≤ 0 (a price drop doesn't lower HF) or whose p is ≤ 0
+// (the other collateral alone covers the debt) are left out.
+func LiquidationPrices(pos *contracts.UserPosition) []LiquidationPrice {
+	var collateralAdj, debt float64
+	for _, r := range pos.Reserves {
+		collateralAdj += adjustedCollateralUSD(r)
+		debt += baseToUSD(r.DebtBase())
+	}
+	if debt == 0 {
+		return nil
+	}
+
+	var out []LiquidationPrice
+	for _, r := range pos.Reserves {
+		adj := adjustedCollateralUSD(r)
+		if adj == 0 || r.Price == nil || r.Price.Sign() == 0 {
+			continue
+		}
+		price := baseToUSD(r.Price)
+		ownDebt := baseToUSD(r.DebtBase())
+
+		// 가격 1달러당 기여분 / Contribution per dollar of price
+		collateralPerUnit := adj / price
+		debtPerUnit := ownDebt / price
+
+		denom := collateralPerUnit - debtPerUnit
+		if denom <= 0 {
+			continue
+		}
+		liqPrice := ((debt - ownDebt) - (collateralAdj - adj)) / denom
+		if liqPrice <= 0 {
+			continue
+		}
+		out = append(out, LiquidationPrice{
+			Asset:            r.Asset,
+			Symbol:           r.Symbol,
+			Price:            price,
+			LiquidationPrice: liqPrice,
+			DistancePct:      (liqPrice/price - 1) * 100,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return math.Abs(out[i].DistancePct) < math.Abs(out[j].DistancePct) })
+	return out
+}
+
+// adjustedCollateralUSD는 리저브 하나의 청산기준 적용 담보 가치입니다 (USD, 담보 미사용이면 0).
+// adjustedCollateralUSD is one reserve's collateral value adjusted by the liquidation threshold (USD, 0 if not collateral).
+func adjustedCollateralUSD(r contracts.ReservePosition) float64 {
+	if !r.UsedAsCollateral || r.LiquidationThreshold == nil || r.LiquidationThreshold.Sign() == 0 {
+		return 0
+	}
+	v := new(big.Int).Mul(r.CollateralBase(), r.LiquidationThreshold)
+	return baseToUSD(v.Quo(v, big.NewInt(bpsDenominator)))
+}
@@ -0,0 +1,100 @@
+// Package risk는 리저브별 포지션으로 가격 충격 시나리오의 위험을 계산합니다.
+// Package risk computes risk under price shock scenarios from per-reserve positions.
+//
+// 헬스팩터 공식 / Health factor formula:
+//
+//	HF = Σ(담보 × 가격 × 청산기준) / Σ(부채 × 가격)
+//	HF = Σ(collateral × price × liquidation_threshold) / Σ(debt × price)
+//
+// 충격은 한 번에 자산 하나의 가격에만 적용되며, 그 자산을 빌린 경우 부채 가치도 함께 줄어듭니다.
+// A shock applies to one asset's price at a time; when that asset is also borrowed, the debt value shrinks too.
+package risk
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// bpsDenominator는 bps 값의 분모입니다 (10000 = 100%).
+// bpsDenominator is the denominator of bps values (10000 = 100%).
+const bpsDenominator = 10000
+
+// DefaultShocks는 기본 가격 충격 시나리오입니다 (-5%, -10%, -20%, -30%).
+// DefaultShocks are the default price shock scenarios (-5%, -10%, -20%, -30%).
+var DefaultShocks = []float64{-0.05, -0.10, -0.20, -0.30}
+
+// ShockLabel은 충격을 메트릭 라벨로 변환합니다 (예: -0.1 → "-10%").
+// ShockLabel converts a shock into a metric label (e.g. -0.1 → "-10%").
+func ShockLabel(shock float64) string {
+	return fmt.Sprintf("%g%%", math.Round(shock*1000)/10)
+}
+
+// ShockedHealthFactor는 asset 가격에 (1 + shock)을 곱했을 때의 헬스팩터와 부채 (USD)를 계산합니다.
+// ShockedHealthFactor computes the health factor and debt (USD) when asset's price is multiplied by (1 + shock).
+//
+// 청산기준과 가격은 포지션에 담긴 값을 쓰므로 e-mode 카테고리가 이미 반영돼 있습니다 (contracts.ApplyEMode).
+// 부채가 없으면 헬스팩터는 +Inf입니다.
+// Thresholds and prices come from the position, so the e-mode category is already applied (contracts.ApplyEMode).
+// The health factor is +Inf when there is no debt.
+func ShockedHealthFactor(pos *contracts.UserPosition, asset common.Address, shock float64) (hf, debtUSD float64) {
+	var collateralAdj float64
+	for _, r := range pos.Reserves {
+		factor := 1.0
+		if r.Asset == asset {
+			factor = 1 + shock
+		}
+		if r.UsedAsCollateral && r.LiquidationThreshold != nil && r.LiquidationThreshold.Sign() > 0 {
+			lt := float64(r.LiquidationThreshold.Int64()) / bpsDenominator
+			collateralAdj += baseToUSD(r.CollateralBase()) * factor * lt
+		}
+		debtUSD += baseToUSD(r.DebtBase()) * factor
+	}
+	if debtUSD == 0 {
+		return math.Inf(1), 0
+	}
+	return collateralAdj / debtUSD, debtUSD
+}
+
+// DebtAtRisk는 담보 자산별, 충격별로 헬스팩터가 1 미만으로 새로 떨어지는 부채 (USD)를 합산합니다.
+// DebtAtRisk sums, per collateral asset and per shock, the debt (USD) that newly falls below health factor 1.
+//
+// 이미 청산 가능한 (HF < 1) 포지션은 충격과 무관하므로 제외합니다.
+// 결과는 asset → shock → USD 입니다.
+// Positions that are already liquidatable (HF < 1) are excluded since they don't depend on the shock.
+// The result is asset → shock → USD.
+func DebtAtRisk(positions []*contracts.UserPosition, shocks []float64) map[common.Address]map[float64]float64 {
+	out := make(map[common.Address]map[float64]float64)
+	for _, pos := range positions {
+		if hf, _ := ShockedHealthFactor(pos, common.Address{}, 0); hf < 1 {
+			continue
+		}
+		for _, r := range pos.Reserves {
+			if !r.UsedAsCollateral || r.Collateral.Sign() == 0 {
+				continue
+			}
+			for _, shock := range shocks {
+				hf, debt := ShockedHealthFactor(pos, r.Asset, shock)
+				if hf >= 1 {
+					continue
+				}
+				if out[r.Asset] == nil {
+					out[r.Asset] = make(map[float64]float64)
+				}
+				out[r.Asset][shock] += debt
+			}
+		}
+	}
+	return out
+}
+
+// baseToUSD는 기본 통화 금액 (USD 8 소수점)을 달러로 변환합니다.
+// baseToUSD converts a base currency amount (USD with 8 decimals) to dollars.
+func baseToUSD(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e8)).Float64()
+	return f
+}
@@ -0,0 +1,487 @@
+// Package rpcpool은 여러 RPC 엔드포인트를 묶어 상태 점검, 라우팅, 페일오버를 제공합니다.
+// Package rpcpool groups multiple RPC endpoints with health checks, routing and failover.
+//
+// 단일 RPC 제공자에 의존하면 시장이 가장 불안정할 때 모니터링이 멈출 수 있습니다.
+// Depending on a single RPC provider means monitoring can go dark exactly when markets are volatile.
+//
+// 노드 운영 관점 / Node operations perspective:
+// - 블록 높이 지연 → 동기화가 늦은 노드 제외 / Block lag → exclude nodes that fall behind
+// - 오류율 → 불안정한 제공자 후순위 / Error rate → deprioritize flaky providers
+// - 지연 시간 → 빠른 제공자 우선 / Latency → prefer fast providers
+package rpcpool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+	"github.com/jeongseup/lending-monitor/internal/ratelimit"
+)
+
+// ErrNoEndpoints는 사용 가능한 엔드포인트가 없을 때 반환됩니다.
+// ErrNoEndpoints is returned when no endpoint is available.
+var ErrNoEndpoints = errors.New("사용 가능한 RPC 엔드포인트 없음 / no RPC endpoints available")
+
+// Options는 풀의 상태 점검 및 라우팅 설정입니다.
+// Options configures pool health checks and routing.
+type Options struct {
+	// HealthCheckInterval은 상태 점검 주기입니다.
+	// HealthCheckInterval is the health check period.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckTimeout은 상태 점검 요청의 타임아웃입니다.
+	// HealthCheckTimeout is the timeout for a health check request.
+	HealthCheckTimeout time.Duration
+
+	// MaxBlockLag는 정상으로 간주할 최대 블록 지연입니다.
+	// MaxBlockLag is the maximum block lag still considered healthy.
+	MaxBlockLag uint64
+
+	// MaxErrorRate는 정상으로 간주할 최대 오류율입니다 (0.0-1.0).
+	// MaxErrorRate is the maximum error rate still considered healthy (0.0-1.0).
+	MaxErrorRate float64
+
+	// Limiter는 모든 엔드포인트 요청이 공유하는 속도 제한기입니다 (nil = 제한 없음).
+	// Limiter is the rate limiter shared by every endpoint request (nil = unlimited).
+	Limiter *ratelimit.Limiter
+}
+
+// DefaultOptions는 기본 풀 설정을 반환합니다.
+// DefaultOptions returns the default pool options.
+func DefaultOptions() Options {
+	return Options{
+		HealthCheckInterval: 15 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+		MaxBlockLag:         3,
+		MaxErrorRate:        0.5,
+	}
+}
+
+// ewmaAlpha는 지연 시간/오류율 지수 이동 평균의 가중치입니다.
+// ewmaAlpha is the weight of the latency/error-rate exponential moving average.
+const ewmaAlpha = 0.2
+
+// endpoint는 단일 RPC 엔드포인트와 그 상태입니다.
+// endpoint is a single RPC endpoint and its state.
+type endpoint struct {
+	name   string
+	client *ethclient.Client
+
+	mu        sync.Mutex
+	head      uint64
+	latency   time.Duration
+	errorRate float64
+	lastErr   error
+}
+
+// record는 요청 결과를 지연 시간/오류율 통계에 반영합니다.
+// record folds a request outcome into the latency/error-rate statistics.
+func (e *endpoint) record(method string, took time.Duration, err error) {
+	result := "ok"
+	failure := 0.0
+	if err != nil {
+		result = "error"
+		failure = 1.0
+	}
+	metrics.RPCRequestsTotal.WithLabelValues(e.name, method, result).Inc()
+	metrics.RPCRequestDuration.WithLabelValues(e.name, method).Observe(took.Seconds())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errorRate = e.errorRate*(1-ewmaAlpha) + failure*ewmaAlpha
+	if err != nil {
+		e.lastErr = err
+		return
+	}
+	if e.latency == 0 {
+		e.latency = took
+	} else {
+		e.latency = time.Duration(float64(e.latency)*(1-ewmaAlpha) + float64(took)*ewmaAlpha)
+	}
+}
+
+// snapshot은 엔드포인트 상태의 일관된 사본입니다.
+// snapshot is a consistent copy of endpoint state.
+type snapshot struct {
+	ep        *endpoint
+	head      uint64
+	latency   time.Duration
+	errorRate float64
+}
+
+// Pool은 여러 RPC 엔드포인트 중 가장 건강한 곳으로 요청을 라우팅합니다.
+// Pool routes requests to the healthiest of several RPC endpoints.
+type Pool struct {
+	endpoints []*endpoint
+	opts      Options
+	logger    *slog.Logger
+
+	mu      sync.RWMutex
+	maxHead uint64
+}
+
+// Dial은 모든 URL에 연결하고 초기 상태 점검을 수행합니다.
+// Dial connects to every URL and performs an initial health check.
+//
+// 일부 엔드포인트 연결에 실패해도 하나 이상 연결되면 풀을 반환합니다.
+// Returns a pool as long as at least one endpoint connects, even if others fail.
+func Dial(ctx context.Context, urls []string, opts Options, logger *slog.Logger) (*Pool, error) {
+	p := &Pool{opts: opts, logger: logger}
+	names := make(map[string]int)
+	for _, raw := range urls {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		// 같은 URL이 두 번 주어져도 라벨은 겹치지 않게 순번을 붙임
+		// Number repeated URLs so their labels don't collide either
+		name := Redact(raw)
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s/%d", name, names[name])
+		}
+		client, err := ethclient.DialContext(ctx, raw)
+		if err != nil {
+			logger.Warn("RPC 연결 실패, 엔드포인트 제외 / Failed to connect RPC, skipping endpoint",
+				"endpoint", name,
+				"error", err,
+			)
+			continue
+		}
+		p.endpoints = append(p.endpoints, &endpoint{name: name, client: client})
+	}
+	if len(p.endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	p.checkAll(ctx)
+	return p, nil
+}
+
+// Start는 주기적인 상태 점검 루프를 백그라운드로 실행합니다.
+// Start runs the periodic health check loop in the background.
+func (p *Pool) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.opts.HealthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.checkAll(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close는 모든 엔드포인트 연결을 닫습니다.
+// Close closes every endpoint connection.
+func (p *Pool) Close() {
+	for _, ep := range p.endpoints {
+		ep.client.Close()
+	}
+}
+
+// Len은 풀의 엔드포인트 수를 반환합니다.
+// Len returns the number of endpoints in the pool.
+func (p *Pool) Len() int {
+	return len(p.endpoints)
+}
+
+// checkAll은 모든 엔드포인트의 블록 높이를 병렬로 조회합니다.
+// checkAll queries the block height of every endpoint in parallel.
+func (p *Pool) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range p.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, p.opts.HealthCheckTimeout)
+			defer cancel()
+
+			if err := p.opts.Limiter.Wait(checkCtx, "eth_blockNumber"); err != nil {
+				return
+			}
+			start := time.Now()
+			head, err := ep.client.BlockNumber(checkCtx)
+			ep.record("eth_blockNumber", time.Since(start), err)
+			if err != nil {
+				p.logger.Warn("RPC 상태 점검 실패 / RPC health check failed",
+					"endpoint", ep.name,
+					"error", err,
+				)
+				return
+			}
+			ep.mu.Lock()
+			ep.head = head
+			ep.mu.Unlock()
+		}(ep)
+	}
+	wg.Wait()
+
+	// 최고 블록 높이 갱신 / Update highest known head
+	var maxHead uint64
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if ep.head > maxHead {
+			maxHead = ep.head
+		}
+		ep.mu.Unlock()
+	}
+	p.mu.Lock()
+	p.maxHead = maxHead
+	p.mu.Unlock()
+
+	for _, s := range p.ranked() {
+		up := 0.0
+		if p.healthy(s) {
+			up = 1.0
+		}
+		metrics.RPCEndpointUp.WithLabelValues(s.ep.name).Set(up)
+		metrics.RPCEndpointBlockLag.WithLabelValues(s.ep.name).Set(float64(p.lag(s)))
+		metrics.RPCEndpointScore.WithLabelValues(s.ep.name).Set(p.score(s))
+	}
+}
+
+// lag는 최고 블록 대비 엔드포인트의 지연 블록 수입니다.
+// lag is how many blocks the endpoint trails the highest known head.
+func (p *Pool) lag(s snapshot) uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if s.head >= p.maxHead {
+		return 0
+	}
+	return p.maxHead - s.head
+}
+
+// healthy는 엔드포인트가 블록 지연과 오류율 기준을 만족하는지 확인합니다.
+// healthy reports whether the endpoint satisfies the block lag and error rate limits.
+func (p *Pool) healthy(s snapshot) bool {
+	return s.head > 0 && p.lag(s) <= p.opts.MaxBlockLag && s.errorRate <= p.opts.MaxErrorRate
+}
+
+// score는 라우팅 점수를 계산합니다 (낮을수록 우선).
+// score computes the routing score (lower is preferred).
+//
+// 점수 = 지연(ms) × (1 + 10 × 오류율) + 100 × 지연 블록 수
+// score = latency(ms) × (1 + 10 × errorRate) + 100 × blocks of lag
+func (p *Pool) score(s snapshot) float64 {
+	latencyMs := float64(s.latency) / float64(time.Millisecond)
+	return latencyMs*(1+10*s.errorRate) + 100*float64(p.lag(s))
+}
+
+// ranked는 건강한 엔드포인트를 먼저, 그 안에서 점수 순으로 정렬해 반환합니다.
+// ranked returns healthy endpoints first, each group ordered by score.
+func (p *Pool) ranked() []snapshot {
+	snaps := make([]snapshot, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		snaps = append(snaps, snapshot{ep: ep, head: ep.head, latency: ep.latency, errorRate: ep.errorRate})
+		ep.mu.Unlock()
+	}
+	sort.SliceStable(snaps, func(i, j int) bool {
+		hi, hj := p.healthy(snaps[i]), p.healthy(snaps[j])
+		if hi != hj {
+			return hi
+		}
+		return p.score(snaps[i]) < p.score(snaps[j])
+	})
+	return snaps
+}
+
+// do는 가장 건강한 엔드포인트부터 요청을 시도하고 실패 시 다음으로 넘어갑니다.
+// do tries the request on the healthiest endpoint first and fails over on error.
+func (p *Pool) do(ctx context.Context, method string, fn func(*ethclient.Client) error) error {
+	var lastErr error
+	for i, s := range p.ranked() {
+		if i > 0 {
+			metrics.RPCFailoversTotal.WithLabelValues(method).Inc()
+			p.logger.Warn("RPC 페일오버 / RPC failover",
+				"method", method,
+				"endpoint", s.ep.name,
+				"previous_error", lastErr,
+			)
+		}
+
+		if err := p.opts.Limiter.Wait(ctx, method); err != nil {
+			return err
+		}
+		start := time.Now()
+		err := fn(s.ep.client)
+		s.ep.record(method, time.Since(start), err)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		// 호출자 취소나 컨트랙트 revert는 다른 엔드포인트에서도 동일하므로 재시도하지 않음
+		// Caller cancellation and contract reverts are identical elsewhere, so don't retry
+		if ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+	}
+	if lastErr == nil {
+		return ErrNoEndpoints
+	}
+	return fmt.Errorf("모든 RPC 엔드포인트 실패 / all RPC endpoints failed: %w", lastErr)
+}
+
+// retryable은 오류가 다른 엔드포인트에서 재시도할 가치가 있는지 판단합니다.
+// retryable reports whether an error is worth retrying on another endpoint.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	// EVM revert (코드 3)는 결정적 결과 / EVM revert (code 3) is a deterministic result
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == 3 {
+		return false
+	}
+	return !strings.Contains(err.Error(), "execution reverted")
+}
+
+// ChainID는 체인 ID를 조회합니다.
+// ChainID retrieves the chain ID.
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	var id *big.Int
+	err := p.do(ctx, "eth_chainId", func(c *ethclient.Client) error {
+		var err error
+		id, err = c.ChainID(ctx)
+		return err
+	})
+	return id, err
+}
+
+// BlockNumber는 최신 블록 번호를 조회합니다.
+// BlockNumber retrieves the latest block number.
+func (p *Pool) BlockNumber(ctx context.Context) (uint64, error) {
+	var n uint64
+	err := p.do(ctx, "eth_blockNumber", func(c *ethclient.Client) error {
+		var err error
+		n, err = c.BlockNumber(ctx)
+		return err
+	})
+	return n, err
+}
+
+// HeaderByNumber는 블록 헤더를 조회합니다 (nil이면 최신 블록).
+// HeaderByNumber retrieves a block header (latest if number is nil).
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var h *types.Header
+	err := p.do(ctx, "eth_getBlockByNumber", func(c *ethclient.Client) error {
+		var err error
+		h, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	return h, err
+}
+
+// CodeAt은 컨트랙트 바이트코드를 조회합니다 (bind.ContractCaller 구현).
+// CodeAt retrieves contract bytecode (implements bind.ContractCaller).
+func (p *Pool) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := p.do(ctx, "eth_getCode", func(c *ethclient.Client) error {
+		var err error
+		code, err = c.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract는 읽기 전용 컨트랙트 호출을 실행합니다 (bind.ContractCaller 구현).
+// CallContract executes a read-only contract call (implements bind.ContractCaller).
+func (p *Pool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out []byte
+	err := p.do(ctx, "eth_call", func(c *ethclient.Client) error {
+		var err error
+		out, err = c.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+// FilterLogs는 과거 로그를 조회합니다.
+// FilterLogs queries historical logs.
+func (p *Pool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := p.do(ctx, "eth_getLogs", func(c *ethclient.Client) error {
+		var err error
+		logs, err = c.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs는 구독을 지원하는 가장 건강한 엔드포인트로 로그를 구독합니다.
+// SubscribeFilterLogs subscribes to logs on the healthiest endpoint that supports subscriptions.
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := p.do(ctx, "eth_subscribe", func(c *ethclient.Client) error {
+		var err error
+		sub, err = c.SubscribeFilterLogs(ctx, q, ch)
+		return err
+	})
+	return sub, err
+}
+
+// SubscribeNewHead는 구독을 지원하는 가장 건강한 엔드포인트로 새 블록 헤더를 구독합니다.
+// SubscribeNewHead subscribes to new block headers on the healthiest endpoint that supports subscriptions.
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := p.do(ctx, "eth_subscribe", func(c *ethclient.Client) error {
+		var err error
+		sub, err = c.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return sub, err
+}
+
+// Redact는 URL에서 경로/쿼리(API 키가 포함될 수 있음)를 제거한 표시용 이름을 반환합니다.
+// 같은 호스트의 다른 키나 경로를 구분하도록 제거한 부분의 짧은 해시를 붙입니다 (예: https://host#361221).
+// Redact returns a display name with the path/query (which may hold API keys) removed.
+// A short hash of the removed part is appended so different keys or paths on one host stay distinct
+// (e.g. https://host#361221).
+func Redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "invalid-url"
+	}
+	name := u.Scheme + "://" + u.Host
+	rest := u.RequestURI()
+	if u.User != nil {
+		rest = u.User.String() + "@" + rest
+	}
+	if rest == "/" {
+		return name
+	}
+	sum := sha256.Sum256([]byte(rest))
+	return name + "#" + hex.EncodeToString(sum[:3])
+}
+
+// SplitURLs는 쉼표로 구분된 RPC URL 목록을 분리합니다.
+// SplitURLs splits a comma-separated list of RPC URLs.
+func SplitURLs(s string) []string {
+	var urls []string
+	for _, u := range strings.Split(s, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
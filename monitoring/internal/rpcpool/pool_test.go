@@ -0,0 +1,183 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// testPool은 연결 없이 상태만 채운 엔드포인트로 풀을 만듭니다.
+// testPool builds a pool of endpoints with preset state and no connections.
+func testPool(maxHead uint64, eps ...*endpoint) *Pool {
+	return &Pool{endpoints: eps, opts: DefaultOptions(), logger: slog.New(slog.DiscardHandler), maxHead: maxHead}
+}
+
+func snap(ep *endpoint) snapshot {
+	return snapshot{ep: ep, head: ep.head, latency: ep.latency, errorRate: ep.errorRate}
+}
+
+func TestScore(t *testing.T) {
+	p := testPool(100)
+	cases := []struct {
+		name string
+		ep   *endpoint
+		want float64
+	}{
+		{"fast, in sync", &endpoint{head: 100, latency: 50 * time.Millisecond}, 50},
+		{"errors multiply latency", &endpoint{head: 100, latency: 50 * time.Millisecond, errorRate: 0.5}, 50 * 6},
+		{"lag adds 100 per block", &endpoint{head: 98, latency: 50 * time.Millisecond}, 50 + 200},
+		{"ahead of max head is no lag", &endpoint{head: 101, latency: 10 * time.Millisecond}, 10},
+	}
+	for _, c := range cases {
+		if got := p.score(snap(c.ep)); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s: score = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestHealthy(t *testing.T) {
+	p := testPool(100)
+	cases := []struct {
+		name string
+		ep   *endpoint
+		want bool
+	}{
+		{"in sync", &endpoint{head: 100}, true},
+		{"never checked", &endpoint{}, false},
+		{"lag at limit", &endpoint{head: 97}, true},
+		{"lag over limit", &endpoint{head: 96}, false},
+		{"error rate at limit", &endpoint{head: 100, errorRate: 0.5}, true},
+		{"error rate over limit", &endpoint{head: 100, errorRate: 0.51}, false},
+	}
+	for _, c := range cases {
+		if got := p.healthy(snap(c.ep)); got != c.want {
+			t.Errorf("%s: healthy = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRanked(t *testing.T) {
+	slow := &endpoint{name: "slow", head: 100, latency: 300 * time.Millisecond}
+	fast := &endpoint{name: "fast", head: 100, latency: 20 * time.Millisecond}
+	flaky := &endpoint{name: "flaky", head: 100, latency: 10 * time.Millisecond, errorRate: 0.9}
+	behind := &endpoint{name: "behind", head: 90, latency: 5 * time.Millisecond}
+	p := testPool(100, flaky, slow, behind, fast)
+
+	var got []string
+	for _, s := range p.ranked() {
+		got = append(got, s.ep.name)
+	}
+	// 건강한 엔드포인트가 점수와 관계없이 먼저, 각 그룹 안에서는 점수 순
+	// (flaky 10ms × 10 = 100 < behind 5ms + 10블록 × 100 = 1005)
+	// Healthy endpoints come first regardless of score, then by score within each group
+	// (flaky 10ms × 10 = 100 < behind 5ms + 10 blocks × 100 = 1005)
+	want := []string{"fast", "slow", "flaky", "behind"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked = %v, want %v", got, want)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	ep := &endpoint{name: "test-record"}
+	ep.record("eth_call", 100*time.Millisecond, nil)
+	if ep.latency != 100*time.Millisecond || ep.errorRate != 0 {
+		t.Fatalf("after first success: latency %v, error rate %v", ep.latency, ep.errorRate)
+	}
+	ep.record("eth_call", 200*time.Millisecond, nil)
+	if ep.latency != 120*time.Millisecond {
+		t.Errorf("latency = %v, want 120ms (EWMA α=%v)", ep.latency, ewmaAlpha)
+	}
+	// 실패는 지연 시간을 바꾸지 않고 오류율만 올림 / Failures raise the error rate without touching latency
+	boom := errors.New("connection reset")
+	ep.record("eth_call", time.Second, boom)
+	if ep.latency != 120*time.Millisecond || math.Abs(ep.errorRate-ewmaAlpha) > 1e-12 || ep.lastErr != boom {
+		t.Errorf("after failure: latency %v, error rate %v, last error %v", ep.latency, ep.errorRate, ep.lastErr)
+	}
+}
+
+// codeError는 JSON-RPC 오류 코드를 가진 오류입니다 (rpc.Error 구현).
+// codeError is an error carrying a JSON-RPC error code (implements rpc.Error).
+type codeError struct {
+	code int
+	msg  string
+}
+
+func (e codeError) Error() string  { return e.msg }
+func (e codeError) ErrorCode() int { return e.code }
+
+func TestRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", errors.New("dial tcp: connection refused"), true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"rate limited", codeError{429, "too many requests"}, true},
+		{"missing state", errors.New("missing trie node abc"), true},
+		{"canceled", context.Canceled, false},
+		{"wrapped canceled", fmt.Errorf("call: %w", context.Canceled), false},
+		{"not found", ethereum.NotFound, false},
+		{"revert code", codeError{3, "reverted: HF too low"}, false},
+		{"revert message", errors.New("execution reverted"), false},
+		{"wrapped revert code", fmt.Errorf("eth_call: %w", codeError{3, "reverted"}), false},
+		{"wrapped EOF", fmt.Errorf("eth_call: %w", io.ErrUnexpectedEOF), true},
+	}
+	for _, c := range cases {
+		if got := retryable(c.err); got != c.want {
+			t.Errorf("%s: retryable(%v) = %v, want %v", c.name, c.err, got, c.want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	cases := map[string]string{
+		"https://eth-mainnet.g.alchemy.com/v2/SECRET": "https://eth-mainnet.g.alchemy.com#361221",
+		"https://eth-mainnet.g.alchemy.com/v2/OTHER":  "https://eth-mainnet.g.alchemy.com#330bb4",
+		"wss://mainnet.infura.io/ws/v3/KEY?x=1":       "wss://mainnet.infura.io#eeb619",
+		"ws://localhost:8545":                         "ws://localhost:8545",
+		"http://localhost:8545/":                      "http://localhost:8545",
+		"not a url":                                   "invalid-url",
+	}
+	for in, want := range cases {
+		if got := Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSplitURLs(t *testing.T) {
+	got := SplitURLs(" ws://a:1 ,, http://b ,")
+	if want := []string{"ws://a:1", "http://b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitURLs = %v, want %v", got, want)
+	}
+	if got := SplitURLs(""); got != nil {
+		t.Errorf("SplitURLs(\"\") = %v, want nil", got)
+	}
+}
+
+func TestDialUniqueNames(t *testing.T) {
+	// 연결은 HTTP 요청 때 이뤄지므로 닫힌 포트로도 풀을 만들 수 있음 (상태 점검만 실패)
+	// HTTP connects per request, so a pool can be built on a closed port (only the health check fails)
+	urls := []string{"http://127.0.0.1:1/v2/KEY", "http://127.0.0.1:1/v2/OTHER", "http://127.0.0.1:1/v2/KEY"}
+	p, err := Dial(context.Background(), urls, DefaultOptions(), slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range p.endpoints {
+		names = append(names, e.name)
+	}
+	want := []string{"http://127.0.0.1:1#51058a", "http://127.0.0.1:1#330bb4", "http://127.0.0.1:1#51058a/2"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,136 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/metrics"
+)
+
+var (
+	// ErrQuorumUnavailable은 쿼럼에 필요한 만큼 건강한 엔드포인트가 없을 때 반환됩니다.
+	// ErrQuorumUnavailable is returned when too few healthy endpoints exist for a quorum.
+	ErrQuorumUnavailable = errors.New("쿼럼에 필요한 엔드포인트 부족 / not enough healthy endpoints for quorum")
+
+	// ErrQuorumMismatch는 엔드포인트들의 응답이 서로 다를 때 반환됩니다.
+	// ErrQuorumMismatch is returned when endpoints disagree on the response.
+	ErrQuorumMismatch = errors.New("엔드포인트 응답 불일치 / endpoints disagree on response")
+)
+
+// QuorumCaller는 여러 엔드포인트에서 같은 호출을 실행하고 결과가 일치할 때만 반환합니다.
+// QuorumCaller runs the same call on several endpoints and only returns when they agree.
+//
+// 청산 가능 (HF < 1) 같은 중요한 판단 전에 단일 제공자의 잘못된 응답으로
+// 오탐 알림이 나가는 것을 막습니다.
+// Guards critical decisions such as "liquidatable (HF < 1)" against false alerts
+// caused by a single provider returning bad data.
+type QuorumCaller struct {
+	pool *Pool
+	size int
+}
+
+// Quorum은 size개의 엔드포인트 합의를 요구하는 bind.ContractCaller를 반환합니다.
+// Quorum returns a bind.ContractCaller that requires agreement across size endpoints.
+func (p *Pool) Quorum(size int) *QuorumCaller {
+	return &QuorumCaller{pool: p, size: size}
+}
+
+// members는 쿼럼에 참여할 상위 건강 엔드포인트와 모두가 가진 가장 높은 블록을 반환합니다.
+// members returns the top healthy endpoints for the quorum and the highest block all of them have.
+func (q *QuorumCaller) members() ([]*endpoint, *big.Int, error) {
+	var eps []*endpoint
+	var minHead uint64
+	for _, s := range q.pool.ranked() {
+		if !q.pool.healthy(s) {
+			break
+		}
+		eps = append(eps, s.ep)
+		if minHead == 0 || s.head < minHead {
+			minHead = s.head
+		}
+		if len(eps) == q.size {
+			return eps, new(big.Int).SetUint64(minHead), nil
+		}
+	}
+	return nil, nil, ErrQuorumUnavailable
+}
+
+// run은 쿼럼 구성원 모두에서 fn을 병렬로 실행하고 결과를 비교합니다.
+// run executes fn on every quorum member in parallel and compares the results.
+//
+// 블록이 지정되지 않으면 모든 구성원이 가진 가장 높은 블록에 고정하여
+// 제공자 간 블록 높이 차이로 인한 오탐 불일치를 피합니다.
+// When no block is given, the call is pinned to the highest block every member has,
+// avoiding false mismatches caused by providers being at different heights.
+func (q *QuorumCaller) run(ctx context.Context, method string, blockNumber *big.Int, fn func(*endpoint, *big.Int) ([]byte, error)) ([]byte, error) {
+	eps, pinned, err := q.members()
+	if err != nil {
+		metrics.RPCQuorumChecksTotal.WithLabelValues("unavailable").Inc()
+		return nil, err
+	}
+	if blockNumber == nil {
+		blockNumber = pinned
+	}
+
+	results := make([][]byte, len(eps))
+	errs := make([]error, len(eps))
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			if errs[i] = q.pool.opts.Limiter.Wait(ctx, method); errs[i] != nil {
+				return
+			}
+			start := time.Now()
+			results[i], errs[i] = fn(ep, blockNumber)
+			ep.record(method, time.Since(start), errs[i])
+		}(i, ep)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			metrics.RPCQuorumChecksTotal.WithLabelValues("unavailable").Inc()
+			return nil, fmt.Errorf("쿼럼 구성원 %s 호출 실패 / quorum member %s call failed: %w", eps[i].name, eps[i].name, err)
+		}
+	}
+	for i := 1; i < len(results); i++ {
+		if !bytes.Equal(results[0], results[i]) {
+			metrics.RPCQuorumChecksTotal.WithLabelValues("mismatch").Inc()
+			q.pool.logger.Warn("쿼럼 불일치 / Quorum mismatch",
+				"method", method,
+				"block", blockNumber,
+				"endpoint_a", eps[0].name,
+				"endpoint_b", eps[i].name,
+			)
+			return nil, ErrQuorumMismatch
+		}
+	}
+	metrics.RPCQuorumChecksTotal.WithLabelValues("agree").Inc()
+	return results[0], nil
+}
+
+// CodeAt은 쿼럼으로 컨트랙트 바이트코드를 조회합니다 (bind.ContractCaller 구현).
+// CodeAt retrieves contract bytecode under quorum (implements bind.ContractCaller).
+func (q *QuorumCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return q.run(ctx, "eth_getCode", blockNumber, func(ep *endpoint, block *big.Int) ([]byte, error) {
+		return ep.client.CodeAt(ctx, contract, block)
+	})
+}
+
+// CallContract는 쿼럼으로 읽기 전용 컨트랙트 호출을 실행합니다 (bind.ContractCaller 구현).
+// CallContract executes a read-only contract call under quorum (implements bind.ContractCaller).
+func (q *QuorumCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return q.run(ctx, "eth_call", blockNumber, func(ep *endpoint, block *big.Int) ([]byte, error) {
+		return ep.client.CallContract(ctx, call, block)
+	})
+}
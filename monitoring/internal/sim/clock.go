@@ -0,0 +1,46 @@
+package sim
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrClockBackwards는 시계를 과거로 되돌리려 할 때 반환됩니다 (block.timestamp는 줄지 않음).
+// ErrClockBackwards is returned when setting the clock into the past (block.timestamp never decreases).
+var ErrClockBackwards = errors.New("시계를 과거로 되돌릴 수 없음 / clock cannot move backwards")
+
+// Clock은 block.timestamp를 대신하는 모의 시계입니다 (초 단위 유닉스 시간).
+// Clock is a mock clock standing in for block.timestamp (unix seconds).
+type Clock struct {
+	now uint64
+}
+
+// NewClock은 start 시각에서 시작하는 시계를 생성합니다.
+// NewClock creates a clock starting at start.
+func NewClock(start uint64) *Clock {
+	return &Clock{now: start}
+}
+
+// Now는 현재 block.timestamp입니다.
+// Now is the current block.timestamp.
+func (c *Clock) Now() uint64 {
+	return c.now
+}
+
+// Advance는 시계를 d만큼 앞으로 보냅니다 (vm.warp(block.timestamp + d), 초 미만 버림).
+// Advance moves the clock forward by d (vm.warp(block.timestamp + d), sub-second part truncated).
+func (c *Clock) Advance(d time.Duration) {
+	if d > 0 {
+		c.now += uint64(d / time.Second)
+	}
+}
+
+// Set은 시계를 ts로 맞춥니다 (vm.warp(ts)).
+// Set moves the clock to ts (vm.warp(ts)).
+func (c *Clock) Set(ts uint64) error {
+	if ts < c.now {
+		return ErrClockBackwards
+	}
+	c.now = ts
+	return nil
+}
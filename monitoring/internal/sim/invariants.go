@@ -0,0 +1,29 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CheckInvariants는 LendingPool.invariant.t.sol의 불변 조건을 모든 리저브에 대해 검사합니다:
+// - invariant_totalDepositsGteTotalBorrows: totalDeposits >= totalBorrows
+// - invariant_borrowIndexGteOne: borrowIndex >= 1e18
+//
+// CheckInvariants checks the invariants of LendingPool.invariant.t.sol for every reserve:
+// - invariant_totalDepositsGteTotalBorrows: totalDeposits >= totalBorrows
+// - invariant_borrowIndexGteOne: borrowIndex >= 1e18
+func (p *LendingPool) CheckInvariants() error {
+	var errs []error
+	for _, asset := range p.st.list {
+		r := p.st.reserves[asset]
+		if r.TotalDeposits.Cmp(r.TotalBorrows) < 0 {
+			errs = append(errs, fmt.Errorf("%w: %s deposits %s < borrows %s",
+				ErrInvariant, asset, r.TotalDeposits, r.TotalBorrows))
+		}
+		if r.BorrowIndex.Cmp(Precision) < 0 {
+			errs = append(errs, fmt.Errorf("%w: %s borrow index %s < 1e18",
+				ErrInvariant, asset, r.BorrowIndex))
+		}
+	}
+	return errors.Join(errs...)
+}
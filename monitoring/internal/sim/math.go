@@ -0,0 +1,51 @@
+package sim
+
+import (
+	"math/big"
+
+	"github.com/jeongseup/lending-monitor/internal/ratemodel"
+)
+
+// 검사된 uint256 연산입니다. 결과는 항상 새 *big.Int이므로 상태에 저장된 값은 불변으로 다룰 수 있습니다.
+// Checked uint256 arithmetic. Results are always fresh *big.Int values, so stored state can be treated as immutable.
+
+// add는 검사된 uint256 덧셈입니다.
+// add is checked uint256 addition.
+func add(a, b *big.Int) (*big.Int, error) {
+	v := new(big.Int).Add(a, b)
+	if v.Cmp(MaxUint256) > 0 {
+		return nil, ratemodel.ErrOverflow
+	}
+	return v, nil
+}
+
+// sub는 검사된 uint256 뺄셈입니다.
+// sub is checked uint256 subtraction.
+func sub(a, b *big.Int) (*big.Int, error) {
+	if a.Cmp(b) < 0 {
+		return nil, ratemodel.ErrUnderflow
+	}
+	return new(big.Int).Sub(a, b), nil
+}
+
+// mulDiv는 a * b / c를 Solidity의 평가 순서대로 계산합니다.
+// mulDiv computes a * b / c in Solidity's evaluation order.
+func mulDiv(a, b, c *big.Int) (*big.Int, error) {
+	v := new(big.Int).Mul(a, b)
+	if v.Cmp(MaxUint256) > 0 {
+		return nil, ratemodel.ErrOverflow
+	}
+	if c.Sign() == 0 {
+		return nil, ratemodel.ErrDivisionByZero
+	}
+	return v.Quo(v, c), nil
+}
+
+// balance는 맵의 잔고를 반환합니다 (없으면 0).
+// balance returns a balance from a map (0 when absent).
+func balance(m map[string]*big.Int, key string) *big.Int {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return new(big.Int)
+}
@@ -0,0 +1,63 @@
+package sim
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrNoPriceFeed는 "No price feed"입니다.
+	// ErrNoPriceFeed is "No price feed".
+	ErrNoPriceFeed = errors.New("가격 피드 없음 / no price feed")
+
+	// ErrInvalidPrice는 "Invalid price"입니다 (가격 <= 0).
+	// ErrInvalidPrice is "Invalid price" (price <= 0).
+	ErrInvalidPrice = errors.New("잘못된 가격 / invalid price")
+
+	// ErrStalePrice는 "Oracle data is stale"입니다.
+	// ErrStalePrice is "Oracle data is stale".
+	ErrStalePrice = errors.New("오라클 데이터 지연 / oracle data is stale")
+)
+
+// price는 피드 하나의 마지막 답변입니다.
+// price is one feed's latest answer.
+type price struct {
+	answer    *big.Int
+	updatedAt uint64
+}
+
+// Oracle은 PriceOracle.sol + MockPriceFeed를 대신하는 모의 오라클입니다 (USD 가격, 8 소수점).
+// Oracle is a mock oracle standing in for PriceOracle.sol + MockPriceFeed (USD prices, 8 decimals).
+type Oracle struct {
+	clock        *Clock
+	maxStaleness uint64
+	prices       map[string]price
+}
+
+// NewOracle은 새로운 Oracle을 생성합니다. maxStaleness가 0이면 지연을 검사하지 않습니다.
+// NewOracle creates a new Oracle. A zero maxStaleness disables the staleness check.
+func NewOracle(clock *Clock, maxStaleness uint64) *Oracle {
+	return &Oracle{clock: clock, maxStaleness: maxStaleness, prices: make(map[string]price)}
+}
+
+// SetPrice는 자산 가격을 현재 시각으로 갱신합니다 (MockPriceFeed.setPrice).
+// SetPrice updates an asset's price at the current time (MockPriceFeed.setPrice).
+func (o *Oracle) SetPrice(asset string, answer *big.Int) {
+	o.prices[asset] = price{answer: new(big.Int).Set(answer), updatedAt: o.clock.Now()}
+}
+
+// Price는 검증된 자산 가격을 반환합니다 (getAssetPrice).
+// Price returns an asset's validated price (getAssetPrice).
+func (o *Oracle) Price(asset string) (*big.Int, error) {
+	p, ok := o.prices[asset]
+	if !ok {
+		return nil, ErrNoPriceFeed
+	}
+	if p.answer.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	if o.maxStaleness > 0 && o.clock.Now()-p.updatedAt > o.maxStaleness {
+		return nil, ErrStalePrice
+	}
+	return new(big.Int).Set(p.answer), nil
+}
@@ -0,0 +1,575 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+	"github.com/jeongseup/lending-monitor/internal/ratemodel"
+)
+
+// Reserve는 LendingPool.ReserveData의 사본입니다 (토큰 주소 대신 자산 이름과 소수점).
+// Reserve is a copy of LendingPool.ReserveData (asset name and decimals instead of token addresses).
+type Reserve struct {
+	Asset                string
+	Decimals             uint8
+	ID                   uint16
+	CollateralFactor     *big.Int
+	LiquidationThreshold *big.Int
+	TotalDeposits        *big.Int
+	TotalBorrows         *big.Int
+	TotalReserves        *big.Int
+	BorrowIndex          *big.Int
+	LastUpdateTime       uint64
+	IsActive             bool
+}
+
+// reserve는 리저브 데이터와 LToken/DebtToken 잔고, 풀이 보유한 기초 자산 (현금)입니다.
+// reserve is the reserve data plus LToken/DebtToken balances and the underlying held by the pool (cash).
+type reserve struct {
+	Reserve
+	lTokens map[string]*big.Int
+	debt    map[string]*big.Int
+	cash    *big.Int
+}
+
+// state는 트랜잭션이 실패하면 되돌려지는 풀의 모든 상태입니다.
+// 저장된 *big.Int는 바꾸지 않고 항상 새 값으로 교체하므로 맵만 복사하면 스냅샷이 됩니다.
+// state is all pool state that is rolled back when a transaction fails.
+// Stored *big.Int values are never mutated, only replaced, so copying the maps is enough for a snapshot.
+type state struct {
+	reserves    map[string]*reserve
+	list        []string
+	configs     map[string]contracts.UserConfigurationMap
+	borrowIndex map[string]map[string]*big.Int
+	wallets     map[string]map[string]*big.Int
+}
+
+// clone은 상태의 스냅샷을 만듭니다.
+// clone takes a snapshot of the state.
+func (s *state) clone() *state {
+	c := &state{
+		reserves:    make(map[string]*reserve, len(s.reserves)),
+		list:        slices.Clone(s.list),
+		configs:     maps.Clone(s.configs),
+		borrowIndex: make(map[string]map[string]*big.Int, len(s.borrowIndex)),
+		wallets:     make(map[string]map[string]*big.Int, len(s.wallets)),
+	}
+	for k, r := range s.reserves {
+		cr := *r
+		cr.lTokens = maps.Clone(r.lTokens)
+		cr.debt = maps.Clone(r.debt)
+		c.reserves[k] = &cr
+	}
+	for k, m := range s.borrowIndex {
+		c.borrowIndex[k] = maps.Clone(m)
+	}
+	for k, m := range s.wallets {
+		c.wallets[k] = maps.Clone(m)
+	}
+	return c
+}
+
+// LendingPool은 LendingPool.sol의 시뮬레이션입니다.
+// 사용자와 자산은 주소 대신 이름 (문자열)으로 식별합니다.
+// LendingPool is a simulation of LendingPool.sol.
+// Users and assets are identified by name (string) instead of address.
+type LendingPool struct {
+	clock  *Clock
+	oracle *Oracle
+	model  *ratemodel.InterestRateModel
+	params Params
+	st     *state
+}
+
+// NewLendingPool은 새로운 LendingPool을 생성합니다.
+// NewLendingPool creates a new LendingPool.
+func NewLendingPool(clock *Clock, oracle *Oracle, model *ratemodel.InterestRateModel) *LendingPool {
+	return &LendingPool{
+		clock:  clock,
+		oracle: oracle,
+		model:  model,
+		params: DefaultParams(),
+		st: &state{
+			reserves:    make(map[string]*reserve),
+			configs:     make(map[string]contracts.UserConfigurationMap),
+			borrowIndex: make(map[string]map[string]*big.Int),
+			wallets:     make(map[string]map[string]*big.Int),
+		},
+	}
+}
+
+// Clock은 풀의 시계를 반환합니다.
+// Clock returns the pool's clock.
+func (p *LendingPool) Clock() *Clock {
+	return p.clock
+}
+
+// Oracle은 풀의 오라클을 반환합니다.
+// Oracle returns the pool's oracle.
+func (p *LendingPool) Oracle() *Oracle {
+	return p.oracle
+}
+
+// Params는 풀 파라미터를 반환합니다.
+// Params returns the pool parameters.
+func (p *LendingPool) Params() Params {
+	return p.params
+}
+
+// SetParams는 청산 보너스, Close Factor, 준비금 비율을 바꿉니다.
+// Close Factor는 0 초과 1 이하, 준비금 비율은 1 이하, 보너스는 0 이상이어야 합니다.
+// SetParams changes the liquidation bonus, close factor and reserve factor.
+// The close factor must be within (0, 1], the reserve factor at most 1, and the bonus non-negative.
+func (p *LendingPool) SetParams(params Params) error {
+	switch {
+	case params.LiquidationBonus == nil || params.CloseFactor == nil || params.ReserveFactor == nil:
+		return fmt.Errorf("%w: 값 누락 / missing value", ErrInvalidParams)
+	case params.LiquidationBonus.Sign() < 0:
+		return fmt.Errorf("%w: liquidation bonus", ErrInvalidParams)
+	case params.CloseFactor.Sign() <= 0 || params.CloseFactor.Cmp(Precision) > 0:
+		return fmt.Errorf("%w: close factor", ErrInvalidParams)
+	case params.ReserveFactor.Sign() < 0 || params.ReserveFactor.Cmp(Precision) > 0:
+		return fmt.Errorf("%w: reserve factor", ErrInvalidParams)
+	}
+	p.params = params
+	return nil
+}
+
+// tx는 fn을 트랜잭션처럼 실행합니다: 실패하면 상태를 되돌리고, 성공하면 불변 조건을 검사합니다.
+// tx runs fn like a transaction: the state is rolled back on failure and invariants are checked on success.
+func (p *LendingPool) tx(fn func() error) error {
+	saved := p.st.clone()
+	if err := fn(); err != nil {
+		p.st = saved
+		return err
+	}
+	return p.CheckInvariants()
+}
+
+// active는 활성 리저브를 반환합니다 (reserveActive modifier).
+// active returns an active reserve (the reserveActive modifier).
+func (p *LendingPool) active(asset string) (*reserve, error) {
+	r, ok := p.st.reserves[asset]
+	if !ok || !r.IsActive {
+		return nil, fmt.Errorf("%s: %w", asset, ErrReserveNotActive)
+	}
+	return r, nil
+}
+
+// Mint는 사용자 지갑에 기초 자산을 발행합니다 (MockERC20.mint, 풀 상태와 무관).
+// Mint mints underlying into a user's wallet (MockERC20.mint, independent of pool state).
+func (p *LendingPool) Mint(user, asset string, amount *big.Int) error {
+	w := p.st.wallets[user]
+	if w == nil {
+		w = make(map[string]*big.Int)
+		p.st.wallets[user] = w
+	}
+	v, err := add(balance(w, asset), amount)
+	if err != nil {
+		return err
+	}
+	w[asset] = v
+	return nil
+}
+
+// InitReserve는 리저브를 추가합니다 (initReserve).
+// InitReserve adds a reserve (initReserve).
+func (p *LendingPool) InitReserve(asset string, decimals uint8, collateralFactor, liquidationThreshold *big.Int) error {
+	return p.tx(func() error {
+		if r, ok := p.st.reserves[asset]; ok && r.IsActive {
+			return fmt.Errorf("%s: %w", asset, ErrReserveExists)
+		}
+		if collateralFactor.Cmp(Precision) > 0 || liquidationThreshold.Cmp(Precision) > 0 ||
+			collateralFactor.Cmp(liquidationThreshold) > 0 {
+			return ErrInvalidFactor
+		}
+		id := uint16(len(p.st.list))
+		p.st.reserves[asset] = &reserve{
+			Reserve: Reserve{
+				Asset:                asset,
+				Decimals:             decimals,
+				ID:                   id,
+				CollateralFactor:     new(big.Int).Set(collateralFactor),
+				LiquidationThreshold: new(big.Int).Set(liquidationThreshold),
+				TotalDeposits:        new(big.Int),
+				TotalBorrows:         new(big.Int),
+				TotalReserves:        new(big.Int),
+				BorrowIndex:          new(big.Int).Set(Precision),
+				LastUpdateTime:       p.clock.Now(),
+				IsActive:             true,
+			},
+			lTokens: make(map[string]*big.Int),
+			debt:    make(map[string]*big.Int),
+			cash:    new(big.Int),
+		}
+		p.st.list = append(p.st.list, asset)
+		return nil
+	})
+}
+
+// Deposit은 기초 자산을 예치합니다 (deposit). 첫 예치이면 담보로 켭니다.
+// Deposit supplies underlying (deposit). The first supply enables it as collateral.
+func (p *LendingPool) Deposit(user, asset string, amount *big.Int) error {
+	return p.tx(func() error {
+		r, err := p.active(asset)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() <= 0 {
+			return ErrZeroAmount
+		}
+		if err := p.accrueInterest(r); err != nil {
+			return err
+		}
+		isFirstSupply := balance(r.lTokens, user).Sign() == 0
+		if err := p.transferIn(r, user, amount); err != nil {
+			return err
+		}
+		if r.lTokens[user], err = add(balance(r.lTokens, user), amount); err != nil {
+			return err
+		}
+		if r.TotalDeposits, err = add(r.TotalDeposits, amount); err != nil {
+			return err
+		}
+		if isFirstSupply {
+			p.st.configs[user] = p.config(user).SetUsingAsCollateral(r.ID, true)
+		}
+		return nil
+	})
+}
+
+// Withdraw는 예치금을 출금합니다 (withdraw).
+// Withdraw withdraws supplied underlying (withdraw).
+func (p *LendingPool) Withdraw(user, asset string, amount *big.Int) error {
+	return p.tx(func() error {
+		r, err := p.active(asset)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() <= 0 {
+			return ErrZeroAmount
+		}
+		if err := p.accrueInterest(r); err != nil {
+			return err
+		}
+		if balance(r.lTokens, user).Cmp(amount) < 0 {
+			return ErrInsufficientLToken
+		}
+		if r.lTokens[user], err = sub(r.lTokens[user], amount); err != nil {
+			return err
+		}
+		if r.TotalDeposits, err = sub(r.TotalDeposits, amount); err != nil {
+			return err
+		}
+		if r.lTokens[user].Sign() == 0 {
+			p.st.configs[user] = p.config(user).SetUsingAsCollateral(r.ID, false)
+		}
+		hf, err := p.HealthFactor(user)
+		if err != nil {
+			return err
+		}
+		if hf.Cmp(Precision) < 0 {
+			debt, err := p.TotalDebtValue(user)
+			if err != nil {
+				return err
+			}
+			if debt.Sign() != 0 {
+				return ErrUndercollateralized
+			}
+		}
+		return p.transferOut(r, user, amount)
+	})
+}
+
+// Borrow는 기초 자산을 빌립니다 (borrow).
+// Borrow borrows underlying (borrow).
+func (p *LendingPool) Borrow(user, asset string, amount *big.Int) error {
+	return p.tx(func() error {
+		r, err := p.active(asset)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() <= 0 {
+			return ErrZeroAmount
+		}
+		if err := p.accrueInterest(r); err != nil {
+			return err
+		}
+		available, err := sub(r.TotalDeposits, r.TotalBorrows)
+		if err != nil {
+			return err
+		}
+		if available.Cmp(amount) < 0 {
+			return ErrInsufficientLiquidity
+		}
+		if r.debt[user], err = add(balance(r.debt, user), amount); err != nil {
+			return err
+		}
+		if r.TotalBorrows, err = add(r.TotalBorrows, amount); err != nil {
+			return err
+		}
+		if p.st.borrowIndex[user] == nil {
+			p.st.borrowIndex[user] = make(map[string]*big.Int)
+		}
+		p.st.borrowIndex[user][asset] = r.BorrowIndex
+		p.st.configs[user] = p.config(user).SetBorrowing(r.ID, true)
+		hf, err := p.HealthFactor(user)
+		if err != nil {
+			return err
+		}
+		if hf.Cmp(Precision) < 0 {
+			return ErrInsufficientCollateral
+		}
+		return p.transferOut(r, user, amount)
+	})
+}
+
+// Repay는 부채를 상환하고 실제 상환액을 반환합니다 (repay, 부채보다 크면 부채만큼).
+// Repay repays debt and returns the amount actually repaid (repay, capped at the debt).
+func (p *LendingPool) Repay(user, asset string, amount *big.Int) (*big.Int, error) {
+	var repaid *big.Int
+	err := p.tx(func() error {
+		r, err := p.active(asset)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() <= 0 {
+			return ErrZeroAmount
+		}
+		if err := p.accrueInterest(r); err != nil {
+			return err
+		}
+		debt := balance(r.debt, user)
+		if debt.Sign() == 0 {
+			return ErrNoDebt
+		}
+		repaid = amount
+		if amount.Cmp(debt) > 0 {
+			repaid = debt
+		}
+		if err := p.transferIn(r, user, repaid); err != nil {
+			return err
+		}
+		if r.debt[user], err = sub(debt, repaid); err != nil {
+			return err
+		}
+		if r.TotalBorrows, err = sub(r.TotalBorrows, repaid); err != nil {
+			return err
+		}
+		if r.debt[user].Sign() == 0 {
+			p.st.configs[user] = p.config(user).SetBorrowing(r.ID, false)
+		}
+		return nil
+	})
+	if err != nil && !isInvariant(err) {
+		return nil, err
+	}
+	return repaid, err
+}
+
+// Liquidate는 borrower의 부채 debtToCover를 대신 갚고 압류한 담보량을 반환합니다 (liquidate).
+// Liquidate repays debtToCover of borrower's debt and returns the collateral seized (liquidate).
+func (p *LendingPool) Liquidate(liquidator, borrower, debtAsset, collateralAsset string, debtToCover *big.Int) (*big.Int, error) {
+	var seized *big.Int
+	err := p.tx(func() error {
+		debtReserve, err := p.active(debtAsset)
+		if err != nil {
+			return err
+		}
+		collateralReserve, err := p.active(collateralAsset)
+		if err != nil {
+			return err
+		}
+		if borrower == liquidator {
+			return ErrSelfLiquidation
+		}
+		if err := p.accrueInterest(debtReserve); err != nil {
+			return err
+		}
+		if err := p.accrueInterest(collateralReserve); err != nil {
+			return err
+		}
+
+		hf, err := p.HealthFactor(borrower)
+		if err != nil {
+			return err
+		}
+		if hf.Cmp(Precision) >= 0 {
+			return ErrHealthy
+		}
+		maxLiquidatable, err := mulDiv(balance(debtReserve.debt, borrower), p.params.CloseFactor, Precision)
+		if err != nil {
+			return err
+		}
+		if debtToCover.Cmp(maxLiquidatable) > 0 {
+			return ErrExceedsCloseFactor
+		}
+
+		debtPrice, err := p.oracle.Price(debtAsset)
+		if err != nil {
+			return err
+		}
+		collateralPrice, err := p.oracle.Price(collateralAsset)
+		if err != nil {
+			return err
+		}
+		// debtToCover * debtPrice * (PRECISION + LIQUIDATION_BONUS) / (collateralPrice * PRECISION)
+		value, err := mulDiv(debtToCover, debtPrice, big.NewInt(1))
+		if err != nil {
+			return err
+		}
+		denominator, err := mulDiv(collateralPrice, Precision, big.NewInt(1))
+		if err != nil {
+			return err
+		}
+		if seized, err = mulDiv(value, new(big.Int).Add(Precision, p.params.LiquidationBonus), denominator); err != nil {
+			return err
+		}
+
+		if err := p.transferIn(debtReserve, liquidator, debtToCover); err != nil {
+			return err
+		}
+		if debtReserve.debt[borrower], err = sub(balance(debtReserve.debt, borrower), debtToCover); err != nil {
+			return err
+		}
+		if debtReserve.TotalBorrows, err = sub(debtReserve.TotalBorrows, debtToCover); err != nil {
+			return err
+		}
+		if debtReserve.debt[borrower].Sign() == 0 {
+			p.st.configs[borrower] = p.config(borrower).SetBorrowing(debtReserve.ID, false)
+		}
+
+		if balance(collateralReserve.lTokens, borrower).Cmp(seized) < 0 {
+			return ErrInsufficientCollateral
+		}
+		if collateralReserve.lTokens[borrower], err = sub(collateralReserve.lTokens[borrower], seized); err != nil {
+			return err
+		}
+		if collateralReserve.TotalDeposits, err = sub(collateralReserve.TotalDeposits, seized); err != nil {
+			return err
+		}
+		if collateralReserve.lTokens[borrower].Sign() == 0 {
+			p.st.configs[borrower] = p.config(borrower).SetUsingAsCollateral(collateralReserve.ID, false)
+		}
+		return p.transferOut(collateralReserve, liquidator, seized)
+	})
+	if err != nil && !isInvariant(err) {
+		return nil, err
+	}
+	return seized, err
+}
+
+// AccrueInterest는 리저브 이자를 현재 시각까지 누적합니다.
+// 컨트랙트에서는 내부 함수지만, 시뮬레이션에서는 다른 호출 없이 인덱스를 갱신할 때 씁니다.
+// AccrueInterest accrues a reserve's interest up to the current time.
+// Internal in the contract; the simulation uses it to update indexes without another call.
+func (p *LendingPool) AccrueInterest(asset string) error {
+	return p.tx(func() error {
+		r, ok := p.st.reserves[asset]
+		if !ok {
+			return fmt.Errorf("%s: %w", asset, ErrReserveNotActive)
+		}
+		return p.accrueInterest(r)
+	})
+}
+
+// accrueInterest는 _accrueInterest입니다.
+// accrueInterest is _accrueInterest.
+func (p *LendingPool) accrueInterest(r *reserve) error {
+	now := p.clock.Now()
+	elapsed := new(big.Int).SetUint64(now - r.LastUpdateTime)
+	if elapsed.Sign() == 0 {
+		return nil
+	}
+	if r.TotalBorrows.Sign() > 0 {
+		rate, err := p.model.BorrowRatePerSecond(r.TotalDeposits, r.TotalBorrows)
+		if err != nil {
+			return err
+		}
+		// totalBorrows * borrowRatePerSecond * timeElapsed / PRECISION
+		perSecond, err := mulDiv(r.TotalBorrows, rate, big.NewInt(1))
+		if err != nil {
+			return err
+		}
+		interest, err := mulDiv(perSecond, elapsed, Precision)
+		if err != nil {
+			return err
+		}
+		reserveShare, err := mulDiv(interest, p.params.ReserveFactor, Precision)
+		if err != nil {
+			return err
+		}
+		if r.TotalReserves, err = add(r.TotalReserves, reserveShare); err != nil {
+			return err
+		}
+		if r.TotalBorrows, err = add(r.TotalBorrows, interest); err != nil {
+			return err
+		}
+		supplierShare, err := sub(interest, reserveShare)
+		if err != nil {
+			return err
+		}
+		if r.TotalDeposits, err = add(r.TotalDeposits, supplierShare); err != nil {
+			return err
+		}
+		// borrowIndex += borrowIndex * borrowRatePerSecond * timeElapsed / PRECISION
+		indexPerSecond, err := mulDiv(r.BorrowIndex, rate, big.NewInt(1))
+		if err != nil {
+			return err
+		}
+		growth, err := mulDiv(indexPerSecond, elapsed, Precision)
+		if err != nil {
+			return err
+		}
+		if r.BorrowIndex, err = add(r.BorrowIndex, growth); err != nil {
+			return err
+		}
+	}
+	r.LastUpdateTime = now
+	return nil
+}
+
+// transferIn은 사용자 지갑에서 풀로 기초 자산을 옮깁니다 (safeTransferFrom).
+// transferIn moves underlying from a user's wallet to the pool (safeTransferFrom).
+func (p *LendingPool) transferIn(r *reserve, user string, amount *big.Int) error {
+	if amount.Sign() == 0 {
+		return nil
+	}
+	w := p.st.wallets[user]
+	if balance(w, r.Asset).Cmp(amount) < 0 {
+		return fmt.Errorf("%s %s: %w", user, r.Asset, ErrInsufficientBalance)
+	}
+	w[r.Asset] = new(big.Int).Sub(w[r.Asset], amount)
+	var err error
+	r.cash, err = add(r.cash, amount)
+	return err
+}
+
+// transferOut은 풀에서 사용자 지갑으로 기초 자산을 옮깁니다 (safeTransfer).
+// transferOut moves underlying from the pool to a user's wallet (safeTransfer).
+func (p *LendingPool) transferOut(r *reserve, user string, amount *big.Int) error {
+	if r.cash.Cmp(amount) < 0 {
+		return fmt.Errorf("pool %s: %w", r.Asset, ErrInsufficientBalance)
+	}
+	r.cash = new(big.Int).Sub(r.cash, amount)
+	return p.Mint(user, r.Asset, amount)
+}
+
+// config는 사용자 설정 비트맵입니다 (스터디 배치).
+// config is a user's configuration bitmap (study layout).
+func (p *LendingPool) config(user string) contracts.UserConfigurationMap {
+	if c, ok := p.st.configs[user]; ok {
+		return c
+	}
+	return contracts.UserConfigurationMap{Data: new(big.Int), Layout: contracts.StudyUserConfigLayout}
+}
+
+// isInvariant는 err이 불변 조건 위반인지 반환합니다 (이 경우 호출 결과는 유효함).
+// isInvariant reports whether err is an invariant violation (the call's result is still valid then).
+func isInvariant(err error) bool {
+	return errors.Is(err, ErrInvariant)
+}
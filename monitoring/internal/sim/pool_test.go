@@ -0,0 +1,252 @@
+package sim
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/jeongseup/lending-monitor/internal/ratemodel"
+)
+
+// e18은 정수 n × 1e18입니다.
+// e18 is the integer n × 1e18.
+func e18(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), Precision)
+}
+
+// newLiquidationPool은 contracts/test/Liquidation.t.sol의 setUp을 재현합니다
+// (ETH $2,000, USDC $1, 18 소수점 가격, Bob이 USDC 50,000 예치).
+// newLiquidationPool reproduces the setUp of contracts/test/Liquidation.t.sol
+// (ETH $2,000, USDC $1, 18-decimal prices, Bob supplies 50,000 USDC).
+func newLiquidationPool(t *testing.T) *LendingPool {
+	t.Helper()
+	clock := NewClock(1_700_000_000)
+	oracle := NewOracle(clock, 3600)
+	oracle.SetPrice("WETH", e18(2000))
+	oracle.SetPrice("USDC", e18(1))
+	model, err := ratemodel.NewInterestRateModel(ratemodel.Params{
+		BaseRate:       big.NewInt(0.02e18),
+		Multiplier:     big.NewInt(0.1e18),
+		JumpMultiplier: big.NewInt(1e18),
+		Kink:           big.NewInt(0.8e18),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewLendingPool(clock, oracle, model)
+	must(t, p.InitReserve("WETH", 18, big.NewInt(0.75e18), big.NewInt(0.80e18)))
+	must(t, p.InitReserve("USDC", 18, big.NewInt(0.80e18), big.NewInt(0.85e18)))
+	for _, user := range []string{"alice", "bob", "liquidator"} {
+		must(t, p.Mint(user, "WETH", e18(100)))
+		must(t, p.Mint(user, "USDC", e18(100_000)))
+	}
+	must(t, p.Deposit("bob", "USDC", e18(50_000)))
+	return p
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLiquidationPriceDrop(t *testing.T) {
+	p := newLiquidationPool(t)
+	must(t, p.Deposit("alice", "WETH", e18(10)))
+	must(t, p.Borrow("alice", "USDC", e18(15_000)))
+
+	// HF = 10 × 1500 × 0.8 / 15000 = 0.8
+	p.Oracle().SetPrice("WETH", e18(1500))
+	hf, err := p.HealthFactor("alice")
+	must(t, err)
+	if want := big.NewInt(0.8e18); hf.Cmp(want) != 0 {
+		t.Fatalf("HF = %s, want %s", hf, want)
+	}
+
+	seized, err := p.Liquidate("liquidator", "alice", "USDC", "WETH", e18(7_500))
+	must(t, err)
+	// 7500 × 1.05 / 1500 = 5.25 ETH
+	if want := new(big.Int).Div(new(big.Int).Mul(e18(7_500), big.NewInt(1.05e18)), e18(1500)); seized.Cmp(want) != 0 {
+		t.Errorf("seized = %s, want %s", seized, want)
+	}
+	if got, want := p.WalletBalance("liquidator", "WETH"), new(big.Int).Add(e18(100), seized); got.Cmp(want) != 0 {
+		t.Errorf("liquidator WETH = %s, want %s", got, want)
+	}
+	if got := p.Debt("alice", "USDC"); got.Cmp(e18(7_500)) != 0 {
+		t.Errorf("alice debt = %s, want %s", got, e18(7_500))
+	}
+	if got, want := p.Supplied("alice", "WETH"), new(big.Int).Sub(e18(10), seized); got.Cmp(want) != 0 {
+		t.Errorf("alice collateral = %s, want %s", got, want)
+	}
+}
+
+// TestParamsLiquidationBonus는 ParameterImpact.t.sol의 test_impact_liquidationBonus를 풀에서 재현합니다.
+// TestParamsLiquidationBonus reproduces test_impact_liquidationBonus from ParameterImpact.t.sol on the pool.
+func TestParamsLiquidationBonus(t *testing.T) {
+	p := newLiquidationPool(t)
+	params := p.Params()
+	params.LiquidationBonus = big.NewInt(0.10e18)
+	params.CloseFactor = big.NewInt(1e18)
+	must(t, p.SetParams(params))
+	must(t, p.Deposit("alice", "WETH", e18(10)))
+	must(t, p.Borrow("alice", "USDC", e18(15_000)))
+	p.Oracle().SetPrice("WETH", e18(1500))
+
+	// 7500 × 1.10 / 1500 = 5.5 ETH
+	seized, err := p.Liquidate("liquidator", "alice", "USDC", "WETH", e18(7_500))
+	must(t, err)
+	if want := big.NewInt(5.5e18); seized.Cmp(want) != 0 {
+		t.Errorf("seized = %s, want %s", seized, want)
+	}
+	// Close Factor 100%: 남은 부채 전액도 한 번에 청산 가능 (담보 부족으로 revert될 뿐 한도 초과는 아님)
+	// Close factor 100%: the remaining debt may be covered at once (reverts on collateral, not on the close factor)
+	if _, err := p.Liquidate("liquidator", "alice", "USDC", "WETH", e18(7_500)); !errors.Is(err, ErrInsufficientCollateral) {
+		t.Errorf("err = %v, want %v", err, ErrInsufficientCollateral)
+	}
+
+	params.CloseFactor = new(big.Int)
+	if err := p.SetParams(params); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("zero close factor: err = %v, want %v", err, ErrInvalidParams)
+	}
+}
+
+func TestRevertRollsBackState(t *testing.T) {
+	p := newLiquidationPool(t)
+	must(t, p.Deposit("alice", "WETH", e18(10)))
+	must(t, p.Borrow("alice", "USDC", e18(15_000)))
+	p.Oracle().SetPrice("WETH", e18(1500))
+
+	before, _ := p.Reserve("USDC")
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"exceeds close factor", func() error {
+			_, err := p.Liquidate("liquidator", "alice", "USDC", "WETH", e18(10_000))
+			return err
+		}, ErrExceedsCloseFactor},
+		{"self liquidation", func() error {
+			_, err := p.Liquidate("alice", "alice", "USDC", "WETH", e18(5_000))
+			return err
+		}, ErrSelfLiquidation},
+		{"borrow while underwater", func() error { return p.Borrow("alice", "USDC", e18(1)) }, ErrInsufficientCollateral},
+		{"withdraw while underwater", func() error { return p.Withdraw("alice", "WETH", e18(1)) }, ErrUndercollateralized},
+		{"zero amount", func() error { return p.Deposit("alice", "WETH", new(big.Int)) }, ErrZeroAmount},
+		{"unknown reserve", func() error { return p.Deposit("alice", "DAI", e18(1)) }, ErrReserveNotActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p.Clock().Advance(time.Hour)
+			p.Oracle().SetPrice("WETH", e18(1500))
+			p.Oracle().SetPrice("USDC", e18(1))
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			// 실패한 호출의 이자 누적도 되돌려져야 함 / Interest accrued by the failed call is rolled back too
+			after, _ := p.Reserve("USDC")
+			if after.TotalBorrows.Cmp(before.TotalBorrows) != 0 || after.LastUpdateTime != before.LastUpdateTime {
+				t.Errorf("state changed: borrows %s → %s, updated %d → %d",
+					before.TotalBorrows, after.TotalBorrows, before.LastUpdateTime, after.LastUpdateTime)
+			}
+		})
+	}
+}
+
+func TestHealthyPositionNotLiquidatable(t *testing.T) {
+	p := newLiquidationPool(t)
+	must(t, p.Deposit("alice", "WETH", e18(10)))
+	must(t, p.Borrow("alice", "USDC", e18(5_000)))
+	if _, err := p.Liquidate("liquidator", "alice", "USDC", "WETH", e18(2_500)); !errors.Is(err, ErrHealthy) {
+		t.Fatalf("err = %v, want ErrHealthy", err)
+	}
+}
+
+func TestAccrueInterest(t *testing.T) {
+	p := newLiquidationPool(t)
+	must(t, p.Deposit("alice", "WETH", e18(10)))
+	must(t, p.Borrow("alice", "USDC", e18(10_000)))
+
+	p.Clock().Advance(365 * 24 * time.Hour)
+	must(t, p.AccrueInterest("USDC"))
+	r, _ := p.Reserve("USDC")
+
+	// 사용률 20% → 2% + 0.2 × 10% = 4%/년 (초당 버림 때문에 약간 작음)
+	// 20% utilization → 2% + 0.2 × 10% = 4%/year (slightly less due to per-second truncation)
+	interest := new(big.Int).Sub(r.TotalBorrows, e18(10_000))
+	if lo, hi := e18(399), e18(400); interest.Cmp(lo) < 0 || interest.Cmp(hi) > 0 {
+		t.Errorf("interest = %s, want ≈ 400e18", interest)
+	}
+	if want := new(big.Int).Div(interest, big.NewInt(10)); r.TotalReserves.Cmp(want) != 0 {
+		t.Errorf("reserves = %s, want %s", r.TotalReserves, want)
+	}
+	if want := new(big.Int).Add(e18(50_000), new(big.Int).Sub(interest, r.TotalReserves)); r.TotalDeposits.Cmp(want) != 0 {
+		t.Errorf("deposits = %s, want %s", r.TotalDeposits, want)
+	}
+	if r.BorrowIndex.Cmp(Precision) <= 0 {
+		t.Errorf("borrow index = %s, want > 1e18", r.BorrowIndex)
+	}
+	// 개인 부채 잔고에는 이자가 반영되지 않음 / Interest is not reflected in individual debt balances
+	if got := p.Debt("alice", "USDC"); got.Cmp(e18(10_000)) != 0 {
+		t.Errorf("alice debt = %s, want %s", got, e18(10_000))
+	}
+}
+
+// TestInvariantHandler는 LendingPool.invariant.t.sol의 핸들러처럼 무작위 호출 시퀀스 뒤에 불변 조건을 확인합니다.
+// TestInvariantHandler checks the invariants after random call sequences, like the handler in LendingPool.invariant.t.sol.
+func TestInvariantHandler(t *testing.T) {
+	usdc := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e6)) }
+	bound := func(rng *rand.Rand, lo, hi *big.Int) *big.Int {
+		span := new(big.Int).Sub(hi, lo)
+		return new(big.Int).Add(lo, new(big.Int).Rand(rng, span.Add(span, big.NewInt(1))))
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		clock := NewClock(1_700_000_000)
+		oracle := NewOracle(clock, 0)
+		oracle.SetPrice("WETH", big.NewInt(2000e8))
+		oracle.SetPrice("USDC", big.NewInt(1e8))
+		model, err := ratemodel.NewInterestRateModel(ratemodel.Params{
+			BaseRate: big.NewInt(0.02e18), Multiplier: big.NewInt(0.1e18),
+			JumpMultiplier: big.NewInt(1e18), Kink: big.NewInt(0.8e18),
+		})
+		must(t, err)
+		p := NewLendingPool(clock, oracle, model)
+		must(t, p.InitReserve("WETH", 18, big.NewInt(0.75e18), big.NewInt(0.80e18)))
+		must(t, p.InitReserve("USDC", 6, big.NewInt(0.80e18), big.NewInt(0.85e18)))
+		must(t, p.Mint("lp", "USDC", usdc(1_000_000)))
+		must(t, p.Deposit("lp", "USDC", usdc(1_000_000)))
+		actors := []string{"a0", "a1", "a2"}
+		for _, a := range actors {
+			must(t, p.Mint(a, "WETH", e18(100)))
+			must(t, p.Mint(a, "USDC", usdc(100_000)))
+		}
+
+		for step := 0; step < 200; step++ {
+			actor := actors[rng.Intn(len(actors))]
+			var err error
+			switch rng.Intn(4) {
+			case 0:
+				err = p.Deposit(actor, "WETH", bound(rng, big.NewInt(1e15), e18(10)))
+			case 1:
+				err = p.Borrow(actor, "USDC", bound(rng, big.NewInt(1e4), usdc(1000)))
+			case 2:
+				_, err = p.Repay(actor, "USDC", bound(rng, big.NewInt(1e4), usdc(1000)))
+			case 3:
+				clock.Advance(time.Duration(bound(rng, big.NewInt(1), big.NewInt(365*24*3600)).Int64()) * time.Second)
+			}
+			// 핸들러처럼 revert는 무시하되 불변 조건 위반은 실패
+			// Like the handler, reverts are ignored but invariant violations fail
+			if errors.Is(err, ErrInvariant) {
+				t.Fatalf("seed %d step %d: %v", seed, step, err)
+			}
+			if err := p.CheckInvariants(); err != nil {
+				t.Fatalf("seed %d step %d: %v", seed, step, err)
+			}
+		}
+	}
+}
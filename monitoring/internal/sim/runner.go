@@ -0,0 +1,386 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jeongseup/lending-monitor/internal/ratemodel"
+)
+
+// Report는 시나리오 실행 결과입니다.
+// Report is the result of running a scenario.
+type Report struct {
+	Name  string
+	Steps []StepResult
+}
+
+// StepResult는 단계 하나의 결과입니다.
+// StepResult is the result of one step.
+type StepResult struct {
+	Index   int
+	Elapsed time.Duration
+	Name    string
+	Action  string
+
+	// Summary는 동작 설명입니다 (예: "alice borrow 15000 USDC").
+	// Summary describes the action (e.g. "alice borrow 15000 USDC").
+	Summary string
+
+	// Outcome은 결과 설명입니다 (예: "ok", "seized 5.25 WETH", "reverted: ...").
+	// Outcome describes the result (e.g. "ok", "seized 5.25 WETH", "reverted: ...").
+	Outcome string
+
+	// Failure는 단계 자체의 실패입니다 (예상하지 못한 revert, 예상한 revert 없음, 불변 조건 위반).
+	// Failure is a failure of the step itself (unexpected revert, missing expected revert, invariant violation).
+	Failure string
+
+	// HealthFactors는 단계에 관련된 사용자의 단계 후 헬스팩터입니다 (1e18 스케일).
+	// HealthFactors are the post-step health factors of the users involved in the step (1e18 scale).
+	HealthFactors map[string]*big.Int
+
+	Checks []CheckResult
+}
+
+// CheckResult는 단언 하나의 결과입니다.
+// CheckResult is the result of one assertion.
+type CheckResult struct {
+	Description string
+	Got         string
+	OK          bool
+}
+
+// Failed는 단계나 단언이 하나라도 실패했는지 반환합니다.
+// Failed reports whether any step or assertion failed.
+func (r *Report) Failed() bool {
+	return r.Failures() > 0
+}
+
+// Failures는 실패한 단계와 단언의 수입니다.
+// Failures is the number of failed steps and assertions.
+func (r *Report) Failures() int {
+	n := 0
+	for _, s := range r.Steps {
+		if s.Failure != "" {
+			n++
+		}
+		for _, c := range s.Checks {
+			if !c.OK {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Run은 시나리오를 새 풀에서 실행합니다. 반환 에러는 설정 실패이며, 단언 실패는 Report에 기록됩니다.
+// Run executes the scenario on a fresh pool. The returned error is a setup failure;
+// assertion failures are recorded in the Report.
+func (s *Scenario) Run() (*Report, error) {
+	_, report, err := s.Execute()
+	return report, err
+}
+
+// Execute는 Run과 같지만 단계가 끝난 풀도 반환합니다 (시나리오를 출발점으로 쓰는 파라미터 스윕 등).
+// Execute is like Run but also returns the pool after the steps (for parameter sweeps starting from a scenario).
+func (s *Scenario) Execute() (*LendingPool, *Report, error) {
+	p, err := s.setup()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: 설정 실패 / setup failed: %w", s.Name, err)
+	}
+	report := &Report{Name: s.Name}
+	for i, st := range s.Steps {
+		report.Steps = append(report.Steps, s.runStep(p, i, st))
+	}
+	return p, report, nil
+}
+
+// setup은 시계, 오라클, 이자율 모델, 리저브, 사용자 잔고를 준비합니다.
+// setup prepares the clock, oracle, rate model, reserves and user balances.
+func (s *Scenario) setup() (*LendingPool, error) {
+	clock := NewClock(s.Start)
+	oracle := NewOracle(clock, s.MaxStaleness)
+
+	rate := func(v, def string) *big.Int {
+		if v == "" {
+			v = def
+		}
+		n, _ := ParseUnits(v, 18)
+		return n
+	}
+	model, err := ratemodel.NewInterestRateModel(ratemodel.Params{
+		BaseRate:       rate(s.RateModel.BaseRate, "0.02"),
+		Multiplier:     rate(s.RateModel.Multiplier, "0.1"),
+		JumpMultiplier: rate(s.RateModel.JumpMultiplier, "1"),
+		Kink:           rate(s.RateModel.Kink, "0.8"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewLendingPool(clock, oracle, model)
+	if err := p.SetParams(Params{
+		LiquidationBonus: rate(s.Params.LiquidationBonus, "0.05"),
+		CloseFactor:      rate(s.Params.CloseFactor, "0.5"),
+		ReserveFactor:    rate(s.Params.ReserveFactor, "0.1"),
+	}); err != nil {
+		return nil, err
+	}
+	for _, r := range s.Reserves {
+		price, _ := ParseUnits(r.Price, *s.PriceDecimals)
+		oracle.SetPrice(r.Asset, price)
+		cf, _ := ParseUnits(r.CollateralFactor, 18)
+		lt, _ := ParseUnits(r.LiquidationThreshold, 18)
+		if err := p.InitReserve(r.Asset, r.Decimals, cf, lt); err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Asset, err)
+		}
+	}
+	for _, actor := range sortedKeys(s.Actors) {
+		for _, asset := range sortedKeys(s.Actors[actor]) {
+			amount, _ := ParseUnits(s.Actors[actor][asset], s.decimals(asset))
+			if err := p.Mint(actor, asset, amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// runStep은 단계 하나를 실행하고 단언을 확인합니다.
+// runStep executes one step and checks its assertions.
+func (s *Scenario) runStep(p *LendingPool, i int, st Step) StepResult {
+	res := StepResult{
+		Index:   i + 1,
+		Elapsed: time.Duration(p.Clock().Now()-s.Start) * time.Second,
+		Name:    st.Name,
+		Action:  st.Action,
+		Outcome: "ok",
+	}
+	amount := func(asset string) *big.Int {
+		v, _ := ParseUnits(st.Amount, s.decimals(asset))
+		return v
+	}
+
+	var err error
+	var involved []string
+	switch st.Action {
+	case ActionDeposit:
+		res.Summary = fmt.Sprintf("%s deposit %s %s", st.Actor, st.Amount, st.Asset)
+		err = p.Deposit(st.Actor, st.Asset, amount(st.Asset))
+		involved = []string{st.Actor}
+	case ActionWithdraw:
+		res.Summary = fmt.Sprintf("%s withdraw %s %s", st.Actor, st.Amount, st.Asset)
+		err = p.Withdraw(st.Actor, st.Asset, amount(st.Asset))
+		involved = []string{st.Actor}
+	case ActionBorrow:
+		res.Summary = fmt.Sprintf("%s borrow %s %s", st.Actor, st.Amount, st.Asset)
+		err = p.Borrow(st.Actor, st.Asset, amount(st.Asset))
+		involved = []string{st.Actor}
+	case ActionRepay:
+		res.Summary = fmt.Sprintf("%s repay %s %s", st.Actor, st.Amount, st.Asset)
+		var repaid *big.Int
+		if repaid, err = p.Repay(st.Actor, st.Asset, amount(st.Asset)); repaid != nil {
+			res.Outcome = fmt.Sprintf("repaid %s %s", FormatUnits(repaid, s.decimals(st.Asset)), st.Asset)
+		}
+		involved = []string{st.Actor}
+	case ActionLiquidate:
+		res.Summary = fmt.Sprintf("%s liquidate %s: %s %s → %s", st.Actor, st.Borrower, st.Amount, st.DebtAsset, st.CollateralAsset)
+		var seized *big.Int
+		if seized, err = p.Liquidate(st.Actor, st.Borrower, st.DebtAsset, st.CollateralAsset, amount(st.DebtAsset)); seized != nil {
+			res.Outcome = fmt.Sprintf("seized %s %s", FormatUnits(seized, s.decimals(st.CollateralAsset)), st.CollateralAsset)
+		}
+		involved = []string{st.Borrower}
+	case ActionPrice:
+		res.Summary = fmt.Sprintf("price %s = %s", st.Asset, st.Price)
+		price, _ := ParseUnits(st.Price, *s.PriceDecimals)
+		p.Oracle().SetPrice(st.Asset, price)
+		involved = p.Users()
+	case ActionWarp:
+		res.Summary = "warp " + st.Duration
+		d, _ := ParseDuration(st.Duration)
+		p.Clock().Advance(d)
+	case ActionAccrue:
+		res.Summary = "accrue " + st.Asset
+		err = p.AccrueInterest(st.Asset)
+	case ActionCheck:
+		res.Summary = "check"
+	}
+
+	switch {
+	case errors.Is(err, ErrInvariant):
+		res.Failure = err.Error()
+	case err != nil && st.ExpectRevert == "":
+		res.Outcome = "reverted: " + err.Error()
+		res.Failure = "예상하지 못한 revert / unexpected revert"
+	case err != nil && !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(st.ExpectRevert)):
+		res.Outcome = "reverted: " + err.Error()
+		res.Failure = fmt.Sprintf("revert 메시지 불일치 / revert message mismatch: want %q", st.ExpectRevert)
+	case err != nil:
+		res.Outcome = "reverted as expected: " + err.Error()
+	case st.ExpectRevert != "":
+		res.Failure = fmt.Sprintf("revert가 일어나지 않음 / did not revert: want %q", st.ExpectRevert)
+	}
+
+	if len(involved) > 0 {
+		res.HealthFactors = make(map[string]*big.Int)
+		for _, user := range involved {
+			// 부채 없는 사용자 (HF = max)는 생략 / Users without debt (HF = max) are omitted
+			if hf, err := p.HealthFactor(user); err == nil && hf.Cmp(MaxUint256) != 0 {
+				res.HealthFactors[user] = hf
+			}
+		}
+	}
+	for _, e := range st.Expect {
+		res.Checks = append(res.Checks, s.check(p, e))
+	}
+	return res
+}
+
+// check는 단언 하나를 평가합니다.
+// check evaluates one assertion.
+func (s *Scenario) check(p *LendingPool, e Expectation) CheckResult {
+	m := metrics[e.Metric]
+	var d uint8 = 18
+	switch {
+	case m.raw:
+		d = 0
+	case m.assetScale:
+		d = s.decimals(e.Asset)
+	}
+
+	desc := e.Metric + "(" + strings.Join(slices.DeleteFunc([]string{e.Actor, e.Asset}, func(v string) bool { return v == "" }), ", ") + ")"
+	got, err := s.metric(p, e)
+	if err != nil {
+		return CheckResult{Description: desc, Got: "error: " + err.Error()}
+	}
+
+	parse := func(v string) *big.Int {
+		if v == "max" {
+			return MaxUint256
+		}
+		n, _ := ParseUnits(v, d)
+		return n
+	}
+	ok := true
+	var bounds []string
+	if e.Eq != "" {
+		ok = ok && got.Cmp(parse(e.Eq)) == 0
+		bounds = append(bounds, "= "+e.Eq)
+	}
+	if e.Min != "" {
+		ok = ok && got.Cmp(parse(e.Min)) >= 0
+		bounds = append(bounds, ">= "+e.Min)
+	}
+	if e.Max != "" {
+		ok = ok && got.Cmp(parse(e.Max)) <= 0
+		bounds = append(bounds, "<= "+e.Max)
+	}
+	return CheckResult{Description: desc + " " + strings.Join(bounds, ", "), Got: FormatUnits(got, d), OK: ok}
+}
+
+// metric은 단언 지표의 현재 값입니다.
+// metric is the current value of an assertion metric.
+func (s *Scenario) metric(p *LendingPool, e Expectation) (*big.Int, error) {
+	r, _ := p.Reserve(e.Asset)
+	switch e.Metric {
+	case "health_factor":
+		return p.HealthFactor(e.Actor)
+	case "collateral_value":
+		return p.TotalCollateralValue(e.Actor)
+	case "debt_value":
+		return p.TotalDebtValue(e.Actor)
+	case "user_configuration":
+		return p.UserConfiguration(e.Actor), nil
+	case "wallet":
+		return p.WalletBalance(e.Actor, e.Asset), nil
+	case "supplied":
+		return p.Supplied(e.Actor, e.Asset), nil
+	case "debt":
+		return p.Debt(e.Actor, e.Asset), nil
+	case "total_deposits":
+		return r.TotalDeposits, nil
+	case "total_borrows":
+		return r.TotalBorrows, nil
+	case "total_reserves":
+		return r.TotalReserves, nil
+	case "cash":
+		return p.Cash(e.Asset), nil
+	case "borrow_index":
+		return r.BorrowIndex, nil
+	case "utilization":
+		return p.UtilizationRate(e.Asset)
+	}
+	return nil, fmt.Errorf("알 수 없는 지표 / unknown metric %q", e.Metric)
+}
+
+// decimals는 자산의 소수점입니다.
+// decimals is an asset's number of decimals.
+func (s *Scenario) decimals(asset string) uint8 {
+	for _, r := range s.Reserves {
+		if r.Asset == asset {
+			return r.Decimals
+		}
+	}
+	return 18
+}
+
+// WriteTimeline은 사람이 읽는 타임라인 보고서를 씁니다.
+// WriteTimeline writes a human-readable timeline report.
+func (r *Report) WriteTimeline(w io.Writer) {
+	fmt.Fprintf(w, "=== %s ===\n", r.Name)
+	for _, s := range r.Steps {
+		mark := "✓"
+		if s.Failure != "" {
+			mark = "✗"
+		}
+		line := fmt.Sprintf("%s %3d  %-13s %-44s %s", mark, s.Index, "+"+formatElapsed(s.Elapsed), s.Summary, s.Outcome)
+		if len(s.HealthFactors) > 0 {
+			var hfs []string
+			for _, user := range sortedKeys(s.HealthFactors) {
+				hfs = append(hfs, user+"="+FormatUnits(s.HealthFactors[user], 18))
+			}
+			line += "  [HF " + strings.Join(hfs, " ") + "]"
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+		if s.Name != "" {
+			fmt.Fprintf(w, "        # %s\n", s.Name)
+		}
+		if s.Failure != "" {
+			fmt.Fprintf(w, "        FAIL: %s\n", s.Failure)
+		}
+		for _, c := range s.Checks {
+			if c.OK {
+				fmt.Fprintf(w, "        ✓ %s (got %s)\n", c.Description, c.Got)
+			} else {
+				fmt.Fprintf(w, "        ✗ %s (got %s)\n", c.Description, c.Got)
+			}
+		}
+	}
+	if n := r.Failures(); n > 0 {
+		fmt.Fprintf(w, "FAIL %s: %d건 실패 / %d failure(s)\n", r.Name, n, n)
+	} else {
+		fmt.Fprintf(w, "PASS %s\n", r.Name)
+	}
+}
+
+// formatElapsed는 경과 시간을 "30d00:00:00" 형태로 씁니다.
+// formatElapsed formats elapsed time as "30d00:00:00".
+func formatElapsed(d time.Duration) string {
+	s := int64(d / time.Second)
+	return fmt.Sprintf("%dd%02d:%02d:%02d", s/86400, s%86400/3600, s%3600/60, s%60)
+}
+
+// sortedKeys는 맵의 키를 정렬해 반환합니다.
+// sortedKeys returns a map's keys sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,421 @@
+package sim
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario는 시뮬레이션 시나리오 파일입니다 (YAML, JSON도 YAML의 부분집합이므로 그대로 읽힘).
+// Scenario is a simulation scenario file (YAML; JSON is a subset of YAML and loads as-is).
+//
+// 금액은 자산 단위의 10진수 문자열입니다 ("50_000", "5.25"). 가격은 price_decimals,
+// 헬스팩터/가치/사용률/인덱스는 1e18 스케일로 변환됩니다 (Scenario.t.sol의 20_000e18과 같은 값).
+// Amounts are decimal strings in asset units ("50_000", "5.25"). Prices are scaled by price_decimals,
+// health factors/values/utilization/indexes by 1e18 (the same values as 20_000e18 in Scenario.t.sol).
+type Scenario struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Start는 시작 시각입니다 (유닉스 초, 기본 1,700,000,000).
+	// Start is the starting time (unix seconds, default 1,700,000,000).
+	Start uint64 `yaml:"start"`
+
+	// MaxStaleness는 오라클 최대 지연 시간입니다 (초, 0 = 검사 안 함).
+	// MaxStaleness is the oracle's maximum staleness (seconds, 0 = unchecked).
+	MaxStaleness uint64 `yaml:"max_staleness"`
+
+	// PriceDecimals는 가격 소수점입니다 (기본 8, Chainlink USD 피드).
+	// PriceDecimals is the number of price decimals (default 8, Chainlink USD feeds).
+	PriceDecimals *uint8 `yaml:"price_decimals"`
+
+	RateModel RateModelSpec                `yaml:"rate_model"`
+	Params    ParamsSpec                   `yaml:"params"`
+	Reserves  []ReserveSpec                `yaml:"reserves"`
+	Actors    map[string]map[string]string `yaml:"actors"`
+	Steps     []Step                       `yaml:"steps"`
+}
+
+// RateModelSpec은 InterestRateModel 파라미터입니다 (10진수 비율, 생략하면 스터디 배포값 2%/10%/100%/80%).
+// RateModelSpec are the InterestRateModel parameters (decimal ratios, defaulting to the study deployment 2%/10%/100%/80%).
+type RateModelSpec struct {
+	BaseRate       string `yaml:"base_rate"`
+	Multiplier     string `yaml:"multiplier"`
+	JumpMultiplier string `yaml:"jump_multiplier"`
+	Kink           string `yaml:"kink"`
+}
+
+// ParamsSpec은 컨트랙트 상수를 덮어쓰는 풀 파라미터입니다 (10진수 비율, 생략하면 5%/50%/10%).
+// ParamsSpec are pool parameters overriding the contract constants (decimal ratios, defaulting to 5%/50%/10%).
+type ParamsSpec struct {
+	LiquidationBonus string `yaml:"liquidation_bonus"`
+	CloseFactor      string `yaml:"close_factor"`
+	ReserveFactor    string `yaml:"reserve_factor"`
+}
+
+// ReserveSpec은 initReserve 인자와 초기 가격입니다.
+// ReserveSpec are the initReserve arguments and the initial price.
+type ReserveSpec struct {
+	Asset                string `yaml:"asset"`
+	Decimals             uint8  `yaml:"decimals"`
+	Price                string `yaml:"price"`
+	CollateralFactor     string `yaml:"collateral_factor"`
+	LiquidationThreshold string `yaml:"liquidation_threshold"`
+}
+
+// Step은 타임라인의 한 단계입니다.
+// Step is one step of the timeline.
+type Step struct {
+	Name   string `yaml:"name"`
+	Action string `yaml:"action"`
+
+	Actor    string `yaml:"actor"`
+	Asset    string `yaml:"asset"`
+	Amount   string `yaml:"amount"`
+	Price    string `yaml:"price"`
+	Duration string `yaml:"duration"`
+
+	// liquidate 전용 / liquidate only
+	Borrower        string `yaml:"borrower"`
+	DebtAsset       string `yaml:"debt_asset"`
+	CollateralAsset string `yaml:"collateral_asset"`
+
+	// ExpectRevert는 기대하는 revert 메시지입니다 (대소문자 무시 부분 일치, 예: "Exceeds close factor").
+	// ExpectRevert is the expected revert message (case-insensitive substring, e.g. "Exceeds close factor").
+	ExpectRevert string `yaml:"expect_revert"`
+
+	Expect []Expectation `yaml:"expect"`
+}
+
+// Expectation은 단계 뒤에 확인하는 단언입니다. eq 또는 min/max 중 하나 이상이 필요합니다.
+// Expectation is an assertion checked after a step. Needs eq or at least one of min/max.
+type Expectation struct {
+	Metric string `yaml:"metric"`
+	Actor  string `yaml:"actor"`
+	Asset  string `yaml:"asset"`
+	Eq     string `yaml:"eq"`
+	Min    string `yaml:"min"`
+	Max    string `yaml:"max"`
+}
+
+// 시나리오 동작 / Scenario actions
+const (
+	ActionDeposit   = "deposit"
+	ActionWithdraw  = "withdraw"
+	ActionBorrow    = "borrow"
+	ActionRepay     = "repay"
+	ActionLiquidate = "liquidate"
+	ActionPrice     = "price"
+	ActionWarp      = "warp"
+	ActionAccrue    = "accrue"
+	ActionCheck     = "check"
+)
+
+// metricSpec은 단언 지표가 어떤 인자와 스케일을 쓰는지 나타냅니다.
+// metricSpec describes which arguments and scale an assertion metric uses.
+type metricSpec struct {
+	actor, asset bool
+	// assetScale이면 자산 소수점, 아니면 1e18 (raw이면 스케일 없음).
+	// Asset decimals when assetScale, 1e18 otherwise (no scale when raw).
+	assetScale, raw bool
+}
+
+var metrics = map[string]metricSpec{
+	"health_factor":      {actor: true},
+	"collateral_value":   {actor: true},
+	"debt_value":         {actor: true},
+	"user_configuration": {actor: true, raw: true},
+	"wallet":             {actor: true, asset: true, assetScale: true},
+	"supplied":           {actor: true, asset: true, assetScale: true},
+	"debt":               {actor: true, asset: true, assetScale: true},
+	"total_deposits":     {asset: true, assetScale: true},
+	"total_borrows":      {asset: true, assetScale: true},
+	"total_reserves":     {asset: true, assetScale: true},
+	"cash":               {asset: true, assetScale: true},
+	"borrow_index":       {asset: true},
+	"utilization":        {asset: true},
+}
+
+// defaultStart는 Start가 없을 때의 시작 시각입니다.
+// defaultStart is the starting time when Start is unset.
+const defaultStart = 1_700_000_000
+
+// LoadScenario는 시나리오 파일을 읽고 검증합니다.
+// LoadScenario reads and validates a scenario file.
+func LoadScenario(path string) (*Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("시나리오 파일 읽기 실패 / failed to read scenario file: %w", err)
+	}
+	return ParseScenario(path, raw)
+}
+
+// ParseScenario는 메모리의 시나리오를 파싱하고 검증합니다. name은 오류 메시지에 쓰입니다.
+// ParseScenario parses and validates a scenario from memory. name is used in error messages.
+func ParseScenario(name string, raw []byte) (*Scenario, error) {
+	var s Scenario
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if s.Name == "" {
+		s.Name = name
+	}
+	if s.Start == 0 {
+		s.Start = defaultStart
+	}
+	if s.PriceDecimals == nil {
+		d := uint8(8)
+		s.PriceDecimals = &d
+	}
+	if errs := s.validate(); len(errs) > 0 {
+		for i, err := range errs {
+			errs[i] = fmt.Errorf("%s: %w", name, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+	return &s, nil
+}
+
+// validate는 실행 전에 잡을 수 있는 오류를 모두 모읍니다.
+// validate collects every error that can be caught before running.
+func (s *Scenario) validate() []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, f := range []field{
+		{"base_rate", s.RateModel.BaseRate}, {"multiplier", s.RateModel.Multiplier},
+		{"jump_multiplier", s.RateModel.JumpMultiplier}, {"kink", s.RateModel.Kink},
+	} {
+		if f.value != "" {
+			if _, err := ParseUnits(f.value, 18); err != nil {
+				fail("rate_model.%s: %v", f.name, err)
+			}
+		}
+	}
+	for _, f := range []field{
+		{"liquidation_bonus", s.Params.LiquidationBonus}, {"close_factor", s.Params.CloseFactor},
+		{"reserve_factor", s.Params.ReserveFactor},
+	} {
+		if f.value != "" {
+			if _, err := ParseUnits(f.value, 18); err != nil {
+				fail("params.%s: %v", f.name, err)
+			}
+		}
+	}
+
+	decimals := make(map[string]uint8)
+	if len(s.Reserves) == 0 {
+		fail("reserves: 리저브가 최소 하나 필요 / at least one reserve is required")
+	}
+	for i, r := range s.Reserves {
+		if r.Asset == "" {
+			fail("reserves[%d].asset: 필수 / required", i)
+			continue
+		}
+		if _, dup := decimals[r.Asset]; dup {
+			fail("reserves[%d].asset: 중복 %q / duplicate %q", i, r.Asset, r.Asset)
+		}
+		decimals[r.Asset] = r.Decimals
+		for _, f := range []field{
+			{"collateral_factor", r.CollateralFactor}, {"liquidation_threshold", r.LiquidationThreshold},
+		} {
+			if _, err := ParseUnits(f.value, 18); err != nil {
+				fail("reserves[%d].%s: %v", i, f.name, err)
+			}
+		}
+		if _, err := ParseUnits(r.Price, *s.PriceDecimals); err != nil {
+			fail("reserves[%d].price: %v", i, err)
+		}
+	}
+
+	for _, actor := range sortedKeys(s.Actors) {
+		for _, asset := range sortedKeys(s.Actors[actor]) {
+			v := s.Actors[actor][asset]
+			d, ok := decimals[asset]
+			if !ok {
+				fail("actors.%s.%s: 알 수 없는 자산 / unknown asset", actor, asset)
+				continue
+			}
+			if _, err := ParseUnits(v, d); err != nil {
+				fail("actors.%s.%s: %v", actor, asset, err)
+			}
+		}
+	}
+
+	for i, st := range s.Steps {
+		where := fmt.Sprintf("steps[%d] (%s)", i, st.Action)
+		asset := func(field, name string) (uint8, bool) {
+			d, ok := decimals[name]
+			if !ok {
+				fail("%s: %s: 알 수 없는 자산 %q / unknown asset %q", where, field, name, name)
+			}
+			return d, ok
+		}
+		require := func(field, v string) bool {
+			if v == "" {
+				fail("%s: %s: 필수 / required", where, field)
+				return false
+			}
+			return true
+		}
+
+		switch st.Action {
+		case ActionDeposit, ActionWithdraw, ActionBorrow, ActionRepay:
+			require("actor", st.Actor)
+			if d, ok := asset("asset", st.Asset); ok && require("amount", st.Amount) {
+				if _, err := ParseUnits(st.Amount, d); err != nil {
+					fail("%s: amount: %v", where, err)
+				}
+			}
+		case ActionLiquidate:
+			require("actor", st.Actor)
+			require("borrower", st.Borrower)
+			asset("collateral_asset", st.CollateralAsset)
+			if d, ok := asset("debt_asset", st.DebtAsset); ok && require("amount", st.Amount) {
+				if _, err := ParseUnits(st.Amount, d); err != nil {
+					fail("%s: amount: %v", where, err)
+				}
+			}
+		case ActionPrice:
+			asset("asset", st.Asset)
+			if require("price", st.Price) {
+				if _, err := ParseUnits(st.Price, *s.PriceDecimals); err != nil {
+					fail("%s: price: %v", where, err)
+				}
+			}
+		case ActionWarp:
+			if require("duration", st.Duration) {
+				if _, err := ParseDuration(st.Duration); err != nil {
+					fail("%s: duration: %v", where, err)
+				}
+			}
+		case ActionAccrue:
+			asset("asset", st.Asset)
+		case ActionCheck:
+			if len(st.Expect) == 0 {
+				fail("%s: expect: 단언이 필요 / assertions required", where)
+			}
+		default:
+			fail("%s: action: 알 수 없는 동작 / unknown action (deposit, withdraw, borrow, repay, liquidate, price, warp, accrue, check)", where)
+		}
+
+		for j, e := range st.Expect {
+			ewhere := fmt.Sprintf("%s: expect[%d] (%s)", where, j, e.Metric)
+			m, ok := metrics[e.Metric]
+			if !ok {
+				fail("%s: metric: 알 수 없는 지표 / unknown metric (%s)", ewhere, strings.Join(metricNames(), ", "))
+				continue
+			}
+			if m.actor && e.Actor == "" {
+				fail("%s: actor: 필수 / required", ewhere)
+			}
+			var d uint8 = 18
+			if m.asset {
+				ad, ok := decimals[e.Asset]
+				if !ok {
+					fail("%s: asset: 알 수 없는 자산 %q / unknown asset %q", ewhere, e.Asset, e.Asset)
+					continue
+				}
+				if m.assetScale {
+					d = ad
+				}
+			}
+			if m.raw {
+				d = 0
+			}
+			if e.Eq == "" && e.Min == "" && e.Max == "" {
+				fail("%s: eq, min, max 중 하나가 필요 / one of eq, min, max is required", ewhere)
+			}
+			for _, f := range []field{{"eq", e.Eq}, {"min", e.Min}, {"max", e.Max}} {
+				if f.value == "" || f.value == "max" {
+					continue
+				}
+				if _, err := ParseUnits(f.value, d); err != nil {
+					fail("%s: %s: %v", ewhere, f.name, err)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// field는 검증 오류 메시지에 쓰이는 필드 이름과 값입니다.
+// field is a field name and value used in validation error messages.
+type field struct {
+	name, value string
+}
+
+// metricNames는 지표 이름을 정렬해 반환합니다.
+// metricNames returns the metric names sorted.
+func metricNames() []string {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseUnits는 10진수 문자열을 decimals 자리 정수로 바꿉니다 ("1.5", 6 → 1500000).
+// 밑줄은 무시하고 ("50_000"), 정확히 표현할 수 없는 값은 오류입니다.
+// ParseUnits converts a decimal string to an integer with decimals places ("1.5", 6 → 1500000).
+// Underscores are ignored ("50_000"), and values that cannot be represented exactly are errors.
+func ParseUnits(s string, decimals uint8) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(strings.ReplaceAll(strings.TrimSpace(s), "_", ""))
+	if !ok {
+		return nil, fmt.Errorf("잘못된 숫자 %q / invalid number %q", s, s)
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("음수 %q / negative %q", s, s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%q는 소수점 %d자리를 넘음 / %q has more than %d decimals", s, decimals, s, decimals)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// FormatUnits는 decimals 자리 정수를 10진수 문자열로 바꿉니다 (끝의 0 제거, MaxUint256은 "max").
+// FormatUnits converts an integer with decimals places to a decimal string (trailing zeros trimmed, MaxUint256 as "max").
+func FormatUnits(v *big.Int, decimals uint8) string {
+	if v.Cmp(MaxUint256) == 0 {
+		return "max"
+	}
+	s := new(big.Rat).SetFrac(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)).FloatString(int(decimals))
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// ParseDuration은 time.ParseDuration에 일 단위 ("30d")를 더한 것입니다.
+// ParseDuration is time.ParseDuration plus a day unit ("30d").
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("잘못된 기간 %q / invalid duration %q", s, s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("음수 기간 %q / negative duration %q", s, s)
+	}
+	return d, nil
+}
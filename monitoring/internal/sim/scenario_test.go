@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExampleScenarios는 저장소의 예제 시나리오가 모두 통과하는지 확인합니다.
+// TestExampleScenarios checks that every example scenario in the repository passes.
+func TestExampleScenarios(t *testing.T) {
+	files, err := filepath.Glob("../../scenarios/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no example scenarios")
+	}
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			s, err := LoadScenario(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			report, err := s.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Failed() {
+				var buf bytes.Buffer
+				report.WriteTimeline(&buf)
+				t.Fatalf("scenario failed:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+const baseScenario = `
+price_decimals: 18
+reserves:
+  - {asset: WETH, decimals: 18, price: "2000", collateral_factor: "0.75", liquidation_threshold: "0.80"}
+  - {asset: USDC, decimals: 6, price: "1", collateral_factor: "0.80", liquidation_threshold: "0.85"}
+actors:
+  alice: {WETH: "10", USDC: "1000"}
+steps:
+`
+
+func TestScenarioFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    string
+		failures int
+	}{
+		{"passing", `
+  - {action: deposit, actor: alice, asset: WETH, amount: "1", expect: [{metric: supplied, actor: alice, asset: WETH, eq: "1"}]}`, 0},
+		{"assertion mismatch", `
+  - {action: deposit, actor: alice, asset: WETH, amount: "1", expect: [{metric: supplied, actor: alice, asset: WETH, eq: "2"}]}`, 1},
+		{"unexpected revert", `
+  - {action: borrow, actor: alice, asset: USDC, amount: "1"}`, 1},
+		{"missing revert", `
+  - {action: deposit, actor: alice, asset: WETH, amount: "1", expect_revert: "Amount must be > 0"}`, 1},
+		{"revert message mismatch", `
+  - {action: deposit, actor: alice, asset: WETH, amount: "100", expect_revert: "Exceeds close factor"}`, 1},
+		{"expected revert", `
+  - {action: deposit, actor: alice, asset: WETH, amount: "100", expect_revert: "insufficient balance"}`, 0},
+		{"range", `
+  - {action: check, expect: [{metric: wallet, actor: alice, asset: USDC, min: "999.999999", max: "1000"}, {metric: health_factor, actor: alice, eq: max}]}`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseScenario(tt.name, []byte(baseScenario+tt.steps))
+			if err != nil {
+				t.Fatal(err)
+			}
+			report, err := s.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := report.Failures(); got != tt.failures {
+				var buf bytes.Buffer
+				report.WriteTimeline(&buf)
+				t.Errorf("failures = %d, want %d\n%s", got, tt.failures, buf.String())
+			}
+		})
+	}
+}
+
+func TestScenarioValidation(t *testing.T) {
+	tests := []struct {
+		name, steps, want string
+	}{
+		{"unknown action", `
+  - {action: flashloan}`, "unknown action"},
+		{"unknown asset", `
+  - {action: deposit, actor: alice, asset: DAI, amount: "1"}`, `unknown asset "DAI"`},
+		{"too many decimals", `
+  - {action: deposit, actor: alice, asset: USDC, amount: "0.0000001"}`, "more than 6 decimals"},
+		{"unknown metric", `
+  - {action: check, expect: [{metric: apy, asset: USDC, eq: "1"}]}`, "unknown metric"},
+		{"missing bound", `
+  - {action: check, expect: [{metric: utilization, asset: USDC}]}`, "one of eq, min, max"},
+		{"bad duration", `
+  - {action: warp, duration: soon}`, "duration"},
+		{"unknown field", `
+  - {action: warp, duration: 1h, typo: 1}`, "field typo not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenario(tt.name, []byte(baseScenario+tt.steps))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnits(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		decimals uint8
+		want     string
+	}{
+		{"50_000", 6, "50000000000"},
+		{"5.25", 18, "5250000000000000000"},
+		{"0.000000000000000001", 18, "1"},
+	} {
+		got, err := ParseUnits(tt.in, tt.decimals)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseUnits(%q, %d) = %v, %v, want %s", tt.in, tt.decimals, got, err, tt.want)
+			continue
+		}
+		if back := FormatUnits(got, tt.decimals); back != strings.ReplaceAll(tt.in, "_", "") {
+			t.Errorf("FormatUnits(%s, %d) = %s, want %s", got, tt.decimals, back, tt.in)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package sim은 스터디 LendingPool.sol을 체인 없이 Go로 재현하는 결정적 시뮬레이터입니다.
+// Package sim is a deterministic simulator reproducing the study LendingPool.sol in Go without a chain.
+//
+// 모의 시계 (Clock)와 모의 오라클 (Oracle)로 구동되며, 모든 금액은 컨트랙트와 같은 uint256 의미론을 따릅니다:
+// - 나눗셈은 버림, 연산 순서도 Solidity 식과 같음
+// - 이자율은 ratemodel.InterestRateModel (LendingPool이 쓰는 모델)로 계산
+// - require 실패는 센티널 에러로 반환하고, 그 호출의 상태 변경은 모두 되돌림 (revert)
+// - 상태를 바꾸는 호출이 끝날 때마다 Solidity 불변성 테스트와 같은 불변 조건을 검사
+//
+// It is driven by a mock clock (Clock) and a mock oracle (Oracle), and every amount follows
+// the contract's uint256 semantics:
+// - Divisions truncate and are evaluated in the same order as the Solidity expressions
+// - Rates come from ratemodel.InterestRateModel (the model LendingPool uses)
+// - Failed requires are returned as sentinel errors, and every state change of that call is rolled back (revert)
+// - The invariants checked by the Solidity invariant tests are verified after every state-changing call
+package sim
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/jeongseup/lending-monitor/internal/ratemodel"
+)
+
+// 컨트랙트 상수 (모두 1e18 스케일).
+// Contract constants (all on a 1e18 scale).
+var (
+	// Precision은 PRECISION = 1e18입니다.
+	// Precision is PRECISION = 1e18.
+	Precision = ratemodel.Precision
+
+	// LiquidationBonus는 청산 보너스입니다 (LIQUIDATION_BONUS = 5%).
+	// LiquidationBonus is the liquidation bonus (LIQUIDATION_BONUS = 5%).
+	LiquidationBonus = big.NewInt(0.05e18)
+
+	// CloseFactor는 한 번에 청산할 수 있는 부채 비율입니다 (CLOSE_FACTOR = 50%).
+	// CloseFactor is the share of debt liquidatable at once (CLOSE_FACTOR = 50%).
+	CloseFactor = big.NewInt(0.5e18)
+
+	// ReserveFactor는 이자 중 준비금으로 가는 비율입니다 (RESERVE_FACTOR = 10%).
+	// ReserveFactor is the share of interest going to reserves (RESERVE_FACTOR = 10%).
+	ReserveFactor = big.NewInt(0.1e18)
+
+	// MaxUint256은 부채가 없을 때의 헬스팩터입니다 (type(uint256).max).
+	// MaxUint256 is the health factor without debt (type(uint256).max).
+	MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// Params는 컨트랙트에서 상수인 풀 파라미터입니다 (1e18 스케일).
+// 파라미터 스윕처럼 다른 값을 실험할 때만 SetParams로 바꿉니다.
+// Params are the pool parameters that are constants in the contract (1e18 scale).
+// Change them with SetParams only to experiment with other values, as in parameter sweeps.
+type Params struct {
+	LiquidationBonus *big.Int
+	CloseFactor      *big.Int
+	ReserveFactor    *big.Int
+}
+
+// DefaultParams는 LendingPool.sol의 상수 값을 반환합니다.
+// DefaultParams returns the LendingPool.sol constant values.
+func DefaultParams() Params {
+	return Params{
+		LiquidationBonus: new(big.Int).Set(LiquidationBonus),
+		CloseFactor:      new(big.Int).Set(CloseFactor),
+		ReserveFactor:    new(big.Int).Set(ReserveFactor),
+	}
+}
+
+// LendingPool.sol의 require 메시지에 대응하는 에러입니다.
+// Errors corresponding to LendingPool.sol's require messages.
+var (
+	// ErrReserveNotActive는 "Reserve not active"입니다.
+	// ErrReserveNotActive is "Reserve not active".
+	ErrReserveNotActive = errors.New("리저브 비활성 / reserve not active")
+
+	// ErrReserveExists는 "Reserve already exists"입니다.
+	// ErrReserveExists is "Reserve already exists".
+	ErrReserveExists = errors.New("리저브가 이미 존재 / reserve already exists")
+
+	// ErrInvalidFactor는 "CF too high", "LT too high", "CF must be <= LT"입니다.
+	// ErrInvalidFactor is "CF too high", "LT too high" or "CF must be <= LT".
+	ErrInvalidFactor = errors.New("잘못된 담보 인정 비율/청산 기준 / invalid collateral factor or liquidation threshold")
+
+	// ErrInvalidParams는 SetParams에 범위를 벗어난 값이 들어왔을 때 반환됩니다 (컨트랙트에는 없음).
+	// ErrInvalidParams is returned when SetParams gets an out-of-range value (not in the contract).
+	ErrInvalidParams = errors.New("잘못된 풀 파라미터 / invalid pool parameters")
+
+	// ErrZeroAmount는 "Amount must be > 0"입니다.
+	// ErrZeroAmount is "Amount must be > 0".
+	ErrZeroAmount = errors.New("금액은 0보다 커야 함 / amount must be > 0")
+
+	// ErrInsufficientLToken은 "Insufficient lToken balance"입니다.
+	// ErrInsufficientLToken is "Insufficient lToken balance".
+	ErrInsufficientLToken = errors.New("lToken 잔고 부족 / insufficient lToken balance")
+
+	// ErrUndercollateralized는 "Withdrawal would cause undercollateralization"입니다.
+	// ErrUndercollateralized is "Withdrawal would cause undercollateralization".
+	ErrUndercollateralized = errors.New("출금 시 담보 부족 / withdrawal would cause undercollateralization")
+
+	// ErrInsufficientLiquidity는 "Insufficient liquidity"입니다.
+	// ErrInsufficientLiquidity is "Insufficient liquidity".
+	ErrInsufficientLiquidity = errors.New("유동성 부족 / insufficient liquidity")
+
+	// ErrInsufficientCollateral은 "Insufficient collateral"입니다 (대출과 청산에서 공통).
+	// ErrInsufficientCollateral is "Insufficient collateral" (shared by borrow and liquidate).
+	ErrInsufficientCollateral = errors.New("담보 부족 / insufficient collateral")
+
+	// ErrNoDebt는 "No debt to repay"입니다.
+	// ErrNoDebt is "No debt to repay".
+	ErrNoDebt = errors.New("상환할 부채 없음 / no debt to repay")
+
+	// ErrSelfLiquidation은 "Cannot liquidate self"입니다.
+	// ErrSelfLiquidation is "Cannot liquidate self".
+	ErrSelfLiquidation = errors.New("자기 자신은 청산 불가 / cannot liquidate self")
+
+	// ErrHealthy는 "Health factor is healthy"입니다.
+	// ErrHealthy is "Health factor is healthy".
+	ErrHealthy = errors.New("헬스팩터가 정상 / health factor is healthy")
+
+	// ErrExceedsCloseFactor는 "Exceeds close factor"입니다.
+	// ErrExceedsCloseFactor is "Exceeds close factor".
+	ErrExceedsCloseFactor = errors.New("청산 한도 초과 / exceeds close factor")
+
+	// ErrInsufficientBalance는 ERC20 전송의 잔고 부족 revert입니다 (사용자 지갑 또는 풀 현금).
+	// ErrInsufficientBalance is the ERC20 transfer revert for an insufficient balance (user wallet or pool cash).
+	ErrInsufficientBalance = errors.New("ERC20 잔고 부족 / ERC20 insufficient balance")
+
+	// ErrInvariant는 호출 후 불변 조건이 깨졌을 때 반환됩니다 (상태는 되돌리지 않음).
+	// ErrInvariant is returned when an invariant breaks after a call (state is not rolled back).
+	ErrInvariant = errors.New("불변 조건 위반 / invariant violated")
+)
@@ -0,0 +1,197 @@
+package sim
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// HealthFactor는 사용자의 헬스팩터입니다 (getHealthFactor, 1e18 = 1.0, 부채가 없으면 MaxUint256).
+// HealthFactor is a user's health factor (getHealthFactor, 1e18 = 1.0, MaxUint256 without debt).
+func (p *LendingPool) HealthFactor(user string) (*big.Int, error) {
+	debt, err := p.TotalDebtValue(user)
+	if err != nil {
+		return nil, err
+	}
+	if debt.Sign() == 0 {
+		return new(big.Int).Set(MaxUint256), nil
+	}
+	collateral, err := p.collateralValue(user, true)
+	if err != nil {
+		return nil, err
+	}
+	return mulDiv(collateral, Precision, debt)
+}
+
+// TotalCollateralValue는 담보 가치의 합입니다 (getTotalCollateralValue, 수량 × 8 소수점 가격 / 1e18).
+// TotalCollateralValue is the sum of collateral value (getTotalCollateralValue, amount × 8-decimal price / 1e18).
+func (p *LendingPool) TotalCollateralValue(user string) (*big.Int, error) {
+	return p.collateralValue(user, false)
+}
+
+// collateralValue는 _getTotalCollateral, adjusted이면 청산 기준을 곱한 _getTotalCollateralAdjusted입니다.
+// collateralValue is _getTotalCollateral, or _getTotalCollateralAdjusted weighted by liquidation threshold when adjusted.
+func (p *LendingPool) collateralValue(user string, adjusted bool) (*big.Int, error) {
+	total := new(big.Int)
+	cfg := p.config(user)
+	for _, asset := range p.st.list {
+		r := p.st.reserves[asset]
+		if !cfg.IsUsingAsCollateral(r.ID) {
+			continue
+		}
+		amount := balance(r.lTokens, user)
+		if amount.Sign() == 0 {
+			continue
+		}
+		price, err := p.oracle.Price(asset)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", asset, err)
+		}
+		value, err := mulDiv(amount, price, Precision)
+		if err != nil {
+			return nil, err
+		}
+		if adjusted {
+			if value, err = mulDiv(value, r.LiquidationThreshold, Precision); err != nil {
+				return nil, err
+			}
+		}
+		if total, err = add(total, value); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// TotalDebtValue는 부채 가치의 합입니다 (getTotalDebtValue).
+// TotalDebtValue is the sum of debt value (getTotalDebtValue).
+func (p *LendingPool) TotalDebtValue(user string) (*big.Int, error) {
+	total := new(big.Int)
+	cfg := p.config(user)
+	for _, asset := range p.st.list {
+		r := p.st.reserves[asset]
+		if !cfg.IsBorrowing(r.ID) {
+			continue
+		}
+		amount := balance(r.debt, user)
+		if amount.Sign() == 0 {
+			continue
+		}
+		price, err := p.oracle.Price(asset)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", asset, err)
+		}
+		value, err := mulDiv(amount, price, Precision)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = add(total, value); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// UtilizationRate는 리저브의 사용률입니다 (getUtilizationRate).
+// UtilizationRate is a reserve's utilization (getUtilizationRate).
+func (p *LendingPool) UtilizationRate(asset string) (*big.Int, error) {
+	r, ok := p.st.reserves[asset]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return p.model.Utilization(r.TotalDeposits, r.TotalBorrows)
+}
+
+// BorrowRate는 리저브의 현재 연 대출 이자율입니다 (interestRateModel.getBorrowRate, 1e18 스케일).
+// BorrowRate is a reserve's current annual borrow rate (interestRateModel.getBorrowRate, 1e18 scale).
+func (p *LendingPool) BorrowRate(asset string) (*big.Int, error) {
+	r, ok := p.st.reserves[asset]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return p.model.BorrowRate(r.TotalDeposits, r.TotalBorrows)
+}
+
+// Reserve는 리저브 데이터의 사본을 반환합니다 (reserves(asset)).
+// Reserve returns a copy of a reserve's data (reserves(asset)).
+func (p *LendingPool) Reserve(asset string) (Reserve, bool) {
+	r, ok := p.st.reserves[asset]
+	if !ok {
+		return Reserve{}, false
+	}
+	return r.Reserve, true
+}
+
+// Reserves는 모든 리저브를 ID 순서로 반환합니다 (reservesList).
+// Reserves returns every reserve in ID order (reservesList).
+func (p *LendingPool) Reserves() []Reserve {
+	out := make([]Reserve, 0, len(p.st.list))
+	for _, asset := range p.st.list {
+		out = append(out, p.st.reserves[asset].Reserve)
+	}
+	return out
+}
+
+// Supplied는 사용자의 LToken 잔고입니다.
+// Supplied is a user's LToken balance.
+func (p *LendingPool) Supplied(user, asset string) *big.Int {
+	if r, ok := p.st.reserves[asset]; ok {
+		return new(big.Int).Set(balance(r.lTokens, user))
+	}
+	return new(big.Int)
+}
+
+// Debt는 사용자의 DebtToken 잔고입니다.
+// 컨트랙트와 같이 이자는 리저브 totalBorrows에만 쌓이고 개인 잔고에는 반영되지 않습니다.
+// Debt is a user's DebtToken balance.
+// As in the contract, interest only accumulates in the reserve's totalBorrows, not in individual balances.
+func (p *LendingPool) Debt(user, asset string) *big.Int {
+	if r, ok := p.st.reserves[asset]; ok {
+		return new(big.Int).Set(balance(r.debt, user))
+	}
+	return new(big.Int)
+}
+
+// Cash는 풀이 보유한 기초 자산 잔고입니다 (IERC20(asset).balanceOf(pool)).
+// Cash is the underlying balance held by the pool (IERC20(asset).balanceOf(pool)).
+func (p *LendingPool) Cash(asset string) *big.Int {
+	if r, ok := p.st.reserves[asset]; ok {
+		return new(big.Int).Set(r.cash)
+	}
+	return new(big.Int)
+}
+
+// WalletBalance는 사용자 지갑의 기초 자산 잔고입니다.
+// WalletBalance is the underlying balance in a user's wallet.
+func (p *LendingPool) WalletBalance(user, asset string) *big.Int {
+	return new(big.Int).Set(balance(p.st.wallets[user], asset))
+}
+
+// UserConfiguration은 사용자 설정 비트맵 값입니다 (getUserConfiguration).
+// UserConfiguration is a user's configuration bitmap value (getUserConfiguration).
+func (p *LendingPool) UserConfiguration(user string) *big.Int {
+	return new(big.Int).Set(p.config(user).Data)
+}
+
+// Users는 풀을 쓴 적이 있는 사용자를 이름순으로 반환합니다.
+// Users returns every user that has touched the pool, sorted by name.
+func (p *LendingPool) Users() []string {
+	seen := make(map[string]bool)
+	for user := range p.st.configs {
+		seen[user] = true
+	}
+	for _, r := range p.st.reserves {
+		for user := range r.lTokens {
+			seen[user] = true
+		}
+		for user := range r.debt {
+			seen[user] = true
+		}
+	}
+	users := make([]string, 0, len(seen))
+	for user := range seen {
+		users = append(users, user)
+	}
+	slices.Sort(users)
+	return users
+}
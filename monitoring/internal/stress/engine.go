@@ -0,0 +1,300 @@
+package stress
+
+import "math"
+
+// dustUSD는 무시할 만큼 작은 잔액입니다 (부동소수점 잔여물 정리용).
+// dustUSD is a balance small enough to ignore (clears floating-point residue).
+const dustUSD = 1e-6
+
+// Params는 청산 시뮬레이션 파라미터입니다.
+// Params are the liquidation simulation parameters.
+type Params struct {
+	// CloseFactor는 청산 한 번에 갚을 수 있는 부채 비율입니다 (스터디 LendingPool.sol의 CLOSE_FACTOR = 0.5).
+	// CloseFactor is the share of debt one liquidation may repay (CLOSE_FACTOR = 0.5 in the study LendingPool.sol).
+	CloseFactor float64
+
+	// FullCloseThreshold 미만의 HF에서는 부채 전액을 청산할 수 있습니다 (Aave V3는 0.95, 0이면 끔).
+	// Below a health factor of FullCloseThreshold the whole debt may be liquidated (0.95 on Aave V3, 0 disables it).
+	FullCloseThreshold float64
+
+	// DefaultDepth는 자산 가격을 1% 움직이는 매도 금액입니다 (USD, 0이면 가격 충격 없음).
+	// DefaultDepth is the sell amount that moves an asset's price by 1% (USD, 0 means no price impact).
+	DefaultDepth float64
+
+	// Depth는 자산별 시장 깊이로 DefaultDepth를 덮어씁니다.
+	// Depth overrides DefaultDepth per asset.
+	Depth map[string]float64
+
+	// MaxRounds는 하루에 돌리는 연쇄 청산 라운드의 상한입니다.
+	// MaxRounds caps the cascade rounds run per day.
+	MaxRounds int
+}
+
+// DefaultParams는 스터디 LendingPool.sol과 같은 청산 규칙에 가격 충격이 없는 파라미터를 반환합니다.
+// DefaultParams returns the study LendingPool.sol liquidation rules with no price impact.
+func DefaultParams() Params {
+	return Params{CloseFactor: 0.5, MaxRounds: 50}
+}
+
+// Result는 경로 하나의 시뮬레이션 결과입니다 (금액은 USD).
+// Result is the simulation result of one path (amounts in USD).
+type Result struct {
+	Path string `json:"path"`
+
+	// Liquidations는 청산 호출 횟수입니다.
+	// Liquidations is the number of liquidation calls.
+	Liquidations int `json:"liquidations"`
+
+	// LiquidationVolume은 청산으로 상환된 부채입니다.
+	// LiquidationVolume is the debt repaid through liquidations.
+	LiquidationVolume float64 `json:"liquidation_volume_usd"`
+
+	// CollateralSeized는 청산자가 보너스를 포함해 가져간 담보입니다 (압류 시점 가격).
+	// CollateralSeized is the collateral liquidators took, bonus included (at the price when seized).
+	CollateralSeized float64 `json:"collateral_seized_usd"`
+
+	// BadDebt는 담보가 바닥난 뒤 남은 부채입니다.
+	// BadDebt is the debt left after the collateral ran out.
+	BadDebt float64 `json:"bad_debt_usd"`
+
+	// ReservesConsumed는 부실 채권을 메우느라 쓴 프로토콜 준비금입니다.
+	// ReservesConsumed is the protocol reserves spent covering bad debt.
+	ReservesConsumed float64 `json:"reserves_consumed_usd"`
+
+	// Uncovered는 준비금으로도 메우지 못한 부실 채권입니다 (예치자 손실).
+	// Uncovered is the bad debt the reserves could not cover (a loss to suppliers).
+	Uncovered float64 `json:"uncovered_usd"`
+
+	// InsolventPositions는 부실 채권을 남긴 포지션 수입니다.
+	// InsolventPositions is the number of positions that left bad debt.
+	InsolventPositions int `json:"insolvent_positions"`
+
+	// MaxDrawdown은 경로 중 자산별 최대 하락률 가운데 가장 큰 값입니다 (0.4 = 40%, 가격 충격 포함).
+	// MaxDrawdown is the largest per-asset peak-to-trough drop along the path (0.4 = 40%, price impact included).
+	MaxDrawdown float64 `json:"max_drawdown"`
+}
+
+// holding은 시뮬레이션 중인 포지션의 자산 하나입니다.
+// holding is one asset of a position under simulation.
+type holding struct {
+	asset            int
+	collateral, debt float64
+	lt, bonus        float64
+}
+
+// account는 시뮬레이션 중인 포지션입니다.
+// account is a position under simulation.
+type account struct {
+	holdings  []holding
+	insolvent bool
+}
+
+// engine은 경로 하나를 시뮬레이션하는 변경 가능한 상태입니다.
+// engine is the mutable state simulating one path.
+type engine struct {
+	params   Params
+	symbols  []string
+	prices   []float64
+	peaks    []float64
+	depth    []float64
+	accounts []*account
+	reserves float64
+	res      Result
+}
+
+// Run은 스냅샷에 경로를 적용하고 결과를 반환합니다. 스냅샷은 변경하지 않습니다.
+// Run applies a path to the snapshot and returns the result. The snapshot is not modified.
+func Run(s *Snapshot, path Path, p Params) Result {
+	e := newEngine(s, p)
+	e.res.Path = path.Name
+	// 시작 시점에 이미 청산 가능한 포지션도 처리합니다.
+	// Positions already liquidatable at the start are handled too.
+	e.cascade()
+	for _, move := range path.Moves {
+		for i, sym := range e.symbols {
+			if m, ok := move[sym]; ok {
+				e.setPrice(i, e.prices[i]*m)
+			}
+		}
+		e.cascade()
+	}
+	return e.res
+}
+
+func newEngine(s *Snapshot, p Params) *engine {
+	if p.MaxRounds <= 0 {
+		p.MaxRounds = DefaultParams().MaxRounds
+	}
+	e := &engine{params: p, reserves: s.ReservesUSD}
+	index := make(map[string]int, len(s.Assets))
+	defaults := make([]Asset, len(s.Assets))
+	for i, a := range s.Assets {
+		index[a.Symbol] = i
+		defaults[i] = a
+		e.symbols = append(e.symbols, a.Symbol)
+		e.prices = append(e.prices, a.Price)
+		e.peaks = append(e.peaks, a.Price)
+		depth := p.DefaultDepth
+		if d, ok := p.Depth[a.Symbol]; ok {
+			depth = d
+		}
+		e.depth = append(e.depth, depth)
+	}
+	for _, pos := range s.Positions {
+		acc := &account{}
+		for _, h := range pos.Reserves {
+			i := index[h.Asset]
+			lt, bonus := h.LiquidationThreshold, h.LiquidationBonus
+			if lt == 0 {
+				lt = defaults[i].LiquidationThreshold
+			}
+			if bonus == 0 {
+				bonus = defaults[i].LiquidationBonus
+			}
+			acc.holdings = append(acc.holdings, holding{asset: i, collateral: h.Collateral, debt: h.Debt, lt: lt, bonus: bonus})
+		}
+		e.accounts = append(e.accounts, acc)
+	}
+	return e
+}
+
+// setPrice는 가격을 바꾸고 최대 하락률을 갱신합니다.
+// setPrice changes a price and updates the max drawdown.
+func (e *engine) setPrice(i int, price float64) {
+	e.prices[i] = price
+	if price > e.peaks[i] {
+		e.peaks[i] = price
+		return
+	}
+	if dd := 1 - price/e.peaks[i]; dd > e.res.MaxDrawdown {
+		e.res.MaxDrawdown = dd
+	}
+}
+
+// cascade는 청산할 포지션이 없어질 때까지 청산 → 담보 매도 → 가격 충격 라운드를 반복합니다.
+// cascade repeats rounds of liquidation → collateral sale → price impact until nothing is liquidatable.
+func (e *engine) cascade() {
+	for range e.params.MaxRounds {
+		sold := make([]float64, len(e.symbols))
+		liquidated := false
+		for _, acc := range e.accounts {
+			if e.liquidate(acc, sold) {
+				liquidated = true
+			}
+		}
+		if !liquidated {
+			return
+		}
+		for i, amount := range sold {
+			if amount == 0 || e.depth[i] <= 0 {
+				continue
+			}
+			drop := math.Min(0.01*amount/e.depth[i], 0.99)
+			e.setPrice(i, e.prices[i]*(1-drop))
+		}
+	}
+}
+
+// liquidate는 포지션이 건전해지거나 담보가 바닥날 때까지 청산합니다.
+// 한 번의 청산은 가장 큰 부채를 Close Factor만큼 갚고, 가장 큰 담보에서 보너스를 더해 압류합니다.
+// liquidate liquidates a position until it is healthy or out of collateral.
+// Each liquidation repays the largest debt up to the close factor and seizes the bonus-inclusive amount
+// from the largest collateral.
+func (e *engine) liquidate(acc *account, sold []float64) bool {
+	if acc.insolvent {
+		return false
+	}
+	acted := false
+	// 연속 청산으로 부채가 절반씩만 줄어드는 경우를 대비한 상한입니다.
+	// A cap for positions whose debt only halves on every successive liquidation.
+	for range 64 {
+		collateral, debt := e.values(acc)
+		if debt <= dustUSD || e.healthFactor(acc) >= 1 {
+			return acted
+		}
+		if collateral <= dustUSD {
+			e.writeOff(acc, debt)
+			return true
+		}
+		d, c := e.largest(acc)
+		cf := e.params.CloseFactor
+		if e.params.FullCloseThreshold > 0 && e.healthFactor(acc) < e.params.FullCloseThreshold {
+			cf = 1
+		}
+		dh, ch := &acc.holdings[d], &acc.holdings[c]
+		repay := dh.debt * e.prices[dh.asset] * cf
+		seize := repay * (1 + ch.bonus)
+		if available := ch.collateral * e.prices[ch.asset]; seize > available {
+			seize = available
+			repay = seize / (1 + ch.bonus)
+		}
+		dh.debt -= repay / e.prices[dh.asset]
+		ch.collateral -= seize / e.prices[ch.asset]
+		if dh.debt*e.prices[dh.asset] < dustUSD {
+			dh.debt = 0
+		}
+		if ch.collateral*e.prices[ch.asset] < dustUSD {
+			ch.collateral = 0
+		}
+		sold[ch.asset] += seize
+		e.res.Liquidations++
+		e.res.LiquidationVolume += repay
+		e.res.CollateralSeized += seize
+		acted = true
+	}
+	return acted
+}
+
+// writeOff는 남은 부채를 부실 채권으로 처리하고 준비금으로 메웁니다.
+// writeOff books the remaining debt as bad debt and covers it from reserves.
+func (e *engine) writeOff(acc *account, debt float64) {
+	for i := range acc.holdings {
+		acc.holdings[i].debt = 0
+	}
+	acc.insolvent = true
+	covered := math.Min(debt, e.reserves)
+	e.reserves -= covered
+	e.res.BadDebt += debt
+	e.res.ReservesConsumed += covered
+	e.res.Uncovered += debt - covered
+	e.res.InsolventPositions++
+}
+
+// values는 포지션의 담보와 부채 가치를 반환합니다 (USD).
+// values returns the position's collateral and debt value (USD).
+func (e *engine) values(acc *account) (collateral, debt float64) {
+	for _, h := range acc.holdings {
+		collateral += h.collateral * e.prices[h.asset]
+		debt += h.debt * e.prices[h.asset]
+	}
+	return collateral, debt
+}
+
+// healthFactor는 (담보 × 청산기준) / 부채입니다. 부채가 없으면 +Inf입니다.
+// healthFactor is (collateral × liquidation threshold) / debt. It is +Inf without debt.
+func (e *engine) healthFactor(acc *account) float64 {
+	var adjusted, debt float64
+	for _, h := range acc.holdings {
+		adjusted += h.collateral * e.prices[h.asset] * h.lt
+		debt += h.debt * e.prices[h.asset]
+	}
+	if debt == 0 {
+		return math.Inf(1)
+	}
+	return adjusted / debt
+}
+
+// largest는 가치가 가장 큰 부채와 담보 보유분의 인덱스를 반환합니다.
+// largest returns the indexes of the holdings with the largest debt and collateral value.
+func (e *engine) largest(acc *account) (debt, collateral int) {
+	var maxDebt, maxColl float64
+	for i, h := range acc.holdings {
+		if v := h.debt * e.prices[h.asset]; v > maxDebt {
+			maxDebt, debt = v, i
+		}
+		if v := h.collateral * e.prices[h.asset]; v > maxColl {
+			maxColl, collateral = v, i
+		}
+	}
+	return debt, collateral
+}
@@ -0,0 +1,239 @@
+package stress
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// daysPerYear는 연율 변동성을 일 단위로 바꿀 때 쓰는 연간 일수입니다 (암호화폐는 매일 거래).
+// daysPerYear is the number of days per year used to scale annual volatility to days (crypto trades every day).
+const daysPerYear = 365
+
+// Path는 가격 경로 하나입니다. Moves[i][symbol]은 i번째 날의 가격 배율입니다 (0.9 = 10% 하락).
+// 배율이 없는 자산은 그날 가격이 변하지 않습니다.
+// Path is one price path. Moves[i][symbol] is the price multiplier on day i (0.9 = a 10% drop).
+// Assets without a multiplier keep their price that day.
+type Path struct {
+	Name  string
+	Moves []map[string]float64
+}
+
+// History는 CSV에서 읽은 일별 가격 이력입니다.
+// History is a daily price history read from CSV.
+type History struct {
+	Dates   []string
+	Symbols []string
+
+	// Prices[i][j]는 Dates[i]의 Symbols[j] 가격입니다. 비어 있던 값은 직전 가격으로 채워집니다.
+	// Prices[i][j] is the price of Symbols[j] on Dates[i]. Empty values are carried forward from the previous row.
+	Prices [][]float64
+}
+
+// LoadHistory는 "date,WETH,WBTC,..." 헤더를 가진 일별 가격 CSV 파일을 읽습니다.
+// LoadHistory reads a daily price CSV file with a "date,WETH,WBTC,..." header.
+func LoadHistory(path string) (*History, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("가격 이력 파일 열기 실패 / failed to open price history: %w", err)
+	}
+	defer f.Close()
+	h, err := ParseHistory(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return h, nil
+}
+
+// ParseHistory는 일별 가격 CSV를 파싱합니다. 첫 열은 날짜, 나머지 열은 자산별 가격이며 행은 시간 순서여야 합니다.
+// ParseHistory parses a daily price CSV. The first column is the date, the others are per-asset prices,
+// and rows must be in chronological order.
+func ParseHistory(r io.Reader) (*History, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 헤더 읽기 실패 / failed to read CSV header: %w", err)
+	}
+	if len(header) < 2 {
+		return nil, errors.New("CSV 헤더에 가격 열이 없음 / CSV header has no price columns")
+	}
+	h := &History{Symbols: make([]string, len(header)-1)}
+	for i, s := range header[1:] {
+		h.Symbols[i] = strings.TrimSpace(s)
+	}
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV 읽기 실패 / failed to read CSV: %w", err)
+		}
+		row := make([]float64, len(h.Symbols))
+		for j, field := range rec[1:] {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				if len(h.Prices) == 0 {
+					return nil, fmt.Errorf("%d행 %s: 첫 행은 비울 수 없음 / line %d %s: first row cannot be empty", line, h.Symbols[j], line, h.Symbols[j])
+				}
+				row[j] = h.Prices[len(h.Prices)-1][j]
+				continue
+			}
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil || !(v > 0) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("%d행 %s: 잘못된 가격 %q / line %d %s: invalid price %q", line, h.Symbols[j], field, line, h.Symbols[j], field)
+			}
+			row[j] = v
+		}
+		h.Dates = append(h.Dates, strings.TrimSpace(rec[0]))
+		h.Prices = append(h.Prices, row)
+	}
+	if len(h.Prices) < 2 {
+		return nil, errors.New("가격 행이 2개 이상 필요 / at least two price rows are required")
+	}
+	return h, nil
+}
+
+// Paths는 이력을 길이 horizon일의 겹치는 창으로 나눠 경로를 만듭니다 (창마다 하루씩 이동).
+// horizon이 0이거나 이력보다 길면 전체 이력을 경로 하나로 씁니다.
+// Paths splits the history into overlapping windows of horizon days (each window shifted by one day).
+// A horizon of 0, or one longer than the history, uses the whole history as a single path.
+func (h *History) Paths(horizon int) []Path {
+	moves := make([]map[string]float64, len(h.Prices)-1)
+	for i := range moves {
+		moves[i] = make(map[string]float64, len(h.Symbols))
+		for j, s := range h.Symbols {
+			moves[i][s] = h.Prices[i+1][j] / h.Prices[i][j]
+		}
+	}
+	if horizon <= 0 || horizon > len(moves) {
+		horizon = len(moves)
+	}
+	paths := make([]Path, 0, len(moves)-horizon+1)
+	for start := 0; start+horizon <= len(moves); start++ {
+		paths = append(paths, Path{
+			Name:  h.Dates[start] + ".." + h.Dates[start+horizon],
+			Moves: moves[start : start+horizon],
+		})
+	}
+	return paths
+}
+
+// Model은 합성 가격 경로 모델입니다 (기하 브라운 운동 + 선택적 Merton 점프).
+// Model is a synthetic price path model (geometric Brownian motion plus optional Merton jumps).
+//
+// 자산 간 상관은 단일 요인 모델로 만듭니다: z_i = √ρ·M + √(1-ρ)·ε_i.
+// 점프는 시장 전체 사건으로, 같은 날 변동성이 0보다 큰 모든 자산에 같은 로그 점프를 적용합니다 (스테이블코인 제외).
+// Correlation between assets comes from a one-factor model: z_i = √ρ·M + √(1-ρ)·ε_i.
+// Jumps are market-wide events that apply the same log jump on the same day to every asset
+// with volatility above zero (stablecoins excluded).
+type Model struct {
+	// DefaultVol은 Vol에 없는 자산의 연율 변동성입니다 (0.8 = 80%).
+	// DefaultVol is the annual volatility of assets missing from Vol (0.8 = 80%).
+	DefaultVol float64
+
+	// Vol은 자산별 연율 변동성입니다. 스테이블코인은 0으로 둡니다.
+	// Vol is the per-asset annual volatility. Set stablecoins to 0.
+	Vol map[string]float64
+
+	// Drift는 연율 기대 수익률입니다 (스트레스 테스트에서는 보통 0).
+	// Drift is the annual expected return (usually 0 for stress tests).
+	Drift float64
+
+	// Correlation은 변동 자산 간 상관계수 ρ입니다 (0~1).
+	// Correlation is the correlation coefficient ρ between volatile assets (0..1).
+	Correlation float64
+
+	// JumpIntensity는 연간 평균 점프 횟수 λ입니다 (0이면 순수 GBM).
+	// JumpIntensity is the mean number of jumps per year λ (0 means pure GBM).
+	JumpIntensity float64
+
+	// JumpMean과 JumpVol은 로그 점프 크기의 평균과 표준편차입니다 (예: -0.2, 0.1).
+	// JumpMean and JumpVol are the mean and standard deviation of the log jump size (e.g. -0.2, 0.1).
+	JumpMean float64
+	JumpVol  float64
+}
+
+// Validate는 모델 파라미터 범위를 검사합니다.
+// Validate checks the model parameter ranges.
+func (m Model) Validate() error {
+	var errs []error
+	if m.DefaultVol < 0 {
+		errs = append(errs, errors.New("vol: 음수 / negative"))
+	}
+	for s, v := range m.Vol {
+		if v < 0 {
+			errs = append(errs, fmt.Errorf("vol %s: 음수 / negative", s))
+		}
+	}
+	if m.Correlation < 0 || m.Correlation > 1 {
+		errs = append(errs, errors.New("correlation: 0~1 범위 / must be within 0..1"))
+	}
+	if m.JumpIntensity < 0 || m.JumpVol < 0 {
+		errs = append(errs, errors.New("jump: 음수 강도/변동성 / negative intensity or volatility"))
+	}
+	return errors.Join(errs...)
+}
+
+// Path는 symbols에 대해 days일짜리 경로를 만듭니다. 같은 (seed, run)은 항상 같은 경로를 만듭니다.
+// Path generates a path of days days for symbols. The same (seed, run) always produces the same path.
+func (m Model) Path(symbols []string, days int, seed uint64, run int) Path {
+	rng := rand.New(rand.NewPCG(seed, uint64(run)))
+	dt := 1.0 / daysPerYear
+	sqrtDt := math.Sqrt(dt)
+	shared, own := math.Sqrt(m.Correlation), math.Sqrt(1-m.Correlation)
+
+	vols := make([]float64, len(symbols))
+	for i, s := range symbols {
+		vols[i] = m.DefaultVol
+		if v, ok := m.Vol[s]; ok {
+			vols[i] = v
+		}
+	}
+
+	p := Path{Name: fmt.Sprintf("run-%d", run), Moves: make([]map[string]float64, days)}
+	for d := range p.Moves {
+		market := rng.NormFloat64()
+		jump := 0.0
+		for range poisson(rng, m.JumpIntensity*dt) {
+			jump += m.JumpMean + m.JumpVol*rng.NormFloat64()
+		}
+		move := make(map[string]float64, len(symbols))
+		for i, s := range symbols {
+			// 종목별 충격은 변동성이 0이어도 뽑아서 자산 목록이 같으면 난수 순서도 같게 유지합니다.
+			// The idiosyncratic draw is taken even at zero volatility so the random sequence only depends on the asset list.
+			z := shared*market + own*rng.NormFloat64()
+			if vols[i] == 0 {
+				move[s] = 1
+				continue
+			}
+			logRet := (m.Drift-vols[i]*vols[i]/2)*dt + vols[i]*sqrtDt*z + jump
+			move[s] = math.Exp(logRet)
+		}
+		p.Moves[d] = move
+	}
+	return p
+}
+
+// poisson은 평균 lambda인 포아송 난수를 뽑습니다 (하루 단위의 작은 lambda용 Knuth 방식).
+// poisson draws a Poisson variate with mean lambda (Knuth's method, meant for the small per-day lambda).
+func poisson(rng *rand.Rand, lambda float64) int {
+	if lambda <= 0 {
+		return 0
+	}
+	limit, k, p := math.Exp(-lambda), 0, 1.0
+	for {
+		p *= rng.Float64()
+		if p <= limit {
+			return k
+		}
+		k++
+	}
+}
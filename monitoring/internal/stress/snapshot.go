@@ -0,0 +1,250 @@
+// Package stress는 포지션 스냅샷에 가격 경로를 적용해 연쇄 청산과 부실 채권을 시뮬레이션합니다.
+// Package stress applies price paths to a position snapshot and simulates cascading liquidations and bad debt.
+//
+// 모든 금액은 USD float64입니다 (risk 패키지와 같은 근사). 한 경로는 일 단위 가격 변화의 나열이며,
+// 매 단계마다 가격을 갱신한 뒤 HF < 1인 포지션을 Close Factor와 청산 보너스로 더 이상 청산할 수 없을 때까지 청산합니다.
+// 청산자가 압류한 담보를 팔면 시장 깊이에 따라 가격이 더 떨어지고, 이 하락이 다음 청산을 부릅니다 (연쇄 청산).
+// 담보가 바닥났는데 남은 부채는 부실 채권이며 프로토콜 준비금이 먼저 흡수합니다.
+//
+// Every amount is a USD float64 (the same approximation as the risk package). A path is a sequence of daily
+// price moves; at each step prices are updated, then positions with HF < 1 are liquidated with the close factor
+// and liquidation bonus until no more liquidations are possible. Liquidators selling seized collateral push the
+// price down further depending on market depth, and that drop triggers the next liquidations (a cascade).
+// Debt left after collateral runs out is bad debt, absorbed first by protocol reserves.
+package stress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// bpsDenominator는 bps 값의 분모입니다 (10000 = 100%).
+// bpsDenominator is the denominator of bps values (10000 = 100%).
+const bpsDenominator = 10000
+
+// Snapshot은 스트레스 테스트의 시작 상태입니다.
+// Snapshot is the starting state of a stress test.
+type Snapshot struct {
+	// Assets는 자산별 시작 가격과 기본 청산 파라미터입니다.
+	// Assets are the per-asset starting prices and default liquidation parameters.
+	Assets []Asset `yaml:"assets" json:"assets"`
+
+	// Positions는 사용자 포지션입니다.
+	// Positions are the user positions.
+	Positions []Position `yaml:"positions" json:"positions"`
+
+	// ReservesUSD는 부실 채권을 흡수할 수 있는 프로토콜 준비금입니다 (USD).
+	// ReservesUSD are the protocol reserves available to absorb bad debt (USD).
+	ReservesUSD float64 `yaml:"reserves_usd" json:"reserves_usd"`
+}
+
+// Asset은 자산 하나의 시작 가격과 기본 파라미터입니다.
+// Asset is one asset's starting price and default parameters.
+type Asset struct {
+	Symbol string  `yaml:"symbol" json:"symbol"`
+	Price  float64 `yaml:"price" json:"price"`
+
+	// LiquidationThreshold와 LiquidationBonus는 비율입니다 (예: 0.825, 0.05).
+	// LiquidationThreshold and LiquidationBonus are ratios (e.g. 0.825, 0.05).
+	LiquidationThreshold float64 `yaml:"liquidation_threshold" json:"liquidation_threshold"`
+	LiquidationBonus     float64 `yaml:"liquidation_bonus" json:"liquidation_bonus"`
+}
+
+// Position은 사용자 한 명의 포지션입니다.
+// Position is one user's position.
+type Position struct {
+	User     string    `yaml:"user" json:"user"`
+	Reserves []Holding `yaml:"reserves" json:"reserves"`
+}
+
+// Holding은 포지션의 자산 하나입니다 (수량은 자산 단위).
+// 청산 파라미터가 0이면 Asset의 기본값을 씁니다 (e-mode처럼 사용자별로 다를 때만 지정).
+// Holding is one asset of a position (amounts in asset units).
+// Zero liquidation parameters fall back to the Asset defaults (set them only when they differ per user, like e-mode).
+type Holding struct {
+	Asset                string  `yaml:"asset" json:"asset"`
+	Collateral           float64 `yaml:"collateral,omitempty" json:"collateral,omitempty"`
+	Debt                 float64 `yaml:"debt,omitempty" json:"debt,omitempty"`
+	LiquidationThreshold float64 `yaml:"liquidation_threshold,omitempty" json:"liquidation_threshold,omitempty"`
+	LiquidationBonus     float64 `yaml:"liquidation_bonus,omitempty" json:"liquidation_bonus,omitempty"`
+}
+
+// LoadSnapshot은 스냅샷 파일 (YAML 또는 JSON)을 읽고 검증합니다.
+// LoadSnapshot reads and validates a snapshot file (YAML or JSON).
+func LoadSnapshot(path string) (*Snapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("스냅샷 파일 읽기 실패 / failed to read snapshot file: %w", err)
+	}
+	var s Snapshot
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save는 스냅샷을 YAML로 저장합니다 (체인에서 읽은 스냅샷을 재사용할 때).
+// Save writes the snapshot as YAML (to reuse a snapshot read from chain).
+func (s *Snapshot) Save(path string) error {
+	raw, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+// Validate는 가격과 파라미터 범위, 알 수 없는 자산을 검사합니다.
+// Validate checks prices and parameter ranges, and unknown assets.
+func (s *Snapshot) Validate() error {
+	var errs []error
+	known := make(map[string]bool, len(s.Assets))
+	for i, a := range s.Assets {
+		switch {
+		case a.Symbol == "":
+			errs = append(errs, fmt.Errorf("assets[%d].symbol: 필수 / required", i))
+		case known[a.Symbol]:
+			errs = append(errs, fmt.Errorf("assets[%d].symbol: 중복 %q / duplicate %q", i, a.Symbol, a.Symbol))
+		}
+		known[a.Symbol] = true
+		if !(a.Price > 0) || math.IsInf(a.Price, 0) {
+			errs = append(errs, fmt.Errorf("assets[%d].price: 양수여야 함 / must be positive", i))
+		}
+		if a.LiquidationThreshold < 0 || a.LiquidationThreshold > 1 {
+			errs = append(errs, fmt.Errorf("assets[%d].liquidation_threshold: 0~1 범위 / must be within 0..1", i))
+		}
+		if a.LiquidationBonus < 0 || a.LiquidationBonus > 1 {
+			errs = append(errs, fmt.Errorf("assets[%d].liquidation_bonus: 0~1 범위 / must be within 0..1", i))
+		}
+	}
+	for i, p := range s.Positions {
+		for j, h := range p.Reserves {
+			if !known[h.Asset] {
+				errs = append(errs, fmt.Errorf("positions[%d].reserves[%d].asset: 알 수 없는 자산 %q / unknown asset %q", i, j, h.Asset, h.Asset))
+			}
+			if h.Collateral < 0 || h.Debt < 0 {
+				errs = append(errs, fmt.Errorf("positions[%d].reserves[%d]: 음수 수량 / negative amount", i, j))
+			}
+		}
+	}
+	if s.ReservesUSD < 0 {
+		errs = append(errs, errors.New("reserves_usd: 음수 / negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// FromPositions는 체인에서 읽은 포지션으로 스냅샷을 만듭니다.
+// 가격은 포지션의 오라클 가격, 청산 파라미터는 e-mode가 반영된 리저브 값입니다.
+// 담보로 쓰지 않는 예치금은 청산에 쓸 수 없으므로 제외합니다.
+// FromPositions builds a snapshot from positions read on chain.
+// Prices are the positions' oracle prices, and liquidation parameters are the reserve values with e-mode applied.
+// Supplied balances not used as collateral cannot be seized, so they are left out.
+func FromPositions(positions []*contracts.UserPosition, reservesUSD float64) *Snapshot {
+	s := &Snapshot{ReservesUSD: reservesUSD}
+	seen := make(map[string]bool)
+	for _, pos := range positions {
+		p := Position{User: pos.User.Hex()}
+		for _, r := range pos.Reserves {
+			symbol := r.Symbol
+			if symbol == "" {
+				symbol = r.Asset.Hex()
+			}
+			if !seen[symbol] && r.Price != nil && r.Price.Sign() > 0 {
+				seen[symbol] = true
+				s.Assets = append(s.Assets, Asset{
+					Symbol:               symbol,
+					Price:                baseToUSD(r.Price),
+					LiquidationThreshold: bpsToRatio(r.LiquidationThreshold),
+					LiquidationBonus:     bonusToRatio(r.LiquidationBonus),
+				})
+			}
+			h := Holding{
+				Asset:                symbol,
+				Debt:                 units(r.Debt, r.Decimals),
+				LiquidationThreshold: bpsToRatio(r.LiquidationThreshold),
+				LiquidationBonus:     bonusToRatio(r.LiquidationBonus),
+			}
+			if r.UsedAsCollateral {
+				h.Collateral = units(r.Collateral, r.Decimals)
+			}
+			if h.Collateral == 0 && h.Debt == 0 {
+				continue
+			}
+			p.Reserves = append(p.Reserves, h)
+		}
+		if len(p.Reserves) > 0 {
+			s.Positions = append(s.Positions, p)
+		}
+	}
+	return s
+}
+
+// ParseSymbolFloats는 "WETH=0.8,WBTC=0.6" 형식의 자산별 값을 파싱합니다.
+// ParseSymbolFloats parses per-asset values of the form "WETH=0.8,WBTC=0.6".
+func ParseSymbolFloats(s string) (map[string]float64, error) {
+	out := make(map[string]float64)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("잘못된 항목 %q (SYMBOL=값) / invalid entry %q (SYMBOL=value)", part, part)
+		}
+		var f float64
+		if _, err := fmt.Sscan(v, &f); err != nil {
+			return nil, fmt.Errorf("잘못된 값 %q / invalid value %q: %w", part, part, err)
+		}
+		out[strings.TrimSpace(k)] = f
+	}
+	return out, nil
+}
+
+// baseToUSD는 기본 통화 금액 (USD 8 소수점)을 달러로 변환합니다.
+// baseToUSD converts a base currency amount (USD with 8 decimals) to dollars.
+func baseToUSD(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e8)).Float64()
+	return f
+}
+
+// units는 자산 단위 정수를 소수 수량으로 변환합니다.
+// units converts an integer amount in asset units to a decimal quantity.
+func units(v *big.Int, decimals uint8) float64 {
+	if v == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))).Float64()
+	return f
+}
+
+// bpsToRatio는 bps 값을 비율로 변환합니다 (8250 → 0.825).
+// bpsToRatio converts a bps value to a ratio (8250 → 0.825).
+func bpsToRatio(v *big.Int) float64 {
+	if v == nil {
+		return 0
+	}
+	return float64(v.Int64()) / bpsDenominator
+}
+
+// bonusToRatio는 Aave 청산 보너스 (10500 = 5%)를 비율로 변환합니다.
+// bonusToRatio converts an Aave liquidation bonus (10500 = 5%) to a ratio.
+func bonusToRatio(v *big.Int) float64 {
+	if v == nil || v.Int64() <= bpsDenominator {
+		return 0
+	}
+	return float64(v.Int64()-bpsDenominator) / bpsDenominator
+}
@@ -0,0 +1,224 @@
+package stress
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/jeongseup/lending-monitor/internal/contracts"
+)
+
+// liquidationSnapshot은 Liquidation.t.sol과 같은 포지션입니다: 10 WETH 담보로 15,000 USDC 차입.
+// liquidationSnapshot is the Liquidation.t.sol position: 15,000 USDC borrowed against 10 WETH.
+func liquidationSnapshot(extra ...Position) *Snapshot {
+	return &Snapshot{
+		Assets: []Asset{
+			{Symbol: "WETH", Price: 2000, LiquidationThreshold: 0.8, LiquidationBonus: 0.05},
+			{Symbol: "USDC", Price: 1, LiquidationThreshold: 0.85, LiquidationBonus: 0.05},
+		},
+		Positions: append([]Position{{User: "alice", Reserves: []Holding{
+			{Asset: "WETH", Collateral: 10},
+			{Asset: "USDC", Debt: 15000},
+		}}}, extra...),
+		ReservesUSD: 500,
+	}
+}
+
+func drop(ratio float64) Path {
+	return Path{Name: "drop", Moves: []map[string]float64{{"WETH": ratio}}}
+}
+
+func approx(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestRunSingleLiquidationRestoresHealth(t *testing.T) {
+	// 2000 → 1800: HF 0.96, 7,500 USDC 상환에 7,875 USD (4.375 WETH) 압류 → HF 1.08.
+	// 2000 → 1800: HF 0.96, repaying 7,500 USDC seizes 7,875 USD (4.375 WETH) → HF 1.08.
+	r := Run(liquidationSnapshot(), drop(0.9), DefaultParams())
+	if r.Liquidations != 1 {
+		t.Fatalf("liquidations = %d, want 1", r.Liquidations)
+	}
+	approx(t, "volume", r.LiquidationVolume, 7500)
+	approx(t, "seized", r.CollateralSeized, 7875)
+	approx(t, "bad debt", r.BadDebt, 0)
+	approx(t, "max drawdown", r.MaxDrawdown, 0.1)
+}
+
+func TestRunBadDebt(t *testing.T) {
+	// 2000 → 1500: 담보 15,000 = 부채 15,000이라 보너스를 주면 담보가 모자랍니다.
+	// 담보 전부 압류로 15,000 / 1.05 상환, 나머지는 부실 채권이고 준비금 500이 먼저 흡수합니다.
+	// 2000 → 1500: 15,000 of collateral against 15,000 of debt cannot pay the bonus.
+	// Seizing everything repays 15,000 / 1.05, the rest is bad debt and the 500 of reserves absorb it first.
+	for _, full := range []float64{0, 0.95} {
+		p := DefaultParams()
+		p.FullCloseThreshold = full
+		r := Run(liquidationSnapshot(), drop(0.75), p)
+		approx(t, "seized", r.CollateralSeized, 15000)
+		approx(t, "volume", r.LiquidationVolume, 15000/1.05)
+		approx(t, "bad debt", r.BadDebt, 15000-15000/1.05)
+		approx(t, "reserves", r.ReservesConsumed, 500)
+		approx(t, "uncovered", r.Uncovered, 15000-15000/1.05-500)
+		if r.InsolventPositions != 1 {
+			t.Errorf("full close %v: insolvent = %d, want 1", full, r.InsolventPositions)
+		}
+		if full > 0 && r.Liquidations != 1 {
+			t.Errorf("full close: liquidations = %d, want 1", r.Liquidations)
+		}
+	}
+}
+
+func TestRunCascadeFromPriceImpact(t *testing.T) {
+	// bob은 1800에서 HF 1.03으로 건전하지만, alice의 담보 7,875 USD 매도가 (1%당 깊이 1,000)
+	// 가격을 7.875% 더 떨어뜨려 bob도 청산됩니다.
+	// bob is healthy at 1800 with HF 1.03, but selling alice's 7,875 USD of collateral (depth 1,000 per 1%)
+	// pushes the price down another 7.875% and bob gets liquidated too.
+	bob := Position{User: "bob", Reserves: []Holding{{Asset: "WETH", Collateral: 10}, {Asset: "USDC", Debt: 14000}}}
+
+	calm := Run(liquidationSnapshot(bob), drop(0.9), DefaultParams())
+	if calm.Liquidations != 1 {
+		t.Fatalf("without impact: liquidations = %d, want 1", calm.Liquidations)
+	}
+
+	p := DefaultParams()
+	p.DefaultDepth = 1000
+	stressed := Run(liquidationSnapshot(bob), drop(0.9), p)
+	if stressed.Liquidations < 2 {
+		t.Fatalf("with impact: liquidations = %d, want cascade", stressed.Liquidations)
+	}
+	if stressed.MaxDrawdown <= calm.MaxDrawdown {
+		t.Errorf("max drawdown %v not above %v", stressed.MaxDrawdown, calm.MaxDrawdown)
+	}
+}
+
+func TestRunDoesNotModifySnapshot(t *testing.T) {
+	s := liquidationSnapshot()
+	before := *s
+	before.Positions = []Position{{User: "alice", Reserves: append([]Holding(nil), s.Positions[0].Reserves...)}}
+	Run(s, drop(0.5), DefaultParams())
+	if !reflect.DeepEqual(s.Positions, before.Positions) {
+		t.Errorf("snapshot modified: %+v", s.Positions)
+	}
+}
+
+func TestHistoryPaths(t *testing.T) {
+	h, err := ParseHistory(strings.NewReader(`date,WETH,USDC
+# 주석 / comment
+2022-06-10,2000,1
+2022-06-11,1800,
+2022-06-12,1500,0.99
+2022-06-13,1650,1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Prices[1][1]; got != 1 {
+		t.Errorf("carried forward USDC = %v, want 1", got)
+	}
+	paths := h.Paths(2)
+	if len(paths) != 2 || paths[0].Name != "2022-06-10..2022-06-12" || paths[1].Name != "2022-06-11..2022-06-13" {
+		t.Fatalf("paths = %+v", paths)
+	}
+	approx(t, "move", paths[0].Moves[1]["WETH"], 1500.0/1800)
+	if all := h.Paths(0); len(all) != 1 || len(all[0].Moves) != 3 {
+		t.Errorf("whole history = %+v", all)
+	}
+
+	for _, bad := range []string{
+		"date\n2022-06-10\n2022-06-11\n",
+		"date,WETH\n2022-06-10,\n2022-06-11,1\n",
+		"date,WETH\n2022-06-10,-1\n2022-06-11,1\n",
+		"date,WETH\n2022-06-10,1\n",
+	} {
+		if _, err := ParseHistory(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseHistory(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestModelPathDeterministic(t *testing.T) {
+	m := Model{DefaultVol: 0.8, Vol: map[string]float64{"USDC": 0}, Correlation: 0.7, JumpIntensity: 20, JumpMean: -0.2, JumpVol: 0.1}
+	symbols := []string{"WETH", "WBTC", "USDC"}
+	a, b := m.Path(symbols, 30, 42, 3), m.Path(symbols, 30, 42, 3)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed and run produced different paths")
+	}
+	if reflect.DeepEqual(a.Moves, m.Path(symbols, 30, 42, 4).Moves) {
+		t.Error("different runs produced the same path")
+	}
+	for d, move := range a.Moves {
+		if move["USDC"] != 1 {
+			t.Errorf("day %d: stablecoin moved %v", d, move["USDC"])
+		}
+		if !(move["WETH"] > 0) {
+			t.Errorf("day %d: WETH multiplier %v", d, move["WETH"])
+		}
+	}
+	if err := (Model{Correlation: 1.5}).Validate(); err == nil {
+		t.Error("correlation 1.5 accepted")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var results []Result
+	for i := 1; i <= 100; i++ {
+		r := Result{LiquidationVolume: float64(i)}
+		if i > 90 {
+			r.BadDebt = float64(i)
+		}
+		results = append(results, r)
+	}
+	s := Summarize(results)
+	approx(t, "probability", s.BadDebtProbability, 0.1)
+	approx(t, "p50 volume", s.LiquidationVolume.P50, 50)
+	approx(t, "p95 volume", s.LiquidationVolume.P95, 95)
+	approx(t, "mean volume", s.LiquidationVolume.Mean, 50.5)
+	approx(t, "p90 bad debt", s.BadDebt.P90, 0)
+	approx(t, "p99 bad debt", s.BadDebt.P99, 99)
+	approx(t, "max bad debt", s.BadDebt.Max, 100)
+}
+
+func TestSnapshotValidate(t *testing.T) {
+	s := liquidationSnapshot(Position{User: "carol", Reserves: []Holding{{Asset: "DAI", Debt: 1}}})
+	if err := s.Validate(); err == nil || !strings.Contains(err.Error(), `unknown asset "DAI"`) {
+		t.Errorf("err = %v, want unknown asset", err)
+	}
+}
+
+func TestFromPositions(t *testing.T) {
+	weth := contracts.Reserve{Asset: common.HexToAddress("0x1"), Symbol: "WETH", Decimals: 18,
+		LiquidationThreshold: big.NewInt(8300), LiquidationBonus: big.NewInt(10500)}
+	usdc := contracts.Reserve{Asset: common.HexToAddress("0x2"), Symbol: "USDC", Decimals: 6,
+		LiquidationThreshold: big.NewInt(7800), LiquidationBonus: big.NewInt(10450)}
+	pos := &contracts.UserPosition{User: common.HexToAddress("0xa"), Reserves: []contracts.ReservePosition{
+		{Reserve: weth, Collateral: new(big.Int).Mul(big.NewInt(2), big.NewInt(1e18)), Debt: new(big.Int), UsedAsCollateral: true, Price: big.NewInt(2000e8)},
+		{Reserve: usdc, Collateral: big.NewInt(5e6), Debt: big.NewInt(1500e6), Price: big.NewInt(1e8)},
+	}}
+	s := FromPositions([]*contracts.UserPosition{pos}, 1e6)
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	want := []Holding{
+		{Asset: "WETH", Collateral: 2, LiquidationThreshold: 0.83, LiquidationBonus: 0.05},
+		{Asset: "USDC", Debt: 1500, LiquidationThreshold: 0.78, LiquidationBonus: 0.045},
+	}
+	if got := s.Positions[0].Reserves; len(got) != 2 {
+		t.Fatalf("holdings = %+v", got)
+	}
+	for i, h := range s.Positions[0].Reserves {
+		w := want[i]
+		if h.Asset != w.Asset || h.Collateral != w.Collateral || h.Debt != w.Debt {
+			t.Errorf("holding %d = %+v, want %+v", i, h, w)
+		}
+		approx(t, w.Asset+" lt", h.LiquidationThreshold, w.LiquidationThreshold)
+		approx(t, w.Asset+" bonus", h.LiquidationBonus, w.LiquidationBonus)
+	}
+	approx(t, "WETH price", s.Assets[0].Price, 2000)
+}
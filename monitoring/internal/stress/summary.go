@@ -0,0 +1,143 @@
+package stress
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// Distribution은 경로들에 걸친 지표 하나의 분포 요약입니다.
+// Distribution summarizes one metric across paths.
+type Distribution struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// Summary는 여러 경로 (몬테카를로 실행 또는 과거 구간) 결과의 백분위 요약입니다.
+// Summary is the percentile summary of results over many paths (Monte Carlo runs or historical windows).
+type Summary struct {
+	Paths int `json:"paths"`
+
+	// BadDebtProbability는 부실 채권이 생긴 경로의 비율입니다.
+	// BadDebtProbability is the share of paths that created bad debt.
+	BadDebtProbability float64 `json:"bad_debt_probability"`
+
+	BadDebt           Distribution `json:"bad_debt_usd"`
+	LiquidationVolume Distribution `json:"liquidation_volume_usd"`
+	ReservesConsumed  Distribution `json:"reserves_consumed_usd"`
+	Uncovered         Distribution `json:"uncovered_usd"`
+	MaxDrawdown       Distribution `json:"max_drawdown"`
+}
+
+// Summarize는 결과들의 백분위 요약을 계산합니다.
+// Summarize computes the percentile summary of results.
+func Summarize(results []Result) Summary {
+	s := Summary{Paths: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+	pick := func(f func(Result) float64) Distribution {
+		vs := make([]float64, len(results))
+		for i, r := range results {
+			vs[i] = f(r)
+		}
+		return distribution(vs)
+	}
+	withBadDebt := 0
+	for _, r := range results {
+		if r.BadDebt > 0 {
+			withBadDebt++
+		}
+	}
+	s.BadDebtProbability = float64(withBadDebt) / float64(len(results))
+	s.BadDebt = pick(func(r Result) float64 { return r.BadDebt })
+	s.LiquidationVolume = pick(func(r Result) float64 { return r.LiquidationVolume })
+	s.ReservesConsumed = pick(func(r Result) float64 { return r.ReservesConsumed })
+	s.Uncovered = pick(func(r Result) float64 { return r.Uncovered })
+	s.MaxDrawdown = pick(func(r Result) float64 { return r.MaxDrawdown })
+	return s
+}
+
+// distribution은 값들의 평균과 최근접 순위 백분위를 계산합니다.
+// distribution computes the mean and nearest-rank percentiles of values.
+func distribution(vs []float64) Distribution {
+	sorted := slices.Clone(vs)
+	slices.Sort(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return Distribution{
+		Mean: sum / float64(len(sorted)),
+		P50:  Percentile(sorted, 0.50),
+		P90:  Percentile(sorted, 0.90),
+		P95:  Percentile(sorted, 0.95),
+		P99:  Percentile(sorted, 0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// Percentile은 정렬된 값들의 최근접 순위 백분위를 반환합니다 (q는 0~1).
+// Percentile returns the nearest-rank percentile of sorted values (q within 0..1).
+func Percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// WriteResults는 경로별 결과 표를 씁니다.
+// WriteResults writes the per-path result table.
+func WriteResults(w io.Writer, results []Result) {
+	fmt.Fprintf(w, "%-24s %6s %16s %14s %14s %14s %8s\n",
+		"path", "liqs", "liquidated", "bad_debt", "reserves_used", "uncovered", "max_dd")
+	for _, r := range results {
+		fmt.Fprintf(w, "%-24s %6d %16s %14s %14s %14s %7.1f%%\n",
+			r.Path, r.Liquidations, usd(r.LiquidationVolume), usd(r.BadDebt),
+			usd(r.ReservesConsumed), usd(r.Uncovered), r.MaxDrawdown*100)
+	}
+}
+
+// WriteSummary는 백분위 요약 표를 씁니다.
+// WriteSummary writes the percentile summary table.
+func (s Summary) WriteSummary(w io.Writer) {
+	fmt.Fprintf(w, "경로 %d개, 부실 채권 발생 확률 %.1f%% / %d paths, bad debt probability %.1f%%\n",
+		s.Paths, s.BadDebtProbability*100, s.Paths, s.BadDebtProbability*100)
+	fmt.Fprintf(w, "%-18s %14s %14s %14s %14s %14s %14s\n", "metric", "mean", "p50", "p90", "p95", "p99", "max")
+	for _, row := range []struct {
+		name string
+		d    Distribution
+	}{
+		{"bad_debt", s.BadDebt},
+		{"liquidated", s.LiquidationVolume},
+		{"reserves_used", s.ReservesConsumed},
+		{"uncovered", s.Uncovered},
+	} {
+		fmt.Fprintf(w, "%-18s %14s %14s %14s %14s %14s %14s\n", row.name,
+			usd(row.d.Mean), usd(row.d.P50), usd(row.d.P90), usd(row.d.P95), usd(row.d.P99), usd(row.d.Max))
+	}
+	d := s.MaxDrawdown
+	fmt.Fprintf(w, "%-18s %13.1f%% %13.1f%% %13.1f%% %13.1f%% %13.1f%% %13.1f%%\n", "max_drawdown",
+		d.Mean*100, d.P50*100, d.P90*100, d.P95*100, d.P99*100, d.Max*100)
+}
+
+// usd는 달러 금액을 짧게 표시합니다 ($1.2M, $350.0K, $12.34).
+// usd formats a dollar amount compactly ($1.2M, $350.0K, $12.34).
+func usd(v float64) string {
+	switch a := math.Abs(v); {
+	case a >= 1e9:
+		return fmt.Sprintf("$%.2fB", v/1e9)
+	case a >= 1e6:
+		return fmt.Sprintf("$%.2fM", v/1e6)
+	case a >= 1e3:
+		return fmt.Sprintf("$%.1fK", v/1e3)
+	default:
+		return fmt.Sprintf("$%.2f", v)
+	}
+}
@@ -0,0 +1,53 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// csvHeader는 CSV 열 이름입니다 (Row의 JSON 이름과 같음).
+// csvHeader are the CSV column names (the same as Row's JSON names).
+var csvHeader = []string{
+	"collateral_factor", "liquidation_threshold", "liquidation_bonus", "close_factor",
+	"base_rate", "multiplier", "jump_multiplier", "kink",
+	"shock", "status", "error",
+	"opened", "rejected", "min_health_factor", "rate_asset", "utilization", "borrow_rate", "reserves_accrued",
+	"liquidatable", "liquidations", "failed_liquidations", "repaid", "seized", "liquidator_profit",
+	"bad_debt", "underwater", "post_min_health_factor",
+}
+
+// WriteCSV는 행을 긴 형식 CSV로 씁니다 (파라미터 열 + 충격 + 지표 열, 히트맵용 피벗에 적합).
+// WriteCSV writes rows as long-format CSV (parameter columns + shock + metric columns, ready to pivot into heatmaps).
+func WriteCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'g', 10, 64) }
+	for _, r := range rows {
+		if err := cw.Write([]string{
+			r.CollateralFactor, r.LiquidationThreshold, r.LiquidationBonus, r.CloseFactor,
+			r.BaseRate, r.Multiplier, r.JumpMultiplier, r.Kink,
+			r.Shock, r.Status, r.Error,
+			strconv.Itoa(r.Opened), strconv.Itoa(r.Rejected), f(r.MinHealthFactor), r.RateAsset,
+			f(r.Utilization), f(r.BorrowRate), f(r.ReservesAccrued),
+			strconv.Itoa(r.Liquidatable), strconv.Itoa(r.Liquidations), strconv.Itoa(r.FailedLiquidations),
+			f(r.Repaid), f(r.Seized), f(r.LiquidatorProfit),
+			f(r.BadDebt), strconv.Itoa(r.Underwater), f(r.PostMinHealthFactor),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON은 행을 JSON 배열로 씁니다.
+// WriteJSON writes rows as a JSON array.
+func WriteJSON(w io.Writer, rows []Row) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
@@ -0,0 +1,445 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/jeongseup/lending-monitor/internal/sim"
+)
+
+// 행 상태 / Row statuses
+const (
+	// StatusOK는 정상 실행된 행입니다.
+	// StatusOK is a row that ran normally.
+	StatusOK = "ok"
+
+	// StatusInvalid는 풀이 받아들이지 않는 조합입니다 (예: 담보 인정 비율 > 청산 기준).
+	// StatusInvalid is a combination the pool rejects (e.g. collateral factor > liquidation threshold).
+	StatusInvalid = "invalid"
+
+	// StatusError는 실행 중 예상하지 못한 오류입니다 (예: 오버플로, 불변 조건 위반).
+	// StatusError is an unexpected error during the run (e.g. overflow, an invariant violation).
+	StatusError = "error"
+)
+
+// maxLiquidationsPerUser는 라운드 하나에서 사용자 한 명에게 하는 청산의 상한입니다.
+// maxLiquidationsPerUser caps the liquidations of one user within one round.
+const maxLiquidationsPerUser = 64
+
+// dustFraction은 사용자의 처음 부채 가치 대비 이보다 작은 청산을 먼지로 보고 멈추는 비율입니다.
+// 회복할 수 없는 포지션에 Close Factor 청산을 반복하면 금액이 기하급수적으로 줄어들며 끝나지 않습니다.
+// dustFraction is the share of a user's initial debt value below which a liquidation counts as dust and stops.
+// Repeating close-factor liquidations on a position that cannot recover shrinks geometrically and never ends.
+const dustFraction = 1e-6
+
+// maxRounds는 연쇄 청산 라운드의 상한입니다.
+// maxRounds caps the cascade rounds.
+const maxRounds = 100
+
+// Row는 조합 하나 × 충격 하나의 결과입니다.
+// 가치는 풀의 가치 단위 (수량 × 가격 / 1e18)를 1e18로 나눈 값으로, 스터디 테스트처럼 토큰과 가격이 모두 18 소수점이면 USD입니다.
+// Row is the result of one combination × one shock.
+// Values are the pool's value unit (amount × price / 1e18) divided by 1e18, which is USD when tokens and prices
+// both use 18 decimals as in the study tests.
+type Row struct {
+	Params
+	Shock  string `json:"shock"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	// Opened와 Rejected는 부채가 있는 사용자 수와 revert된 기준 시나리오 단계 수입니다.
+	// Opened and Rejected are the number of users with debt and the number of reverted base scenario steps.
+	Opened   int `json:"opened"`
+	Rejected int `json:"rejected"`
+
+	// MinHealthFactor는 충격 전 대출자 중 가장 낮은 헬스팩터입니다 (대출자가 없으면 0).
+	// MinHealthFactor is the lowest health factor among borrowers before the shock (0 without borrowers).
+	MinHealthFactor float64 `json:"min_health_factor"`
+
+	// RateAsset은 사용률이 가장 높은 리저브이고, Utilization과 BorrowRate는 충격 전 그 리저브의 값입니다.
+	// RateAsset is the most utilized reserve, and Utilization and BorrowRate are its pre-shock values.
+	RateAsset   string  `json:"rate_asset"`
+	Utilization float64 `json:"utilization"`
+	BorrowRate  float64 `json:"borrow_rate"`
+
+	// ReservesAccrued는 Horizon 동안 쌓인 프로토콜 준비금의 가치입니다.
+	// ReservesAccrued is the value of protocol reserves accrued over the horizon.
+	ReservesAccrued float64 `json:"reserves_accrued"`
+
+	// Liquidatable은 충격 직후 HF < 1인 사용자 수입니다.
+	// Liquidatable is the number of users with HF < 1 right after the shock.
+	Liquidatable int `json:"liquidatable"`
+
+	Liquidations       int `json:"liquidations"`
+	FailedLiquidations int `json:"failed_liquidations"`
+
+	// Repaid, Seized, LiquidatorProfit은 청산으로 상환된 부채, 압류된 담보, 그 차이의 가치입니다.
+	// Repaid, Seized and LiquidatorProfit are the value of debt repaid, collateral seized and their difference.
+	Repaid           float64 `json:"repaid"`
+	Seized           float64 `json:"seized"`
+	LiquidatorProfit float64 `json:"liquidator_profit"`
+
+	// BadDebt는 청산이 끝난 뒤 담보 가치를 넘는 부채 가치의 합입니다 (담보를 다 팔아도 갚을 수 없는 부분).
+	// BadDebt is the sum of debt value exceeding collateral value after liquidations (what selling all collateral cannot repay).
+	BadDebt float64 `json:"bad_debt"`
+
+	// Underwater는 청산이 끝난 뒤에도 HF < 1인 사용자 수입니다 (부실 채권, 청산 실패, 유동성 부족 등).
+	// Underwater is the number of users still at HF < 1 after liquidations (bad debt, failed liquidations, missing liquidity, ...).
+	Underwater int `json:"underwater"`
+
+	// PostMinHealthFactor는 청산 후 부채가 남은 사용자 중 가장 낮은 헬스팩터입니다.
+	// PostMinHealthFactor is the lowest health factor among users left with debt after liquidations.
+	PostMinHealthFactor float64 `json:"post_min_health_factor"`
+}
+
+// Run은 모든 조합을 workers개의 고루틴으로 병렬 실행하고, 조합 순서 × 충격 순서로 행을 반환합니다.
+// ctx가 취소되면 남은 조합을 건너뛰고 ctx 에러를 반환합니다.
+// Run executes every combination in parallel on workers goroutines and returns rows in combination × shock order.
+// When ctx is cancelled the remaining combinations are skipped and the ctx error is returned.
+func (s *Spec) Run(ctx context.Context, workers int) ([]Row, error) {
+	combos, err := s.Combinations()
+	if err != nil {
+		return nil, err
+	}
+	workers = max(1, min(workers, len(combos)))
+	results := make([][]Row, len(combos))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.Evaluate(combos[i])
+			}
+		}()
+	}
+feed:
+	for i := range combos {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
+}
+
+// Evaluate는 조합 하나를 모든 충격에 대해 실행합니다. 충격마다 새 풀에서 기준 시나리오를 다시 실행합니다.
+// Evaluate runs one combination for every shock. Each shock replays the base scenario on a fresh pool.
+func (s *Spec) Evaluate(p Params) []Row {
+	rows := make([]Row, len(s.Shocks))
+	for i, shock := range s.Shocks {
+		rows[i] = s.evaluate(p, shock)
+	}
+	return rows
+}
+
+// evaluate는 조합 하나 × 충격 하나를 실행합니다.
+// evaluate runs one combination × one shock.
+func (s *Spec) evaluate(p Params, shock Shock) Row {
+	row := Row{Params: p, Shock: shock.Name, Status: StatusOK}
+	fail := func(status string, err error) Row {
+		row.Status, row.Error = status, err.Error()
+		return row
+	}
+	if ratio(p.CollateralFactor).Cmp(ratio(p.LiquidationThreshold)) > 0 {
+		return fail(StatusInvalid, fmt.Errorf("%w: CF must be <= LT", sim.ErrInvalidFactor))
+	}
+
+	pool, report, err := s.scenario(p).Execute()
+	if err != nil {
+		return fail(StatusInvalid, err)
+	}
+	for _, st := range report.Steps {
+		if st.Failure != "" {
+			row.Rejected++
+		}
+	}
+
+	// 충격 전 가격 (Horizon 뒤에 오라클을 다시 갱신하는 데 씀)
+	// Pre-shock prices (used to refresh the oracle after the horizon)
+	prices := make(map[string]*big.Int)
+	for _, r := range pool.Reserves() {
+		price, err := pool.Oracle().Price(r.Asset)
+		if err != nil {
+			return fail(StatusError, fmt.Errorf("%s: %w", r.Asset, err))
+		}
+		prices[r.Asset] = price
+	}
+	if s.Horizon != "" {
+		d, _ := sim.ParseDuration(s.Horizon)
+		pool.Clock().Advance(d)
+		for _, r := range pool.Reserves() {
+			if err := pool.AccrueInterest(r.Asset); err != nil {
+				return fail(StatusError, err)
+			}
+			// 스터디 풀의 가치 공식 그대로 (수량 × 가격 / 1e18)
+			// The study pool's value formula as-is (amount × price / 1e18)
+			reserves, _ := pool.Reserve(r.Asset)
+			row.ReservesAccrued += toFloat(value(reserves.TotalReserves, prices[r.Asset]))
+		}
+	}
+
+	borrowers, minHF, err := healthFactors(pool, s.Liquidator)
+	if err != nil {
+		return fail(StatusError, err)
+	}
+	row.Opened, row.MinHealthFactor = len(borrowers), minHF
+	if err := rateMetrics(pool, &row); err != nil {
+		return fail(StatusError, err)
+	}
+
+	// 충격: 모든 가격을 현재 시각으로 다시 기록 (Horizon 뒤 지연 방지)
+	// Shock: re-record every price at the current time (avoids staleness after the horizon)
+	for asset, price := range prices {
+		if pct, ok := shock.Change[asset]; ok {
+			price = applyChange(price, pct)
+		}
+		pool.Oracle().SetPrice(asset, price)
+	}
+	for _, u := range borrowers {
+		hf, err := pool.HealthFactor(u)
+		if err != nil {
+			return fail(StatusError, err)
+		}
+		if hf.Cmp(sim.Precision) < 0 {
+			row.Liquidatable++
+		}
+	}
+
+	if err := s.cascade(pool, borrowers, &row); err != nil {
+		return fail(StatusError, err)
+	}
+	row.LiquidatorProfit = row.Seized - row.Repaid
+
+	post := false
+	for _, u := range borrowers {
+		debt, err := pool.TotalDebtValue(u)
+		if err != nil {
+			return fail(StatusError, err)
+		}
+		if debt.Sign() == 0 {
+			continue
+		}
+		collateral, err := pool.TotalCollateralValue(u)
+		if err != nil {
+			return fail(StatusError, err)
+		}
+		hf, err := pool.HealthFactor(u)
+		if err != nil {
+			return fail(StatusError, err)
+		}
+		// 청산 뒤 남은 담보 먼지 (몇 wei)가 있어도 부족분은 부실 채권
+		// The shortfall is bad debt even when dust collateral (a few wei) is left after liquidations
+		if shortfall := new(big.Int).Sub(debt, collateral); shortfall.Sign() > 0 {
+			row.BadDebt += toFloat(shortfall)
+		}
+		if hf.Cmp(sim.Precision) < 0 {
+			row.Underwater++
+		}
+		if f := toFloat(hf); !post || f < row.PostMinHealthFactor {
+			row.PostMinHealthFactor, post = f, true
+		}
+	}
+	return row
+}
+
+// scenario는 기준 시나리오의 사본에 조합의 파라미터를 적용합니다.
+// scenario applies the combination's parameters to a copy of the base scenario.
+func (s *Spec) scenario(p Params) *sim.Scenario {
+	sc := *s.base
+	sc.Reserves = slices.Clone(s.base.Reserves)
+	for i := range sc.Reserves {
+		if sc.Reserves[i].Asset == s.Target {
+			sc.Reserves[i].CollateralFactor = p.CollateralFactor
+			sc.Reserves[i].LiquidationThreshold = p.LiquidationThreshold
+		}
+	}
+	sc.RateModel = sim.RateModelSpec{BaseRate: p.BaseRate, Multiplier: p.Multiplier, JumpMultiplier: p.JumpMultiplier, Kink: p.Kink}
+	sc.Params.LiquidationBonus = p.LiquidationBonus
+	sc.Params.CloseFactor = p.CloseFactor
+	return &sc
+}
+
+// cascade는 HF < 1인 사용자가 없어지거나 더 청산할 수 없을 때까지 청산합니다.
+// 청산마다 가치가 가장 큰 부채를 Close Factor만큼 (담보가 모자라면 담보가 허용하는 만큼) 갚고,
+// 가치가 가장 큰 담보를 압류합니다. 청산자에게는 필요한 만큼 발행합니다.
+// cascade liquidates until no user has HF < 1 or nothing more can be liquidated.
+// Each liquidation repays the largest debt up to the close factor (or what the collateral allows when short)
+// and seizes the largest collateral. The liquidator is minted what it needs.
+func (s *Spec) cascade(pool *sim.LendingPool, borrowers []string, row *Row) error {
+	params := pool.Params()
+	dust := make(map[string]*big.Int, len(borrowers))
+	for _, u := range borrowers {
+		debt, err := pool.TotalDebtValue(u)
+		if err != nil {
+			return err
+		}
+		d, _ := new(big.Float).Mul(new(big.Float).SetInt(debt), big.NewFloat(dustFraction)).Int(nil)
+		dust[u] = d
+	}
+	done := make(map[string]bool, len(borrowers))
+	for range maxRounds {
+		acted := false
+		for _, u := range borrowers {
+			for range maxLiquidationsPerUser {
+				if done[u] {
+					break
+				}
+				hf, err := pool.HealthFactor(u)
+				if err != nil {
+					return err
+				}
+				if hf.Cmp(sim.Precision) >= 0 {
+					break
+				}
+				debtAsset, collateralAsset := largest(pool, u)
+				if debtAsset == "" || collateralAsset == "" {
+					break
+				}
+				debtPrice, err := pool.Oracle().Price(debtAsset)
+				if err != nil {
+					return err
+				}
+				collateralPrice, err := pool.Oracle().Price(collateralAsset)
+				if err != nil {
+					return err
+				}
+				cover := new(big.Int).Mul(pool.Debt(u, debtAsset), params.CloseFactor)
+				cover.Quo(cover, sim.Precision)
+				// seized = cover × debtPrice × (1 + bonus) / (collateralPrice × 1e18) ≤ supplied
+				maxCover := new(big.Int).Mul(pool.Supplied(u, collateralAsset), collateralPrice)
+				maxCover.Mul(maxCover, sim.Precision)
+				maxCover.Quo(maxCover, new(big.Int).Mul(debtPrice, new(big.Int).Add(sim.Precision, params.LiquidationBonus)))
+				// 담보가 바닥나거나 먼지 청산만 남으면 이 사용자는 끝 (남은 부채는 부실 채권)
+				// Done with this user once collateral runs out or only dust remains (leftover debt is bad debt)
+				if maxCover.Cmp(cover) <= 0 {
+					cover = maxCover
+					done[u] = true
+				}
+				if cover.Sign() == 0 || value(cover, debtPrice).Cmp(dust[u]) < 0 {
+					done[u] = true
+					break
+				}
+				if err := pool.Mint(s.Liquidator, debtAsset, cover); err != nil {
+					return err
+				}
+				seized, err := pool.Liquidate(s.Liquidator, u, debtAsset, collateralAsset, cover)
+				if err != nil {
+					row.FailedLiquidations++
+					break
+				}
+				row.Liquidations++
+				row.Repaid += toFloat(value(cover, debtPrice))
+				row.Seized += toFloat(value(seized, collateralPrice))
+				acted = true
+			}
+		}
+		if !acted {
+			return nil
+		}
+	}
+	return nil
+}
+
+// healthFactors는 부채가 있는 사용자 (청산자 제외)와 그중 가장 낮은 헬스팩터를 반환합니다.
+// healthFactors returns the users with debt (excluding the liquidator) and the lowest health factor among them.
+func healthFactors(pool *sim.LendingPool, liquidator string) ([]string, float64, error) {
+	var borrowers []string
+	var minHF float64
+	for _, u := range pool.Users() {
+		if u == liquidator {
+			continue
+		}
+		debt, err := pool.TotalDebtValue(u)
+		if err != nil {
+			return nil, 0, err
+		}
+		if debt.Sign() == 0 {
+			continue
+		}
+		hf, err := pool.HealthFactor(u)
+		if err != nil {
+			return nil, 0, err
+		}
+		if f := toFloat(hf); len(borrowers) == 0 || f < minHF {
+			minHF = f
+		}
+		borrowers = append(borrowers, u)
+	}
+	return borrowers, minHF, nil
+}
+
+// rateMetrics는 사용률이 가장 높은 리저브의 사용률과 대출 이자율을 기록합니다.
+// rateMetrics records the utilization and borrow rate of the most utilized reserve.
+func rateMetrics(pool *sim.LendingPool, row *Row) error {
+	var best *big.Int
+	for _, r := range pool.Reserves() {
+		u, err := pool.UtilizationRate(r.Asset)
+		if err != nil {
+			return err
+		}
+		if best == nil || u.Cmp(best) > 0 {
+			best, row.RateAsset = u, r.Asset
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	rate, err := pool.BorrowRate(row.RateAsset)
+	if err != nil {
+		return err
+	}
+	row.Utilization, row.BorrowRate = toFloat(best), toFloat(rate)
+	return nil
+}
+
+// largest는 사용자의 가치가 가장 큰 부채 자산과 담보 자산을 반환합니다 (없으면 "").
+// largest returns the user's debt and collateral assets with the largest value ("" when none).
+func largest(pool *sim.LendingPool, user string) (debtAsset, collateralAsset string) {
+	maxDebt, maxCollateral := new(big.Int), new(big.Int)
+	for _, r := range pool.Reserves() {
+		price, err := pool.Oracle().Price(r.Asset)
+		if err != nil {
+			continue
+		}
+		if v := value(pool.Debt(user, r.Asset), price); v.Cmp(maxDebt) > 0 {
+			maxDebt, debtAsset = v, r.Asset
+		}
+		if v := value(pool.Supplied(user, r.Asset), price); v.Cmp(maxCollateral) > 0 {
+			maxCollateral, collateralAsset = v, r.Asset
+		}
+	}
+	return debtAsset, collateralAsset
+}
+
+// applyChange는 가격에 변화율 (%)을 적용합니다. 10억분의 1 단위로 반올림한 비율을 씁니다.
+// applyChange applies a percentage change to a price, using the ratio rounded to parts per billion.
+func applyChange(price *big.Int, pct float64) *big.Int {
+	factor := ratio(formatRatio(1 + pct/100))
+	out := new(big.Int).Mul(price, factor)
+	return out.Quo(out, sim.Precision)
+}
+
+// value는 스터디 풀의 가치 공식 수량 × 가격 / 1e18입니다.
+// value is the study pool's value formula amount × price / 1e18.
+func value(amount, price *big.Int) *big.Int {
+	v := new(big.Int).Mul(amount, price)
+	return v.Quo(v, sim.Precision)
+}
+
+// toFloat는 1e18 스케일 값을 float64로 바꿉니다.
+// toFloat converts a 1e18-scaled value to float64.
+func toFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(sim.Precision)).Float64()
+	return f
+}
@@ -0,0 +1,312 @@
+// Package sweep은 리스크 파라미터 조합마다 LendingPool 시뮬레이터를 돌려 결과 격자를 만듭니다.
+// Package sweep runs the LendingPool simulator for every combination of risk parameters and builds a result grid.
+//
+// contracts/test/ParameterImpact.t.sol이 파라미터 몇 개를 손으로 비교한다면, 스윕은 범위의 모든 조합
+// (담보 인정 비율, 청산 기준, 청산 보너스, Close Factor, 금리 모델 kink/기울기)에 대해
+// 기준 시나리오로 포지션을 열고, 가격 충격마다 연쇄 청산을 실행해 부실 채권과 청산 규모를 기록합니다.
+// 결과는 조합 × 충격당 한 행이라 피벗하면 바로 히트맵이 됩니다.
+//
+// Where contracts/test/ParameterImpact.t.sol compares a few parameters by hand, a sweep takes every combination
+// in the ranges (collateral factor, liquidation threshold, liquidation bonus, close factor, rate model
+// kink/slopes), opens positions with a base scenario, runs cascading liquidations for each price shock and
+// records bad debt and liquidation volume. Results have one row per combination × shock, so pivoting them
+// gives a heatmap directly.
+package sweep
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jeongseup/lending-monitor/internal/sim"
+)
+
+// Spec은 스윕 정의 파일입니다 (YAML/JSON).
+// Spec is a sweep definition file (YAML/JSON).
+type Spec struct {
+	// Scenario는 포지션을 여는 기준 시나리오 파일입니다 (스펙 파일 기준 상대 경로).
+	// 단계 중 revert된 것은 그 조합에서 거절된 포지션으로 셉니다 (예: 낮은 담보 인정 비율로 대출 실패).
+	// Scenario is the base scenario file that opens positions (relative to the spec file).
+	// Steps that revert count as positions rejected under that combination (e.g. a borrow failing at a low collateral factor).
+	Scenario string `yaml:"scenario"`
+
+	// Target은 담보 인정 비율과 청산 기준을 스윕할 리저브입니다.
+	// Target is the reserve whose collateral factor and liquidation threshold are swept.
+	Target string `yaml:"target"`
+
+	// Liquidator는 청산자 이름입니다 (청산마다 필요한 만큼 발행, 기본 "liquidator").
+	// Liquidator is the liquidator's name (minted as needed per liquidation, default "liquidator").
+	Liquidator string `yaml:"liquidator"`
+
+	// Horizon은 충격 전에 이자를 누적할 기간입니다 (예: "30d", 생략하면 누적 안 함).
+	// Horizon is how long interest accrues before the shock (e.g. "30d", no accrual when empty).
+	Horizon string `yaml:"horizon"`
+
+	Shocks []Shock `yaml:"shocks"`
+	Grid   Grid    `yaml:"grid"`
+
+	// base는 읽어 둔 기준 시나리오입니다.
+	// base is the loaded base scenario.
+	base *sim.Scenario
+}
+
+// Shock은 가격 충격 시나리오 하나입니다. Change는 자산별 가격 변화율입니다 (%, "-30" = 30% 하락).
+// Shock is one price shock scenario. Change is the per-asset price change (%, "-30" = a 30% drop).
+type Shock struct {
+	Name   string             `yaml:"name"`
+	Change map[string]float64 `yaml:"change"`
+}
+
+// Grid는 스윕할 범위입니다. 각 값은 "0.6:0.8:0.05" (시작:끝:간격), "0.7,0.75,0.8" (목록) 또는 "0.8" 형식이며,
+// 비워 두면 기준 시나리오의 값 하나만 씁니다.
+// Grid are the ranges to sweep. Each value is "0.6:0.8:0.05" (from:to:step), "0.7,0.75,0.8" (a list) or "0.8",
+// and an empty value uses the base scenario's single value.
+//
+// 스터디 LendingPool의 borrow는 HF ≥ 1 (청산 기준)만 검사하므로, 담보 인정 비율은 CF ≤ LT 검증에만 쓰이고
+// 대출 한도는 청산 기준이 정합니다.
+// The study LendingPool's borrow only checks HF ≥ 1 (liquidation threshold), so the collateral factor is only
+// used for the CF ≤ LT check and the liquidation threshold sets the borrow limit.
+type Grid struct {
+	CollateralFactor     string `yaml:"collateral_factor"`
+	LiquidationThreshold string `yaml:"liquidation_threshold"`
+	LiquidationBonus     string `yaml:"liquidation_bonus"`
+	CloseFactor          string `yaml:"close_factor"`
+	BaseRate             string `yaml:"base_rate"`
+	Multiplier           string `yaml:"multiplier"`
+	JumpMultiplier       string `yaml:"jump_multiplier"`
+	Kink                 string `yaml:"kink"`
+}
+
+// Params는 한 조합의 파라미터 값입니다 (10진수 문자열, 시뮬레이터 단위).
+// Params are one combination's parameter values (decimal strings, in simulator units).
+type Params struct {
+	CollateralFactor     string `json:"collateral_factor"`
+	LiquidationThreshold string `json:"liquidation_threshold"`
+	LiquidationBonus     string `json:"liquidation_bonus"`
+	CloseFactor          string `json:"close_factor"`
+	BaseRate             string `json:"base_rate"`
+	Multiplier           string `json:"multiplier"`
+	JumpMultiplier       string `json:"jump_multiplier"`
+	Kink                 string `json:"kink"`
+}
+
+// LoadSpec은 스윕 스펙과 기준 시나리오를 읽고 검증합니다.
+// LoadSpec reads and validates a sweep spec and its base scenario.
+func LoadSpec(path string) (*Spec, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("스윕 스펙 파일 읽기 실패 / failed to read sweep spec: %w", err)
+	}
+	var s Spec
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Scenario == "" {
+		return nil, fmt.Errorf("%s: scenario: 필수 / required", path)
+	}
+	scenarioPath := s.Scenario
+	if !filepath.IsAbs(scenarioPath) {
+		scenarioPath = filepath.Join(filepath.Dir(path), scenarioPath)
+	}
+	base, err := sim.LoadScenario(scenarioPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Bind(base); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Bind는 기준 시나리오를 붙이고 스펙을 검증합니다 (파일 없이 스펙을 만들 때 사용).
+// Bind attaches the base scenario and validates the spec (used when building a spec without files).
+func (s *Spec) Bind(base *sim.Scenario) error {
+	s.base = base
+	if s.Liquidator == "" {
+		s.Liquidator = "liquidator"
+	}
+	var errs []error
+	known := make(map[string]bool)
+	for _, r := range base.Reserves {
+		known[r.Asset] = true
+	}
+	if !known[s.Target] {
+		errs = append(errs, fmt.Errorf("target: 알 수 없는 리저브 %q / unknown reserve %q", s.Target, s.Target))
+	}
+	if s.Horizon != "" {
+		if _, err := sim.ParseDuration(s.Horizon); err != nil {
+			errs = append(errs, fmt.Errorf("horizon: %w", err))
+		}
+	}
+	if len(s.Shocks) == 0 {
+		errs = append(errs, errors.New("shocks: 충격이 최소 하나 필요 / at least one shock is required"))
+	}
+	for i, sh := range s.Shocks {
+		if sh.Name == "" {
+			errs = append(errs, fmt.Errorf("shocks[%d].name: 필수 / required", i))
+		}
+		for _, asset := range slices.Sorted(maps.Keys(sh.Change)) {
+			pct := sh.Change[asset]
+			if !known[asset] {
+				errs = append(errs, fmt.Errorf("shocks[%d].change: 알 수 없는 자산 %q / unknown asset %q", i, asset, asset))
+			}
+			if pct <= -100 {
+				errs = append(errs, fmt.Errorf("shocks[%d].change.%s: -100%% 초과여야 함 / must be above -100%%", i, asset))
+			}
+		}
+	}
+	if _, err := s.Combinations(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Base는 기준 시나리오를 반환합니다.
+// Base returns the base scenario.
+func (s *Spec) Base() *sim.Scenario {
+	return s.base
+}
+
+// Combinations는 격자의 모든 조합을 반환합니다 (마지막 축이 가장 빨리 변함).
+// Combinations returns every combination of the grid (the last axis varies fastest).
+func (s *Spec) Combinations() ([]Params, error) {
+	def := s.defaults()
+	axes := []struct {
+		name, spec, def string
+		set             func(*Params, string)
+	}{
+		{"collateral_factor", s.Grid.CollateralFactor, def.CollateralFactor, func(p *Params, v string) { p.CollateralFactor = v }},
+		{"liquidation_threshold", s.Grid.LiquidationThreshold, def.LiquidationThreshold, func(p *Params, v string) { p.LiquidationThreshold = v }},
+		{"liquidation_bonus", s.Grid.LiquidationBonus, def.LiquidationBonus, func(p *Params, v string) { p.LiquidationBonus = v }},
+		{"close_factor", s.Grid.CloseFactor, def.CloseFactor, func(p *Params, v string) { p.CloseFactor = v }},
+		{"base_rate", s.Grid.BaseRate, def.BaseRate, func(p *Params, v string) { p.BaseRate = v }},
+		{"multiplier", s.Grid.Multiplier, def.Multiplier, func(p *Params, v string) { p.Multiplier = v }},
+		{"jump_multiplier", s.Grid.JumpMultiplier, def.JumpMultiplier, func(p *Params, v string) { p.JumpMultiplier = v }},
+		{"kink", s.Grid.Kink, def.Kink, func(p *Params, v string) { p.Kink = v }},
+	}
+	combos := []Params{{}}
+	for _, axis := range axes {
+		values := []string{axis.def}
+		if axis.spec != "" {
+			var err error
+			if values, err = ParseRange(axis.spec); err != nil {
+				return nil, fmt.Errorf("grid.%s: %w", axis.name, err)
+			}
+		}
+		next := make([]Params, 0, len(combos)*len(values))
+		for _, c := range combos {
+			for _, v := range values {
+				p := c
+				axis.set(&p, v)
+				next = append(next, p)
+			}
+		}
+		combos = next
+	}
+	return combos, nil
+}
+
+// defaults는 기준 시나리오의 파라미터 값입니다 (시나리오에 없으면 시뮬레이터 기본값).
+// defaults are the base scenario's parameter values (the simulator defaults when the scenario has none).
+func (s *Spec) defaults() Params {
+	or := func(v, def string) string {
+		if v == "" {
+			return def
+		}
+		return sim.FormatUnits(ratio(v), 18)
+	}
+	b := s.base
+	p := Params{
+		LiquidationBonus: or(b.Params.LiquidationBonus, "0.05"),
+		CloseFactor:      or(b.Params.CloseFactor, "0.5"),
+		BaseRate:         or(b.RateModel.BaseRate, "0.02"),
+		Multiplier:       or(b.RateModel.Multiplier, "0.1"),
+		JumpMultiplier:   or(b.RateModel.JumpMultiplier, "1"),
+		Kink:             or(b.RateModel.Kink, "0.8"),
+	}
+	for _, r := range b.Reserves {
+		if r.Asset == s.Target {
+			p.CollateralFactor = sim.FormatUnits(ratio(r.CollateralFactor), 18)
+			p.LiquidationThreshold = sim.FormatUnits(ratio(r.LiquidationThreshold), 18)
+		}
+	}
+	return p
+}
+
+// ParseRange는 "시작:끝:간격", 쉼표 목록 또는 단일 값을 10진수 문자열 목록으로 바꿉니다.
+// 끝 값은 간격이 딱 맞으면 포함됩니다. 값은 18 소수점 이하여야 합니다.
+// ParseRange turns "from:to:step", a comma list or a single value into a list of decimal strings.
+// The end value is included when the step lands on it. Values must have at most 18 decimals.
+func ParseRange(spec string) ([]string, error) {
+	var out []string
+	if from, rest, ok := strings.Cut(spec, ":"); ok {
+		to, step, ok := strings.Cut(rest, ":")
+		if !ok {
+			return nil, fmt.Errorf("잘못된 범위 %q (시작:끝:간격) / invalid range %q (from:to:step)", spec, spec)
+		}
+		f, err1 := strconv.ParseFloat(strings.TrimSpace(from), 64)
+		t, err2 := strconv.ParseFloat(strings.TrimSpace(to), 64)
+		st, err3 := strconv.ParseFloat(strings.TrimSpace(step), 64)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return nil, fmt.Errorf("잘못된 범위 %q / invalid range %q: %w", spec, spec, err)
+		}
+		if st <= 0 || t < f {
+			return nil, fmt.Errorf("잘못된 범위 %q: 간격 > 0, 끝 ≥ 시작 / invalid range %q: step > 0, to ≥ from", spec, spec)
+		}
+		// 부동소수점 누적 오차로 끝 값이 빠지지 않도록 간격 수를 반올림해 셉니다.
+		// Count steps with rounding so floating-point error does not drop the end value.
+		n := int(math.Floor((t-f)/st + 1e-9))
+		if n > 10_000 {
+			return nil, fmt.Errorf("범위 %q의 값이 너무 많음 / range %q has too many values", spec, spec)
+		}
+		for i := 0; i <= n; i++ {
+			out = append(out, formatRatio(f+float64(i)*st))
+		}
+	} else {
+		for _, v := range strings.Split(spec, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("빈 범위 / empty range")
+	}
+	for i, v := range out {
+		n, err := sim.ParseUnits(v, 18)
+		if err != nil {
+			return nil, err
+		}
+		// 같은 값이 "0.80"과 "0.8"로 갈라지지 않도록 정규화 (히트맵 피벗용)
+		// Normalize so the same value does not split into "0.80" and "0.8" (for heatmap pivots)
+		out[i] = sim.FormatUnits(n, 18)
+	}
+	return out, nil
+}
+
+// formatRatio는 범위 값을 소수점 9자리에서 반올림해 짧은 10진수로 씁니다 (0.65000000001 → "0.65").
+// formatRatio rounds a range value at 9 decimals and writes it as a short decimal (0.65000000001 → "0.65").
+func formatRatio(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e9)/1e9, 'f', -1, 64)
+}
+
+// ratio는 10진수 비율 문자열을 1e18 스케일 정수로 바꿉니다 (검증된 값 전용).
+// ratio converts a decimal ratio string to a 1e18-scaled integer (validated values only).
+func ratio(v string) *big.Int {
+	n, _ := sim.ParseUnits(v, 18)
+	return n
+}
@@ -0,0 +1,190 @@
+package sweep
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/jeongseup/lending-monitor/internal/sim"
+)
+
+// baseScenario는 Alice 한 명이 ETH 10개로 USDC 14,000을 빌린 풀입니다 (이자 누적 없음).
+// baseScenario is a pool where only Alice borrows 14,000 USDC against 10 ETH (no interest accrual).
+const baseScenario = `
+name: sweep-test
+price_decimals: 18
+max_staleness: 0
+reserves:
+  - {asset: WETH, decimals: 18, price: "2000", collateral_factor: "0.75", liquidation_threshold: "0.80"}
+  - {asset: USDC, decimals: 18, price: "1", collateral_factor: "0.80", liquidation_threshold: "0.85"}
+actors:
+  alice: {WETH: "10"}
+  bob: {USDC: "50_000"}
+  erin: {WETH: "20"}
+steps:
+  - {action: deposit, actor: bob, asset: USDC, amount: "50_000"}
+  - {action: deposit, actor: erin, asset: WETH, amount: "20"}
+  - {action: deposit, actor: alice, asset: WETH, amount: "10"}
+  - {action: borrow, actor: alice, asset: USDC, amount: "14_000"}
+`
+
+func newSpec(t *testing.T, grid Grid, shocks ...Shock) *Spec {
+	t.Helper()
+	base, err := sim.ParseScenario("sweep-test", []byte(baseScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Spec{Target: "WETH", Shocks: shocks, Grid: grid}
+	if err := s.Bind(base); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func approx(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := map[string][]string{
+		"0.6:0.75:0.05": {"0.6", "0.65", "0.7", "0.75"},
+		"0.05, 0.1":     {"0.05", "0.1"},
+		"0.8":           {"0.8"},
+	}
+	for in, want := range cases {
+		got, err := ParseRange(in)
+		if err != nil {
+			t.Fatalf("%q: %v", in, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("ParseRange(%q) = %v, want %v", in, got, want)
+		}
+	}
+	for _, in := range []string{"0.8:0.6:0.05", "0.1:0.2:0", "abc", "0:1:0.00001"} {
+		if _, err := ParseRange(in); err == nil {
+			t.Errorf("ParseRange(%q): expected error", in)
+		}
+	}
+}
+
+func TestCombinationsOrder(t *testing.T) {
+	s := newSpec(t, Grid{LiquidationBonus: "0.05,0.1", CloseFactor: "0.50,1"}, Shock{Name: "none"})
+	combos, err := s.Combinations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range combos {
+		got = append(got, c.LiquidationBonus+"/"+c.CloseFactor)
+		// 스윕하지 않는 축은 기준 시나리오 값
+		// Axes that are not swept keep the base scenario values
+		if c.CollateralFactor != "0.75" || c.LiquidationThreshold != "0.8" {
+			t.Errorf("unswept axes = %s/%s, want 0.75/0.8", c.CollateralFactor, c.LiquidationThreshold)
+		}
+	}
+	want := []string{"0.05/0.5", "0.05/1", "0.1/0.5", "0.1/1"}
+	if !slices.Equal(got, want) {
+		t.Errorf("combinations = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluateLiquidationAndBadDebt(t *testing.T) {
+	s := newSpec(t, Grid{CloseFactor: "1"},
+		Shock{Name: "eth-20", Change: map[string]float64{"WETH": -20}},
+		Shock{Name: "eth-50", Change: map[string]float64{"WETH": -50}},
+	)
+	combos, err := s.Combinations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := s.Evaluate(combos[0])
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+
+	// ETH $1,600: HF = 16,000 × 0.8 / 14,000 < 1, 부채 전액 상환, 담보 14,700 압류
+	// ETH $1,600: HF = 16,000 × 0.8 / 14,000 < 1, all debt repaid, 14,700 of collateral seized
+	r := rows[0]
+	if r.Status != StatusOK || r.Opened != 1 || r.Liquidatable != 1 || r.Liquidations != 1 {
+		t.Fatalf("eth-20 row = %+v", r)
+	}
+	approx(t, "MinHealthFactor", r.MinHealthFactor, 2000*10*0.8/14_000)
+	approx(t, "Repaid", r.Repaid, 14_000)
+	approx(t, "Seized", r.Seized, 14_700)
+	approx(t, "LiquidatorProfit", r.LiquidatorProfit, 700)
+	approx(t, "BadDebt", r.BadDebt, 0)
+
+	// ETH $1,000: 담보 10,000으로 10,000 / 1.05만 갚고 나머지는 부실 채권
+	// ETH $1,000: 10,000 of collateral repays only 10,000 / 1.05 and the rest is bad debt
+	r = rows[1]
+	if r.Status != StatusOK || r.Underwater != 1 {
+		t.Fatalf("eth-50 row = %+v", r)
+	}
+	approx(t, "Repaid", r.Repaid, 10_000/1.05)
+	approx(t, "BadDebt", r.BadDebt, 14_000-10_000/1.05)
+}
+
+func TestEvaluateInvalidCombination(t *testing.T) {
+	s := newSpec(t, Grid{CollateralFactor: "0.75", LiquidationThreshold: "0.7"},
+		Shock{Name: "none"},
+	)
+	combos, _ := s.Combinations()
+	rows := s.Evaluate(combos[0])
+	if len(rows) != 1 || rows[0].Status != StatusInvalid || rows[0].Error == "" {
+		t.Fatalf("rows = %+v, want one invalid row", rows)
+	}
+
+	// LT가 낮아 대출이 거절되면 오류가 아니라 Rejected로 기록 (20,000 × 0.65 < 14,000)
+	// A borrow rejected by a lower LT is recorded as Rejected, not as an error (20,000 × 0.65 < 14,000)
+	s = newSpec(t, Grid{CollateralFactor: "0.6", LiquidationThreshold: "0.65"}, Shock{Name: "none"})
+	combos, _ = s.Combinations()
+	r := s.Evaluate(combos[0])[0]
+	if r.Status != StatusOK || r.Rejected != 1 || r.Opened != 0 {
+		t.Fatalf("row = %+v, want one rejected borrow", r)
+	}
+}
+
+func TestRunOrderAndCSV(t *testing.T) {
+	s := newSpec(t, Grid{LiquidationBonus: "0.05,0.1", Kink: "0.8,0.9"},
+		Shock{Name: "eth-20", Change: map[string]float64{"WETH": -20}},
+		Shock{Name: "eth-40", Change: map[string]float64{"WETH": -40}},
+	)
+	rows, err := s.Run(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combos, _ := s.Combinations()
+	if len(rows) != len(combos)*2 {
+		t.Fatalf("rows = %d, want %d", len(rows), len(combos)*2)
+	}
+	for i, r := range rows {
+		if r.Params != combos[i/2] || r.Shock != s.Shocks[i%2].Name {
+			t.Errorf("row %d = %+v/%s, want %+v/%s", i, r.Params, r.Shock, combos[i/2], s.Shocks[i%2].Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(rows)+1 || !slices.Equal(records[0], csvHeader) {
+		t.Fatalf("csv has %d records, header %v", len(records), records[0])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Run(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run(canceled) err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Package trend는 계정별 헬스팩터 관측값의 이동 창을 유지하고 청산까지 남은 시간을 추정합니다.
+// Package trend keeps a rolling window of health factor observations per account and estimates the time to liquidation.
+//
+// 두 가지 추정을 합칩니다:
+// - 추세: 창 안의 관측값에 대한 최소제곱 기울기로 HF가 1.0을 지나는 시점을 외삽
+// - 이자: 가격이 고정일 때 예치/대출 이자율 차이만으로 HF가 1.0에 도달하는 시점
+// 둘 중 더 이른 시점을 예상 청산 시간으로 봅니다.
+//
+// Two estimates are combined:
+// - Trend: extrapolate when HF crosses 1.0 from the least-squares slope of observations in the window
+// - Interest: when HF reaches 1.0 from the supply/borrow rate gap alone, prices held fixed
+// The earlier of the two is the projected time to liquidation.
+//
+// DevOps 관점:
+// - HF가 아직 경고 임계값 위에 있어도 빠르게 떨어지는 포지션을 미리 알립니다
+//
+// DevOps perspective:
+// - Warns early about positions falling fast while HF is still above the warning threshold
+package trend
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Options는 추세 추적기 설정입니다.
+// Options configures the trend tracker.
+type Options struct {
+	// Window는 기울기 계산에 쓰는 관측 기간입니다.
+	// Window is the observation period used for the slope.
+	Window time.Duration
+
+	// MinSamples는 기울기를 계산하기 위한 최소 관측 수입니다.
+	// MinSamples is the minimum number of observations needed for a slope.
+	MinSamples int
+
+	// MinSpan은 기울기를 계산하기 위한 첫 관측과 마지막 관측 사이의 최소 간격입니다.
+	// MinSpan is the minimum time between the first and last observation needed for a slope.
+	MinSpan time.Duration
+}
+
+// DefaultOptions는 기본 설정을 반환합니다.
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		Window:     time.Hour,
+		MinSamples: 3,
+		MinSpan:    5 * time.Minute,
+	}
+}
+
+// Observation은 헬스팩터 관측값 하나입니다.
+// Observation is one health factor observation.
+type Observation struct {
+	Time time.Time
+	HF   float64
+}
+
+// Projection은 계정 하나의 추세와 예상 청산 시간입니다.
+// Projection is one account's trend and projected time to liquidation.
+type Projection struct {
+	// SlopePerHour는 시간당 헬스팩터 변화량입니다 (HasSlope가 true일 때만 유효).
+	// SlopePerHour is the health factor change per hour (valid only when HasSlope is true).
+	SlopePerHour float64
+	HasSlope     bool
+
+	// TrendETA는 추세대로 HF가 1.0을 지나기까지의 시간입니다 (HasTrendETA가 false면 떨어지지 않음).
+	// TrendETA is the time until HF crosses 1.0 on the current trend (not falling when HasTrendETA is false).
+	TrendETA    time.Duration
+	HasTrendETA bool
+
+	// InterestETA는 이자만으로 HF가 1.0에 도달하기까지의 시간입니다.
+	// InterestETA is the time until interest alone brings HF to 1.0.
+	InterestETA    time.Duration
+	HasInterestETA bool
+}
+
+// ETA는 두 추정 중 더 이른 예상 청산 시간을 반환합니다. 둘 다 없으면 false입니다.
+// ETA returns the earlier of the two projected times to liquidation. False when neither exists.
+func (p Projection) ETA() (time.Duration, bool) {
+	switch {
+	case p.HasTrendETA && p.HasInterestETA:
+		return min(p.TrendETA, p.InterestETA), true
+	case p.HasTrendETA:
+		return p.TrendETA, true
+	case p.HasInterestETA:
+		return p.InterestETA, true
+	}
+	return 0, false
+}
+
+// Tracker는 계정별 헬스팩터 관측값을 보관합니다. 여러 고루틴에서 안전합니다.
+// Tracker stores health factor observations per account. Safe for concurrent use.
+type Tracker struct {
+	opts Options
+
+	mu     sync.Mutex
+	series map[common.Address][]Observation
+}
+
+// NewTracker는 새로운 Tracker를 생성합니다.
+// NewTracker creates a new Tracker.
+func NewTracker(opts Options) *Tracker {
+	if opts.MinSamples < 2 {
+		opts.MinSamples = 2
+	}
+	return &Tracker{
+		opts:   opts,
+		series: make(map[common.Address][]Observation),
+	}
+}
+
+// Observe는 관측값을 추가하고 창 밖의 오래된 관측값을 버립니다.
+// Observe adds an observation and drops old ones that fall outside the window.
+func (t *Tracker) Observe(addr common.Address, at time.Time, hf float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	obs := append(t.series[addr], Observation{Time: at, HF: hf})
+	cutoff := at.Add(-t.opts.Window)
+	i := 0
+	for i < len(obs) && obs[i].Time.Before(cutoff) {
+		i++
+	}
+	t.series[addr] = obs[i:]
+}
+
+// Retain은 keep에 없는 계정의 관측값을 삭제합니다 (감시 목록에서 빠진 계정 정리).
+// Retain deletes observations of accounts not in keep (cleans up accounts removed from the watch list).
+func (t *Tracker) Retain(keep map[common.Address]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for addr := range t.series {
+		if !keep[addr] {
+			delete(t.series, addr)
+		}
+	}
+}
+
+// Project는 계정의 추세와 추세 기준 예상 청산 시간을 계산합니다.
+// 이자 기준 추정 (InterestETA)은 호출자가 채웁니다 (risk.InterestTimeToLiquidation 참고).
+// Project computes the account's trend and trend-based projected time to liquidation.
+// The interest-based estimate (InterestETA) is filled in by the caller (see risk.InterestTimeToLiquidation).
+func (t *Tracker) Project(addr common.Address) Projection {
+	t.mu.Lock()
+	obs := append([]Observation(nil), t.series[addr]...)
+	t.mu.Unlock()
+
+	var p Projection
+	if len(obs) == 0 {
+		return p
+	}
+	latest := obs[len(obs)-1]
+
+	slope, ok := t.slope(obs)
+	if !ok {
+		return p
+	}
+	p.SlopePerHour, p.HasSlope = slope, true
+	switch {
+	case latest.HF <= 1:
+		p.TrendETA, p.HasTrendETA = 0, true
+	case slope < 0:
+		hours := (latest.HF - 1) / -slope
+		if hours*float64(time.Hour) < math.MaxInt64 {
+			p.TrendETA, p.HasTrendETA = time.Duration(hours*float64(time.Hour)), true
+		}
+	}
+	return p
+}
+
+// slope는 관측값의 최소제곱 기울기를 시간당 HF 변화량으로 반환합니다.
+// slope returns the least-squares slope of the observations as HF change per hour.
+func (t *Tracker) slope(obs []Observation) (float64, bool) {
+	if len(obs) < t.opts.MinSamples {
+		return 0, false
+	}
+	origin := obs[0].Time
+	if obs[len(obs)-1].Time.Sub(origin) < t.opts.MinSpan {
+		return 0, false
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	n := float64(len(obs))
+	for _, o := range obs {
+		x := o.Time.Sub(origin).Hours()
+		sumX += x
+		sumY += o.HF
+		sumXY += x * o.HF
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denom, true
+}
@@ -0,0 +1,122 @@
+package trend
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestProject(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	type sample struct {
+		after time.Duration
+		hf    float64
+	}
+	tests := []struct {
+		name      string
+		samples   []sample
+		wantSlope bool
+		slope     float64
+		wantETA   bool
+		eta       time.Duration
+	}{
+		{
+			name:      "flat",
+			samples:   []sample{{0, 1.5}, {10 * time.Minute, 1.5}, {20 * time.Minute, 1.5}},
+			wantSlope: true, slope: 0,
+		},
+		{
+			name:      "rising",
+			samples:   []sample{{0, 1.5}, {30 * time.Minute, 2}, {time.Hour, 2.5}},
+			wantSlope: true, slope: 1,
+		},
+		{
+			name:      "already liquidatable",
+			samples:   []sample{{0, 1.2}, {30 * time.Minute, 1.1}, {time.Hour, 0.95}},
+			wantSlope: true, slope: -0.25,
+			wantETA: true, eta: 0,
+		},
+		{
+			name:      "rising but still below 1",
+			samples:   []sample{{0, 0.8}, {30 * time.Minute, 0.85}, {time.Hour, 0.9}},
+			wantSlope: true, slope: 0.1,
+			wantETA: true, eta: 0,
+		},
+		{
+			name:    "too few samples",
+			samples: []sample{{0, 3}, {time.Hour, 2}},
+		},
+		{
+			name:    "too short a span",
+			samples: []sample{{0, 3}, {time.Minute, 2.5}, {2 * time.Minute, 2}},
+		},
+		{
+			// 시간당 -1: HF 2에서 1.0까지 정확히 1시간 / -1 per hour: exactly one hour from HF 2 to 1.0
+			name:      "linear decline",
+			samples:   []sample{{0, 3}, {30 * time.Minute, 2.5}, {time.Hour, 2}},
+			wantSlope: true, slope: -1,
+			wantETA: true, eta: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTracker(DefaultOptions())
+			addr := common.HexToAddress("0x1")
+			for _, s := range tt.samples {
+				tr.Observe(addr, start.Add(s.after), s.hf)
+			}
+			p := tr.Project(addr)
+			if p.HasSlope != tt.wantSlope || (tt.wantSlope && math.Abs(p.SlopePerHour-tt.slope) > 1e-9) {
+				t.Errorf("slope = %v (%v), want %v (%v)", p.SlopePerHour, p.HasSlope, tt.slope, tt.wantSlope)
+			}
+			eta, ok := p.ETA()
+			if ok != tt.wantETA || eta != tt.eta {
+				t.Errorf("ETA = %v (%v), want %v (%v)", eta, ok, tt.eta, tt.wantETA)
+			}
+		})
+	}
+}
+
+func TestObserveWindow(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	opts := DefaultOptions()
+	opts.Window = time.Hour
+	tr := NewTracker(opts)
+	addr := common.HexToAddress("0x1")
+
+	// 창 밖의 급락은 버려지고 창 안의 평탄한 관측만 남음
+	// The drop outside the window is discarded, leaving only the flat observations inside it
+	tr.Observe(addr, start, 5)
+	for _, after := range []time.Duration{90 * time.Minute, 100 * time.Minute, 110 * time.Minute} {
+		tr.Observe(addr, start.Add(after), 1.5)
+	}
+	if p := tr.Project(addr); !p.HasSlope || p.SlopePerHour != 0 {
+		t.Errorf("slope = %v (%v), want 0 from the in-window samples", p.SlopePerHour, p.HasSlope)
+	}
+
+	tr.Retain(map[common.Address]bool{})
+	if p := tr.Project(addr); p.HasSlope {
+		t.Errorf("projection after Retain = %+v, want none", p)
+	}
+}
+
+func TestProjectionETA(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Projection
+		want   time.Duration
+		wantOK bool
+	}{
+		{"none", Projection{}, 0, false},
+		{"trend only", Projection{TrendETA: time.Hour, HasTrendETA: true}, time.Hour, true},
+		{"interest only", Projection{InterestETA: 2 * time.Hour, HasInterestETA: true}, 2 * time.Hour, true},
+		{"earlier of both", Projection{TrendETA: 3 * time.Hour, HasTrendETA: true, InterestETA: 2 * time.Hour, HasInterestETA: true}, 2 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.p.ETA(); got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: ETA() = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}